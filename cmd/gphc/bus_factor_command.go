@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vahidaghazadeh/gphc/internal/checkers"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+)
+
+// runAuthorsByDir prints the blame-based bus factor of each directory
+func runAuthorsByDir(cmd *cobra.Command, repoPath string) {
+	depth, _ := cmd.Flags().GetInt("depth")
+	inactiveDays, _ := cmd.Flags().GetInt("inactive-days")
+	halfLife, _ := cmd.Flags().GetInt("half-life")
+	format, _ := cmd.Flags().GetString("format")
+	outputFile, _ := cmd.Flags().GetString("output")
+
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	options := busFactorOptions(repositoryConfig)
+	if cmd.Flags().Changed("depth") {
+		options.Depth = depth
+	}
+	if inactiveDays > 0 {
+		options.InactiveDays = inactiveDays
+	}
+	if halfLife > 0 {
+		options.HalfLifeDays = halfLife
+	}
+
+	report, err := checkers.AnalyzeBusFactor(repoPath, options)
+	if err != nil {
+		fmt.Printf("Error analyzing bus factor: %v\n", err)
+		os.Exit(1)
+	}
+
+	if format == "json" {
+		outputBusFactorJSON(report, outputFile)
+		return
+	}
+
+	fmt.Printf("🚌 Bus Factor by Directory\n")
+	fmt.Printf("Repository: %s\n", repoPath)
+	fmt.Printf("Files blamed: %d, lines: %d, half-life: %d days, inactive after: %d days\n",
+		report.Files, report.Lines, report.HalfLifeDays, report.InactiveDays)
+	if report.Skipped > 0 {
+		fmt.Printf("Files git blame could not read, skipped: %d\n", report.Skipped)
+	}
+	if report.Lines == 0 {
+		fmt.Printf("\nNo blamed lines to analyze\n")
+		return
+	}
+	fmt.Printf("Repository bus factor: %d (authors holding %.0f%% of the knowledge)\n\n", report.BusFactor, report.Coverage*100)
+
+	fmt.Printf("%-40s %6s %8s %4s %-9s %s\n", "Directory", "Files", "Lines", "BF", "Risk", "Top owners")
+	for _, directory := range report.Directories {
+		var owners []string
+		for i, owner := range directory.Owners {
+			if i == 3 {
+				break
+			}
+			name := fmt.Sprintf("%s %.0f%%", owner.Name, owner.Share*100)
+			if owner.Inactive {
+				name += " (inactive)"
+			}
+			owners = append(owners, name)
+		}
+		fmt.Printf("%-40s %6d %8d %4d %-9s %s\n", directory.Directory, directory.Files, directory.Lines,
+			directory.BusFactor, directory.Risk, strings.Join(owners, ", "))
+	}
+
+	if len(report.AtRisk) == 0 {
+		fmt.Printf("\n✅ Every directory has an active knowledgeable author\n")
+		return
+	}
+	fmt.Printf("\n⚠️ Knowledge-loss risks:\n")
+	for _, directory := range report.Directories {
+		if directory.Risk != "critical" {
+			continue
+		}
+		owner := directory.Owners[0]
+		if directory.SoleOwnerInactive {
+			fmt.Printf("  %s: only %s knows it (%.0f%%), last commit %s\n", directory.Directory, owner.Name,
+				owner.Share*100, owner.LastActive.Format("2006-01-02"))
+		} else {
+			fmt.Printf("  %s: all %d knowledgeable authors are inactive\n", directory.Directory, directory.BusFactor)
+		}
+	}
+}
+
+// busFactorOptions maps the bus factor and identity configuration to analysis options
+func busFactorOptions(cfg *config.Config) checkers.BusFactorOptions {
+	options := checkers.DefaultBusFactorOptions()
+	options.Depth = cfg.BusFactor.Depth
+	options.HalfLifeDays = cfg.BusFactor.HalfLifeDays
+	options.InactiveDays = cfg.BusFactor.InactiveDays
+	options.Coverage = cfg.BusFactor.Coverage
+	if len(cfg.BusFactor.Exclude) > 0 {
+		options.Exclude = cfg.BusFactor.Exclude
+	}
+	options.Identity = identityOptions(cfg.Identity)
+	return options
+}
+
+func outputBusFactorJSON(report *checkers.BusFactorReport, outputFile string) {
+	jsonData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, jsonData, 0644); err != nil {
+			fmt.Printf("Error writing JSON file: %v\n", err)
+			return
+		}
+		fmt.Printf("Results written to %s\n", outputFile)
+	} else {
+		fmt.Printf("%s\n", string(jsonData))
+	}
+}
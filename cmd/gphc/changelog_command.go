@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/vahidaghazadeh/gphc/internal/checkers"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+)
+
+func runChangelog(cmd *cobra.Command, args []string) {
+	stdout, _ := cmd.Flags().GetBool("stdout")
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+	repoPath := "."
+	if len(args) > 0 {
+		repoPath = args[0]
+	}
+
+	if !isGitRepository(repoPath) {
+		fmt.Printf("Error: %s is not a Git repository\n", repoPath)
+		os.Exit(1)
+	}
+
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	cfg := repositoryConfig.Changelog
+	if cmd.Flags().Changed("format") {
+		cfg.Format, _ = cmd.Flags().GetString("format")
+	}
+	if cmd.Flags().Changed("template") {
+		cfg.Template, _ = cmd.Flags().GetString("template")
+		if !cmd.Flags().Changed("format") {
+			cfg.Format = checkers.ChangelogFormatTemplate
+		}
+	}
+	if cmd.Flags().Changed("authors") {
+		cfg.Authors, _ = cmd.Flags().GetBool("authors")
+	}
+	if cmd.Flags().Changed("output") {
+		cfg.Output, _ = cmd.Flags().GetString("output")
+	}
+
+	options, err := changelogOptions(repoPath, cfg, repositoryConfig.Release.TagPrefix)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	output := ""
+	if !stdout {
+		output = cfg.Output
+		if !filepath.IsAbs(output) {
+			output = filepath.Join(repoPath, output)
+		}
+	}
+	if cmd.Flags().Changed("component") {
+		name, _ := cmd.Flags().GetString("component")
+		component, err := findReleaseComponent(repoPath, repositoryConfig.Release, name)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		options = component.ChangelogOptions(options)
+		if !stdout && !cmd.Flags().Changed("output") {
+			output = checkers.ComponentChangelogPath(repoPath, component)
+		}
+	}
+	content, err := checkers.GenerateChangelogWithOptions(repoPath, output, options, !overwrite)
+	if err != nil {
+		fmt.Printf("Error generating changelog: %v\n", err)
+		os.Exit(1)
+	}
+	if stdout {
+		fmt.Print(content)
+		return
+	}
+	fmt.Printf("Changelog written to %s\n", output)
+}
+
+// changelogOptions maps the changelog configuration to generator options, reading the template file
+// relative to the repository
+func changelogOptions(repoPath string, cfg config.Changelog, tagPrefix string) (checkers.ChangelogOptions, error) {
+	options := checkers.DefaultChangelogOptions()
+	if cfg.Format != "" {
+		options.Format = cfg.Format
+	}
+	options.TagPrefix = tagPrefix
+	options.Unreleased = cfg.Unreleased
+	options.Authors = cfg.Authors
+	for commitType, section := range cfg.Types {
+		options.Types[commitType] = section
+	}
+	if cfg.Template != "" {
+		path := cfg.Template
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(repoPath, path)
+		}
+		template, err := os.ReadFile(path)
+		if err != nil {
+			return options, fmt.Errorf("read changelog template: %w", err)
+		}
+		options.Template = string(template)
+	}
+	return options, nil
+}
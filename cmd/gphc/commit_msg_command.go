@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vahidaghazadeh/gphc/internal/checkers"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+)
+
+func runCommitMsg(cmd *cobra.Command, args []string) {
+	format, _ := cmd.Flags().GetString("format")
+	messageFile := args[0]
+
+	repoPath := pathFlag
+	if repoPath == "" {
+		var err error
+		repoPath, err = os.Getwd()
+		if err != nil {
+			fmt.Printf("Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	content, err := os.ReadFile(messageFile)
+	if err != nil {
+		fmt.Printf("Error reading commit message: %v\n", err)
+		os.Exit(1)
+	}
+
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	rules, err := commitMessageRules(repositoryConfig)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	rules.CommentChar = checkers.GitCommentChar(repoPath)
+
+	message, problems, err := checkers.LintCommitMessage(string(content), rules)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	problems = enabledMessageProblems(problems, repositoryConfig.Hooks.CommitMsg)
+
+	switch format {
+	case "json":
+		payload := struct {
+			File     string                        `json:"file"`
+			Valid    bool                          `json:"valid"`
+			Message  *checkers.CommitMessage       `json:"message"`
+			Problems []checkers.CommitMessageError `json:"problems"`
+		}{File: messageFile, Valid: len(problems) == 0, Message: message, Problems: problems}
+		if payload.Problems == nil {
+			payload.Problems = []checkers.CommitMessageError{}
+		}
+		jsonData, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			fmt.Printf("Error marshaling JSON: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonData))
+	default:
+		if len(problems) == 0 {
+			fmt.Println("Commit message follows the configured rules")
+			break
+		}
+		for _, problem := range problems {
+			fmt.Printf("%s:%s\n", messageFile, problem)
+		}
+		fmt.Printf("\n%d problem(s) in commit message; expected subject format: %s\n", len(problems), rules.Convention.Format())
+		fmt.Printf("Your message is kept in %s; fix it and retry with: git commit -e -F %s\n", messageFile, messageFile)
+	}
+
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// commitMessageRules builds the commit-msg lint rules from gphc.yml
+func commitMessageRules(cfg *config.Config) (checkers.CommitMessageRules, error) {
+	convention, err := commitConvention(cfg.CommitConvention)
+	if err != nil {
+		return checkers.CommitMessageRules{}, err
+	}
+	return checkers.CommitMessageRules{
+		Convention:            convention,
+		MaxSubjectLength:      cfg.MaxCommitMessageLength,
+		MaxBodyLineLength:     cfg.CommitMessage.MaxBodyLineLength,
+		RequiredTrailers:      cfg.CommitMessage.RequiredTrailers,
+		RequireIssueReference: cfg.CommitMessage.RequireIssueReference,
+		IssuePattern:          cfg.CommitMessage.IssuePattern,
+	}, nil
+}
+
+// enabledMessageProblems drops problems whose rule is not enabled under hooks.commit_msg.
+// An empty subject is always reported.
+func enabledMessageProblems(problems []checkers.CommitMessageError, enabled []string) []checkers.CommitMessageError {
+	var kept []checkers.CommitMessageError
+	for _, problem := range problems {
+		if problem.Rule == checkers.RuleSubjectEmpty || hookCheckEnabled(enabled, problem.Rule) {
+			kept = append(kept, problem)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vahidaghazadeh/gphc/internal/exporter"
+	"github.com/vahidaghazadeh/gphc/internal/git"
+	"github.com/vahidaghazadeh/gphc/internal/reporter"
+	"github.com/vahidaghazadeh/gphc/internal/scorer"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func runCompare(cmd *cobra.Command, args []string) {
+	format, _ := cmd.Flags().GetString("format")
+	outputFile, _ := cmd.Flags().GetString("output")
+
+	base, err := readHealthReport(args[0])
+	if err != nil {
+		fmt.Printf("Error reading base report: %v\n", err)
+		os.Exit(1)
+	}
+	head, err := readHealthReport(args[1])
+	if err != nil {
+		fmt.Printf("Error reading head report: %v\n", err)
+		os.Exit(1)
+	}
+
+	comparison := scorer.Compare(base, head)
+	comparison.Base, comparison.Head = args[0], args[1]
+	if err := writeComparison(comparison, format, outputFile); err != nil {
+		fmt.Printf("Error exporting comparison: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// readHealthReport reads a health report exported as JSON
+func readHealthReport(path string) (*types.HealthReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report types.HealthReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("%s is not a JSON health report: %w", path, err)
+	}
+	return &report, nil
+}
+
+// compareToRef checks out ref in a temporary worktree and compares its report with head. The
+// ref is checked with the working tree's configuration and commit selection, so the delta
+// reflects changes to the repository rather than to gphc.yml.
+func compareToRef(repoPath, ref string, head *types.HealthReport, selection git.CommitSelection) (*types.ReportComparison, error) {
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("load configuration: %w", err)
+	}
+	worktree, err := git.AddWorktree(repoPath, ref)
+	if err != nil {
+		return nil, err
+	}
+	defer worktree.Remove()
+
+	base, err := buildHealthReportWithConfig(worktree.Path, repositoryConfig, selection)
+	if err != nil {
+		return nil, err
+	}
+	comparison := scorer.Compare(base, head)
+	comparison.Base, comparison.Head = ref, "working tree"
+	return comparison, nil
+}
+
+// writeComparison prints a comparison in the terminal or exports it to stdout or a file
+func writeComparison(comparison *types.ReportComparison, format, outputFile string) error {
+	var output string
+	if format == "terminal" {
+		output = reporter.NewReporter().Compare(comparison)
+	} else {
+		var err error
+		output, err = exporter.NewExporter().ExportComparison(comparison, exporter.ExportFormat(format))
+		if err != nil {
+			return err
+		}
+	}
+
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, []byte(output), 0644); err != nil {
+			return fmt.Errorf("write %s: %w", outputFile, err)
+		}
+		fmt.Printf("Comparison exported to: %s\n", outputFile)
+		return nil
+	}
+	fmt.Print(output)
+	if format == "terminal" || format == "markdown" {
+		fmt.Println()
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vahidaghazadeh/gphc/internal/checkers"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func runGoMod(cmd *cobra.Command, args []string) {
+	maxTags, _ := cmd.Flags().GetInt("max-tags")
+	noAPI, _ := cmd.Flags().GetBool("no-api")
+	format, _ := cmd.Flags().GetString("format")
+	outputFile, _ := cmd.Flags().GetString("output")
+
+	repoPath := "."
+	if len(args) > 0 {
+		repoPath = args[0]
+	}
+
+	if !isGitRepository(repoPath) {
+		fmt.Printf("Error: %s is not a Git repository\n", repoPath)
+		os.Exit(1)
+	}
+
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	options := goModuleOptions(repositoryConfig.GoModules)
+	if maxTags > 0 {
+		options.MaxTags = maxTags
+	}
+	// Type-checking two releases is too slow for every health check but is what gomod is run for
+	options.APIDiff = !noAPI
+
+	result, report := checkers.NewGoModuleCheckerWithOptions(options).CheckWithReport(&types.RepositoryData{Path: repoPath})
+
+	switch format {
+	case "json":
+		outputGoModJSON(result, report, outputFile)
+	default:
+		fmt.Printf("🐹 Go Module Release Hygiene\n")
+		fmt.Printf("Repository: %s\n\n", repoPath)
+		for _, detail := range result.Details {
+			fmt.Println(detail)
+		}
+		if report != nil {
+			for _, module := range report.Modules {
+				if len(module.APIChanges) == 0 {
+					continue
+				}
+				fmt.Printf("\nAPI changes in %s (%s → %s):\n", module.Path, module.PreviousTag, module.LatestTag)
+				for _, change := range module.APIChanges {
+					marker := "+"
+					switch {
+					case change.Breaking:
+						marker = "!"
+					case change.Change == checkers.APIChanged:
+						marker = "~"
+					}
+					name := change.QualifiedName()
+					description := change.New
+					if change.Change == checkers.APIRemoved {
+						description = change.Old
+					} else if change.Change == checkers.APIChanged {
+						description = change.Old + " → " + change.New
+					}
+					fmt.Printf("  %s %-8s %s: %s\n", marker, change.Change, name, description)
+				}
+			}
+		}
+		fmt.Printf("\nGo Module Score: %d/100\n", result.Score)
+		switch result.Status {
+		case types.StatusFail:
+			fmt.Printf("❌ %s\n", result.Message)
+		case types.StatusWarning:
+			fmt.Printf("⚠️ %s\n", result.Message)
+		default:
+			fmt.Printf("✅ %s\n", result.Message)
+		}
+	}
+
+	if result.Status == types.StatusFail {
+		os.Exit(1)
+	}
+}
+
+// goModuleOptions maps the gomod configuration to checker options
+func goModuleOptions(cfg config.GoModules) checkers.GoModuleOptions {
+	return checkers.GoModuleOptions{
+		MaxTags: cfg.MaxTags,
+		APIDiff: cfg.APIDiff,
+	}
+}
+
+func outputGoModJSON(result *types.CheckResult, report *checkers.GoModuleReport, outputFile string) {
+	payload := struct {
+		Result *types.CheckResult       `json:"result"`
+		Report *checkers.GoModuleReport `json:"report,omitempty"`
+	}{Result: result, Report: report}
+	jsonData, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, jsonData, 0644); err != nil {
+			fmt.Printf("Error writing JSON file: %v\n", err)
+			return
+		}
+		fmt.Printf("Results written to %s\n", outputFile)
+	} else {
+		fmt.Printf("%s\n", string(jsonData))
+	}
+}
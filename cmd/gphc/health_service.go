@@ -2,19 +2,32 @@ package main
 
 import (
 	"fmt"
+	"reflect"
 
+	"github.com/spf13/cobra"
 	"github.com/vahidaghazadeh/gphc/internal/checkers"
 	"github.com/vahidaghazadeh/gphc/internal/git"
+	"github.com/vahidaghazadeh/gphc/internal/identity"
 	"github.com/vahidaghazadeh/gphc/internal/scorer"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
 	"github.com/vahidaghazadeh/gphc/pkg/types"
 )
 
 func buildHealthReport(repoPath string) (*types.HealthReport, error) {
+	return buildHealthReportForSelection(repoPath, git.CommitSelection{})
+}
+
+// buildHealthReportForSelection runs the health check with the commit checkers limited to a slice of history
+func buildHealthReportForSelection(repoPath string, selection git.CommitSelection) (*types.HealthReport, error) {
 	repositoryConfig, err := loadRepositoryConfig(repoPath)
 	if err != nil {
 		return nil, fmt.Errorf("load configuration: %w", err)
 	}
+	return buildHealthReportWithConfig(repoPath, repositoryConfig, selection)
+}
 
+// buildHealthReportWithConfig runs the health check with a given configuration instead of the repository's own
+func buildHealthReportWithConfig(repoPath string, repositoryConfig *config.Config, selection git.CommitSelection) (*types.HealthReport, error) {
 	analyzer, err := git.NewRepositoryAnalyzerWithOptions(
 		repoPath,
 		repositoryConfig.MaxCommitsToAnalyze,
@@ -23,44 +36,168 @@ func buildHealthReport(repoPath string) (*types.HealthReport, error) {
 	if err != nil {
 		return nil, fmt.Errorf("initialize repository analyzer: %w", err)
 	}
+	analyzer.SetCommitSelection(selection)
 
 	data, err := analyzer.Analyze()
 	if err != nil {
 		return nil, fmt.Errorf("analyze repository: %w", err)
 	}
 
+	convention, err := commitConvention(repositoryConfig.CommitConvention)
+	if err != nil {
+		return nil, err
+	}
+
+	historyChecker, err := checkers.NewHistoryShapeCheckerWithStrategy(repositoryConfig.History.Strategy, repositoryConfig.History.MainBranch)
+	if err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+
+	components, err := releaseComponents(repoPath, repositoryConfig.Release)
+	if err != nil {
+		return nil, fmt.Errorf("release components: %w", err)
+	}
+
+	policyDoc, policyPath, err := checkers.FindPolicyDocument(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("load policy file: %w", err)
+	}
+	policyChecker := checkers.NewGitPolicyChecker()
+	if policyDoc != nil {
+		policyChecker = checkers.NewGitPolicyCheckerWithPolicy(policyDoc, policyPath)
+	}
+
+	sizeChecker := checkers.NewRepositorySizeCheckerWithThresholds(sizeThresholds(repositoryConfig.RepositorySize))
+	sizeChecker.SetScanHistory(repositoryConfig.RepositorySize.ScanHistory)
+
 	allCheckers := []checkers.Checker{
 		checkers.NewDocChecker(),
 		checkers.NewSetupChecker(),
 		checkers.NewIgnoreChecker(),
-		checkers.NewConventionalCommitChecker(),
+		checkers.NewConventionalCommitCheckerWithConvention(convention),
 		checkers.NewMsgLengthCheckerWithLimit(repositoryConfig.MaxCommitMessageLength),
 		checkers.NewCommitSizeCheckerWithLimit(repositoryConfig.MaxCommitSizeLines),
-		checkers.NewCommitAuthorInsightsChecker(),
+		historyChecker,
+		checkers.NewCommitAuthorInsightsCheckerWithIdentity(identityOptions(repositoryConfig.Identity)),
+		checkers.NewCodeOwnersCheckerWithOptions(checkers.CodeOwnersOptions{
+			Syntax:          repositoryConfig.CodeOwners.Syntax,
+			StaleDays:       repositoryConfig.CodeOwners.StaleDays,
+			TopContributors: repositoryConfig.CodeOwners.TopContributors,
+			Identity:        identityOptions(repositoryConfig.Identity),
+		}),
 		checkers.NewCodebaseSmellChecker(),
+		checkers.NewHotspotCheckerWithOptions(hotspotOptions(repositoryConfig.Hotspots)),
 		checkers.NewLocalBranchChecker(),
 		checkers.NewStaleBranchChecker(),
 		checkers.NewBareRepoChecker(),
 		checkers.NewStashChecker(),
+		sizeChecker,
 		checkers.NewGitHubIntegrationChecker(),
 		checkers.NewGitLabIntegrationChecker(),
-		checkers.NewTagChecker(),
+		checkers.NewTagCheckerWithOptions(tagCheckerOptions(components, repositoryConfig)),
+		checkers.NewGoModuleCheckerWithOptions(goModuleOptions(repositoryConfig.GoModules)),
 		checkers.NewSecretChecker(),
 		checkers.NewTransitiveDependencyChecker(),
-		checkers.NewGitPolicyChecker(),
+		policyChecker,
 		checkers.NewBinaryFileChecker(),
 	}
 
-	healthScorer := scorer.NewScorerWithWeights(map[types.Category]int{
-		types.CategoryDocs:      repositoryConfig.Weights.Documentation,
-		types.CategoryCommits:   repositoryConfig.Weights.Commits,
-		types.CategoryHygiene:   repositoryConfig.Weights.Hygiene,
-		types.CategoryStructure: repositoryConfig.Weights.Structure,
-		types.CategorySecurity:  repositoryConfig.Weights.Security,
-	})
+	scoring, err := scoringOptions(repositoryConfig)
+	if err != nil {
+		return nil, err
+	}
+	healthScorer := scorer.NewScorerWithOptions(scoring)
 	for _, checker := range allCheckers {
-		healthScorer.AddResult(*checker.Check(data))
+		healthScorer.AddWeightedResult(*checker.Check(data), checker.Weight())
 	}
 
 	return healthScorer.CalculateHealthReport(), nil
 }
+
+// scoringOptions maps the category weights and scoring configuration to scorer options
+func scoringOptions(cfg *config.Config) (scorer.Options, error) {
+	options := scorer.Options{
+		CategoryWeights: map[types.Category]int{
+			types.CategoryDocs:      cfg.Weights.Documentation,
+			types.CategoryCommits:   cfg.Weights.Commits,
+			types.CategoryHygiene:   cfg.Weights.Hygiene,
+			types.CategoryStructure: cfg.Weights.Structure,
+			types.CategorySecurity:  cfg.Weights.Security,
+		},
+		CheckWeights: cfg.Scoring.Checks,
+	}
+	for _, band := range cfg.Scoring.Grades {
+		options.Grades = append(options.Grades, types.GradeBand{Grade: band.Grade, Min: band.Min})
+	}
+	if len(options.Grades) == 0 {
+		options.Grades = scorer.DefaultGradeBands()
+	}
+	// The default penalty names grade D, which custom grade bands may not define;
+	// left unchanged, it caps at the second-lowest configured band instead
+	if len(cfg.Scoring.Grades) > 0 && reflect.DeepEqual(cfg.Scoring.Penalties, config.DefaultConfig().Scoring.Penalties) {
+		options.Penalties = scorer.DefaultPenaltiesFor(options.Grades)
+	} else {
+		for _, penalty := range cfg.Scoring.Penalties {
+			options.Penalties = append(options.Penalties, scorer.Penalty{
+				Name:     penalty.Name,
+				Check:    penalty.Check,
+				Status:   penalty.Status,
+				Severity: penalty.Severity,
+				MaxScore: penalty.MaxScore,
+				MaxGrade: penalty.MaxGrade,
+			})
+		}
+	}
+	if err := options.Validate(); err != nil {
+		return options, fmt.Errorf("scoring: %w", err)
+	}
+	return options, nil
+}
+
+// commitConvention builds the commit convention profile configured in gphc.yml
+func commitConvention(cfg config.CommitConvention) (*checkers.CommitConvention, error) {
+	convention, err := checkers.NewCommitConvention(checkers.CommitConventionOptions{
+		Profile:      cfg.Profile,
+		Types:        cfg.Types,
+		Scopes:       cfg.Scopes,
+		RequireScope: cfg.RequireScope,
+		Projects:     cfg.Projects,
+		Pattern:      cfg.Pattern,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("commit convention: %w", err)
+	}
+	return convention, nil
+}
+
+// identityOptions maps the identity configuration to resolver options
+func identityOptions(cfg config.Identity) identity.Options {
+	return identity.Options{
+		UseMailmap:  cfg.Mailmap,
+		MergeByName: cfg.MergeByName,
+		ExcludeBots: cfg.ExcludeBots,
+		BotPatterns: cfg.Bots,
+	}
+}
+
+// addCommitSelectionFlags registers the flags that choose which commits are analyzed
+func addCommitSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().String("range", "", "Analyze a revision range (e.g. v1.2.0..HEAD)")
+	cmd.Flags().String("since", "", "Analyze commits more recent than a date (e.g. 2024-01-01, \"3 months ago\")")
+	cmd.Flags().String("until", "", "Analyze commits older than a date")
+	cmd.Flags().Bool("all-refs", false, "Analyze commits reachable from all branches, tags and remotes")
+	cmd.Flags().Bool("no-merges", false, "Exclude merge commits")
+	cmd.Flags().Int("max-commits", 0, "Maximum number of commits to analyze (default: max_commits_to_analyze for HEAD, unlimited otherwise)")
+}
+
+// commitSelectionFromFlags reads the flags registered by addCommitSelectionFlags
+func commitSelectionFromFlags(cmd *cobra.Command) git.CommitSelection {
+	var selection git.CommitSelection
+	selection.Range, _ = cmd.Flags().GetString("range")
+	selection.Since, _ = cmd.Flags().GetString("since")
+	selection.Until, _ = cmd.Flags().GetString("until")
+	selection.AllRefs, _ = cmd.Flags().GetBool("all-refs")
+	selection.NoMerges, _ = cmd.Flags().GetBool("no-merges")
+	selection.MaxCommits, _ = cmd.Flags().GetInt("max-commits")
+	return selection
+}
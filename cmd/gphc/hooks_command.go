@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vahidaghazadeh/gphc/internal/hooks"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+)
+
+func runHooksInstall(cmd *cobra.Command, args []string) {
+	manager, stages := hookManager(args)
+	if err := manager.Install(stages); err != nil {
+		fmt.Printf("Error installing hooks: %v\n", err)
+		os.Exit(1)
+	}
+	for _, status := range manager.Status() {
+		if !containsStage(stages, status.Stage) {
+			continue
+		}
+		fmt.Printf("Installed %s hook: %s\n", status.Stage, status.Path)
+		if status.Chained != "" {
+			fmt.Printf("  existing hook kept and run first: %s\n", status.Chained)
+		}
+	}
+}
+
+func runHooksUninstall(cmd *cobra.Command, args []string) {
+	manager, stages := hookManager(args)
+	before := manager.Status()
+	if err := manager.Uninstall(stages); err != nil {
+		fmt.Printf("Error uninstalling hooks: %v\n", err)
+		os.Exit(1)
+	}
+	for _, status := range before {
+		if !containsStage(stages, status.Stage) || !status.Managed {
+			continue
+		}
+		if status.Chained != "" {
+			fmt.Printf("Removed %s hook; restored %s\n", status.Stage, status.Path)
+		} else {
+			fmt.Printf("Removed %s hook\n", status.Stage)
+		}
+	}
+}
+
+func runHooksStatus(cmd *cobra.Command, args []string) {
+	manager, _ := hookManager(nil)
+	repositoryConfig, err := loadRepositoryConfig(hookRepoPath())
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Hooks directory: %s\n\n", manager.HooksDir())
+	for _, status := range manager.Status() {
+		state := "not installed"
+		switch {
+		case status.Managed && status.Chained != "":
+			state = "installed (chains " + status.Chained + ")"
+		case status.Managed:
+			state = "installed"
+		case status.Foreign:
+			state = "foreign hook (not managed by gphc)"
+		}
+		fmt.Printf("%-11s %s\n", status.Stage, state)
+		fmt.Printf("%-11s checks: %s\n", "", strings.Join(hookChecks(repositoryConfig, status.Stage), ", "))
+	}
+}
+
+// hookManager resolves the repository hooks directory and the stages named on the command line
+func hookManager(args []string) (*hooks.Manager, []string) {
+	repoPath := hookRepoPath()
+	if !isGitRepository(repoPath) {
+		fmt.Printf("Error: %s is not a Git repository\n", repoPath)
+		os.Exit(1)
+	}
+
+	// Hooks invoke the binary that installed them so they work without gphc on PATH
+	command, err := os.Executable()
+	if err != nil {
+		command = "gphc"
+	}
+	manager, err := hooks.NewManager(repoPath, command)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	stages := args
+	if len(stages) == 0 {
+		stages = hooks.Stages
+	}
+	return manager, stages
+}
+
+func hookRepoPath() string {
+	if pathFlag != "" {
+		return pathFlag
+	}
+	path, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Error getting current directory: %v\n", err)
+		os.Exit(1)
+	}
+	return path
+}
+
+// hookChecks returns the checks gphc.yml enables for a hook stage
+func hookChecks(cfg *config.Config, stage string) []string {
+	switch stage {
+	case hooks.StagePreCommit:
+		return cfg.Hooks.PreCommit
+	case hooks.StageCommitMsg:
+		return cfg.Hooks.CommitMsg
+	case hooks.StagePrePush:
+		return cfg.Hooks.PrePush
+	}
+	return nil
+}
+
+func containsStage(stages []string, stage string) bool {
+	for _, candidate := range stages {
+		if candidate == stage {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vahidaghazadeh/gphc/internal/checkers"
+	"github.com/vahidaghazadeh/gphc/internal/git"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func runHotspots(cmd *cobra.Command, args []string) {
+	top, _ := cmd.Flags().GetInt("top")
+	halfLife, _ := cmd.Flags().GetInt("half-life")
+	format, _ := cmd.Flags().GetString("format")
+	outputFile, _ := cmd.Flags().GetString("output")
+
+	repoPath := "."
+	if len(args) > 0 {
+		repoPath = args[0]
+	}
+
+	if !isGitRepository(repoPath) {
+		fmt.Printf("Error: %s is not a Git repository\n", repoPath)
+		os.Exit(1)
+	}
+
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	options := hotspotOptions(repositoryConfig.Hotspots)
+	if top > 0 {
+		options.TopN = top
+	}
+	if halfLife > 0 {
+		options.HalfLifeDays = halfLife
+	}
+
+	// Hotspots need more history than the health check's latest commits
+	selection := commitSelectionFromFlags(cmd)
+	if selection.IsDefault() {
+		selection.Since = repositoryConfig.Hotspots.Since
+		if selection.Since == "" {
+			selection.MaxCommits = repositoryConfig.MaxCommitsToAnalyze
+		}
+	}
+
+	analyzer, err := git.NewRepositoryAnalyzer(repoPath)
+	if err != nil {
+		fmt.Printf("Error initializing analyzer: %v\n", err)
+		os.Exit(1)
+	}
+	analyzer.SetCommitSelection(selection)
+	data, err := analyzer.Analyze()
+	if err != nil {
+		fmt.Printf("Error analyzing repository: %v\n", err)
+		os.Exit(1)
+	}
+
+	result, report := checkers.NewHotspotCheckerWithOptions(options).CheckWithReport(data)
+
+	switch format {
+	case "json":
+		outputHotspotsJSON(result, report, outputFile)
+	default:
+		fmt.Printf("🔥 Change Hotspots\n")
+		fmt.Printf("Repository: %s\n", repoPath)
+		if report == nil {
+			fmt.Printf("\n%s\n", result.Message)
+			return
+		}
+		fmt.Printf("Commits analyzed: %d, files changed: %d, half-life: %d days\n\n", report.CommitsAnalyzed, report.FilesChanged, report.HalfLifeDays)
+
+		fmt.Printf("%-4s %-50s %8s %8s %12s %8s %s\n", "#", "File", "Score", "Commits", "Lines", "Authors", "Tests")
+		for i, hotspot := range report.Hotspots {
+			tests := "yes"
+			if !hotspot.HasTests {
+				tests = "no"
+			}
+			fmt.Printf("%-4d %-50s %8.1f %8d %12s %8d %s\n", i+1, hotspot.Path, hotspot.Score, hotspot.Commits,
+				fmt.Sprintf("+%d/-%d", hotspot.LinesAdded, hotspot.LinesDeleted), hotspot.Authors, tests)
+		}
+
+		if len(report.Coupled) > 0 {
+			fmt.Printf("\nFiles that change together:\n")
+			for _, pair := range report.Coupled {
+				fmt.Printf("  %s <-> %s: %d shared commits (%.0f%%)\n", pair.FileA, pair.FileB, pair.SharedCommits, pair.Coupling*100)
+			}
+		}
+		if len(report.UntestedHotspots) > 0 {
+			fmt.Printf("\nHigh-churn files without tests:\n")
+			for _, hotspot := range report.UntestedHotspots {
+				fmt.Printf("  %s (%d commits)\n", hotspot.Path, hotspot.Commits)
+			}
+		}
+
+		fmt.Printf("\nHotspot Score: %d/100\n", result.Score)
+		if result.Status == types.StatusPass {
+			fmt.Printf("✅ %s\n", result.Message)
+		} else {
+			fmt.Printf("⚠️ %s\n", result.Message)
+		}
+	}
+}
+
+// hotspotOptions maps the hotspots configuration to checker options
+func hotspotOptions(cfg config.Hotspots) checkers.HotspotOptions {
+	return checkers.HotspotOptions{
+		TopN:             cfg.Top,
+		HalfLifeDays:     cfg.HalfLifeDays,
+		MinSharedCommits: cfg.MinSharedCommits,
+		MinCoupling:      cfg.MinCoupling,
+	}
+}
+
+func outputHotspotsJSON(result *types.CheckResult, report *checkers.HotspotReport, outputFile string) {
+	payload := struct {
+		Result *types.CheckResult      `json:"result"`
+		Report *checkers.HotspotReport `json:"report,omitempty"`
+	}{Result: result, Report: report}
+	jsonData, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, jsonData, 0644); err != nil {
+			fmt.Printf("Error writing JSON file: %v\n", err)
+			return
+		}
+		fmt.Printf("Results written to %s\n", outputFile)
+	} else {
+		fmt.Printf("%s\n", string(jsonData))
+	}
+}
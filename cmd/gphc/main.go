@@ -17,6 +17,7 @@ import (
 	"github.com/vahidaghazadeh/gphc/internal/checkers"
 	"github.com/vahidaghazadeh/gphc/internal/exporter"
 	"github.com/vahidaghazadeh/gphc/internal/git"
+	"github.com/vahidaghazadeh/gphc/internal/identity"
 	"github.com/vahidaghazadeh/gphc/internal/reporter"
 	"github.com/vahidaghazadeh/gphc/internal/scorer"
 	"github.com/vahidaghazadeh/gphc/pkg/config"
@@ -39,7 +40,12 @@ var badgeCmd = &cobra.Command{
 	Use:   "badge [path]",
 	Short: "Generate health badge for a Git repository",
 	Long: `Generate a health badge (shields.io style) for the repository.
-This command runs a quick health check and generates a badge URL and markdown.`,
+This command runs the health check and generates a badge URL and markdown for the overall
+score and for each category. Use --category to generate the badge of one category only.
+
+Examples:
+  git hc badge                         # Overall and category badges
+  git hc badge --category security     # Only the Security badge`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runBadge,
 }
@@ -66,7 +72,10 @@ var authorsCmd = &cobra.Command{
 	Use:   "authors [path]",
 	Short: "Analyze commit author patterns and bus factor risk",
 	Long: `Analyze commit history to identify contributor patterns and bus factor risks.
-Shows contributor distribution, single author dominance, and team participation metrics.`,
+Shows contributor distribution, single author dominance, and team participation metrics.
+Use --range, --since/--until, --all-refs and --no-merges to choose the commits analyzed.
+Use --by-dir for the bus factor of each directory from line-level blame ownership.
+Use --timeline for weekly activity, onboarding and offboarding, commit time heatmaps and review-free commits.`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runAuthors,
 }
@@ -80,12 +89,56 @@ Checks for missing tests, oversized directories, poor organization, and maintain
 	Run:  runCodebase,
 }
 
+var sizeCmd = &cobra.Command{
+	Use:   "size [path]",
+	Short: "Analyze repository size and packfile health",
+	Long: `Measure how heavy a clone of the repository is.
+Reports pack size, loose objects, the largest blobs across all history with the
+paths and commits that introduced them, the largest trees, and per-directory growth.
+Thresholds come from the repository_size section of gphc.yml.
+
+Examples:
+  git hc size                          # Analyze the current repository
+  git hc size --top 20                 # Show the 20 largest blobs and trees
+  git hc size --months 12              # Show a year of per-directory growth
+  git hc size --max-pack-size 200      # Warn above 200 MB
+  git hc size --format json            # JSON output format`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runSize,
+}
+
+var hotspotsCmd = &cobra.Command{
+	Use:   "hotspots [path]",
+	Short: "Rank files by churn and recency and find files that change together",
+	Long: `Find the files where change concentrates.
+Files are ranked by lines changed, with older changes counting less (half-life decay).
+Also lists file pairs that keep changing in the same commits (temporal coupling)
+and high-churn source files with no tests next to them.
+Without commit selection flags the hotspots.since window from gphc.yml is analyzed.
+
+Examples:
+  git hc hotspots                          # Analyze the last 12 months
+  git hc hotspots --since "6 months ago"   # Choose the history window
+  git hc hotspots --top 20 --half-life 30  # More files, favor recent changes
+  git hc hotspots --format json            # JSON output format`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runHotspots,
+}
+
 var scanCmd = &cobra.Command{
 	Use:   "scan [path]",
 	Short: "Scan multiple repositories for health analysis",
 	Long: `Scan multiple repositories simultaneously for health analysis.
 Supports recursive scanning to find all Git repositories in directories.
-Perfect for organizations with many projects.`,
+Perfect for organizations with many projects.
+Keeps the full health report of every repository and aggregates them: per-check
+failure counts, category averages with the worst offenders, and a score histogram.
+
+Examples:
+  git hc scan ~/projects --recursive                        # Terminal summary
+  git hc scan ~/projects -r --format html -o org.html       # HTML report
+  git hc scan ~/projects -r --format csv -o org.csv         # For spreadsheets
+  git hc scan ~/projects -r --format json --worst 10        # Full JSON with 10 offenders per category`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runScan,
 }
@@ -121,11 +174,73 @@ var tagsCmd = &cobra.Command{
 	Use:   "tags [path]",
 	Short: "Analyze and manage Git tags and releases",
 	Long: `Validate semantic tags, check freshness and unreleased commits,
-suggest next semantic version, and optionally generate a changelog.`,
+suggest next semantic version, and optionally generate a changelog.
+Tag integrity is verified too: signatures, semver regressions, release tags missing
+from the release branch, lightweight tags shadowing annotated ones and moved tags.
+Each run records the tags in .git/gphc/tag-history.json; a moved tag is reported until
+it is restored or accepted with --ack-move. Health checks only read this store.
+Monorepo components configured under release.components are tracked separately,
+each with its own tag prefix (api/v1.4.0) and paths.`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runTags,
 }
 
+var releaseCmd = &cobra.Command{
+	Use:   "release [path]",
+	Short: "Cut a release from conventional commits",
+	Long: `Compute the next semantic version from the conventional commits since the last release,
+update the version file, merge the release notes into the changelog and create an annotated tag.
+Use --pre for release candidates and betas, and --dry-run to see everything before it happens.
+In a monorepo, --component releases one configured component from the commits touching its paths.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runRelease,
+}
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog [path]",
+	Short: "Generate a changelog covering every release tag",
+	Long: `Generate a Keep a Changelog (or template-driven) changelog with a dated section for every
+release tag, breaking changes called out and commits and issues linked to the remote.
+Generated sections are merged into the existing file; manual edits are kept.
+--component writes the changelog of one monorepo component from its own tags and paths.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runChangelog,
+}
+
+var gomodCmd = &cobra.Command{
+	Use:   "gomod [path]",
+	Short: "Check Go module releases",
+	Long: `Check the go.mod of every Go module's recent release tags.
+Reports v2+ tags whose module path lacks the matching major version suffix, replace
+directives in tagged go.mod files, malformed retractions, tags that build a different
+module path, and imports of the previous major version. The exported API of the last
+two stable tags is compared with go/types to find breaking changes.
+
+Examples:
+  git hc gomod                         # Check the current repository
+  git hc gomod --max-tags 5            # Only the 5 latest tags of each module
+  git hc gomod --no-api                # Skip the API comparison
+  git hc gomod --format json           # JSON output format`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runGoMod,
+}
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <base.json> <head.json>",
+	Short: "Compare two health reports",
+	Long: `Compare two JSON health reports written by git hc check --format json.
+Shows the overall and per-category score change, the grade transition, checks that
+changed status, and findings that are new in the head report or resolved since the base.
+Use git hc check --compare-to <ref> to compare the working tree against another ref.
+
+Examples:
+  git hc compare main.json pr.json                    # Terminal output
+  git hc compare main.json pr.json --format markdown  # For a pull request comment
+  git hc compare main.json pr.json --format json      # JSON output format`,
+	Args: cobra.ExactArgs(2),
+	Run:  runCompare,
+}
+
 var suggestCmd = &cobra.Command{
 	Use:     "suggest [path]",
 	Aliases: []string{"comment"},
@@ -190,8 +305,15 @@ var policyCmd = &cobra.Command{
 	Long: `Validate Git security policies including commit signatures, push policies,
 sensitive file detection, and branch protection settings.
 
+When the repository contains a .gphc-policy.yml file (or --policy points to one),
+its declared rules replace the built-in push, branch protection and signing
+thresholds and each rule is reported as PASS or FAIL.
+
 Examples:
   git hc security policy                          # Basic policy validation
+  git hc security policy --policy org-policy.yml  # Evaluate a declarative policy file
+  git hc security policy --allowed-signers .github/allowed_signers  # Verify SSH signatures
+  git hc security policy --gpg-home ./keys        # Verify GPG signatures against a keyring
   git hc security policy --check-signing          # Focus on commit signatures
   git hc security policy --check-files            # Focus on sensitive files
   git hc security policy --format json            # JSON output format
@@ -209,6 +331,7 @@ Examples:
   git hc security binaries                        # Basic binary audit
   git hc security binaries --max-size 50mb        # Set custom size threshold
   git hc security binaries --check-history        # Include Git history scan
+  git hc security binaries --lfs-threshold 5mb    # Expect binaries from 5 MB to use Git LFS
   git hc security binaries --format json          # JSON output format
   git hc security binaries --severity high        # Only show high/critical issues`,
 	Run: runBinariesAudit,
@@ -235,6 +358,9 @@ func init() {
 	policyCmd.Flags().Bool("check-files", true, "Check for sensitive files")
 	policyCmd.Flags().Bool("check-push", true, "Check push policies")
 	policyCmd.Flags().Bool("check-branches", true, "Check branch protection")
+	policyCmd.Flags().String("policy", "", "Policy file path (default: .gphc-policy.yml in the repository)")
+	policyCmd.Flags().String("allowed-signers", "", "SSH allowed signers file (default: gpg.ssh.allowedSignersFile or the repository's allowed_signers)")
+	policyCmd.Flags().String("gpg-home", "", "GPG keyring directory used to verify GPG signatures")
 	policyCmd.Flags().String("severity", "low", "Minimum severity level (low, medium, high, critical)")
 	policyCmd.Flags().String("format", "table", "Output format (table, json, yaml)")
 	policyCmd.Flags().String("output", "", "Output file path")
@@ -244,6 +370,7 @@ func init() {
 	binariesCmd.Flags().Bool("check-executables", true, "Check for executable files")
 	binariesCmd.Flags().Bool("check-large", true, "Check for large files")
 	binariesCmd.Flags().Bool("check-suspicious", true, "Check for suspicious file types")
+	binariesCmd.Flags().String("lfs-threshold", "1mb", "Size from which tracked binaries should use Git LFS (0 disables)")
 	binariesCmd.Flags().String("severity", "low", "Minimum severity level (low, medium, high, critical)")
 	binariesCmd.Flags().String("format", "table", "Output format (table, json, yaml)")
 	binariesCmd.Flags().String("output", "", "Output file path")
@@ -275,6 +402,9 @@ func init() {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(preCommitCmd)
+	rootCmd.AddCommand(commitMsgCmd)
+	rootCmd.AddCommand(prePushCmd)
+	rootCmd.AddCommand(hooksCmd)
 	rootCmd.AddCommand(commitCmd)
 	rootCmd.AddCommand(diffCmd)
 	rootCmd.AddCommand(suggestCmd)
@@ -283,19 +413,56 @@ func init() {
 	rootCmd.AddCommand(gitlabCmd)
 	rootCmd.AddCommand(authorsCmd)
 	rootCmd.AddCommand(codebaseCmd)
+	rootCmd.AddCommand(sizeCmd)
+	rootCmd.AddCommand(hotspotsCmd)
 	rootCmd.AddCommand(scanCmd)
 	rootCmd.AddCommand(tuiCmd)
 	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(tagsCmd)
+	rootCmd.AddCommand(releaseCmd)
+	rootCmd.AddCommand(changelogCmd)
+	rootCmd.AddCommand(gomodCmd)
+	rootCmd.AddCommand(compareCmd)
 	rootCmd.AddCommand(securityCmd)
 
 	// Add export format flags
 	checkCmd.Flags().StringVarP(&exportFormat, "format", "f", "terminal", "Output format: terminal, json, yaml, markdown, html")
 	checkCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path (default: stdout)")
+	badgeCmd.Flags().String("category", "", "Only generate the badge of one category: docs, commits, hygiene, structure, security")
+	checkCmd.Flags().Bool("explain", false, "Show how the overall score and grade were computed")
+	checkCmd.Flags().String("compare-to", "", "Report the change from this ref instead of the full report (terminal, json, yaml, markdown)")
+	compareCmd.Flags().StringP("format", "f", "terminal", "Output format: terminal, json, yaml, markdown")
+	compareCmd.Flags().StringP("output", "o", "", "Output file path (default: stdout)")
+	addCommitSelectionFlags(checkCmd)
+	addCommitSelectionFlags(authorsCmd)
+
+	// Add authors --by-dir flags
+	authorsCmd.Flags().Bool("by-dir", false, "Show the blame-based bus factor of each directory")
+	authorsCmd.Flags().Int("depth", 0, "Group --by-dir by the first N path segments (default: package directory)")
+	authorsCmd.Flags().Int("inactive-days", 0, "Days without commits before an author counts as inactive (default from gphc.yml)")
+	authorsCmd.Flags().Int("half-life", 0, "Days after which a blamed line counts half (default from gphc.yml)")
+	authorsCmd.Flags().String("format", "text", "Output format for --by-dir and --timeline (text, json)")
+	authorsCmd.Flags().String("output", "", "Output file path for --by-dir and --timeline JSON")
+
+	// Add authors --timeline flags
+	authorsCmd.Flags().Bool("timeline", false, "Show contributor activity over time and working patterns")
+	authorsCmd.Flags().Int("weeks", 0, "Weeks charted by --timeline (default from gphc.yml)")
 
 	// Add pre-commit command flags
 	preCommitCmd.Flags().StringVarP(&pathFlag, "path", "p", "", "Repository path to check")
 
+	// Add commit-msg command flags
+	commitMsgCmd.Flags().StringVarP(&pathFlag, "path", "p", "", "Repository path whose gphc.yml applies")
+	commitMsgCmd.Flags().String("format", "text", "Output format (text, json)")
+
+	// Add pre-push and hooks command flags
+	prePushCmd.Flags().StringVarP(&pathFlag, "path", "p", "", "Repository path to check")
+	prePushCmd.Flags().String("format", "text", "Output format (text, json)")
+	hooksCmd.PersistentFlags().StringVarP(&pathFlag, "path", "p", "", "Repository path whose hooks are managed")
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+	hooksCmd.AddCommand(hooksStatusCmd)
+
 	// Add suggest command flags
 	suggestCmd.Flags().StringVarP(&pathFlag, "path", "p", "", "Repository path to analyze")
 
@@ -308,14 +475,32 @@ func init() {
 	diffCmd.Flags().BoolVar(&diffUnstaged, "unstaged", false, "Show unstaged changes only")
 	diffCmd.Flags().StringVarP(&pathFlag, "path", "p", "", "Repository path to analyze")
 
+	// Add size command flags
+	sizeCmd.Flags().Int("top", 10, "Number of largest blobs and trees to show")
+	sizeCmd.Flags().Int("months", 6, "Months of per-directory growth to show (0 disables)")
+	sizeCmd.Flags().Float64("max-pack-size", 0, "Repository size threshold in MB (default from gphc.yml)")
+	sizeCmd.Flags().Int("max-loose-objects", 0, "Loose object threshold (default from gphc.yml)")
+	sizeCmd.Flags().Float64("max-blob-size", 0, "Blob size threshold in MB (default from gphc.yml)")
+	sizeCmd.Flags().String("format", "table", "Output format (table, json)")
+	sizeCmd.Flags().String("output", "", "Output file path")
+
+	// Add hotspots command flags
+	hotspotsCmd.Flags().Int("top", 0, "Number of hotspots and coupled pairs to show (default from gphc.yml)")
+	hotspotsCmd.Flags().Int("half-life", 0, "Days after which a change counts half (default from gphc.yml)")
+	hotspotsCmd.Flags().String("format", "table", "Output format (table, json)")
+	hotspotsCmd.Flags().String("output", "", "Output file path")
+	addCommitSelectionFlags(hotspotsCmd)
+
 	// Add scan command flags
 	scanCmd.Flags().BoolVarP(&recursiveScan, "recursive", "r", false, "Recursively scan subdirectories for Git repositories")
-	scanCmd.Flags().IntVarP(&minScore, "min-score", "m", 0, "Minimum health score threshold")
+	scanCmd.Flags().IntVarP(&minScore, "min-score", "m", 0, "Only list repositories at or above this health score; summaries cover all of them")
 	scanCmd.Flags().StringSliceVarP(&excludePatterns, "exclude", "e", []string{}, "Exclude directories matching patterns")
 	scanCmd.Flags().StringSliceVarP(&includePatterns, "include", "i", []string{}, "Include only files matching patterns")
 	scanCmd.Flags().IntVarP(&parallelJobs, "parallel", "p", 4, "Number of parallel jobs for scanning")
 	scanCmd.Flags().BoolVarP(&detailedReport, "detailed", "d", false, "Generate detailed report")
 	scanCmd.Flags().StringVarP(&scanOutputFile, "output", "o", "", "Output file path (default: stdout)")
+	scanCmd.Flags().StringP("format", "f", "terminal", "Output format: terminal, json, yaml, markdown, html, csv")
+	scanCmd.Flags().Int("worst", scorer.DefaultWorstOffenders, "Lowest scoring repositories listed per category")
 
 	// Add serve command flags
 	serveCmd.Flags().StringVarP(&serverHost, "host", "H", "localhost", "Host to bind the server to")
@@ -330,6 +515,36 @@ func init() {
 	tagsCmd.Flags().BoolVar(&tagsSuggest, "suggest", false, "Suggest next semantic version")
 	tagsCmd.Flags().StringVar(&tagsChangelogOut, "changelog", "", "Generate changelog to file (e.g. CHANGELOG.md)")
 	tagsCmd.Flags().BoolVar(&tagsEnforce, "enforce-tags", false, "Fail if tag policies are violated")
+	tagsCmd.Flags().StringVar(&tagsComponent, "component", "", "Only check, suggest and changelog this monorepo component")
+	tagsCmd.Flags().StringVar(&tagsFormat, "format", "text", "Output format: text, json (result with structured integrity findings)")
+	tagsCmd.Flags().StringVar(&tagsOutput, "output", "", "Write JSON output to file")
+	tagsCmd.Flags().StringSliceVar(&tagsAckMoves, "ack-move", nil, "Accept the new commit of a moved tag (repeatable)")
+
+	// Add gomod command flags
+	gomodCmd.Flags().Int("max-tags", 0, "Recent tags of each module to check (default from gphc.yml)")
+	gomodCmd.Flags().Bool("no-api", false, "Skip the exported API comparison of the last two stable tags")
+	gomodCmd.Flags().String("format", "text", "Output format (text, json)")
+	gomodCmd.Flags().String("output", "", "Output file path")
+
+	// Add release command flags
+	releaseCmd.Flags().Bool("dry-run", false, "Show the version, notes and changes without writing anything")
+	releaseCmd.Flags().String("pre", "", "Pre-release channel, e.g. rc or beta (tags -rc.N)")
+	releaseCmd.Flags().String("build", "", "Build metadata appended as +<build>")
+	releaseCmd.Flags().String("bump", "", "Force a major, minor or patch bump")
+	releaseCmd.Flags().Bool("sign", false, "Create a signed tag (default from gphc.yml)")
+	releaseCmd.Flags().String("version-file", "", "File whose version is updated (default from gphc.yml or detected)")
+	releaseCmd.Flags().String("changelog", "", "Changelog the notes are merged into (default from gphc.yml)")
+	releaseCmd.Flags().Bool("no-changelog", false, "Do not update the changelog")
+	releaseCmd.Flags().String("component", "", "Release this monorepo component from release.components")
+
+	// Add changelog command flags
+	changelogCmd.Flags().String("output", "", "Changelog file (default from gphc.yml)")
+	changelogCmd.Flags().Bool("stdout", false, "Print the generated changelog instead of writing it")
+	changelogCmd.Flags().String("format", "", "Changelog format: keep-a-changelog, template (default from gphc.yml)")
+	changelogCmd.Flags().String("template", "", "text/template file for the template format")
+	changelogCmd.Flags().Bool("authors", false, "Credit the author of each entry")
+	changelogCmd.Flags().Bool("overwrite", false, "Replace the file instead of merging into it")
+	changelogCmd.Flags().String("component", "", "Generate the changelog of this monorepo component")
 }
 
 var (
@@ -356,6 +571,10 @@ var (
 	tagsSuggest      bool
 	tagsChangelogOut string
 	tagsEnforce      bool
+	tagsComponent    string
+	tagsFormat       string
+	tagsOutput       string
+	tagsAckMoves     []string
 
 	// diff command flags
 	diffStaged   bool
@@ -366,7 +585,16 @@ var checkCmd = &cobra.Command{
 	Use:   "check [path]",
 	Short: "Run health check on a Git repository",
 	Long: `Run a comprehensive health check on the specified Git repository.
-If no path is provided, the current directory will be checked.`,
+If no path is provided, the current directory will be checked.
+Commit checks cover the latest max_commits_to_analyze commits from HEAD unless
+another slice of history is selected.
+
+Examples:
+  git hc check --range v1.2.0..HEAD          # Commits since a release
+  git hc check --since "3 months ago"        # A time window
+  git hc check --all-refs --no-merges        # Full history of every ref, without merges
+  git hc check --explain                     # Show how the score and grade were computed
+  git hc check --compare-to main -f markdown # Score change against main`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runCheck,
 }
@@ -404,11 +632,90 @@ This command will:
 	Run: runUpdate,
 }
 
+var commitMsgCmd = &cobra.Command{
+	Use:   "commit-msg <file>",
+	Short: "Lint a commit message file (commit-msg hook)",
+	Long: `Validate a commit message as the commit-msg Git hook.
+Git passes the path of the message file as the only argument. The message
+is parsed into subject, body and trailers and checked against gphc.yml:
+subject length (max_commit_message_length), the commit_convention profile,
+the blank line after the subject, body wrapping, required trailers such as
+Signed-off-by and issue references (commit_message).
+Returns a non-zero exit code and line:column errors if the message is invalid.
+
+Examples:
+  git hc commit-msg .git/COMMIT_EDITMSG
+  echo 'git hc commit-msg "$1"' > .git/hooks/commit-msg`,
+	Args: cobra.ExactArgs(1),
+	Run:  runCommitMsg,
+}
+
+var prePushCmd = &cobra.Command{
+	Use:   "pre-push [remote] [url]",
+	Short: "Check outgoing commits before a push (pre-push hook)",
+	Long: `Check the commits a push sends as the pre-push Git hook.
+Git passes the remote name and URL as arguments and one line per ref update on
+stdin. For each ref the outgoing range is computed from the remote's tip (or the
+remote-tracking refs for new branches), and only those commits are checked:
+  conventional  commit subjects follow the commit_convention profile
+  length        subjects fit max_commit_message_length
+  commit_size   commits stay under max_commit_size_lines
+  signatures    every commit has a good signature
+  secrets       no secrets in added lines
+  binaries      no executables, disguised binaries or blobs above max_blob_size_mb
+  policy        push rules of .gphc-policy.yml (protected branches, forbidden files)
+The gates that run are listed under hooks.pre_push in gphc.yml. The commit gates check
+each commit on its own, so a single violating commit fails the gate.
+Returns a non-zero exit code, which blocks the push, if a gate fails.
+
+Examples:
+  git hc hooks install pre-push
+  echo "refs/heads/main $(git rev-parse HEAD) refs/heads/main $(git rev-parse origin/main)" | git hc pre-push origin`,
+	Args: cobra.MaximumNArgs(2),
+	Run:  runPrePush,
+}
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Install and manage the Git hooks that run gphc",
+	Long: `Install, remove and inspect the pre-commit, commit-msg and pre-push hooks.
+Hooks are written to the repository hooks directory, honoring core.hooksPath.
+An existing hook is kept as <hook>.pre-gphc and run before the gphc checks, and
+is restored on uninstall. The checks each stage runs are chosen in the hooks
+section of gphc.yml.
+
+Examples:
+  git hc hooks install                 # Install all hooks
+  git hc hooks install pre-push        # Install only the pre-push hook
+  git hc hooks status                  # Show installed hooks and their checks
+  git hc hooks uninstall               # Remove gphc hooks`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install [stage...]",
+	Short: "Install gphc hooks (pre-commit, commit-msg, pre-push)",
+	Run:   runHooksInstall,
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall [stage...]",
+	Short: "Remove gphc hooks and restore chained hooks",
+	Run:   runHooksUninstall,
+}
+
+var hooksStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which hooks are installed and the checks they run",
+	Args:  cobra.NoArgs,
+	Run:   runHooksStatus,
+}
+
 var preCommitCmd = &cobra.Command{
 	Use:   "pre-commit [path]",
 	Short: "Run quick pre-commit checks on staged files",
 	Long: `Run quick health checks suitable for pre-commit hooks.
-This command performs fast checks on staged files and current commit.
+This command performs fast checks on staged files.
+Commit messages are validated by the commit-msg command.
 Designed for integration with pre-commit framework and Husky.
 Returns non-zero exit code if issues are found.
 
@@ -455,31 +762,32 @@ func runPreCommit(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	repositoryConfig, err := loadRepositoryConfig(path)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	enabled := repositoryConfig.Hooks.PreCommit
+
 	fmt.Printf("Pre-commit check on %d staged files\n", len(stagedFiles))
 
 	// Run quick checks
 	issues := 0
 
 	// Check 1: File formatting
-	if !checkFileFormatting(path, stagedFiles) {
+	if hookCheckEnabled(enabled, "format") && !checkFileFormatting(path, stagedFiles) {
 		fmt.Println("Some files are not properly formatted")
 		issues++
 	}
 
-	// Check 2: Commit message (if committing)
-	if !checkCommitMessage(path) {
-		fmt.Println("Commit message doesn't follow conventional format")
-		issues++
-	}
-
-	// Check 3: Large files
-	if !checkLargeFiles(path, stagedFiles) {
+	// Check 2: Large files
+	if hookCheckEnabled(enabled, "large_files") && !checkLargeFiles(path, stagedFiles) {
 		fmt.Println("Some files are too large")
 		issues++
 	}
 
-	// Check 4: Sensitive files
-	if !checkSensitiveFiles(stagedFiles) {
+	// Check 3: Sensitive files
+	if hookCheckEnabled(enabled, "sensitive_files") && !checkSensitiveFiles(stagedFiles) {
 		fmt.Println("Sensitive files detected in staging area")
 		issues++
 	}
@@ -675,18 +983,35 @@ func runCheck(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("Analyzing repository: %s\n", path)
 
-	healthReport, err := buildHealthReport(path)
+	selection := commitSelectionFromFlags(cmd)
+	healthReport, err := buildHealthReportForSelection(path, selection)
 	if err != nil {
 		fmt.Printf("Error running health check: %v\n", err)
 		return
 	}
 
+	if compareTo, _ := cmd.Flags().GetString("compare-to"); compareTo != "" {
+		comparison, err := compareToRef(path, compareTo, healthReport, selection)
+		if err != nil {
+			fmt.Printf("Error comparing with %s: %v\n", compareTo, err)
+			os.Exit(1)
+		}
+		if err := writeComparison(comparison, exportFormat, outputFile); err != nil {
+			fmt.Printf("Error exporting comparison: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Handle different output formats
 	if exportFormat == "terminal" {
 		// Display results in terminal format
 		reporter := reporter.NewReporter()
 		output := reporter.Report(healthReport)
 		fmt.Println(output)
+		if explain, _ := cmd.Flags().GetBool("explain"); explain {
+			fmt.Println(reporter.Explain(healthReport))
+		}
 	} else {
 		// Export in specified format
 		exp := exporter.NewExporter()
@@ -740,53 +1065,46 @@ func runBadge(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("Analyzing repository: %s\n", path)
 
-	// Initialize analyzer
-	analyzer, err := git.NewRepositoryAnalyzer(path)
-	if err != nil {
-		fmt.Printf("Error initializing repository analyzer: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Analyze repository
-	data, err := analyzer.Analyze()
+	// Badges use the same checks, weights and penalties as git hc check
+	healthReport, err := buildHealthReport(path)
 	if err != nil {
-		fmt.Printf("Error analyzing repository: %v\n", err)
+		fmt.Printf("Error running health check: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Initialize checkers
-	allCheckers := []checkers.Checker{
-		checkers.NewDocChecker(),
-		checkers.NewIgnoreChecker(),
-		checkers.NewConventionalCommitChecker(),
-		checkers.NewMsgLengthChecker(),
-		checkers.NewCommitAuthorInsightsChecker(),
-		checkers.NewCodebaseSmellChecker(),
-		checkers.NewLocalBranchChecker(),
-		checkers.NewStaleBranchChecker(),
-		checkers.NewStashChecker(),
-		checkers.NewGitHubIntegrationChecker(),
-		checkers.NewGitLabIntegrationChecker(),
-	}
-
-	// Run all checkers
-	scorer := scorer.NewScorer()
-	for _, checker := range allCheckers {
-		result := checker.Check(data)
-		scorer.AddResult(*result)
+	exp := exporter.NewExporter()
+	categoryKey, _ := cmd.Flags().GetString("category")
+	if categoryKey != "" {
+		category, err := types.ParseCategory(categoryKey)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		score := healthReport.Category(category)
+		if score == nil {
+			fmt.Printf("Error: no %s checks ran\n", category.Key())
+			os.Exit(1)
+		}
+		fmt.Printf("%s Score: %d/100 (%s)\n\n", score.Name, score.Score, score.Grade)
+		fmt.Printf("🔗 Badge URL:\n%s\n\n", exp.GenerateCategoryBadgeURL(*score))
+		fmt.Printf("Markdown Badge:\n%s\n", exp.GenerateCategoryMarkdownBadge(*score))
+		return
 	}
 
-	// Generate report
-	healthReport := scorer.CalculateHealthReport()
-
 	// Generate badge
-	exp := exporter.NewExporter()
 	badgeURL := exp.GenerateBadgeURL(healthReport.OverallScore)
 	markdownBadge := exp.GenerateMarkdownBadge(healthReport.OverallScore)
 
 	fmt.Printf("Health Score: %d/100 (%s)\n\n", healthReport.OverallScore, healthReport.Grade)
 	fmt.Printf("🔗 Badge URL:\n%s\n\n", badgeURL)
 	fmt.Printf("Markdown Badge:\n%s\n", markdownBadge)
+
+	if len(healthReport.Categories) > 0 {
+		fmt.Printf("\nCategory Badges:\n")
+		for _, category := range healthReport.Categories {
+			fmt.Printf("%-34s %3d/100 %-5s %s\n", category.Name, category.Score, "("+category.Grade+")", exp.GenerateCategoryMarkdownBadge(category))
+		}
+	}
 }
 
 func runGitHub(cmd *cobra.Command, args []string) {
@@ -930,6 +1248,15 @@ func runAuthors(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if byDir, _ := cmd.Flags().GetBool("by-dir"); byDir {
+		runAuthorsByDir(cmd, path)
+		return
+	}
+	if timeline, _ := cmd.Flags().GetBool("timeline"); timeline {
+		runAuthorsTimeline(cmd, path)
+		return
+	}
+
 	fmt.Printf("Analyzing commit authors: %s\n", path)
 
 	// Initialize analyzer
@@ -938,6 +1265,7 @@ func runAuthors(cmd *cobra.Command, args []string) {
 		fmt.Printf("❌ Error initializing analyzer: %v\n", err)
 		os.Exit(1)
 	}
+	analyzer.SetCommitSelection(commitSelectionFromFlags(cmd))
 
 	// Analyze repository
 	data, err := analyzer.Analyze()
@@ -946,8 +1274,15 @@ func runAuthors(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	repositoryConfig, err := loadRepositoryConfig(path)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	options := identityOptions(repositoryConfig.Identity)
+
 	// Initialize author insights checker
-	checker := checkers.NewCommitAuthorInsightsChecker()
+	checker := checkers.NewCommitAuthorInsightsCheckerWithIdentity(options)
 
 	// Run author insights check
 	result := checker.Check(data)
@@ -969,13 +1304,17 @@ func runAuthors(cmd *cobra.Command, args []string) {
 	if len(data.Commits) > 0 {
 		fmt.Printf("\nBus Factor Analysis:\n")
 
-		// Count unique authors
-		authorMap := make(map[string]bool)
-		for _, commit := range data.Commits {
-			authorMap[commit.Author] = true
+		// Count unique people, merging aliases and leaving out bots
+		index, err := checkers.ResolveAuthors(path, data.Commits, options)
+		if err != nil {
+			fmt.Printf("Error resolving author identities: %v\n", err)
+			os.Exit(1)
+		}
+		uniqueAuthors := len(index.Contributors())
+		if uniqueAuthors == 0 {
+			fmt.Printf("  No human contributors found\n")
+			return
 		}
-
-		uniqueAuthors := len(authorMap)
 
 		if uniqueAuthors == 1 {
 			fmt.Printf("  HIGH RISK: Single contributor project\n")
@@ -998,6 +1337,29 @@ func runAuthors(cmd *cobra.Command, args []string) {
 			fmt.Printf("  Bus Factor: %d (Low Risk)\n", uniqueAuthors)
 			fmt.Printf("  Recommendation: Excellent team distribution\n")
 		}
+
+		var merged []*identity.Identity
+		for _, id := range index.Contributors() {
+			if len(id.Aliases) > 1 {
+				merged = append(merged, id)
+			}
+		}
+		if len(merged) > 0 {
+			fmt.Printf("\nMerged Identities:\n")
+			for _, id := range merged {
+				var aliases []string
+				for _, alias := range id.Aliases {
+					aliases = append(aliases, fmt.Sprintf("%s <%s>", alias.Name, alias.Email))
+				}
+				fmt.Printf("  %s <%s>: %s\n", id.Name, id.Email, strings.Join(aliases, ", "))
+			}
+		}
+		if bots := index.Bots(); len(bots) > 0 {
+			fmt.Printf("\nBots:\n")
+			for _, bot := range bots {
+				fmt.Printf("  %s <%s>: %d commits\n", bot.Name, bot.Email, bot.Commits)
+			}
+		}
 	}
 }
 
@@ -1157,72 +1519,6 @@ func checkFileFormatting(repoPath string, files []string) bool {
 	return len(output) == 0
 }
 
-func checkCommitMessage(repoPath string) bool {
-	// Check if we're in the middle of a commit (has COMMIT_EDITMSG)
-	commitMsgPath := filepath.Join(repoPath, ".git", "COMMIT_EDITMSG")
-	if _, err := os.Stat(commitMsgPath); err == nil {
-		// Read the commit message file
-		content, err := os.ReadFile(commitMsgPath)
-		if err != nil {
-			return false
-		}
-
-		message := strings.TrimSpace(string(content))
-		// Remove comments (lines starting with #)
-		lines := strings.Split(message, "\n")
-		var cleanLines []string
-		for _, line := range lines {
-			if !strings.HasPrefix(strings.TrimSpace(line), "#") {
-				cleanLines = append(cleanLines, line)
-			}
-		}
-		message = strings.Join(cleanLines, "\n")
-		message = strings.TrimSpace(message)
-
-		// Check conventional commit format
-		conventionalPrefixes := []string{
-			"feat:", "fix:", "docs:", "style:", "refactor:", "test:", "chore:",
-			"perf:", "ci:", "build:", "revert:", "feat!", "fix!",
-		}
-
-		for _, prefix := range conventionalPrefixes {
-			if strings.HasPrefix(message, prefix) {
-				return true
-			}
-		}
-
-		return false
-	}
-
-	// If not committing, check the last commit message
-	cmd := exec.Command("git", "log", "-1", "--pretty=%s")
-	cmd.Dir = repoPath
-
-	output, err := cmd.Output()
-	if err != nil {
-		return true // If no commits yet, consider it valid
-	}
-
-	message := strings.TrimSpace(string(output))
-	if message == "" {
-		return true // Empty message is valid for first commit
-	}
-
-	// Check conventional commit format
-	conventionalPrefixes := []string{
-		"feat:", "fix:", "docs:", "style:", "refactor:", "test:", "chore:",
-		"perf:", "ci:", "build:", "revert:", "feat!", "fix!",
-	}
-
-	for _, prefix := range conventionalPrefixes {
-		if strings.HasPrefix(message, prefix) {
-			return true
-		}
-	}
-
-	return false
-}
-
 func displayColoredDiff(diffOutput string) {
 	lines := strings.Split(diffOutput, "\n")
 
@@ -1290,6 +1586,9 @@ func checkSensitiveFiles(files []string) bool {
 }
 
 func runScan(cmd *cobra.Command, args []string) {
+	format, _ := cmd.Flags().GetString("format")
+	worst, _ := cmd.Flags().GetInt("worst")
+
 	var scanPath string
 	if len(args) > 0 {
 		scanPath = args[0]
@@ -1302,8 +1601,10 @@ func runScan(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	fmt.Printf("Multi-Repository Health Scan Results\n")
-	fmt.Printf("====================================\n\n")
+	if format == "terminal" {
+		fmt.Printf("Multi-Repository Health Scan Results\n")
+		fmt.Printf("====================================\n\n")
+	}
 
 	// Find Git repositories
 	repos, err := findGitRepositories(scanPath, recursiveScan)
@@ -1326,7 +1627,7 @@ func runScan(cmd *cobra.Command, args []string) {
 	}
 
 	type scanOutcome struct {
-		result ScanResult
+		result types.RepositoryScan
 		err    error
 	}
 	jobs := make(chan string)
@@ -1336,14 +1637,15 @@ func runScan(cmd *cobra.Command, args []string) {
 			for repo := range jobs {
 				report, err := buildHealthReport(repo)
 				if err != nil {
-					outcomes <- scanOutcome{result: ScanResult{Path: repo}, err: err}
+					outcomes <- scanOutcome{result: types.RepositoryScan{Path: repo}, err: err}
 					continue
 				}
-				outcomes <- scanOutcome{result: ScanResult{
-					Name:  filepath.Base(repo),
-					Path:  repo,
-					Score: report.OverallScore,
-					Grade: report.Grade,
+				outcomes <- scanOutcome{result: types.RepositoryScan{
+					Name:   filepath.Base(repo),
+					Path:   repo,
+					Score:  report.OverallScore,
+					Grade:  report.Grade,
+					Report: report,
 				}}
 			}
 		}()
@@ -1355,31 +1657,70 @@ func runScan(cmd *cobra.Command, args []string) {
 		close(jobs)
 	}()
 
-	results := make([]ScanResult, 0, len(repos))
+	results := make([]types.RepositoryScan, 0, len(repos))
+	var scanErrors []types.ScanError
 	for range repos {
 		outcome := <-outcomes
 		if outcome.err != nil {
-			fmt.Printf("Error scanning %s: %v\n", outcome.result.Path, outcome.err)
+			scanErrors = append(scanErrors, types.ScanError{Path: outcome.result.Path, Error: outcome.err.Error()})
 			continue
 		}
-		if minScore == 0 || outcome.result.Score >= minScore {
-			results = append(results, outcome.result)
+		results = append(results, outcome.result)
+	}
+	sort.Slice(scanErrors, func(i, j int) bool { return scanErrors[i].Path < scanErrors[j].Path })
+
+	// The summaries cover every scanned repository; --min-score only trims the listing
+	scanReport := scorer.AggregateScan(results, worst)
+	if minScore > 0 {
+		listed := scanReport.Repositories[:0]
+		for _, result := range scanReport.Repositories {
+			if result.Score >= minScore {
+				listed = append(listed, result)
+			}
+		}
+		scanReport.Repositories = listed
+	}
+	scanReport.Root = scanPath
+	if absolutePath, err := filepath.Abs(scanPath); err == nil {
+		scanReport.Root = absolutePath
+	}
+	scanReport.Timestamp = time.Now()
+	scanReport.Errors = scanErrors
+
+	if format != "terminal" {
+		output, err := exporter.NewExporter().ExportScan(scanReport, exporter.ExportFormat(format))
+		if err != nil {
+			fmt.Printf("Error exporting scan results: %v\n", err)
+			os.Exit(1)
+		}
+		if scanOutputFile == "" {
+			fmt.Print(output)
+			return
+		}
+		if err := os.WriteFile(scanOutputFile, []byte(output), 0644); err != nil {
+			fmt.Printf("Error writing scan results: %v\n", err)
+			os.Exit(1)
 		}
+		fmt.Printf("Results written to %s\n", scanOutputFile)
+		return
 	}
-	sort.Slice(results, func(i, j int) bool { return results[i].Path < results[j].Path })
 
-	totalScore := 0.0
-	for _, result := range results {
-		totalScore += float64(result.Score)
+	for _, scanError := range scanReport.Errors {
+		fmt.Printf("Error scanning %s: %s\n", scanError.Path, scanError.Error)
+	}
+	for _, result := range scanReport.Repositories {
 		if detailedReport {
 			fmt.Printf("%s\n  Path: %s\n  Score: %d/100 (%s)\n", result.Name, result.Path, result.Score, result.Grade)
+			for _, category := range result.Report.Categories {
+				fmt.Printf("  %s: %d/100 (%s)\n", category.Name, category.Score, category.Grade)
+			}
 		} else {
 			fmt.Printf("%s: %d/100 (%s)\n", result.Name, result.Score, result.Grade)
 		}
 	}
 
 	if scanOutputFile != "" {
-		data, err := json.MarshalIndent(results, "", "  ")
+		data, err := json.MarshalIndent(scanReport, "", "  ")
 		if err != nil {
 			fmt.Printf("Error encoding scan results: %v\n", err)
 			return
@@ -1391,29 +1732,50 @@ func runScan(cmd *cobra.Command, args []string) {
 		fmt.Printf("Results written to %s\n", scanOutputFile)
 	}
 
-	// Calculate average
-	if len(results) > 0 {
-		averageScore := totalScore / float64(len(results))
-		fmt.Printf("\nSummary:\n")
-		fmt.Printf("  Total Repositories: %d\n", len(results))
-		fmt.Printf("  Average Health: %.1f/100\n", averageScore)
-
-		if len(results) > 0 {
-			// Find highest and lowest scores
-			highest := results[0]
-			lowest := results[0]
+	if scanReport.Summary.TotalRepositories > 0 {
+		printScanAggregates(scanReport)
+	}
+}
 
-			for _, result := range results {
-				if result.Score > highest.Score {
-					highest = result
-				}
-				if result.Score < lowest.Score {
-					lowest = result
-				}
+// printScanAggregates prints the fleet-wide summary, score distribution, category averages
+// and most failed checks of a scan
+func printScanAggregates(report *types.ScanReport) {
+	summary := report.Summary
+	fmt.Printf("\nSummary:\n")
+	fmt.Printf("  Total Repositories: %d\n", summary.TotalRepositories)
+	fmt.Printf("  Average Health: %.1f/100\n", summary.AverageScore)
+	fmt.Printf("  Median Health: %.1f/100\n", summary.MedianScore)
+	fmt.Printf("  Highest Score: %s (%d/100)\n", summary.Highest.Name, summary.Highest.Score)
+	fmt.Printf("  Lowest Score: %s (%d/100)\n", summary.Lowest.Name, summary.Lowest.Score)
+
+	fmt.Printf("\nScore Distribution:\n")
+	for i := len(report.Histogram) - 1; i >= 0; i-- {
+		bucket := report.Histogram[i]
+		fmt.Printf("  %6s | %s %d\n", bucket.Label(), strings.Repeat("█", bucket.Count), bucket.Count)
+	}
+
+	if len(report.Categories) > 0 {
+		fmt.Printf("\nCategory Averages:\n")
+		for _, category := range report.Categories {
+			fmt.Printf("  %-36s %5.1f (min %d, max %d)\n", category.Name, category.AverageScore, category.MinScore, category.MaxScore)
+			var offenders []string
+			for _, offender := range category.WorstOffenders {
+				offenders = append(offenders, fmt.Sprintf("%s (%d)", offender.Name, offender.Score))
 			}
+			fmt.Printf("    Worst: %s\n", strings.Join(offenders, ", "))
+		}
+	}
 
-			fmt.Printf("  Highest Score: %s (%d/100)\n", highest.Name, highest.Score)
-			fmt.Printf("  Lowest Score: %s (%d/100)\n", lowest.Name, lowest.Score)
+	var failing []types.CheckFailureCount
+	for _, check := range report.CheckFailures {
+		if check.Failed > 0 && len(failing) < 10 {
+			failing = append(failing, check)
+		}
+	}
+	if len(failing) > 0 {
+		fmt.Printf("\nMost Failed Checks:\n")
+		for _, check := range failing {
+			fmt.Printf("  %-16s %-40s %d failed, %d warnings\n", check.ID, check.Name, check.Failed, check.Warnings)
 		}
 	}
 }
@@ -1443,13 +1805,6 @@ func matchesRepositoryPattern(repo string, patterns []string) bool {
 	return false
 }
 
-type ScanResult struct {
-	Name  string
-	Path  string
-	Score int
-	Grade string
-}
-
 func findGitRepositories(rootPath string, recursive bool) ([]string, error) {
 	var repos []string
 
@@ -1520,6 +1875,7 @@ func runServe(cmd *cobra.Command, args []string) {
 	mux.HandleFunc("/", handleDashboard)
 	mux.HandleFunc("/api/health", handleHealthAPI)
 	mux.HandleFunc("/api/tags", handleTagsAPI)
+	mux.HandleFunc("/api/authors/timeline", handleTimelineAPI)
 	mux.HandleFunc("/api/diff", handleDiffAPI)
 	mux.HandleFunc("/api/export/json", handleExportJSON)
 
@@ -1574,9 +1930,39 @@ func runTags(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Run TagChecker alone for this command
-	tc := checkers.NewTagChecker()
-	res := tc.Check(data)
+	repositoryConfig, err := loadRepositoryConfig(path)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	components, err := releaseComponents(path, repositoryConfig.Release)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if tagsComponent != "" {
+		component, err := checkers.FindComponent(components, tagsComponent)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		components = []checkers.ReleaseComponent{component}
+	}
+
+	// Run TagChecker alone for this command; only this command records tags in the history store
+	options := tagCheckerOptions(components, repositoryConfig)
+	options.Integrity.RecordTags = repositoryConfig.Tags.TrackMoves
+	options.Integrity.AcknowledgedMoves = tagsAckMoves
+	tc := checkers.NewTagCheckerWithOptions(options)
+	res, report := tc.CheckWithReport(data)
+
+	if tagsFormat == "json" {
+		outputTagsJSON(res, report, tagsOutput)
+		if tagsEnforce && (res.Status == types.StatusFail || res.Score < 50) {
+			os.Exit(1)
+		}
+		return
+	}
 
 	// Print concise report
 	fmt.Println("Tag & Release Health")
@@ -1591,23 +1977,33 @@ func runTags(cmd *cobra.Command, args []string) {
 
 	// Suggest next tag
 	if tagsSuggest {
-		next, err := checkers.SuggestNextTag(path)
-		if err == nil {
-			fmt.Printf("\nAuto-suggested next tag: %s\n", next)
+		if len(components) == 0 {
+			next, err := checkers.SuggestNextTag(path)
+			if err == nil {
+				fmt.Printf("\nAuto-suggested next tag: %s\n", next)
+			}
+		} else {
+			fmt.Printf("\nAuto-suggested next tags:\n")
+			for _, component := range components {
+				if next, err := checkers.SuggestComponentTag(path, component); err == nil {
+					fmt.Printf("- %s: %s\n", component.Name, next)
+				}
+			}
 		}
 	}
 
 	// Generate changelog
 	if tagsChangelogOut != "" {
-		content, err := checkers.GenerateChangelog(path, tagsChangelogOut)
+		options := checkers.DefaultChangelogOptions()
+		options.TagPrefix = repositoryConfig.Release.TagPrefix
+		if tagsComponent != "" {
+			options = components[0].ChangelogOptions(options)
+		}
+		_, err := checkers.GenerateChangelogWithOptions(path, tagsChangelogOut, options, true)
 		if err != nil {
 			fmt.Printf("Error generating changelog: %v\n", err)
 		} else {
-			if tagsChangelogOut != "" {
-				fmt.Printf("Changelog generated: %s\n", tagsChangelogOut)
-			} else {
-				fmt.Println(content)
-			}
+			fmt.Printf("Changelog generated: %s\n", tagsChangelogOut)
 		}
 	}
 
@@ -1,7 +1,12 @@
 package main
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/vahidaghazadeh/gphc/internal/checkers"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
 )
 
 func TestVersion(t *testing.T) {
@@ -161,3 +166,78 @@ func TestFilterRepositories(t *testing.T) {
 		t.Fatalf("filterRepositories() = %#v", got)
 	}
 }
+
+func TestCommitGatesFailOnAnyViolation(t *testing.T) {
+	cfg := config.DefaultConfig()
+	commits := []types.CommitInfo{
+		{Hash: "1111111111", Subject: "feat: add login", LinesAdded: 20},
+		{Hash: "2222222222", Subject: "added creds " + strings.Repeat("x", 80), LinesAdded: 900},
+	}
+
+	results, err := commitGates(cfg, commits)
+	if err != nil {
+		t.Fatalf("commitGates failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected three gates, got %+v", results)
+	}
+	// One bad commit out of two fails every gate, even though half the commits conform
+	for _, result := range results {
+		if result.Passed || len(result.Details) != 1 || !strings.HasPrefix(result.Details[0], "22222222 ") {
+			t.Errorf("expected %s to fail on the second commit: %+v", result.Gate, result)
+		}
+	}
+
+	// Subjects are measured in characters, so a 66 character subject of multi-byte runes fits
+	accented := types.CommitInfo{Hash: "3333333333", Subject: "feat: " + strings.Repeat("é", 60), LinesAdded: 500}
+	results, _ = commitGates(cfg, []types.CommitInfo{commits[0], accented})
+	for _, result := range results {
+		if !result.Passed {
+			t.Errorf("expected %s to pass: %+v", result.Gate, result)
+		}
+	}
+
+	// Subjects git writes for merges and reverts pass the conventional gate
+	generated := []types.CommitInfo{
+		{Hash: "4444444444", Subject: "Merge branch 'feature/login' into main", IsMerge: true},
+		{Hash: "5555555555", Subject: `Revert "feat: add login"`},
+	}
+	results, _ = commitGates(cfg, generated)
+	if !results[0].Passed || results[0].Gate != "conventional" {
+		t.Errorf("expected generated subjects to pass: %+v", results[0])
+	}
+}
+
+func TestSignatureGateFollowsPolicySigning(t *testing.T) {
+	defaults := config.DefaultConfig().Hooks.PrePush
+	if required := signatureGateRequirement(defaults, nil); required != 0 {
+		t.Errorf("default gates without a signing policy require %.1f%% signed commits", required)
+	}
+
+	// A policy that requires signing turns the gate on without listing it in gphc.yml
+	doc := &checkers.PolicyDocument{Signing: &checkers.SigningRule{RequiredPercentage: 90}}
+	if required := signatureGateRequirement(defaults, doc); required != 90 {
+		t.Errorf("signing policy requirement = %.1f, want 90", required)
+	}
+	if required := signatureGateRequirement(append(defaults, "signatures"), doc); required != 100 {
+		t.Errorf("listed signatures gate requirement = %.1f, want 100", required)
+	}
+}
+
+func TestScoringOptionsCustomGradesKeepDefaultPenalty(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Scoring.Grades = []config.GradeBand{{Grade: "A", Min: 90}, {Grade: "B", Min: 75}, {Grade: "C", Min: 0}}
+	options, err := scoringOptions(cfg)
+	if err != nil {
+		t.Fatalf("custom grades without D rejected: %v", err)
+	}
+	if len(options.Penalties) != 1 || options.Penalties[0].MaxGrade != "B" {
+		t.Errorf("default penalty = %+v, want a cap at B", options.Penalties)
+	}
+
+	// A penalty the user wrote still has to name a configured grade
+	cfg.Scoring.Penalties = []config.ScorePenalty{{Check: "secret-scanning", Severity: "high", MaxGrade: "D"}}
+	if _, err := scoringOptions(cfg); err == nil {
+		t.Error("expected an error for a penalty capping at an unknown grade")
+	}
+}
@@ -0,0 +1,385 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"github.com/vahidaghazadeh/gphc/internal/checkers"
+	"github.com/vahidaghazadeh/gphc/internal/git"
+	"github.com/vahidaghazadeh/gphc/internal/hooks"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// pushGateResult is the outcome of one pre-push gate
+type pushGateResult struct {
+	Gate    string   `json:"gate"`
+	Passed  bool     `json:"passed"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// pushRange is the set of commits one ref update sends
+type pushRange struct {
+	LocalRef  string   `json:"local_ref"`
+	RemoteRef string   `json:"remote_ref"`
+	Range     string   `json:"range"`
+	Commits   []string `json:"commits"`
+}
+
+func runPrePush(cmd *cobra.Command, args []string) {
+	format, _ := cmd.Flags().GetString("format")
+
+	repoPath := pathFlag
+	if repoPath == "" {
+		var err error
+		repoPath, err = os.Getwd()
+		if err != nil {
+			fmt.Printf("Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if !isGitRepository(repoPath) {
+		fmt.Printf("Error: %s is not a Git repository\n", repoPath)
+		os.Exit(1)
+	}
+
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	updates, err := hooks.ParsePushUpdates(os.Stdin)
+	if err != nil {
+		fmt.Printf("Error reading ref updates: %v\n", err)
+		os.Exit(1)
+	}
+
+	var ranges []pushRange
+	var commits []string
+	var refs []checkers.PushedRef
+	seen := make(map[string]bool)
+	for _, update := range updates {
+		// Deletions push no commits but the policy still checks which branches they remove
+		refs = append(refs, checkers.PushedRef{RemoteRef: update.RemoteRef, LocalOID: update.LocalOID, RemoteOID: update.RemoteOID})
+		if update.IsDelete() {
+			continue
+		}
+		outgoing, err := hooks.OutgoingCommits(repoPath, remote, update)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		ranges = append(ranges, pushRange{
+			LocalRef:  update.LocalRef,
+			RemoteRef: update.RemoteRef,
+			Range:     updateRange(update),
+			Commits:   outgoing,
+		})
+		for _, commit := range outgoing {
+			if !seen[commit] {
+				seen[commit] = true
+				commits = append(commits, commit)
+			}
+		}
+	}
+	if len(refs) == 0 {
+		fmt.Println("No refs to check")
+		return
+	}
+
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	results, err := runPushGates(repoPath, repositoryConfig, refs, commits)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if !result.Passed {
+			failed++
+		}
+	}
+
+	switch format {
+	case "json":
+		payload := struct {
+			Remote  string           `json:"remote,omitempty"`
+			Ranges  []pushRange      `json:"ranges"`
+			Commits int              `json:"commits"`
+			Gates   []pushGateResult `json:"gates"`
+			Blocked bool             `json:"blocked"`
+		}{Remote: remote, Ranges: ranges, Commits: len(commits), Gates: results, Blocked: failed > 0}
+		jsonData, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			fmt.Printf("Error marshaling JSON: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonData))
+	default:
+		fmt.Printf("Pre-push check on %d outgoing commit(s)\n", len(commits))
+		for _, r := range ranges {
+			fmt.Printf("  %s -> %s: %s (%d commit(s))\n", r.LocalRef, r.RemoteRef, r.Range, len(r.Commits))
+		}
+		fmt.Println()
+		for _, result := range results {
+			status := "PASS"
+			if !result.Passed {
+				status = "FAIL"
+			}
+			fmt.Printf("%s %-13s %s\n", status, result.Gate, result.Message)
+			if !result.Passed {
+				for _, detail := range result.Details {
+					fmt.Printf("     %s\n", detail)
+				}
+			}
+		}
+		if failed == 0 {
+			fmt.Println("\nAll pre-push checks passed")
+		} else {
+			fmt.Printf("\n%d pre-push check(s) failed; push rejected\n", failed)
+		}
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// runPushGates runs the pre-push gates enabled in gphc.yml over the outgoing commits
+func runPushGates(repoPath string, cfg *config.Config, refs []checkers.PushedRef, commits []string) ([]pushGateResult, error) {
+	enabled := cfg.Hooks.PrePush
+	var results []pushGateResult
+
+	// The policy document also switches on the signatures gate when it declares a signing requirement
+	doc, policyPath, policyErr := checkers.FindPolicyDocument(repoPath)
+	if policyErr != nil && hookCheckEnabled(enabled, "policy") {
+		return nil, fmt.Errorf("load policy: %w", policyErr)
+	}
+
+	// Gates 1-3: commit message format, length and commit size, validated commit by commit
+	if len(commits) > 0 && (hookCheckEnabled(enabled, "conventional") || hookCheckEnabled(enabled, "length") || hookCheckEnabled(enabled, "commit_size")) {
+		analyzer, err := git.NewRepositoryAnalyzer(repoPath)
+		if err != nil {
+			return nil, err
+		}
+		commitInfos, err := analyzer.AnalyzeCommitsByHash(commits)
+		if err != nil {
+			return nil, err
+		}
+		gates, err := commitGates(cfg, commitInfos)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, gates...)
+	}
+
+	// Gate 4: outgoing commits carry good signatures
+	if required := signatureGateRequirement(enabled, doc); required > 0 && len(commits) > 0 {
+		signatures, err := checkers.VerifyCommitSignatures(repoPath, commits, signatureVerificationOptions(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("verify signatures: %w", err)
+		}
+		result := pushGateResult{Gate: "signatures"}
+		for _, signature := range signatures {
+			if signature.Status != checkers.SignatureGood {
+				result.Details = append(result.Details, fmt.Sprintf("%s: %s", types.ShortHash(signature.Hash), signature.Status))
+			}
+		}
+		good := len(signatures) - len(result.Details)
+		result.Passed = len(signatures) == 0 || float64(good)/float64(len(signatures))*100 >= required
+		switch {
+		case len(result.Details) == 0:
+			result.Message = fmt.Sprintf("All %d commits are signed", len(signatures))
+		case required < 100:
+			result.Message = fmt.Sprintf("%d of %d commits lack a good signature (%.1f%% must be signed)", len(result.Details), len(signatures), required)
+		default:
+			result.Message = fmt.Sprintf("%d of %d commits lack a good signature", len(result.Details), len(signatures))
+		}
+		if result.Passed {
+			result.Details = nil
+		}
+		results = append(results, result)
+	}
+
+	// Gate 5: secrets added by the outgoing commits
+	if hookCheckEnabled(enabled, "secrets") && len(commits) > 0 {
+		secrets, err := checkers.NewSecretChecker().ScanCommits(repoPath, commits, false, "high", 0.8)
+		if err != nil {
+			return nil, fmt.Errorf("scan commits for secrets: %w", err)
+		}
+		result := pushGateResult{Gate: "secrets", Passed: len(secrets) == 0, Message: "No secrets in outgoing commits"}
+		if len(secrets) > 0 {
+			result.Message = fmt.Sprintf("%d potential secret(s) in outgoing commits", len(secrets))
+			for _, secret := range secrets {
+				result.Details = append(result.Details, fmt.Sprintf("%s:%d %s (commit %s)", secret.File, secret.Line, secret.Type, types.ShortHash(secret.Commit)))
+			}
+		}
+		results = append(results, result)
+	}
+
+	// Gate 6: executables, disguised binaries and oversized files added by the outgoing commits
+	if hookCheckEnabled(enabled, "binaries") && len(commits) > 0 {
+		report, err := checkers.NewBinaryFileChecker().ScanCommits(repoPath, commits, cfg.RepositorySize.MaxBlobSizeMB)
+		if err != nil {
+			return nil, fmt.Errorf("scan commits for binaries: %w", err)
+		}
+		result := pushGateResult{Gate: "binaries", Passed: true, Message: "No executables or large files in outgoing commits"}
+		for _, files := range [][]checkers.BinaryFile{report.ExecutableFiles, report.SuspiciousFiles, report.MismatchedFiles, report.LargeFiles} {
+			for _, file := range files {
+				if file.Type != "large" && file.Severity != "high" && file.Severity != "critical" {
+					continue
+				}
+				result.Details = append(result.Details, fmt.Sprintf("%s: %s [%s] (commit %s)", file.Path, file.Description, file.Severity, types.ShortHash(file.Commit)))
+			}
+		}
+		if len(result.Details) > 0 {
+			result.Passed = false
+			result.Message = fmt.Sprintf("%d binary file finding(s) in outgoing commits", len(result.Details))
+		}
+		results = append(results, result)
+	}
+
+	// Gate 7: policy document rules for pushes
+	if hookCheckEnabled(enabled, "policy") {
+		if doc != nil {
+			rules, err := checkers.EvaluatePushPolicy(repoPath, doc, refs, commits)
+			if err != nil {
+				return nil, err
+			}
+			result := pushGateResult{Gate: "policy", Passed: true, Message: fmt.Sprintf("%s push rules satisfied", filepath.Base(policyPath))}
+			for _, rule := range rules {
+				if rule.Passed {
+					continue
+				}
+				result.Passed = false
+				result.Details = append(result.Details, fmt.Sprintf("%s [%s]", rule.Message, rule.Severity))
+				for _, detail := range rule.Details {
+					result.Details = append(result.Details, "  "+detail)
+				}
+			}
+			if !result.Passed {
+				result.Message = fmt.Sprintf("%s push rules violated", filepath.Base(policyPath))
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// commitGates runs the conventional, length and commit_size gates enabled in gphc.yml.
+// Each commit is validated on its own and any violation fails the gate.
+func commitGates(cfg *config.Config, commits []types.CommitInfo) ([]pushGateResult, error) {
+	enabled := cfg.Hooks.PrePush
+	var results []pushGateResult
+
+	if hookCheckEnabled(enabled, "conventional") {
+		convention, err := commitConvention(cfg.CommitConvention)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, commitGate("conventional", commits,
+			fmt.Sprintf("follow %s format", convention.DisplayName()),
+			func(commit types.CommitInfo) string {
+				// Merge and revert subjects git writes are exempt, as in the commit-msg hook
+				if checkers.ParseCommitMessage(commit.Subject).IsGenerated() {
+					return ""
+				}
+				if violations := convention.Validate(commit.Subject); len(violations) > 0 {
+					return violations[0].Message
+				}
+				return ""
+			}))
+	}
+	if hookCheckEnabled(enabled, "length") {
+		maxLength := cfg.MaxCommitMessageLength
+		if maxLength <= 0 {
+			maxLength = 72
+		}
+		results = append(results, commitGate("length", commits,
+			fmt.Sprintf("fit the %d character subject limit", maxLength),
+			func(commit types.CommitInfo) string {
+				if length := utf8.RuneCountInString(commit.Subject); length > maxLength {
+					return fmt.Sprintf("subject is %d characters", length)
+				}
+				return ""
+			}))
+	}
+	if hookCheckEnabled(enabled, "commit_size") {
+		// The same checker as git hc check, so both agree on what is oversized
+		sizeChecker := checkers.NewCommitSizeCheckerWithLimit(cfg.MaxCommitSizeLines)
+		results = append(results, commitGate("commit_size", commits,
+			fmt.Sprintf("stay under %d changed lines", sizeChecker.MaxLines()),
+			func(commit types.CommitInfo) string {
+				if sizeChecker.IsOversized(commit) {
+					return fmt.Sprintf("%d changed lines", commit.LinesAdded+commit.LinesDeleted)
+				}
+				return ""
+			}))
+	}
+
+	return results, nil
+}
+
+// commitGate fails when violation reports a problem with any commit; rule completes
+// "All N commits ..." and "N of M commits don't ..."
+func commitGate(gate string, commits []types.CommitInfo, rule string, violation func(types.CommitInfo) string) pushGateResult {
+	result := pushGateResult{Gate: gate, Passed: true}
+	for _, commit := range commits {
+		if problem := violation(commit); problem != "" {
+			result.Details = append(result.Details, fmt.Sprintf("%s %s (%s)", types.ShortHash(commit.Hash), commit.Subject, problem))
+		}
+	}
+	if len(result.Details) > 0 {
+		result.Passed = false
+		result.Message = fmt.Sprintf("%d of %d commits don't %s", len(result.Details), len(commits), rule)
+	} else {
+		result.Message = fmt.Sprintf("All %d commits %s", len(commits), rule)
+	}
+	return result
+}
+
+// updateRange describes the commits an update sends in rev-list notation
+func updateRange(update hooks.PushUpdate) string {
+	if update.IsNew() {
+		return types.ShortHash(update.LocalOID) + " (new ref)"
+	}
+	return types.ShortHash(update.RemoteOID) + ".." + types.ShortHash(update.LocalOID)
+}
+
+// signatureGateRequirement is the percentage of outgoing commits that must carry a good signature:
+// all of them when hooks.pre_push lists signatures, otherwise the policy document's
+// signing.required_percentage; zero leaves the gate off
+func signatureGateRequirement(enabled []string, doc *checkers.PolicyDocument) float64 {
+	if hookCheckEnabled(enabled, "signatures") {
+		return 100
+	}
+	if doc != nil && doc.Signing != nil {
+		return doc.Signing.RequiredPercentage
+	}
+	return 0
+}
+
+// hookCheckEnabled reports whether a hook check is listed in gphc.yml;
+// dashes and underscores are interchangeable in check names
+func hookCheckEnabled(checks []string, name string) bool {
+	name = strings.ReplaceAll(name, "-", "_")
+	for _, check := range checks {
+		if strings.ReplaceAll(strings.ToLower(strings.TrimSpace(check)), "-", "_") == name {
+			return true
+		}
+	}
+	return false
+}
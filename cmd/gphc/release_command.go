@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vahidaghazadeh/gphc/internal/checkers"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+)
+
+func runRelease(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	noChangelog, _ := cmd.Flags().GetBool("no-changelog")
+
+	repoPath := "."
+	if len(args) > 0 {
+		repoPath = args[0]
+	}
+
+	if !isGitRepository(repoPath) {
+		fmt.Printf("Error: %s is not a Git repository\n", repoPath)
+		os.Exit(1)
+	}
+
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	options := releaseOptions(repositoryConfig)
+	if cmd.Flags().Changed("component") {
+		name, _ := cmd.Flags().GetString("component")
+		component, err := findReleaseComponent(repoPath, repositoryConfig.Release, name)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		options = component.ReleaseOptions(options)
+	}
+	if cmd.Flags().Changed("pre") {
+		options.Prerelease, _ = cmd.Flags().GetString("pre")
+	}
+	if cmd.Flags().Changed("build") {
+		options.Build, _ = cmd.Flags().GetString("build")
+	}
+	if cmd.Flags().Changed("bump") {
+		options.Bump, _ = cmd.Flags().GetString("bump")
+	}
+	if cmd.Flags().Changed("sign") {
+		options.Sign, _ = cmd.Flags().GetBool("sign")
+	}
+	if cmd.Flags().Changed("version-file") {
+		options.VersionFile, _ = cmd.Flags().GetString("version-file")
+	}
+	if cmd.Flags().Changed("changelog") {
+		options.Changelog, _ = cmd.Flags().GetString("changelog")
+	}
+	if noChangelog {
+		options.Changelog = ""
+	}
+
+	plan, err := checkers.PlanRelease(repoPath, options)
+	if err != nil {
+		fmt.Printf("Error planning release: %v\n", err)
+		os.Exit(1)
+	}
+
+	previous := plan.PreviousTag
+	if previous == "" {
+		previous = "first release"
+	}
+	fmt.Printf("🚀 Release %s (%s bump, %d commits since %s)\n\n", plan.Tag, plan.Bump, len(plan.Commits), previous)
+	fmt.Printf("%s\n", plan.Notes)
+
+	var files []string
+	for path := range plan.Files {
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	kind := "annotated"
+	if plan.Sign {
+		kind = "signed"
+	}
+
+	if dryRun {
+		fmt.Printf("Dry run, nothing was changed. A release would:\n")
+		if plan.VersionFile != "" {
+			fmt.Printf("  • set the version in %s to %s\n", plan.VersionFile, plan.Version.String())
+		}
+		if plan.Changelog != "" {
+			fmt.Printf("  • merge the notes into %s\n", plan.Changelog)
+		}
+		if len(files) > 0 {
+			release := plan.Version.String()
+			if plan.Component != "" {
+				release = plan.Tag
+			}
+			fmt.Printf("  • commit %s as \"chore(release): %s\"\n", strings.Join(files, ", "), release)
+		}
+		fmt.Printf("  • create the %s tag %s with the notes as its message\n", kind, plan.Tag)
+		return
+	}
+
+	if err := checkers.ApplyRelease(repoPath, plan); err != nil {
+		fmt.Printf("Error creating release: %v\n", err)
+		os.Exit(1)
+	}
+	if len(files) > 0 {
+		fmt.Printf("✅ Committed %s\n", strings.Join(files, ", "))
+	}
+	fmt.Printf("✅ Created %s tag %s\n", kind, plan.Tag)
+	fmt.Printf("\nPublish it with: git push --follow-tags\n")
+}
+
+// releaseOptions maps the release configuration to release options. The notes use the changelog's
+// type-to-section mapping so release entries match those of gphc changelog.
+func releaseOptions(cfg *config.Config) checkers.ReleaseOptions {
+	sections := checkers.DefaultChangelogTypes()
+	for commitType, section := range cfg.Changelog.Types {
+		sections[commitType] = section
+	}
+	return checkers.ReleaseOptions{
+		TagPrefix:      cfg.Release.TagPrefix,
+		VersionFile:    cfg.Release.VersionFile,
+		Changelog:      cfg.Release.Changelog,
+		Sign:           cfg.Release.Sign,
+		ChangelogTypes: sections,
+	}
+}
+
+// releaseComponents resolves the monorepo components configured in gphc.yml, plus the Go modules
+// when release.go_modules is set
+func releaseComponents(repoPath string, cfg config.Release) ([]checkers.ReleaseComponent, error) {
+	var configured []checkers.ReleaseComponent
+	for _, component := range cfg.Components {
+		configured = append(configured, checkers.ReleaseComponent{
+			Name:        component.Name,
+			TagPrefix:   component.TagPrefix,
+			Paths:       component.Paths,
+			Exclude:     component.Exclude,
+			Changelog:   component.Changelog,
+			VersionFile: component.VersionFile,
+		})
+	}
+	return checkers.ResolveComponents(repoPath, configured, cfg.GoModules)
+}
+
+// findReleaseComponent resolves the components and returns the one with the name
+func findReleaseComponent(repoPath string, cfg config.Release, name string) (checkers.ReleaseComponent, error) {
+	components, err := releaseComponents(repoPath, cfg)
+	if err != nil {
+		return checkers.ReleaseComponent{}, err
+	}
+	return checkers.FindComponent(components, name)
+}
@@ -338,6 +338,9 @@ func runPolicyValidation(cmd *cobra.Command, args []string) {
 	minSeverity, _ := cmd.Flags().GetString("severity")
 	format, _ := cmd.Flags().GetString("format")
 	outputFile, _ := cmd.Flags().GetString("output")
+	policyPath, _ := cmd.Flags().GetString("policy")
+	allowedSigners, _ := cmd.Flags().GetString("allowed-signers")
+	gpgHome, _ := cmd.Flags().GetString("gpg-home")
 
 	// Determine repository path
 	repoPath := "."
@@ -351,16 +354,32 @@ func runPolicyValidation(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	policyDoc, policyPath, err := loadPolicyDocument(repoPath, policyPath)
+	if err != nil {
+		fmt.Printf("Error loading policy file: %v\n", err)
+		os.Exit(1)
+	}
+
 	fmt.Printf("🔍 Validating Git security policies...\n")
 	fmt.Printf("Repository: %s\n", repoPath)
 	fmt.Printf("Check signing: %v\n", checkSigning)
 	fmt.Printf("Check files: %v\n", checkFiles)
 	fmt.Printf("Check push policies: %v\n", checkPush)
 	fmt.Printf("Check branch protection: %v\n", checkBranches)
+	if policyDoc != nil {
+		fmt.Printf("Policy file: %s\n", policyPath)
+	}
 	fmt.Printf("Minimum severity: %s\n\n", minSeverity)
 
 	// Run Git policy checker
 	policyChecker := checkers.NewGitPolicyChecker()
+	if policyDoc != nil {
+		policyChecker = checkers.NewGitPolicyCheckerWithPolicy(policyDoc, policyPath)
+	}
+	policyChecker.SetSignatureVerification(checkers.SignatureVerificationOptions{
+		AllowedSignersFile: allowedSigners,
+		GPGHome:            gpgHome,
+	})
 
 	// Create RepositoryData for the checker
 	analyzer, err := git.NewRepositoryAnalyzer(repoPath)
@@ -374,7 +393,7 @@ func runPolicyValidation(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	result := policyChecker.CheckWithOptions(data, checkSigning, checkFiles, checkPush, checkBranches, minSeverity)
+	result, report := policyChecker.CheckWithReport(data, checkSigning, checkFiles, checkPush, checkBranches, minSeverity)
 
 	// Process results
 	if result.Status == types.StatusFail {
@@ -386,9 +405,9 @@ func runPolicyValidation(cmd *cobra.Command, args []string) {
 	// Display results based on format
 	switch format {
 	case "json":
-		outputPolicyJSON(result, outputFile)
+		outputPolicyJSON(result, report.RuleResults, outputFile)
 	case "yaml":
-		outputPolicyYAML(result, outputFile)
+		outputPolicyYAML(result, report.RuleResults, outputFile)
 	default:
 		outputPolicyTable(result, minSeverity)
 	}
@@ -429,9 +448,32 @@ func outputPolicyTable(result *types.CheckResult, minSeverity string) {
 	fmt.Printf("Security Score: %d/100\n\n", result.Score)
 }
 
+// loadPolicyDocument loads an explicit policy file or the repository's .gphc-policy.yml
+func loadPolicyDocument(repoPath, policyPath string) (*checkers.PolicyDocument, string, error) {
+	if policyPath == "" {
+		return checkers.FindPolicyDocument(repoPath)
+	}
+	doc, err := checkers.LoadPolicyDocument(policyPath)
+	if err != nil {
+		return nil, "", err
+	}
+	return doc, policyPath, nil
+}
+
+// policyPayload returns the bare result, or the result with per-rule outcomes when a policy file was evaluated
+func policyPayload(result *types.CheckResult, rules []checkers.PolicyRuleResult) interface{} {
+	if len(rules) == 0 {
+		return result
+	}
+	return struct {
+		Result *types.CheckResult          `json:"result" yaml:"result"`
+		Rules  []checkers.PolicyRuleResult `json:"rules" yaml:"rules"`
+	}{Result: result, Rules: rules}
+}
+
 // outputPolicyJSON outputs policy validation results in JSON format
-func outputPolicyJSON(result *types.CheckResult, outputFile string) {
-	jsonData, err := json.MarshalIndent(result, "", "  ")
+func outputPolicyJSON(result *types.CheckResult, rules []checkers.PolicyRuleResult, outputFile string) {
+	jsonData, err := json.MarshalIndent(policyPayload(result, rules), "", "  ")
 	if err != nil {
 		fmt.Printf("Error marshaling JSON: %v\n", err)
 		return
@@ -450,8 +492,8 @@ func outputPolicyJSON(result *types.CheckResult, outputFile string) {
 }
 
 // outputPolicyYAML outputs policy validation results in YAML format
-func outputPolicyYAML(result *types.CheckResult, outputFile string) {
-	yamlData, err := yaml.Marshal(result)
+func outputPolicyYAML(result *types.CheckResult, rules []checkers.PolicyRuleResult, outputFile string) {
+	yamlData, err := yaml.Marshal(policyPayload(result, rules))
 	if err != nil {
 		fmt.Printf("Error marshaling YAML: %v\n", err)
 		return
@@ -476,12 +518,14 @@ func runBinariesAudit(cmd *cobra.Command, args []string) {
 	checkExecutables, _ := cmd.Flags().GetBool("check-executables")
 	checkLarge, _ := cmd.Flags().GetBool("check-large")
 	checkSuspicious, _ := cmd.Flags().GetBool("check-suspicious")
+	lfsThresholdStr, _ := cmd.Flags().GetString("lfs-threshold")
 	minSeverity, _ := cmd.Flags().GetString("severity")
 	format, _ := cmd.Flags().GetString("format")
 	outputFile, _ := cmd.Flags().GetString("output")
 
 	// Parse max size
 	maxSizeMB := parseSizeToMB(maxSizeStr)
+	lfsThresholdMB := parseSizeToMB(lfsThresholdStr)
 
 	// Determine repository path
 	repoPath := "."
@@ -502,10 +546,12 @@ func runBinariesAudit(cmd *cobra.Command, args []string) {
 	fmt.Printf("Check executables: %v\n", checkExecutables)
 	fmt.Printf("Check large files: %v\n", checkLarge)
 	fmt.Printf("Check suspicious files: %v\n", checkSuspicious)
+	fmt.Printf("LFS threshold: %s (%.1f MB)\n", lfsThresholdStr, lfsThresholdMB)
 	fmt.Printf("Minimum severity: %s\n\n", minSeverity)
 
 	// Run binary file checker
 	binaryChecker := checkers.NewBinaryFileChecker()
+	binaryChecker.SetLFSThreshold(lfsThresholdMB)
 
 	// Create RepositoryData for the checker
 	analyzer, err := git.NewRepositoryAnalyzer(repoPath)
@@ -524,6 +570,8 @@ func runBinariesAudit(cmd *cobra.Command, args []string) {
 	// Process results
 	if result.Status == types.StatusFail {
 		fmt.Printf("❌ Binary audit found issues: %s\n", result.Message)
+	} else if result.Status == types.StatusWarning {
+		fmt.Printf("⚠️ Binary audit found warnings: %s\n", result.Message)
 	} else {
 		fmt.Printf("✅ Binary audit passed: %s\n", result.Message)
 	}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -80,6 +81,7 @@ func handleHealthAPI(w http.ResponseWriter, r *http.Request) {
 		"overall_score": healthReport.OverallScore,
 		"grade":         healthReport.Grade,
 		"summary":       healthReport.Summary,
+		"categories":    healthReport.Categories,
 		"timestamp":     healthReport.Timestamp.Format(time.RFC3339),
 		"repository":    filepath.Base(repoPath),
 	}
@@ -296,9 +298,20 @@ func handleTagsAPI(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		http.Error(w, "Error loading configuration", http.StatusInternalServerError)
+		return
+	}
+	components, err := releaseComponents(repoPath, repositoryConfig.Release)
+	if err != nil {
+		http.Error(w, "Error resolving release components", http.StatusInternalServerError)
+		return
+	}
+
 	// Run TagChecker
-	tagChecker := checkers.NewTagChecker()
-	result := tagChecker.Check(data)
+	tagChecker := checkers.NewTagCheckerWithOptions(tagCheckerOptions(components, repositoryConfig))
+	result, report := tagChecker.CheckWithReport(data)
 
 	// Set CORS headers if enabled
 	if serverCORS {
@@ -317,11 +330,62 @@ func handleTagsAPI(w http.ResponseWriter, r *http.Request) {
 		"timestamp":  result.Timestamp.Format(time.RFC3339),
 		"repository": filepath.Base(repoPath),
 	}
+	if report != nil {
+		response["integrity"] = report
+	}
+	if len(components) > 0 {
+		if statuses, err := checkers.AnalyzeComponents(repoPath, components); err == nil {
+			response["components"] = statuses
+		}
+	}
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		http.Error(w, "Error encoding response", http.StatusInternalServerError)
 	}
 }
 
+// handleTimelineAPI serves the contributor timeline; ?weeks=N overrides the charted weeks
+func handleTimelineAPI(w http.ResponseWriter, r *http.Request) {
+	// Check authentication if enabled
+	if serverAuth {
+		if !isAuthorized(r) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="GPHC Dashboard"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("401 Unauthorized"))
+			return
+		}
+	}
+
+	repoPath := serverRepoPath
+
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		http.Error(w, "Error loading configuration", http.StatusInternalServerError)
+		return
+	}
+	options := timelineOptions(repoPath, repositoryConfig)
+	if weeks, err := strconv.Atoi(r.URL.Query().Get("weeks")); err == nil && weeks > 0 && weeks <= 520 {
+		options.Weeks = weeks
+	}
+
+	timeline, err := buildAuthorTimeline(repoPath, git.CommitSelection{}, options)
+	if err != nil {
+		http.Error(w, "Error analyzing repository", http.StatusInternalServerError)
+		return
+	}
+
+	// Set CORS headers if enabled
+	if serverCORS {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(timeline); err != nil {
+		http.Error(w, "Error encoding response", http.StatusInternalServerError)
+	}
+}
+
 func handleExportJSON(w http.ResponseWriter, r *http.Request) {
 	// Check authentication if enabled
 	if serverAuth {
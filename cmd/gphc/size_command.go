@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/vahidaghazadeh/gphc/internal/checkers"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func runSize(cmd *cobra.Command, args []string) {
+	top, _ := cmd.Flags().GetInt("top")
+	months, _ := cmd.Flags().GetInt("months")
+	maxPackSize, _ := cmd.Flags().GetFloat64("max-pack-size")
+	maxLooseObjects, _ := cmd.Flags().GetInt("max-loose-objects")
+	maxBlobSize, _ := cmd.Flags().GetFloat64("max-blob-size")
+	format, _ := cmd.Flags().GetString("format")
+	outputFile, _ := cmd.Flags().GetString("output")
+
+	repoPath := "."
+	if len(args) > 0 {
+		repoPath = args[0]
+	}
+
+	if !isGitRepository(repoPath) {
+		fmt.Printf("Error: %s is not a Git repository\n", repoPath)
+		os.Exit(1)
+	}
+
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	thresholds := sizeThresholds(repositoryConfig.RepositorySize)
+	if maxPackSize > 0 {
+		thresholds.MaxPackSizeMB = maxPackSize
+	}
+	if maxLooseObjects > 0 {
+		thresholds.MaxLooseObjects = maxLooseObjects
+	}
+	if maxBlobSize > 0 {
+		thresholds.MaxBlobSizeMB = maxBlobSize
+	}
+
+	sizeChecker := checkers.NewRepositorySizeCheckerWithThresholds(thresholds)
+	result, report := sizeChecker.CheckWithOptions(&types.RepositoryData{Path: repoPath}, checkers.SizeAnalysisOptions{
+		TopN:           top,
+		IncludeOrigins: true,
+		GrowthMonths:   months,
+	})
+
+	switch format {
+	case "json":
+		outputSizeJSON(result, report, outputFile)
+	default:
+		fmt.Printf("📦 Repository Size Analysis\n")
+		fmt.Printf("Repository: %s\n", repoPath)
+		fmt.Printf("Thresholds: repository %.0f MB, loose objects %d, blob %.0f MB\n\n",
+			thresholds.MaxPackSizeMB, thresholds.MaxLooseObjects, thresholds.MaxBlobSizeMB)
+		for _, detail := range result.Details {
+			fmt.Println(detail)
+		}
+		fmt.Printf("\nSize Score: %d/100\n", result.Score)
+		switch result.Status {
+		case types.StatusFail:
+			fmt.Printf("❌ %s\n", result.Message)
+		case types.StatusWarning:
+			fmt.Printf("⚠️ %s\n", result.Message)
+		default:
+			fmt.Printf("✅ %s\n", result.Message)
+		}
+		if report != nil && len(report.LargestBlobs) > 0 && len(report.Warnings) > 0 {
+			fmt.Printf("\nTo shrink history, move large files to Git LFS or rewrite them out with git filter-repo.\n")
+		}
+	}
+
+	if result.Status == types.StatusFail {
+		os.Exit(1)
+	}
+}
+
+// sizeThresholds maps the repository_size configuration to checker thresholds
+func sizeThresholds(cfg config.RepositorySize) checkers.SizeThresholds {
+	return checkers.SizeThresholds{
+		MaxPackSizeMB:   cfg.MaxPackSizeMB,
+		MaxLooseObjects: cfg.MaxLooseObjects,
+		MaxBlobSizeMB:   cfg.MaxBlobSizeMB,
+	}
+}
+
+func outputSizeJSON(result *types.CheckResult, report *checkers.RepositorySizeReport, outputFile string) {
+	payload := struct {
+		Result *types.CheckResult             `json:"result"`
+		Report *checkers.RepositorySizeReport `json:"report,omitempty"`
+	}{Result: result, Report: report}
+	jsonData, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, jsonData, 0644); err != nil {
+			fmt.Printf("Error writing JSON file: %v\n", err)
+			return
+		}
+		fmt.Printf("Results written to %s\n", outputFile)
+	} else {
+		fmt.Printf("%s\n", string(jsonData))
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/vahidaghazadeh/gphc/internal/checkers"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// tagCheckerOptions maps the release components and tag integrity configuration to tag checker options
+func tagCheckerOptions(components []checkers.ReleaseComponent, cfg *config.Config) checkers.TagCheckerOptions {
+	integrity := checkers.DefaultTagIntegrityOptions()
+	integrity.TagPrefixes = nil
+	integrity.ReleaseBranch = cfg.Tags.ReleaseBranch
+	if integrity.ReleaseBranch == "" {
+		integrity.ReleaseBranch = cfg.History.MainBranch
+	}
+	if cfg.Tags.MaintenanceBranches != nil {
+		integrity.MaintenanceBranches = cfg.Tags.MaintenanceBranches
+	}
+	integrity.RequireSigned = cfg.Tags.RequireSigned
+	integrity.Signatures = signatureVerificationOptions(cfg)
+	integrity.TrackMoves = cfg.Tags.TrackMoves
+	return checkers.TagCheckerOptions{Components: components, Integrity: integrity}
+}
+
+// signatureVerificationOptions maps the configured allowed signers file and GPG keyring,
+// which verify both tag and commit signatures
+func signatureVerificationOptions(cfg *config.Config) checkers.SignatureVerificationOptions {
+	return checkers.SignatureVerificationOptions{
+		AllowedSignersFile: cfg.Tags.AllowedSignersFile,
+		GPGHome:            cfg.Tags.GPGHome,
+	}
+}
+
+func outputTagsJSON(result *types.CheckResult, report *checkers.TagIntegrityReport, outputFile string) {
+	payload := struct {
+		Result *types.CheckResult           `json:"result"`
+		Report *checkers.TagIntegrityReport `json:"integrity,omitempty"`
+	}{Result: result, Report: report}
+	jsonData, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, jsonData, 0644); err != nil {
+			fmt.Printf("Error writing JSON file: %v\n", err)
+			return
+		}
+		fmt.Printf("Results written to %s\n", outputFile)
+	} else {
+		fmt.Printf("%s\n", string(jsonData))
+	}
+}
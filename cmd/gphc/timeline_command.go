@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vahidaghazadeh/gphc/internal/checkers"
+	"github.com/vahidaghazadeh/gphc/internal/git"
+	"github.com/vahidaghazadeh/gphc/pkg/config"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// sparkBlocks draw a value from 1 to 8 eighths; zero is drawn as a space
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// heatShades draw a heatmap cell from empty to the busiest cell
+var heatShades = []rune(" ░▒▓█")
+
+var weekdayNames = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// runAuthorsTimeline prints contributor activity over time and working patterns
+func runAuthorsTimeline(cmd *cobra.Command, repoPath string) {
+	weeks, _ := cmd.Flags().GetInt("weeks")
+	format, _ := cmd.Flags().GetString("format")
+	outputFile, _ := cmd.Flags().GetString("output")
+
+	repositoryConfig, err := loadRepositoryConfig(repoPath)
+	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	options := timelineOptions(repoPath, repositoryConfig)
+	if weeks > 0 {
+		options.Weeks = weeks
+	}
+
+	timeline, err := buildAuthorTimeline(repoPath, commitSelectionFromFlags(cmd), options)
+	if err != nil {
+		fmt.Printf("Error analyzing contributor activity: %v\n", err)
+		os.Exit(1)
+	}
+
+	if format == "json" {
+		outputTimelineJSON(timeline, outputFile)
+		return
+	}
+
+	fmt.Printf("📈 Contributor Timeline\n")
+	fmt.Printf("Repository: %s\n", repoPath)
+	if len(timeline.Authors) == 0 {
+		fmt.Printf("\nNo commits to analyze\n")
+		return
+	}
+	fmt.Printf("Weeks: %s to %s\n\n", timeline.Weeks[0], timeline.Weeks[len(timeline.Weeks)-1])
+
+	fmt.Printf("Commits per week:\n")
+	for _, author := range timeline.Authors {
+		status := ""
+		if !author.Active {
+			status = " (inactive)"
+		}
+		fmt.Printf("  %-24s %s %4d%s\n", truncateName(author.Name, 24), sparkline(author.Weekly), author.Commits, status)
+	}
+	fmt.Printf("  %-24s %s\n", "Active contributors", sparkline(timeline.ActiveContributors))
+	fmt.Printf("  %-24s now %d, peak %d\n", "", timeline.ActiveContributors[len(timeline.ActiveContributors)-1], maxInt(timeline.ActiveContributors))
+
+	if len(timeline.Onboarded) > 0 {
+		fmt.Printf("\nOnboarded:\n")
+		for _, event := range timeline.Onboarded {
+			fmt.Printf("  %s  %s <%s>\n", event.Date.Format("2006-01-02"), event.Name, event.Email)
+		}
+	}
+	if len(timeline.Offboarded) > 0 {
+		fmt.Printf("\nOffboarded (no commits for %d days):\n", options.InactiveDays)
+		for _, event := range timeline.Offboarded {
+			fmt.Printf("  %s  %s <%s>\n", event.Date.Format("2006-01-02"), event.Name, event.Email)
+		}
+	}
+
+	fmt.Printf("\nCommit times (author's local time):\n")
+	fmt.Printf("       %s\n", "0     6     12    18   23")
+	busiest := 0
+	for _, hours := range timeline.Hours {
+		busiest = maxInt(append([]int{busiest}, hours[:]...))
+	}
+	// Monday first, as in most calendars
+	for _, day := range []int{1, 2, 3, 4, 5, 6, 0} {
+		var row strings.Builder
+		for _, count := range timeline.Hours[day] {
+			row.WriteRune(heatShade(count, busiest))
+		}
+		fmt.Printf("  %s  %s %5d\n", weekdayNames[day], row.String(), timeline.Weekdays[day])
+	}
+
+	var zones []string
+	for i, zone := range timeline.Timezones {
+		if i == 5 {
+			break
+		}
+		zones = append(zones, fmt.Sprintf("UTC%s (%d)", zone.Offset, zone.Commits))
+	}
+	fmt.Printf("\nTimezones: %s\n", strings.Join(zones, ", "))
+
+	review := timeline.Review
+	branches := strings.Join(append([]string{review.Branch}, review.Branches...), ", ")
+	fmt.Printf("\nReview-free commits on %s: %d of %d (%.0f%%)\n", branches, review.ReviewFree, review.Landed, review.Ratio*100)
+	for i, commit := range review.Commits {
+		if i == 5 {
+			fmt.Printf("  ... and %d more\n", len(review.Commits)-5)
+			break
+		}
+		fmt.Printf("  %s %s (%s)\n", types.ShortHash(commit.Hash), commit.Subject, commit.Author)
+	}
+}
+
+// buildAuthorTimeline analyzes the selected commits; without a selection the charted weeks are analyzed
+func buildAuthorTimeline(repoPath string, selection git.CommitSelection, options checkers.TimelineOptions) (*checkers.AuthorTimeline, error) {
+	if selection.IsDefault() {
+		selection.Since = fmt.Sprintf("%d weeks ago", options.Weeks)
+	}
+	analyzer, err := git.NewRepositoryAnalyzer(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	analyzer.SetCommitSelection(selection)
+	data, err := analyzer.Analyze()
+	if err != nil {
+		return nil, err
+	}
+	return checkers.AnalyzeAuthorTimeline(repoPath, data.Commits, options)
+}
+
+// timelineOptions maps the timeline, history and identity configuration to analysis options.
+// Review evidence is also checked on tags.release_branch and the protected branches of .gphc-policy.yml.
+func timelineOptions(repoPath string, cfg *config.Config) checkers.TimelineOptions {
+	options := checkers.DefaultTimelineOptions()
+	options.Weeks = cfg.Timeline.Weeks
+	options.ActiveWindowWeeks = cfg.Timeline.ActiveWindowWeeks
+	options.InactiveDays = cfg.Timeline.InactiveDays
+	options.MainBranch = cfg.History.MainBranch
+	if cfg.Tags.ReleaseBranch != "" {
+		options.ProtectedBranches = append(options.ProtectedBranches, cfg.Tags.ReleaseBranch)
+	}
+	// An invalid policy document is reported by the policy check, not here
+	if doc, _, err := checkers.FindPolicyDocument(repoPath); err == nil && doc != nil && doc.ProtectedBranches != nil {
+		options.ProtectedBranches = append(options.ProtectedBranches, doc.ProtectedBranches.Patterns...)
+	}
+	options.Identity = identityOptions(cfg.Identity)
+	return options
+}
+
+func sparkline(values []int) string {
+	highest := maxInt(values)
+	var line strings.Builder
+	for _, value := range values {
+		if value == 0 || highest == 0 {
+			line.WriteRune(' ')
+			continue
+		}
+		line.WriteRune(sparkBlocks[(value*len(sparkBlocks)-1)/highest])
+	}
+	return line.String()
+}
+
+func heatShade(value, highest int) rune {
+	if value == 0 || highest == 0 {
+		return heatShades[0]
+	}
+	return heatShades[1+(value*(len(heatShades)-1)-1)/highest]
+}
+
+func maxInt(values []int) int {
+	highest := 0
+	for _, value := range values {
+		if value > highest {
+			highest = value
+		}
+	}
+	return highest
+}
+
+func truncateName(name string, width int) string {
+	runes := []rune(name)
+	if len(runes) <= width {
+		return name
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+func outputTimelineJSON(timeline *checkers.AuthorTimeline, outputFile string) {
+	jsonData, err := json.MarshalIndent(timeline, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, jsonData, 0644); err != nil {
+			fmt.Printf("Error writing JSON file: %v\n", err)
+			return
+		}
+		fmt.Printf("Results written to %s\n", outputFile)
+	} else {
+		fmt.Printf("%s\n", string(jsonData))
+	}
+}
@@ -134,6 +134,11 @@ func (m *TUIModel) renderOverview() string {
 		return "No data available"
 	}
 
+	var categories strings.Builder
+	for _, category := range m.healthReport.Categories {
+		categories.WriteString(fmt.Sprintf("%-34s %3d/100 (%s)\n", category.Name, category.Score, category.Grade))
+	}
+
 	return fmt.Sprintf(`Health Score: %d/100 (%s)
 
 Total Checks: %d
@@ -141,6 +146,7 @@ Passed: %d
 Failed: %d
 Warnings: %d
 
+%s
 Repository: %s
 Last Updated: %s`,
 		m.healthReport.OverallScore,
@@ -149,6 +155,7 @@ Last Updated: %s`,
 		m.healthReport.Summary.PassedChecks,
 		m.healthReport.Summary.FailedChecks,
 		m.healthReport.Summary.WarningChecks,
+		categories.String(),
 		filepath.Base(m.repoPath),
 		m.healthReport.Timestamp.Format("2006-01-02 15:04:05"))
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/vahidaghazadeh/gphc/internal/identity"
 	"github.com/vahidaghazadeh/gphc/pkg/types"
 )
 
@@ -15,21 +16,34 @@ type AuthorStats struct {
 	Email      string
 	Commits    int
 	Percentage float64
+	// Aliases is the number of name and email combinations merged into this author
+	Aliases int
+	// Heuristic is set when the aliases were merged by email or name rather than by .mailmap
+	Heuristic bool
+	Bot       bool
 }
 
 // CommitAuthorInsightsChecker analyzes commit author patterns
 type CommitAuthorInsightsChecker struct {
 	BaseChecker
+	identity identity.Options
 }
 
 // NewCommitAuthorInsightsChecker creates a new commit author insights checker
 func NewCommitAuthorInsightsChecker() *CommitAuthorInsightsChecker {
+	return NewCommitAuthorInsightsCheckerWithIdentity(identity.DefaultOptions())
+}
+
+// NewCommitAuthorInsightsCheckerWithIdentity creates a commit author insights checker
+// that resolves authors with the given identity options
+func NewCommitAuthorInsightsCheckerWithIdentity(options identity.Options) *CommitAuthorInsightsChecker {
 	return &CommitAuthorInsightsChecker{
 		BaseChecker: BaseChecker{
 			id:       "CAI-701",
 			name:     "Commit Author Insights",
 			category: types.CategoryCommits,
 		},
+		identity: options,
 	}
 }
 
@@ -56,10 +70,21 @@ func (c *CommitAuthorInsightsChecker) Check(data *types.RepositoryData) *types.C
 	}
 
 	// Analyze commit authors
-	authorStats := c.analyzeAuthors(data.Commits)
+	index, err := ResolveAuthors(data.Path, data.Commits, c.identity)
+	if err != nil {
+		result.Status = types.StatusWarning
+		result.Score = 0
+		result.Message = "Could not resolve author identities"
+		result.Details = append(result.Details, err.Error())
+		return result
+	}
+	authorStats, botStats := c.analyzeAuthors(index)
 
 	// Calculate total commits
-	totalCommits := len(data.Commits)
+	totalCommits := 0
+	for _, author := range authorStats {
+		totalCommits += author.Commits
+	}
 
 	// Sort authors by commit count (descending)
 	sort.Slice(authorStats, func(i, j int) bool {
@@ -78,8 +103,15 @@ func (c *CommitAuthorInsightsChecker) Check(data *types.RepositoryData) *types.C
 
 	for i, author := range topContributors {
 		rank := i + 1
-		result.Details = append(result.Details, fmt.Sprintf("  %d. %s (%d commits, %.1f%%)",
-			rank, author.Name, author.Commits, author.Percentage))
+		aliases := ""
+		if author.Aliases > 1 {
+			aliases = fmt.Sprintf(", %d aliases", author.Aliases)
+		}
+		result.Details = append(result.Details, fmt.Sprintf("  %d. %s (%d commits, %.1f%%%s)",
+			rank, author.Name, author.Commits, author.Percentage, aliases))
+	}
+	for _, bot := range botStats {
+		result.Details = append(result.Details, fmt.Sprintf("Bot excluded: %s (%d commits)", bot.Name, bot.Commits))
 	}
 
 	// Check for single author dominance
@@ -141,6 +173,18 @@ func (c *CommitAuthorInsightsChecker) Check(data *types.RepositoryData) *types.C
 			result.Details = append(result.Details, fmt.Sprintf("%d contributor(s) with minimal activity (<5%%)", inactiveContributors))
 		}
 
+		// Authors committing under several names or emails were merged; those .mailmap
+		// already maps need no advice
+		merged := 0
+		for _, author := range authorStats {
+			if author.Heuristic {
+				merged++
+			}
+		}
+		if merged > 0 {
+			result.Details = append(result.Details, fmt.Sprintf("%d contributor(s) commit under several names or emails; list them in .mailmap", merged))
+		}
+
 		// Check for email consistency
 		emailConsistency := c.checkEmailConsistency(authorStats)
 		if !emailConsistency {
@@ -152,7 +196,7 @@ func (c *CommitAuthorInsightsChecker) Check(data *types.RepositoryData) *types.C
 
 	} else {
 		result.Status = types.StatusWarning
-		result.Score = 0
+		score = 0
 		result.Message = "No author information found"
 		result.Details = append(result.Details, "Unable to extract author information from commits")
 	}
@@ -161,34 +205,41 @@ func (c *CommitAuthorInsightsChecker) Check(data *types.RepositoryData) *types.C
 	return result
 }
 
-// analyzeAuthors analyzes commit authors and returns statistics
-func (c *CommitAuthorInsightsChecker) analyzeAuthors(commits []types.CommitInfo) []AuthorStats {
-	authorMap := make(map[string]*AuthorStats)
-	totalCommits := len(commits)
-
-	// Count commits per author
-	for _, commit := range commits {
-		authorKey := fmt.Sprintf("%s <%s>", commit.Author, commit.AuthorEmail)
-
-		if stats, exists := authorMap[authorKey]; exists {
-			stats.Commits++
-		} else {
-			authorMap[authorKey] = &AuthorStats{
-				Name:    commit.Author,
-				Email:   commit.AuthorEmail,
-				Commits: 1,
-			}
+// analyzeAuthors returns statistics for the contributors of an identity index and for the bots it excludes
+func (c *CommitAuthorInsightsChecker) analyzeAuthors(index *identity.Index) ([]AuthorStats, []AuthorStats) {
+	stats := func(ids []*identity.Identity) []AuthorStats {
+		total := 0
+		for _, id := range ids {
+			total += id.Commits
+		}
+		var authorStats []AuthorStats
+		for _, id := range ids {
+			authorStats = append(authorStats, AuthorStats{
+				Name:       id.Name,
+				Email:      id.Email,
+				Commits:    id.Commits,
+				Percentage: float64(id.Commits) / float64(total) * 100,
+				Aliases:    len(id.Aliases),
+				Heuristic:  id.Heuristic,
+				Bot:        id.Bot,
+			})
 		}
+		return authorStats
 	}
 
-	// Convert to slice and calculate percentages
-	var authorStats []AuthorStats
-	for _, stats := range authorMap {
-		stats.Percentage = float64(stats.Commits) / float64(totalCommits) * 100
-		authorStats = append(authorStats, *stats)
+	if !c.identity.ExcludeBots {
+		return stats(index.Identities()), nil
 	}
+	return stats(index.People()), stats(index.Bots())
+}
 
-	return authorStats
+// ResolveAuthors groups the authors of commits into identities using the repository .mailmap
+func ResolveAuthors(repoPath string, commits []types.CommitInfo, options identity.Options) (*identity.Index, error) {
+	resolver, err := identity.NewResolver(repoPath, options)
+	if err != nil {
+		return nil, err
+	}
+	return resolver.Resolve(identity.PeopleFromCommits(commits)), nil
 }
 
 // checkEmailConsistency checks if authors use consistent email addresses
@@ -0,0 +1,331 @@
+package checkers
+
+import (
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/internal/identity"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// TimelineOptions tunes the contributor activity timeline
+type TimelineOptions struct {
+	// Weeks is the number of most recent weeks charted
+	Weeks int
+	// ActiveWindowWeeks is how many trailing weeks a contributor counts as active after a commit
+	ActiveWindowWeeks int
+	// InactiveDays is how long a contributor may go without committing before they count as offboarded
+	InactiveDays int
+	// MainBranch is the protected branch whose landed commits are checked for review; empty detects it
+	MainBranch string
+	// ProtectedBranches are further branch names or globs, such as release/*, whose landed commits are checked too
+	ProtectedBranches []string
+	// Identity controls how commit authors are merged into people
+	Identity identity.Options
+	// Now is the end of the timeline; zero means time.Now
+	Now time.Time
+}
+
+// DefaultTimelineOptions returns the settings used when gphc.yml sets none
+func DefaultTimelineOptions() TimelineOptions {
+	return TimelineOptions{
+		Weeks:             26,
+		ActiveWindowWeeks: 4,
+		InactiveDays:      90,
+		Identity:          identity.DefaultOptions(),
+	}
+}
+
+// AuthorActivity is one contributor's commits per week
+type AuthorActivity struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Commits int    `json:"commits"`
+	// FirstCommit and LastCommit span the contributor's whole history, not only the analyzed commits
+	FirstCommit time.Time `json:"first_commit"`
+	LastCommit  time.Time `json:"last_commit"`
+	Active      bool      `json:"active"`
+	// Weekly holds the commits of each week listed in AuthorTimeline.Weeks
+	Weekly []int `json:"weekly"`
+}
+
+// ContributorEvent is a contributor's first commit (onboarding) or last commit before going inactive (offboarding)
+type ContributorEvent struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+// TimezoneCount is the number of commits made with one UTC offset
+type TimezoneCount struct {
+	Offset  string `json:"offset"`
+	Commits int    `json:"commits"`
+}
+
+// ReviewStats counts the commits that landed on the protected branches without review evidence
+type ReviewStats struct {
+	Branch string `json:"branch"`
+	// Branches are the other protected branches checked besides Branch
+	Branches []string `json:"branches,omitempty"`
+	// Landed is the number of first-parent commits of the branch, merges included
+	Landed     int             `json:"landed"`
+	ReviewFree int             `json:"review_free"`
+	Ratio      float64         `json:"ratio"`
+	Commits    []HistoryCommit `json:"commits,omitempty"`
+}
+
+// AuthorTimeline is the contributor activity and working patterns of the analyzed commits
+type AuthorTimeline struct {
+	// Weeks are the Mondays starting each charted week, oldest first
+	Weeks []string `json:"weeks"`
+	// ActiveContributors is the number of contributors active in each week
+	ActiveContributors []int              `json:"active_contributors"`
+	Authors            []AuthorActivity   `json:"authors"`
+	Onboarded          []ContributorEvent `json:"onboarded,omitempty"`
+	Offboarded         []ContributorEvent `json:"offboarded,omitempty"`
+	// Hours is indexed by weekday (Sunday first) and hour in the author's own timezone
+	Hours     [7][24]int      `json:"hours"`
+	Weekdays  [7]int          `json:"weekdays"`
+	Timezones []TimezoneCount `json:"timezones"`
+	Review    ReviewStats     `json:"review"`
+}
+
+var (
+	// reviewRefRe matches the pull or merge request references hosting platforms add when merging
+	reviewRefRe = regexp.MustCompile(`(?i)^Merge pull request #\d+|\(#\d+\)\s*$|pull request #\d+|See merge request \S*!\d+`)
+	// reviewTrailerRe matches the trailers review tools add, such as Gerrit's Reviewed-on
+	reviewTrailerRe = regexp.MustCompile(`(?im)^(Reviewed-by|Reviewed-on|Approved-by|Acked-by):`)
+)
+
+// AnalyzeAuthorTimeline charts the weekly activity of each contributor, when they joined
+// and left, when they commit and how many commits landed on the main branch unreviewed
+func AnalyzeAuthorTimeline(repoPath string, commits []types.CommitInfo, options TimelineOptions) (*AuthorTimeline, error) {
+	defaults := DefaultTimelineOptions()
+	if options.Weeks <= 0 {
+		options.Weeks = defaults.Weeks
+	}
+	if options.ActiveWindowWeeks <= 0 {
+		options.ActiveWindowWeeks = defaults.ActiveWindowWeeks
+	}
+	if options.InactiveDays <= 0 {
+		options.InactiveDays = defaults.InactiveDays
+	}
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+
+	activity, err := authorActivity(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	people := identity.PeopleFromCommits(commits)
+	seen := make(map[identity.Person]bool)
+	for _, person := range people {
+		seen[identity.Person{Name: person.Name, Email: person.Email}] = true
+	}
+	for person := range activity {
+		if !seen[person] {
+			people = append(people, person)
+		}
+	}
+	resolver, err := identity.NewResolver(repoPath, options.Identity)
+	if err != nil {
+		return nil, err
+	}
+	index := resolver.Resolve(people)
+
+	spans := make(map[*identity.Identity]activitySpan)
+	for person, span := range activity {
+		id := index.Lookup(person.Name, person.Email)
+		current, ok := spans[id]
+		if !ok || span.first.Before(current.first) {
+			current.first = span.first
+		}
+		if span.last.After(current.last) {
+			current.last = span.last
+		}
+		spans[id] = current
+	}
+
+	// The timeline runs from the week of the oldest analyzed commit, at most Weeks back, to now
+	end := weekStart(options.Now)
+	start := end.AddDate(0, 0, -7*(options.Weeks-1))
+	oldest := end
+	for _, commit := range commits {
+		if week := weekStart(commit.Date); week.Before(oldest) {
+			oldest = week
+		}
+	}
+	if oldest.After(start) {
+		start = oldest
+	}
+	timeline := &AuthorTimeline{}
+	weekIndex := make(map[string]int)
+	for week := start; !week.After(end); week = week.AddDate(0, 0, 7) {
+		weekIndex[week.Format("2006-01-02")] = len(timeline.Weeks)
+		timeline.Weeks = append(timeline.Weeks, week.Format("2006-01-02"))
+	}
+
+	authors := make(map[*identity.Identity]*AuthorActivity)
+	timezones := make(map[string]int)
+	var order []*identity.Identity
+	for _, commit := range commits {
+		id := index.Lookup(commit.Author, commit.AuthorEmail)
+		if id.Bot && options.Identity.ExcludeBots {
+			continue
+		}
+		author := authors[id]
+		if author == nil {
+			author = &AuthorActivity{Name: id.Name, Email: id.Email, Weekly: make([]int, len(timeline.Weeks))}
+			authors[id] = author
+			order = append(order, id)
+		}
+		author.Commits++
+		// Commits not reachable from HEAD, such as with --all-refs, extend the span too
+		span := spans[id]
+		if span.first.IsZero() || commit.Date.Before(span.first) {
+			span.first = commit.Date
+		}
+		if commit.Date.After(span.last) {
+			span.last = commit.Date
+		}
+		spans[id] = span
+		if i, ok := weekIndex[weekStart(commit.Date).Format("2006-01-02")]; ok {
+			author.Weekly[i]++
+		}
+
+		timeline.Hours[commit.Date.Weekday()][commit.Date.Hour()]++
+		timeline.Weekdays[commit.Date.Weekday()]++
+		timezones[commit.Date.Format("-07:00")]++
+	}
+
+	cutoff := options.Now.AddDate(0, 0, -options.InactiveDays)
+	for _, id := range order {
+		author := authors[id]
+		span := spans[id]
+		author.FirstCommit, author.LastCommit = span.first, span.last
+		author.Active = !span.last.Before(cutoff)
+		timeline.Authors = append(timeline.Authors, *author)
+	}
+	sort.SliceStable(timeline.Authors, func(i, j int) bool {
+		return timeline.Authors[i].Commits > timeline.Authors[j].Commits
+	})
+
+	for id, span := range spans {
+		if id.Bot && options.Identity.ExcludeBots {
+			continue
+		}
+		if !span.first.Before(start) {
+			timeline.Onboarded = append(timeline.Onboarded, ContributorEvent{Name: id.Name, Email: id.Email, Date: span.first})
+		}
+		if span.last.Before(cutoff) && !span.last.Before(start) {
+			timeline.Offboarded = append(timeline.Offboarded, ContributorEvent{Name: id.Name, Email: id.Email, Date: span.last})
+		}
+	}
+	sortEvents := func(events []ContributorEvent) {
+		sort.Slice(events, func(i, j int) bool {
+			if !events[i].Date.Equal(events[j].Date) {
+				return events[i].Date.Before(events[j].Date)
+			}
+			return events[i].Email < events[j].Email
+		})
+	}
+	sortEvents(timeline.Onboarded)
+	sortEvents(timeline.Offboarded)
+
+	// A contributor stays active for ActiveWindowWeeks weeks after each commit
+	timeline.ActiveContributors = make([]int, len(timeline.Weeks))
+	for _, author := range timeline.Authors {
+		last := -options.ActiveWindowWeeks
+		for i := range timeline.Weeks {
+			if author.Weekly[i] > 0 {
+				last = i
+			}
+			if i-last < options.ActiveWindowWeeks {
+				timeline.ActiveContributors[i]++
+			}
+		}
+	}
+
+	for offset, count := range timezones {
+		timeline.Timezones = append(timeline.Timezones, TimezoneCount{Offset: offset, Commits: count})
+	}
+	sort.Slice(timeline.Timezones, func(i, j int) bool {
+		if timeline.Timezones[i].Commits != timeline.Timezones[j].Commits {
+			return timeline.Timezones[i].Commits > timeline.Timezones[j].Commits
+		}
+		return timeline.Timezones[i].Offset < timeline.Timezones[j].Offset
+	})
+
+	timeline.Review = reviewStats(repoPath, commits, options.MainBranch, options.ProtectedBranches)
+	return timeline, nil
+}
+
+// reviewStats checks the analyzed commits on the first-parent chain of the main branch and of
+// the protected branches for evidence of review: a pull or merge request reference or a review trailer
+func reviewStats(repoPath string, commits []types.CommitInfo, configured string, protected []string) ReviewStats {
+	branch := resolveMainBranch(repoPath, configured)
+	mainline := revisionSet(repoPath, "--first-parent", branch)
+	stats := ReviewStats{Branch: branch}
+	refs, branches := protectedBranchRefs(repoPath, protected)
+	for _, ref := range refs {
+		for hash := range revisionSet(repoPath, "--first-parent", ref) {
+			mainline[hash] = true
+		}
+	}
+	for _, name := range branches {
+		if name != branch {
+			stats.Branches = append(stats.Branches, name)
+		}
+	}
+	for _, commit := range commits {
+		if !mainline[commit.Hash] {
+			continue
+		}
+		stats.Landed++
+		if reviewRefRe.MatchString(commit.Subject) || reviewRefRe.MatchString(commit.Body) || reviewTrailerRe.MatchString(commit.Body) {
+			continue
+		}
+		stats.ReviewFree++
+		stats.Commits = append(stats.Commits, historyCommit(commit))
+	}
+	if stats.Landed > 0 {
+		stats.Ratio = float64(stats.ReviewFree) / float64(stats.Landed)
+	}
+	return stats
+}
+
+// protectedBranchRefs lists the local and remote-tracking branch refs matching the patterns,
+// and the branch names they hold
+func protectedBranchRefs(repoPath string, patterns []string) (refs, branches []string) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, nil
+	}
+	remotes := policyRemotes(repoPath)
+	for _, ref := range strings.Fields(string(output)) {
+		branch := policyBranchName(ref, remotes)
+		if branch == "HEAD" || !branchMatchesAny(branch, patterns) {
+			continue
+		}
+		refs = append(refs, ref)
+		if !containsString(branches, branch) {
+			branches = append(branches, branch)
+		}
+	}
+	return refs, branches
+}
+
+// weekStart returns midnight UTC of the Monday starting the week of t, taken in t's own timezone
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
@@ -0,0 +1,88 @@
+package checkers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/internal/git"
+)
+
+func TestAnalyzeAuthorTimeline(t *testing.T) {
+	repo := t.TempDir()
+	runGit(t, repo, "init", "-q")
+	commitAs := func(name, date, message string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, name+".txt"), []byte(date+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, repo, "add", ".")
+		runGit(t, repo, "-c", "user.name="+name, "-c", "user.email="+name+"@example.com",
+			"commit", "-qm", message, "--date", date)
+	}
+	commitAs("alice", "2023-11-01T09:30:00+09:00", "feat: parser")
+	commitAs("bob", "2024-05-28T14:00:00-05:00", "feat: api (#12)")
+	commitAs("carol", "2024-06-03T10:00:00+02:00", "fix: routes\n\nReviewed-by: bob <bob@example.com>")
+
+	analyzer, err := git.NewRepositoryAnalyzer(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyzer.SetCommitSelection(git.CommitSelection{AllRefs: true})
+	data, err := analyzer.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := DefaultTimelineOptions()
+	options.Weeks = 52
+	options.Now = time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+	timeline, err := AnalyzeAuthorTimeline(repo, data.Commits, options)
+	if err != nil {
+		t.Fatalf("AnalyzeAuthorTimeline failed: %v", err)
+	}
+
+	if timeline.Weeks[0] != "2023-10-30" || timeline.Weeks[len(timeline.Weeks)-1] != "2024-06-03" {
+		t.Errorf("unexpected week range %s..%s", timeline.Weeks[0], timeline.Weeks[len(timeline.Weeks)-1])
+	}
+	if len(timeline.Authors) != 3 {
+		t.Fatalf("expected 3 authors, got %+v", timeline.Authors)
+	}
+	for _, author := range timeline.Authors {
+		if author.Name == "alice" && (author.Active || author.Weekly[0] != 1) {
+			t.Errorf("alice should be inactive with a commit in the first week, got %+v", author)
+		}
+	}
+	if len(timeline.Onboarded) != 3 || len(timeline.Offboarded) != 1 || timeline.Offboarded[0].Name != "alice" {
+		t.Errorf("unexpected onboarding %+v and offboarding %+v", timeline.Onboarded, timeline.Offboarded)
+	}
+	if active := timeline.ActiveContributors[len(timeline.ActiveContributors)-1]; active != 2 {
+		t.Errorf("expected 2 active contributors in the last week, got %d", active)
+	}
+
+	// Commit times are taken in the author's own timezone
+	if timeline.Hours[time.Wednesday][9] != 1 || timeline.Hours[time.Tuesday][14] != 1 || timeline.Weekdays[time.Monday] != 1 {
+		t.Errorf("unexpected heatmap %v", timeline.Hours)
+	}
+	if len(timeline.Timezones) != 3 {
+		t.Errorf("expected 3 timezones, got %+v", timeline.Timezones)
+	}
+
+	review := timeline.Review
+	if review.Landed != 3 || review.ReviewFree != 1 || review.Commits[0].Subject != "feat: parser" {
+		t.Errorf("only the direct commit should be review-free, got %+v", review)
+	}
+
+	// Direct commits to a protected release branch have landed as well
+	runGit(t, repo, "checkout", "-q", "-b", "release/1.0")
+	commitAs("carol", "2024-06-04T10:00:00+02:00", "fix: backport")
+	data, err = analyzer.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	review = reviewStats(repo, data.Commits, "", []string{"release/*"})
+	if review.Landed != 4 || review.ReviewFree != 2 || len(review.Branches) != 1 || review.Branches[0] != "release/1.0" {
+		t.Errorf("the release branch commit should be review-free, got %+v", review)
+	}
+}
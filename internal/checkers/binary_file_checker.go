@@ -14,6 +14,7 @@ import (
 // BinaryFileChecker audits executable and large files in repository
 type BinaryFileChecker struct {
 	BaseChecker
+	lfsThresholdMB float64
 }
 
 // BinaryFile represents a detected binary or large file
@@ -27,6 +28,10 @@ type BinaryFile struct {
 	InGitignore bool    `json:"in_gitignore"`
 	InHistory   bool    `json:"in_history"`
 	Extension   string  `json:"extension"`
+	ContentType string  `json:"content_type,omitempty"`
+	Commit      string  `json:"commit,omitempty"`
+	// ExtensionMismatch marks an executable whose extension also hides what it is
+	ExtensionMismatch bool `json:"extension_mismatch,omitempty"`
 }
 
 // BinaryAuditReport represents the complete binary file audit report
@@ -34,9 +39,11 @@ type BinaryAuditReport struct {
 	ExecutableFiles []BinaryFile `json:"executable_files"`
 	LargeFiles      []BinaryFile `json:"large_files"`
 	SuspiciousFiles []BinaryFile `json:"suspicious_files"`
+	MismatchedFiles []BinaryFile `json:"mismatched_files"`
 	TotalSize       int64        `json:"total_size"`
 	TotalSizeMB     float64      `json:"total_size_mb"`
 	FileCount       int          `json:"file_count"`
+	LFS             *LFSReport   `json:"lfs,omitempty"`
 	Score           int          `json:"score"`
 	Errors          []string     `json:"errors,omitempty"`
 }
@@ -44,10 +51,17 @@ type BinaryAuditReport struct {
 // NewBinaryFileChecker creates a new BinaryFileChecker
 func NewBinaryFileChecker() *BinaryFileChecker {
 	return &BinaryFileChecker{
-		BaseChecker: NewBaseChecker("Executable & Large File Audit", "BINARY-AUDIT", types.CategorySecurity, 6),
+		BaseChecker:    NewBaseChecker("Executable & Large File Audit", "BINARY-AUDIT", types.CategorySecurity, 6),
+		lfsThresholdMB: 1.0,
 	}
 }
 
+// SetLFSThreshold sets the size from which tracked binaries are expected to live in Git LFS.
+// A threshold of zero disables the recommendation.
+func (c *BinaryFileChecker) SetLFSThreshold(thresholdMB float64) {
+	c.lfsThresholdMB = thresholdMB
+}
+
 // Check performs binary and large file audit
 func (c *BinaryFileChecker) Check(data *types.RepositoryData) *types.CheckResult {
 	return c.CheckWithOptions(data, true, true, true, false, 10.0)
@@ -76,6 +90,7 @@ func (c *BinaryFileChecker) CheckWithSeverity(data *types.RepositoryData, checkE
 		ExecutableFiles: []BinaryFile{},
 		LargeFiles:      []BinaryFile{},
 		SuspiciousFiles: []BinaryFile{},
+		MismatchedFiles: []BinaryFile{},
 		TotalSize:       0,
 		TotalSizeMB:     0,
 		FileCount:       0,
@@ -88,13 +103,29 @@ func (c *BinaryFileChecker) CheckWithSeverity(data *types.RepositoryData, checkE
 	// Check Git history for binary files
 	if checkHistory {
 		c.checkGitHistoryForBinaryFiles(data.Path, report, checkExecutables, checkSuspicious)
+		if checkExecutables || checkSuspicious {
+			c.checkHistoricalBlobContent(data.Path, report, checkExecutables, checkSuspicious)
+		}
 	}
+
+	// Check Git LFS usage
+	if lfsReport, err := auditLFS(data.Path, c.lfsThresholdMB); err != nil {
+		report.Errors = append(report.Errors, "LFS audit: "+err.Error())
+	} else {
+		report.LFS = lfsReport
+		report.LargeFiles = excludeLFSPointers(report.LargeFiles, lfsReport)
+		lfsReport.NotLFSTracked = filterLFSFiles(lfsReport.NotLFSTracked, minSeverity)
+		lfsReport.NotPointers = filterLFSFiles(lfsReport.NotPointers, minSeverity)
+		lfsReport.MissingObjects = filterLFSFiles(lfsReport.MissingObjects, minSeverity)
+	}
+
 	report.ExecutableFiles = filterBinaryFiles(report.ExecutableFiles, minSeverity)
 	report.LargeFiles = filterBinaryFiles(report.LargeFiles, minSeverity)
 	report.SuspiciousFiles = filterBinaryFiles(report.SuspiciousFiles, minSeverity)
-	report.FileCount = len(report.ExecutableFiles) + len(report.LargeFiles) + len(report.SuspiciousFiles)
+	report.MismatchedFiles = filterBinaryFiles(report.MismatchedFiles, minSeverity)
+	report.FileCount = len(report.ExecutableFiles) + len(report.LargeFiles) + len(report.SuspiciousFiles) + len(report.MismatchedFiles)
 	report.TotalSize = 0
-	for _, files := range [][]BinaryFile{report.ExecutableFiles, report.LargeFiles, report.SuspiciousFiles} {
+	for _, files := range [][]BinaryFile{report.ExecutableFiles, report.LargeFiles, report.SuspiciousFiles, report.MismatchedFiles} {
 		for _, file := range files {
 			report.TotalSize += file.Size
 		}
@@ -106,10 +137,14 @@ func (c *BinaryFileChecker) CheckWithSeverity(data *types.RepositoryData, checkE
 	result.Score = score
 
 	// Update result based on findings
-	totalIssues := len(report.ExecutableFiles) + len(report.LargeFiles) + len(report.SuspiciousFiles)
+	totalIssues := len(report.ExecutableFiles) + len(report.LargeFiles) + len(report.SuspiciousFiles) + len(report.MismatchedFiles)
+	lfsIssues := report.LFS.issueCount()
 	if totalIssues > 0 {
 		result.Status = types.StatusFail
 		result.Message = fmt.Sprintf("Found %d binary/large file issues", totalIssues)
+	} else if lfsIssues > 0 {
+		result.Status = types.StatusWarning
+		result.Message = fmt.Sprintf("Found %d Git LFS issues", lfsIssues)
 	} else {
 		result.Status = types.StatusPass
 		result.Message = "No suspicious binary or large files found"
@@ -119,14 +154,16 @@ func (c *BinaryFileChecker) CheckWithSeverity(data *types.RepositoryData, checkE
 	result.Details = append(result.Details, fmt.Sprintf("Executable Files: %d", len(report.ExecutableFiles)))
 	result.Details = append(result.Details, fmt.Sprintf("Large Files: %d", len(report.LargeFiles)))
 	result.Details = append(result.Details, fmt.Sprintf("Suspicious Files: %d", len(report.SuspiciousFiles)))
+	result.Details = append(result.Details, fmt.Sprintf("Extension Mismatches: %d", len(report.MismatchedFiles)))
 	result.Details = append(result.Details, fmt.Sprintf("Total Size: %.1f MB", report.TotalSizeMB))
 	result.Details = append(result.Details, fmt.Sprintf("File Count: %d", report.FileCount))
 	for _, scanError := range report.Errors {
 		result.Details = append(result.Details, "Scan warning: "+scanError)
 	}
+	result.Details = append(result.Details, lfsDetails(report.LFS)...)
 
 	// Add summary table
-	if len(report.ExecutableFiles) > 0 || len(report.LargeFiles) > 0 || len(report.SuspiciousFiles) > 0 {
+	if totalIssues > 0 {
 		result.Details = append(result.Details, "")
 		result.Details = append(result.Details, "📋 File Summary Table:")
 		result.Details = append(result.Details, "┌─────────────────┬──────────┬──────────┬──────────┬──────────┐")
@@ -178,6 +215,19 @@ func (c *BinaryFileChecker) CheckWithSeverity(data *types.RepositoryData, checkE
 			result.Details = append(result.Details, fmt.Sprintf("│ Suspicious      │ %-8d │ %-8.1f │ %-8s │ Active   │", suspCount, suspSize, suspSeverity))
 		}
 
+		// Extension mismatch summary
+		if len(report.MismatchedFiles) > 0 {
+			mismatchSize := 0.0
+			mismatchSeverity := "low"
+			for _, file := range report.MismatchedFiles {
+				mismatchSize += file.SizeMB
+				if binarySeverityLevel(file.Severity) > binarySeverityLevel(mismatchSeverity) {
+					mismatchSeverity = file.Severity
+				}
+			}
+			result.Details = append(result.Details, fmt.Sprintf("│ Mismatched      │ %-8d │ %-8.1f │ %-8s │ Active   │", len(report.MismatchedFiles), mismatchSize, mismatchSeverity))
+		}
+
 		result.Details = append(result.Details, "└─────────────────┴──────────┴──────────┴──────────┴──────────┘")
 		result.Details = append(result.Details, "")
 
@@ -194,6 +244,9 @@ func (c *BinaryFileChecker) CheckWithSeverity(data *types.RepositoryData, checkE
 					} else if file.InHistory {
 						status = " (in history)"
 					}
+					if file.ExtensionMismatch {
+						status += " - " + file.Description
+					}
 					result.Details = append(result.Details, fmt.Sprintf("  • %s [%s] %.1f MB%s", file.Path, file.Severity, file.SizeMB, status))
 				}
 				result.Details = append(result.Details, fmt.Sprintf("  ... and %d more files (use --format json for complete list)", len(report.ExecutableFiles)-maxExecutable))
@@ -205,6 +258,9 @@ func (c *BinaryFileChecker) CheckWithSeverity(data *types.RepositoryData, checkE
 					} else if file.InHistory {
 						status = " (in history)"
 					}
+					if file.ExtensionMismatch {
+						status += " - " + file.Description
+					}
 					result.Details = append(result.Details, fmt.Sprintf("  • %s [%s] %.1f MB%s", file.Path, file.Severity, file.SizeMB, status))
 				}
 			}
@@ -266,6 +322,25 @@ func (c *BinaryFileChecker) CheckWithSeverity(data *types.RepositoryData, checkE
 					result.Details = append(result.Details, fmt.Sprintf("  • %s [%s] %.1f MB%s", file.Path, file.Severity, file.SizeMB, status))
 				}
 			}
+			if len(report.MismatchedFiles) > 0 {
+				result.Details = append(result.Details, "")
+			}
+		}
+
+		if len(report.MismatchedFiles) > 0 {
+			result.Details = append(result.Details, "🎭 Extension Mismatches:")
+			maxMismatched := 20
+			for i, file := range report.MismatchedFiles {
+				if i == maxMismatched {
+					result.Details = append(result.Details, fmt.Sprintf("  ... and %d more files (use --format json for complete list)", len(report.MismatchedFiles)-maxMismatched))
+					break
+				}
+				status := ""
+				if file.InHistory {
+					status = " (in history)"
+				}
+				result.Details = append(result.Details, fmt.Sprintf("  • %s [%s] %s%s", file.Path, file.Severity, file.Description, status))
+			}
 		}
 	}
 
@@ -317,8 +392,15 @@ func (c *BinaryFileChecker) scanDirectoryForBinaryFiles(repoPath string, report
 		fileSize := info.Size()
 		fileExt := strings.ToLower(filepath.Ext(fileName))
 
+		// Identify the content from its magic number
+		var content FileType
+		if checkExecutables || checkSuspicious {
+			content = detectFileType(sniffFile(path))
+		}
+
 		// Check for executable files
-		if checkExecutables && c.isExecutableFile(fileName, fileExt) {
+		extensionExecutable := checkExecutables && c.isExecutableFile(fileName, fileExt)
+		if extensionExecutable {
 			binaryFile := BinaryFile{
 				Path:        relPath,
 				Size:        fileSize,
@@ -329,8 +411,17 @@ func (c *BinaryFileChecker) scanDirectoryForBinaryFiles(repoPath string, report
 				InGitignore: c.isInGitignore(repoPath, relPath),
 				InHistory:   false,
 				Extension:   fileExt,
+				ContentType: content.Kind,
 			}
-			report.ExecutableFiles = append(report.ExecutableFiles, binaryFile)
+			report.ExecutableFiles = append(report.ExecutableFiles, noteExtensionMismatch(binaryFile, content))
+			report.TotalSize += fileSize
+			report.FileCount++
+		}
+
+		// Check for executables that an unrelated extension hides
+		contentExecutable := checkExecutables && !extensionExecutable && isContentExecutable(content)
+		if contentExecutable {
+			report.ExecutableFiles = append(report.ExecutableFiles, c.contentFinding(repoPath, relPath, fileSize, fileExt, content, "executable", false))
 			report.TotalSize += fileSize
 			report.FileCount++
 		}
@@ -371,6 +462,13 @@ func (c *BinaryFileChecker) scanDirectoryForBinaryFiles(repoPath string, report
 			report.FileCount++
 		}
 
+		// Check for extensions that contradict the file content; executables already note it
+		if checkSuspicious && !extensionExecutable && !contentExecutable && extensionMismatch(fileExt, content) {
+			report.MismatchedFiles = append(report.MismatchedFiles, c.contentFinding(repoPath, relPath, fileSize, fileExt, content, "mismatch", false))
+			report.TotalSize += fileSize
+			report.FileCount++
+		}
+
 		return nil
 	})
 
@@ -440,6 +538,196 @@ func (c *BinaryFileChecker) checkGitHistoryForBinaryFiles(repoPath string, repor
 	}
 }
 
+// checkHistoricalBlobContent sniffs every reachable blob that is not in the current index,
+// so renamed executables and disguised archives that were later removed are still found
+func (c *BinaryFileChecker) checkHistoricalBlobContent(repoPath string, report *BinaryAuditReport, checkExecutables, checkSuspicious bool) {
+	objects, err := reachableObjects(repoPath)
+	if err != nil {
+		report.Errors = append(report.Errors, "history content scan: "+err.Error())
+		return
+	}
+
+	current := make(map[string]bool)
+	if blobs, err := trackedBlobs(repoPath); err == nil {
+		for _, blob := range blobs {
+			current[blob.oid] = true
+		}
+	}
+
+	historical := make(map[string]reachableObject)
+	oids := []string{}
+	for _, object := range objects {
+		if object.objectType != "blob" || object.path == "" || current[object.oid] {
+			continue
+		}
+		if _, seen := historical[object.oid]; seen {
+			continue
+		}
+		historical[object.oid] = object
+		oids = append(oids, object.oid)
+	}
+
+	reported := make(map[string]bool)
+	for _, files := range [][]BinaryFile{report.ExecutableFiles, report.MismatchedFiles} {
+		for _, file := range files {
+			reported[file.Type+"\x00"+filepath.ToSlash(file.Path)] = true
+		}
+	}
+	add := func(files *[]BinaryFile, file BinaryFile) {
+		key := file.Type + "\x00" + file.Path
+		if reported[key] {
+			return
+		}
+		reported[key] = true
+		*files = append(*files, file)
+		report.FileCount++
+	}
+
+	err = sniffBlobs(repoPath, oids, func(oid string, head []byte) {
+		object := historical[oid]
+		content := detectFileType(head)
+		fileExt := strings.ToLower(filepath.Ext(object.path))
+		extensionExecutable := checkExecutables && c.isExecutableFile(filepath.Base(object.path), fileExt)
+		contentExecutable := checkExecutables && !extensionExecutable && isContentExecutable(content)
+		if contentExecutable {
+			add(&report.ExecutableFiles, c.contentFinding(repoPath, object.path, object.size, fileExt, content, "executable", true))
+		}
+		if checkSuspicious && !extensionExecutable && !contentExecutable && extensionMismatch(fileExt, content) {
+			add(&report.MismatchedFiles, c.contentFinding(repoPath, object.path, object.size, fileExt, content, "mismatch", true))
+		}
+	})
+	if err != nil {
+		report.Errors = append(report.Errors, "history content scan: "+err.Error())
+	}
+}
+
+// ScanCommits audits the files that specific commits add or modify, such as the commits
+// of a push. Content is read from the committed blobs rather than the working tree.
+func (c *BinaryFileChecker) ScanCommits(repoPath string, commits []string, maxSizeMB float64) (*BinaryAuditReport, error) {
+	report := &BinaryAuditReport{
+		ExecutableFiles: []BinaryFile{},
+		LargeFiles:      []BinaryFile{},
+		SuspiciousFiles: []BinaryFile{},
+		MismatchedFiles: []BinaryFile{},
+	}
+
+	changed, err := commitChangedFiles(repoPath, commits)
+	if err != nil {
+		return nil, err
+	}
+	byOID := make(map[string][]changedFile)
+	var oids []string
+	for _, file := range changed {
+		if _, seen := byOID[file.oid]; !seen {
+			oids = append(oids, file.oid)
+		}
+		byOID[file.oid] = append(byOID[file.oid], file)
+	}
+	sizes, err := blobSizes(repoPath, oids)
+	if err != nil {
+		return nil, err
+	}
+
+	add := func(files *[]BinaryFile, file BinaryFile, commit string) {
+		file.Commit = commit
+		*files = append(*files, file)
+		report.TotalSize += file.Size
+		report.FileCount++
+	}
+	err = sniffBlobs(repoPath, oids, func(oid string, head []byte) {
+		content := detectFileType(head)
+		fileSize := sizes[oid]
+		for _, file := range byOID[oid] {
+			fileName := filepath.Base(file.path)
+			fileExt := strings.ToLower(filepath.Ext(fileName))
+			executable := true
+			switch {
+			case c.isExecutableFile(fileName, fileExt):
+				add(&report.ExecutableFiles, noteExtensionMismatch(BinaryFile{
+					Path:        file.path,
+					Size:        fileSize,
+					SizeMB:      float64(fileSize) / (1024 * 1024),
+					Type:        "executable",
+					Severity:    c.getExecutableSeverity(fileExt),
+					Description: c.getExecutableDescription(fileExt),
+					Extension:   fileExt,
+					ContentType: content.Kind,
+				}, content), file.commit)
+			case isContentExecutable(content):
+				add(&report.ExecutableFiles, c.contentFinding(repoPath, file.path, fileSize, fileExt, content, "executable", false), file.commit)
+			default:
+				executable = false
+			}
+			if fileSize > int64(maxSizeMB*1024*1024) {
+				add(&report.LargeFiles, BinaryFile{
+					Path:        file.path,
+					Size:        fileSize,
+					SizeMB:      float64(fileSize) / (1024 * 1024),
+					Type:        "large",
+					Severity:    c.getLargeFileSeverity(fileSize),
+					Description: fmt.Sprintf("Large file: %.1f MB", float64(fileSize)/(1024*1024)),
+					Extension:   fileExt,
+				}, file.commit)
+			}
+			if c.isSuspiciousFile(fileName, fileExt) {
+				add(&report.SuspiciousFiles, BinaryFile{
+					Path:        file.path,
+					Size:        fileSize,
+					SizeMB:      float64(fileSize) / (1024 * 1024),
+					Type:        "suspicious",
+					Severity:    "high",
+					Description: fmt.Sprintf("Suspicious file type: %s", fileExt),
+					Extension:   fileExt,
+				}, file.commit)
+			}
+			if !executable && extensionMismatch(fileExt, content) {
+				add(&report.MismatchedFiles, c.contentFinding(repoPath, file.path, fileSize, fileExt, content, "mismatch", false), file.commit)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.TotalSizeMB = float64(report.TotalSize) / (1024 * 1024)
+	report.Score = c.calculateScore(report)
+	return report, nil
+}
+
+// contentFinding builds a finding for a file identified by its content rather than its name
+func (c *BinaryFileChecker) contentFinding(repoPath, filePath string, fileSize int64, fileExt string, content FileType, findingType string, inHistory bool) BinaryFile {
+	description := fmt.Sprintf("%s detected from file content", content.Description)
+	if findingType == "mismatch" {
+		description = mismatchDescription(fileExt, content)
+	}
+	file := BinaryFile{
+		Path:        filePath,
+		Size:        fileSize,
+		SizeMB:      float64(fileSize) / (1024 * 1024),
+		Type:        findingType,
+		Severity:    contentSeverity(content),
+		Description: description,
+		InGitignore: c.isInGitignore(repoPath, filePath),
+		InHistory:   inHistory,
+		Extension:   fileExt,
+		ContentType: content.Kind,
+	}
+	if findingType == "executable" {
+		file = noteExtensionMismatch(file, content)
+	}
+	return file
+}
+
+// noteExtensionMismatch records on an executable finding that its extension contradicts its
+// content, so a renamed binary is one finding rather than an executable and a mismatch
+func noteExtensionMismatch(file BinaryFile, content FileType) BinaryFile {
+	if extensionMismatch(file.Extension, content) {
+		file.ExtensionMismatch = true
+		file.Description = mismatchDescription(file.Extension, content)
+	}
+	return file
+}
+
 // isExecutableFile checks if a file is executable based on extension
 func (c *BinaryFileChecker) isExecutableFile(fileName, fileExt string) bool {
 	executableExtensions := map[string]bool{
@@ -665,6 +953,20 @@ func (c *BinaryFileChecker) calculateScore(report *BinaryAuditReport) int {
 		}
 	}
 
+	// Deduct points for extensions that contradict the content
+	for _, file := range report.MismatchedFiles {
+		if !file.InGitignore {
+			switch file.Severity {
+			case "critical":
+				score -= 30
+			case "high":
+				score -= 20
+			default:
+				score -= 10
+			}
+		}
+	}
+
 	// Deduct points for files in history
 	for _, file := range report.ExecutableFiles {
 		if file.InHistory {
@@ -678,6 +980,13 @@ func (c *BinaryFileChecker) calculateScore(report *BinaryAuditReport) int {
 		}
 	}
 
+	// Deduct points for Git LFS issues
+	if report.LFS != nil {
+		score -= 15 * len(report.LFS.NotPointers)
+		score -= 5 * len(report.LFS.NotLFSTracked)
+		score -= 5 * len(report.LFS.MissingObjects)
+	}
+
 	// Ensure score doesn't go below 0
 	if score < 0 {
 		score = 0
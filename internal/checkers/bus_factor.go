@@ -0,0 +1,469 @@
+package checkers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"os/exec"
+	"path"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/internal/identity"
+)
+
+// BusFactorOptions tunes the blame-based knowledge analysis
+type BusFactorOptions struct {
+	// Depth groups files by their first Depth path segments; 0 groups by the file's own directory (package)
+	Depth int
+	// HalfLifeDays is the age at which a line counts half as much knowledge as one written today
+	HalfLifeDays int
+	// InactiveDays is how long an author may go without committing before their knowledge is at risk
+	InactiveDays int
+	// Coverage is the share of a directory's knowledge the bus factor authors must hold together
+	Coverage float64
+	// Exclude lists gitignore-style patterns of files left out, such as vendored or generated code
+	Exclude []string
+	// Identity controls how blamed authors are merged into people
+	Identity identity.Options
+	// Now is the reference time for decay and inactivity; zero means time.Now
+	Now time.Time
+}
+
+// KnowledgeOwner is an author's share of the decayed blame knowledge of a directory
+type KnowledgeOwner struct {
+	Name       string    `json:"name"`
+	Email      string    `json:"email"`
+	Lines      int       `json:"lines"`
+	Share      float64   `json:"share"`
+	LastActive time.Time `json:"last_active"`
+	Inactive   bool      `json:"inactive"`
+}
+
+// DirectoryBusFactor is the bus factor of one directory
+type DirectoryBusFactor struct {
+	Directory string `json:"directory"`
+	Files     int    `json:"files"`
+	Lines     int    `json:"lines"`
+	// BusFactor is the number of authors who together hold Coverage of the knowledge
+	BusFactor int              `json:"bus_factor"`
+	Owners    []KnowledgeOwner `json:"owners"`
+	// Risk is critical when every bus factor author is inactive, high for a bus factor of one, otherwise low
+	Risk string `json:"risk"`
+	// SoleOwnerInactive marks directories whose only knowledgeable author has gone inactive
+	SoleOwnerInactive bool `json:"sole_owner_inactive"`
+}
+
+// BusFactorReport holds the per-directory bus factors of a repository
+type BusFactorReport struct {
+	Files        int                  `json:"files"`
+	Lines        int                  `json:"lines"`
+	HalfLifeDays int                  `json:"half_life_days"`
+	InactiveDays int                  `json:"inactive_days"`
+	Coverage     float64              `json:"coverage"`
+	BusFactor    int                  `json:"bus_factor"`
+	Owners       []KnowledgeOwner     `json:"owners"`
+	Directories  []DirectoryBusFactor `json:"directories"`
+	// AtRisk lists the directories whose knowledge rests with inactive authors
+	AtRisk []string `json:"at_risk,omitempty"`
+	// Skipped counts the files git blame could not attribute; they are left out of the analysis
+	Skipped int `json:"skipped,omitempty"`
+}
+
+// defaultBusFactorExcludes leaves out code nobody writes by hand
+var defaultBusFactorExcludes = []string{
+	"vendor/", "node_modules/", "third_party/", "*.min.js", "*.min.css",
+	"go.sum", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "Cargo.lock", "poetry.lock", "composer.lock", "Gemfile.lock",
+}
+
+// DefaultBusFactorOptions returns the settings used when gphc.yml sets none
+func DefaultBusFactorOptions() BusFactorOptions {
+	return BusFactorOptions{
+		HalfLifeDays: 365,
+		InactiveDays: 90,
+		Coverage:     0.8,
+		Exclude:      defaultBusFactorExcludes,
+		Identity:     identity.DefaultOptions(),
+	}
+}
+
+// blameLines is the number of lines an author holds in a file and their decayed weight
+type blameLines struct {
+	person identity.Person
+	lines  int
+	weight float64
+}
+
+// AnalyzeBusFactor blames every text file at HEAD and computes the bus factor per directory.
+// Each blamed line counts as knowledge of its author, decayed by the age of the commit.
+func AnalyzeBusFactor(repoPath string, options BusFactorOptions) (*BusFactorReport, error) {
+	defaults := DefaultBusFactorOptions()
+	if options.HalfLifeDays <= 0 {
+		options.HalfLifeDays = defaults.HalfLifeDays
+	}
+	if options.InactiveDays <= 0 {
+		options.InactiveDays = defaults.InactiveDays
+	}
+	if options.Coverage <= 0 || options.Coverage > 1 {
+		options.Coverage = defaults.Coverage
+	}
+	if options.Exclude == nil {
+		options.Exclude = defaults.Exclude
+	}
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+
+	files, err := blameableFiles(repoPath, options.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	blames := blameFiles(repoPath, files, options)
+	activity, err := authorActivity(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Blamed authors and every commit author are resolved together so aliases line up
+	counts := make(map[string]int)
+	var people []identity.Person
+	addPerson := func(name, email string) {
+		key := name + "\x00" + strings.ToLower(email)
+		if _, ok := counts[key]; !ok {
+			people = append(people, identity.Person{Name: name, Email: email})
+		}
+		counts[key]++
+	}
+	for _, fileBlame := range blames {
+		for _, lines := range fileBlame {
+			addPerson(lines.person.Name, lines.person.Email)
+		}
+	}
+	for person := range activity {
+		addPerson(person.Name, person.Email)
+	}
+	for i := range people {
+		people[i].Commits = counts[people[i].Name+"\x00"+strings.ToLower(people[i].Email)]
+	}
+	resolver, err := identity.NewResolver(repoPath, options.Identity)
+	if err != nil {
+		return nil, err
+	}
+	index := resolver.Resolve(people)
+
+	active := make(map[*identity.Identity]time.Time)
+	for person, span := range activity {
+		id := index.Lookup(person.Name, person.Email)
+		if span.last.After(active[id]) {
+			active[id] = span.last
+		}
+	}
+
+	report := &BusFactorReport{
+		HalfLifeDays: options.HalfLifeDays,
+		InactiveDays: options.InactiveDays,
+		Coverage:     options.Coverage,
+	}
+	type knowledge struct {
+		lines  map[*identity.Identity]int
+		weight map[*identity.Identity]float64
+		files  int
+		total  int
+	}
+	newKnowledge := func() *knowledge {
+		return &knowledge{lines: make(map[*identity.Identity]int), weight: make(map[*identity.Identity]float64)}
+	}
+	repository := newKnowledge()
+	directories := make(map[string]*knowledge)
+	for _, file := range files {
+		if _, ok := blames[file]; !ok {
+			report.Skipped++
+			continue
+		}
+		directory := busFactorDirectory(file, options.Depth)
+		if directories[directory] == nil {
+			directories[directory] = newKnowledge()
+		}
+		for _, k := range []*knowledge{repository, directories[directory]} {
+			k.files++
+			for _, lines := range blames[file] {
+				id := index.Lookup(lines.person.Name, lines.person.Email)
+				if id.Bot && options.Identity.ExcludeBots {
+					continue
+				}
+				k.lines[id] += lines.lines
+				k.weight[id] += lines.weight
+				k.total += lines.lines
+			}
+		}
+	}
+
+	cutoff := options.Now.AddDate(0, 0, -options.InactiveDays)
+	summarize := func(k *knowledge) (int, []KnowledgeOwner) {
+		total := 0.0
+		for _, weight := range k.weight {
+			total += weight
+		}
+		var owners []KnowledgeOwner
+		for id, weight := range k.weight {
+			share := 0.0
+			if total > 0 {
+				share = weight / total
+			}
+			owners = append(owners, KnowledgeOwner{
+				Name:       id.Name,
+				Email:      id.Email,
+				Lines:      k.lines[id],
+				Share:      math.Round(share*1000) / 1000,
+				LastActive: active[id],
+				Inactive:   active[id].Before(cutoff),
+			})
+		}
+		sort.Slice(owners, func(i, j int) bool {
+			if owners[i].Share != owners[j].Share {
+				return owners[i].Share > owners[j].Share
+			}
+			return owners[i].Email < owners[j].Email
+		})
+
+		busFactor, covered := 0, 0.0
+		for _, owner := range owners {
+			if covered >= options.Coverage-1e-9 {
+				break
+			}
+			busFactor++
+			covered += owner.Share
+		}
+		return busFactor, owners
+	}
+
+	report.Files, report.Lines = repository.files, repository.total
+	report.BusFactor, report.Owners = summarize(repository)
+
+	for name, k := range directories {
+		directory := DirectoryBusFactor{Directory: name, Files: k.files, Lines: k.total}
+		directory.BusFactor, directory.Owners = summarize(k)
+
+		allInactive := directory.BusFactor > 0
+		for _, owner := range directory.Owners[:directory.BusFactor] {
+			allInactive = allInactive && owner.Inactive
+		}
+		directory.SoleOwnerInactive = directory.BusFactor == 1 && allInactive
+		switch {
+		case allInactive:
+			directory.Risk = "critical"
+			report.AtRisk = append(report.AtRisk, name)
+		case directory.BusFactor == 1:
+			directory.Risk = "high"
+		default:
+			directory.Risk = "low"
+		}
+		report.Directories = append(report.Directories, directory)
+	}
+	sort.Slice(report.Directories, func(i, j int) bool {
+		a, b := report.Directories[i], report.Directories[j]
+		if a.BusFactor != b.BusFactor {
+			return a.BusFactor < b.BusFactor
+		}
+		if a.Lines != b.Lines {
+			return a.Lines > b.Lines
+		}
+		return a.Directory < b.Directory
+	})
+	sort.Strings(report.AtRisk)
+	return report, nil
+}
+
+// busFactorDirectory groups a file by its directory, truncated to depth segments when depth > 0
+func busFactorDirectory(file string, depth int) string {
+	directory := path.Dir(file)
+	if directory == "." {
+		return "/"
+	}
+	if depth > 0 {
+		if segments := strings.Split(directory, "/"); len(segments) > depth {
+			directory = strings.Join(segments[:depth], "/")
+		}
+	}
+	return directory + "/"
+}
+
+// blameableFiles lists the text files at HEAD that are not excluded
+func blameableFiles(repoPath string, exclude []string) ([]string, error) {
+	if !hasHead(repoPath) {
+		return nil, nil
+	}
+	// -z keeps non-ASCII and special characters in paths unquoted
+	cmd := exec.Command("git", "grep", "-z", "-I", "-l", "-e", "", "HEAD", "--")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		// git grep exits 1 when no file matches
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("list text files: %w", err)
+	}
+
+	var files []string
+	for _, entry := range strings.Split(string(output), "\x00") {
+		file := strings.TrimPrefix(entry, "HEAD:")
+		if file == "" {
+			continue
+		}
+		excluded := false
+		for _, pattern := range exclude {
+			if matchGitPattern(pattern, file) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// blameFiles blames files at HEAD in parallel; a file git blame fails on is left out of the
+// results rather than failing the whole analysis
+func blameFiles(repoPath string, files []string, options BusFactorOptions) map[string][]blameLines {
+	results := make(map[string][]blameLines, len(files))
+	var mu sync.Mutex
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range jobs {
+				lines, err := blameFile(repoPath, file, options)
+				if err != nil {
+					continue
+				}
+				mu.Lock()
+				results[file] = lines
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, file := range files {
+		jobs <- file
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// blameFile attributes the lines of a file at HEAD to their authors using git blame --incremental
+func blameFile(repoPath, file string, options BusFactorOptions) ([]blameLines, error) {
+	cmd := exec.Command("git", "blame", "--incremental", "-w", "HEAD", "--", file)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("blame %s: %w", file, err)
+	}
+
+	type commitInfo struct {
+		person identity.Person
+		date   time.Time
+	}
+	commits := make(map[string]*commitInfo)
+	byAuthor := make(map[string]*blameLines)
+	var order []string
+
+	var current *commitInfo
+	var lines int
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			current.person.Name = value
+		case "author-mail":
+			current.person.Email = strings.Trim(value, "<>")
+		case "author-time":
+			if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+				current.date = time.Unix(seconds, 0)
+			}
+		case "filename":
+			// Each group ends with the filename; add its lines to the commit's author
+			if current == nil {
+				continue
+			}
+			ageDays := math.Max(options.Now.Sub(current.date).Hours()/24, 0)
+			weight := math.Pow(0.5, ageDays/float64(options.HalfLifeDays))
+			authorKey := current.person.Name + "\x00" + strings.ToLower(current.person.Email)
+			if byAuthor[authorKey] == nil {
+				byAuthor[authorKey] = &blameLines{person: current.person}
+				order = append(order, authorKey)
+			}
+			byAuthor[authorKey].lines += lines
+			byAuthor[authorKey].weight += float64(lines) * weight
+		default:
+			// <sha> <source line> <result line> <number of lines> starts a group
+			fields := strings.Fields(line)
+			if len(fields) == 4 && len(fields[0]) >= 40 {
+				if commits[fields[0]] == nil {
+					commits[fields[0]] = &commitInfo{}
+				}
+				current = commits[fields[0]]
+				lines, _ = strconv.Atoi(fields[3])
+			}
+		}
+	}
+
+	result := make([]blameLines, 0, len(order))
+	for _, key := range order {
+		result = append(result, *byAuthor[key])
+	}
+	return result, scanner.Err()
+}
+
+// activitySpan is the author date of a person's first and latest commit
+type activitySpan struct {
+	first time.Time
+	last  time.Time
+}
+
+// authorActivity returns the first and latest commit of each author reachable from HEAD
+func authorActivity(repoPath string) (map[identity.Person]activitySpan, error) {
+	activity := make(map[identity.Person]activitySpan)
+	if !hasHead(repoPath) {
+		return activity, nil
+	}
+	cmd := exec.Command("git", "log", "--format=%an%x1f%ae%x1f%aI", "HEAD")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("read commit authors: %w", err)
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 3 {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			continue
+		}
+		person := identity.Person{Name: fields[0], Email: fields[1]}
+		span, ok := activity[person]
+		if !ok || date.Before(span.first) {
+			span.first = date
+		}
+		if date.After(span.last) {
+			span.last = date
+		}
+		activity[person] = span
+	}
+	return activity, nil
+}
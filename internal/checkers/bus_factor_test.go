@@ -0,0 +1,131 @@
+package checkers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeBusFactor(t *testing.T) {
+	repo := createGitRepository(t)
+	twoYearsAgo := time.Now().AddDate(-2, 0, 0).Format(time.RFC3339)
+
+	commitAs := func(name, date string, files map[string]int) {
+		t.Helper()
+		for file, lines := range files {
+			path := filepath.Join(repo, file)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			content := ""
+			if existing, err := os.ReadFile(path); err == nil {
+				content = string(existing)
+			}
+			for i := 0; i < lines; i++ {
+				content += fmt.Sprintf("// %s line %d\n", name, i)
+			}
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		runGit(t, repo, "add", ".")
+		args := []string{"-c", "user.name=" + name, "-c", "user.email=" + strings.ToLower(name) + "@example.com", "commit", "-qm", "feat: " + name}
+		if date != "" {
+			args = append(args, "--date", date)
+		}
+		runGit(t, repo, args...)
+	}
+
+	commitAs("Alice", twoYearsAgo, map[string]int{"legacy/parser.go": 40, "mixed/core.go": 30, "vendor/lib/lib.go": 100})
+	commitAs("Bob", "", map[string]int{"api/handler.go": 20, "mixed/core.go": 10})
+	commitAs("Carol", "", map[string]int{"api/routes.go": 20})
+
+	options := DefaultBusFactorOptions()
+	options.Identity.UseMailmap = false
+	report, err := AnalyzeBusFactor(repo, options)
+	if err != nil {
+		t.Fatalf("AnalyzeBusFactor failed: %v", err)
+	}
+
+	directories := make(map[string]DirectoryBusFactor)
+	for _, directory := range report.Directories {
+		directories[directory.Directory] = directory
+	}
+	if _, ok := directories["vendor/lib/"]; ok {
+		t.Errorf("vendored code should be excluded, got %+v", report.Directories)
+	}
+
+	legacy := directories["legacy/"]
+	if legacy.BusFactor != 1 || !legacy.SoleOwnerInactive || legacy.Risk != "critical" {
+		t.Errorf("legacy/ should be a critical sole-owner risk, got %+v", legacy)
+	}
+	if len(report.AtRisk) != 1 || report.AtRisk[0] != "legacy/" {
+		t.Errorf("expected legacy/ at risk, got %v", report.AtRisk)
+	}
+
+	api := directories["api/"]
+	if api.BusFactor != 2 || api.Risk != "low" || api.Lines != 40 {
+		t.Errorf("api/ should be shared by two active authors, got %+v", api)
+	}
+
+	// Alice wrote more of mixed/ but her lines are two half-lives old
+	mixed := directories["mixed/"]
+	if len(mixed.Owners) != 2 || mixed.Owners[0].Name != "Bob" || mixed.Owners[1].Lines != 30 {
+		t.Fatalf("decay should rank Bob first in mixed/, got %+v", mixed.Owners)
+	}
+	if mixed.Owners[0].Share < 0.5 || mixed.Owners[1].Inactive != true || mixed.BusFactor != 2 {
+		t.Errorf("unexpected mixed/ ownership: %+v", mixed)
+	}
+
+	options.Depth = 1
+	options.Exclude = []string{}
+	report, err = AnalyzeBusFactor(repo, options)
+	if err != nil {
+		t.Fatalf("AnalyzeBusFactor failed: %v", err)
+	}
+	found := false
+	for _, directory := range report.Directories {
+		found = found || directory.Directory == "vendor/"
+	}
+	if !found {
+		t.Errorf("with no excludes and depth 1, vendor/ should be grouped, got %+v", report.Directories)
+	}
+}
+
+func TestAnalyzeBusFactorNonASCIIPaths(t *testing.T) {
+	repo := createGitRepository(t)
+	for name, content := range map[string]string{"café.go": "package main\n", "docs/my notes.txt": "notes\n"} {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "feat: add files")
+
+	options := DefaultBusFactorOptions()
+	options.Identity.UseMailmap = false
+	report, err := AnalyzeBusFactor(repo, options)
+	if err != nil {
+		t.Fatalf("AnalyzeBusFactor failed: %v", err)
+	}
+	directories := make(map[string]DirectoryBusFactor)
+	for _, directory := range report.Directories {
+		directories[directory.Directory] = directory
+	}
+	if report.Skipped != 0 || directories["docs/"].Lines != 1 {
+		t.Errorf("expected every file blamed, got %d skipped in %+v", report.Skipped, report.Directories)
+	}
+
+	// A file blame cannot read is skipped instead of failing the analysis
+	blames := blameFiles(repo, []string{"café.go", "missing.go"}, options)
+	if _, ok := blames["café.go"]; !ok || len(blames) != 1 {
+		t.Errorf("unexpected blames %+v", blames)
+	}
+}
@@ -0,0 +1,680 @@
+package checkers
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// Changelog formats
+const (
+	ChangelogFormatKeepAChangelog = "keep-a-changelog"
+	ChangelogFormatTemplate       = "template"
+)
+
+// ChangelogOptions configures changelog generation
+type ChangelogOptions struct {
+	// Format is keep-a-changelog or template
+	Format string
+	// Template is a text/template rendering a Changelog; required by the template format
+	Template string
+	// Types maps commit types to section titles; an empty title hides the type.
+	// The "other" key places commits that do not follow the convention.
+	Types map[string]string
+	// TagPrefix selects the release tags, usually "v" or "api/v" for a monorepo component
+	TagPrefix string
+	// Major limits the changelog to tags of one major version, as for a Go module in a v2 subdirectory
+	Major int
+	// Paths limit the entries to commits touching these pathspecs; empty means the whole repository
+	Paths []string
+	// Unreleased adds a section for the commits after the latest tag
+	Unreleased bool
+	// Authors credits the author of each entry
+	Authors bool
+	// RemoteURL overrides the origin remote used for commit, issue and compare links
+	RemoteURL string
+}
+
+// keepAChangelogSections are the section titles of Keep a Changelog in their order
+var keepAChangelogSections = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+// DefaultChangelogTypes maps conventional commit types to Keep a Changelog sections
+func DefaultChangelogTypes() map[string]string {
+	return map[string]string{
+		"feat":       "Added",
+		"perf":       "Changed",
+		"refactor":   "Changed",
+		"revert":     "Changed",
+		"deprecate":  "Deprecated",
+		"remove":     "Removed",
+		"fix":        "Fixed",
+		"security":   "Security",
+		"other":      "Changed",
+		"docs":       "",
+		"style":      "",
+		"test":       "",
+		"build":      "",
+		"ci":         "",
+		"chore":      "",
+		"release":    "",
+		"wip":        "",
+		"dependabot": "",
+	}
+}
+
+// DefaultChangelogOptions returns the settings used when gphc.yml sets none
+func DefaultChangelogOptions() ChangelogOptions {
+	return ChangelogOptions{
+		Format:     ChangelogFormatKeepAChangelog,
+		Types:      DefaultChangelogTypes(),
+		TagPrefix:  "v",
+		Unreleased: true,
+	}
+}
+
+// ChangelogEntry is one commit in a changelog section
+type ChangelogEntry struct {
+	Hash        string
+	ShortHash   string
+	Type        string
+	Scope       string
+	Description string
+	Author      string
+	// BreakingNote is the BREAKING CHANGE footer, or the description for a "!" header
+	BreakingNote string
+	CommitURL    string
+	// Text is the rendered markdown line: scope, description with linked issues, commit link and author
+	Text string
+}
+
+// ChangelogSection is the entries of one section title
+type ChangelogSection struct {
+	Title   string
+	Entries []ChangelogEntry
+}
+
+// ChangelogRelease is one version of the changelog
+type ChangelogRelease struct {
+	// Version is the tag without its prefix, or "Unreleased"
+	Version     string
+	Tag         string
+	PreviousTag string
+	Date        time.Time
+	// Breaking lists the breaking changes, which also appear in their own section
+	Breaking   []ChangelogEntry
+	Sections   []ChangelogSection
+	CompareURL string
+}
+
+// Changelog is the full history of releases, newest first
+type Changelog struct {
+	Releases  []ChangelogRelease
+	RemoteURL string
+}
+
+// RemoteLinks builds web links for a hosted repository
+type RemoteLinks struct {
+	// WebURL is the repository home page, e.g. https://github.com/owner/repo
+	WebURL string
+	// Host is github, gitlab or bitbucket; other hosts are linked like GitHub
+	Host string
+}
+
+var (
+	scpRemoteRe   = regexp.MustCompile(`^(?:[\w.-]+@)?([\w.-]+):(.+?)(?:\.git)?/?$`)
+	urlRemoteRe   = regexp.MustCompile(`^(?:https?|ssh|git)://(?:[^@/]+@)?([^/:]+)(?::\d+)?/(.+?)(?:\.git)?/?$`)
+	issueRefRe    = regexp.MustCompile(`(^|[\s(])#(\d+)\b`)
+	linkRefLineRe = regexp.MustCompile(`^\[[^\]]+\]:\s+\S+`)
+	versionHeadRe = regexp.MustCompile(`^##\s+\[?([^\]\s]+)\]?`)
+	shortHashRe   = regexp.MustCompile(`\b[0-9a-f]{8}\b`)
+)
+
+// ParseRemoteURL turns an SSH or HTTPS remote into web links; ok is false for local paths
+func ParseRemoteURL(remote string) (RemoteLinks, bool) {
+	remote = strings.TrimSpace(remote)
+	var host, path string
+	if match := urlRemoteRe.FindStringSubmatch(remote); match != nil {
+		host, path = match[1], match[2]
+	} else if match := scpRemoteRe.FindStringSubmatch(remote); match != nil && !strings.Contains(match[1], "/") {
+		host, path = match[1], match[2]
+	} else {
+		return RemoteLinks{}, false
+	}
+	links := RemoteLinks{WebURL: "https://" + host + "/" + strings.TrimPrefix(path, "/"), Host: "github"}
+	switch {
+	case strings.Contains(host, "gitlab"):
+		links.Host = "gitlab"
+	case strings.Contains(host, "bitbucket"):
+		links.Host = "bitbucket"
+	}
+	return links, true
+}
+
+// CommitURL links a commit
+func (l RemoteLinks) CommitURL(hash string) string {
+	switch l.Host {
+	case "gitlab":
+		return l.WebURL + "/-/commit/" + hash
+	case "bitbucket":
+		return l.WebURL + "/commits/" + hash
+	}
+	return l.WebURL + "/commit/" + hash
+}
+
+// IssueURL links an issue number
+func (l RemoteLinks) IssueURL(number string) string {
+	if l.Host == "gitlab" {
+		return l.WebURL + "/-/issues/" + number
+	}
+	return l.WebURL + "/issues/" + number
+}
+
+// CompareURL links the changes between two revisions; an empty from links the tag itself
+func (l RemoteLinks) CompareURL(from, to string) string {
+	switch {
+	case from == "" && l.Host == "gitlab":
+		return l.WebURL + "/-/tags/" + to
+	case from == "" && l.Host == "bitbucket":
+		return l.WebURL + "/src/" + to
+	case from == "":
+		return l.WebURL + "/releases/tag/" + to
+	case l.Host == "gitlab":
+		return l.WebURL + "/-/compare/" + from + "..." + to
+	case l.Host == "bitbucket":
+		return l.WebURL + "/branches/compare/" + to + "%0D" + from
+	}
+	return l.WebURL + "/compare/" + from + "..." + to
+}
+
+// BuildChangelog walks every release tag reachable from HEAD, oldest to newest, and groups
+// the commits between consecutive tags into sections by their type
+func BuildChangelog(repoPath string, options ChangelogOptions) (*Changelog, error) {
+	if options.Types == nil {
+		options.Types = DefaultChangelogTypes()
+	}
+	if !hasHead(repoPath) {
+		return nil, fmt.Errorf("repository has no commits")
+	}
+
+	changelog := &Changelog{}
+	links, linked := changelogRemoteLinks(repoPath, options.RemoteURL)
+	if linked {
+		changelog.RemoteURL = links.WebURL
+	}
+
+	tags, err := versionTags(repoPath, options.TagPrefix, options.Major, true)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].version.Compare(tags[j].version) < 0 })
+	dates, err := tagDates(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []ChangelogRelease
+	previous := ""
+	for _, tag := range tags {
+		commits, err := releaseCommitsBetween(repoPath, previous, tag.name, options.Paths...)
+		if err != nil {
+			return nil, err
+		}
+		release := buildChangelogRelease(tag.version.String(), tag.name, previous, commits, options, links, linked)
+		release.Date = dates[tag.name]
+		releases = append(releases, release)
+		previous = tag.name
+	}
+	if options.Unreleased {
+		commits, err := releaseCommitsBetween(repoPath, previous, "HEAD", options.Paths...)
+		if err != nil {
+			return nil, err
+		}
+		if len(commits) > 0 {
+			releases = append(releases, buildChangelogRelease("Unreleased", "HEAD", previous, commits, options, links, linked))
+		}
+	}
+
+	for i := len(releases) - 1; i >= 0; i-- {
+		changelog.Releases = append(changelog.Releases, releases[i])
+	}
+	return changelog, nil
+}
+
+// changelogRemoteLinks resolves the links of remote, or of the origin remote when it is empty
+func changelogRemoteLinks(repoPath, remote string) (RemoteLinks, bool) {
+	if remote == "" {
+		cmd := exec.Command("git", "remote", "get-url", "origin")
+		cmd.Dir = repoPath
+		if output, err := cmd.Output(); err == nil {
+			remote = strings.TrimSpace(string(output))
+		}
+	}
+	return ParseRemoteURL(remote)
+}
+
+// buildChangelogRelease groups the commits of one release into its sections
+func buildChangelogRelease(version, tag, previous string, commits []ReleaseCommit, options ChangelogOptions, links RemoteLinks, linked bool) ChangelogRelease {
+	release := ChangelogRelease{Version: version, Tag: tag, PreviousTag: previous}
+	if linked {
+		release.CompareURL = links.CompareURL(previous, tag)
+	}
+
+	bySection := make(map[string][]ChangelogEntry)
+	var custom []string
+	// Commits are listed oldest first within a section
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		entry := changelogEntry(commit, options.Authors, links, linked)
+		if commit.Breaking {
+			breaking := entry
+			breaking.Text = changelogEntryText(commit, entry.BreakingNote, options.Authors, links, linked)
+			release.Breaking = append(release.Breaking, breaking)
+		}
+
+		key := commit.Type
+		if key == "" {
+			key = "other"
+		}
+		title, mapped := options.Types[key]
+		if !mapped {
+			title = options.Types["other"]
+		}
+		if title == "" && !commit.Breaking {
+			continue
+		}
+		if title == "" {
+			title = "Changed"
+		}
+		if !containsString(keepAChangelogSections, title) && !containsString(custom, title) {
+			custom = append(custom, title)
+		}
+		bySection[title] = append(bySection[title], entry)
+	}
+
+	for _, title := range append(append([]string{}, keepAChangelogSections...), custom...) {
+		if entries := bySection[title]; len(entries) > 0 {
+			release.Sections = append(release.Sections, ChangelogSection{Title: title, Entries: entries})
+		}
+	}
+	return release
+}
+
+func changelogEntry(commit ReleaseCommit, authors bool, links RemoteLinks, linked bool) ChangelogEntry {
+	entry := ChangelogEntry{
+		Hash:         commit.Hash,
+		ShortHash:    types.ShortHash(commit.Hash),
+		Type:         commit.Type,
+		Scope:        commit.Scope,
+		Description:  commit.Description,
+		Author:       commit.Author,
+		BreakingNote: commit.BreakingNote,
+	}
+	if entry.BreakingNote == "" && commit.Breaking {
+		entry.BreakingNote = commit.Description
+	}
+	if linked {
+		entry.CommitURL = links.CommitURL(commit.Hash)
+	}
+	entry.Text = changelogEntryText(commit, commit.Description, authors, links, linked)
+	return entry
+}
+
+// changelogEntryText renders "**scope:** text ([abc1234](url)) by Author" with issue references linked
+func changelogEntryText(commit ReleaseCommit, text string, authors bool, links RemoteLinks, linked bool) string {
+	var b strings.Builder
+	if commit.Scope != "" {
+		b.WriteString("**" + commit.Scope + ":** ")
+	}
+	if linked {
+		text = issueRefRe.ReplaceAllStringFunc(text, func(match string) string {
+			parts := issueRefRe.FindStringSubmatch(match)
+			return parts[1] + "[#" + parts[2] + "](" + links.IssueURL(parts[2]) + ")"
+		})
+	}
+	b.WriteString(text)
+	if linked {
+		b.WriteString(" ([" + types.ShortHash(commit.Hash) + "](" + links.CommitURL(commit.Hash) + "))")
+	} else {
+		b.WriteString(" (" + types.ShortHash(commit.Hash) + ")")
+	}
+	if authors && commit.Author != "" {
+		b.WriteString(" by " + commit.Author)
+	}
+	return b.String()
+}
+
+// tagDates returns the date of each tag: the tagger date of annotated tags, the commit date otherwise
+func tagDates(repoPath string) (map[string]time.Time, error) {
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname:strip=2)%09%(creatordate:iso-strict)", "refs/tags")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("read tag dates: %w", err)
+	}
+	dates := make(map[string]time.Time)
+	for _, line := range strings.Split(string(output), "\n") {
+		name, date, found := strings.Cut(line, "\t")
+		if !found {
+			continue
+		}
+		if when, err := time.Parse(time.RFC3339, date); err == nil {
+			dates[name] = when
+		}
+	}
+	return dates, nil
+}
+
+// keepAChangelogTemplate renders https://keepachangelog.com/en/1.1.0/
+const keepAChangelogTemplate = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+{{range .Releases}}
+## [{{.Version}}]{{if not .Date.IsZero}} - {{.Date.Format "2006-01-02"}}{{end}}
+{{if .Breaking}}
+### BREAKING CHANGES
+{{range .Breaking}}
+- {{.Text}}{{end}}
+{{end}}{{range .Sections}}
+### {{.Title}}
+{{range .Entries}}
+- {{.Text}}{{end}}
+{{end}}{{end}}
+{{range .Releases}}{{if .CompareURL}}[{{.Version}}]: {{.CompareURL}}
+{{end}}{{end}}`
+
+var blankLinesRe = regexp.MustCompile(`\n{3,}`)
+
+// RenderChangelog renders the changelog as Keep a Changelog markdown or with a custom template
+func RenderChangelog(changelog *Changelog, options ChangelogOptions) (string, error) {
+	source := keepAChangelogTemplate
+	switch options.Format {
+	case "", ChangelogFormatKeepAChangelog:
+	case ChangelogFormatTemplate:
+		if options.Template == "" {
+			return "", fmt.Errorf("the template format needs a template")
+		}
+		source = options.Template
+	default:
+		return "", fmt.Errorf("unknown changelog format %q (use %s or %s)", options.Format, ChangelogFormatKeepAChangelog, ChangelogFormatTemplate)
+	}
+
+	tmpl, err := template.New("changelog").Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("parse changelog template: %w", err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, changelog); err != nil {
+		return "", fmt.Errorf("render changelog: %w", err)
+	}
+	return strings.TrimSpace(blankLinesRe.ReplaceAllString(b.String(), "\n\n")) + "\n", nil
+}
+
+// changelogDocument is a markdown changelog split into its header, version sections and link references
+type changelogDocument struct {
+	header   string
+	sections []changelogDocSection
+	links    []string
+}
+
+type changelogDocSection struct {
+	version string
+	text    string
+}
+
+// parseChangelogDocument splits a changelog at its "## " version headings; the link reference
+// definitions at the end of the file are kept apart so they can be merged
+func parseChangelogDocument(content string) changelogDocument {
+	var doc changelogDocument
+	lines := strings.SplitAfter(content, "\n")
+
+	end := len(lines)
+	for end > 0 {
+		line := strings.TrimSpace(lines[end-1])
+		if line != "" && !linkRefLineRe.MatchString(line) {
+			break
+		}
+		if line != "" {
+			doc.links = append([]string{line}, doc.links...)
+		}
+		end--
+	}
+
+	var current *changelogDocSection
+	var header strings.Builder
+	for _, line := range lines[:end] {
+		if match := versionHeadRe.FindStringSubmatch(line); match != nil {
+			doc.sections = append(doc.sections, changelogDocSection{version: match[1]})
+			current = &doc.sections[len(doc.sections)-1]
+		}
+		if current == nil {
+			header.WriteString(line)
+		} else {
+			current.text += line
+		}
+	}
+	doc.header = header.String()
+	return doc
+}
+
+// MergeChangelog merges a generated changelog into an existing one without losing manual edits.
+// Sections already present are kept as written, except that generated Unreleased entries whose
+// commit is not mentioned yet are added and Unreleased entries whose commit is listed under a
+// version are removed; new versions are inserted in version order, missing link references
+// are appended and the Unreleased link follows the latest release.
+func MergeChangelog(existing, generated string) string {
+	if strings.TrimSpace(existing) == "" {
+		return generated
+	}
+	current := parseChangelogDocument(existing)
+	fresh := parseChangelogDocument(generated)
+
+	have := make(map[string]int)
+	for i, section := range current.sections {
+		have[strings.ToLower(section.version)] = i
+	}
+	for _, section := range fresh.sections {
+		i, ok := have[strings.ToLower(section.version)]
+		switch {
+		case !ok:
+			current.sections = append(current.sections, section)
+			have[strings.ToLower(section.version)] = len(current.sections) - 1
+		case strings.EqualFold(section.version, "Unreleased"):
+			current.sections[i].text = mergeUnreleased(current.sections[i].text, section.text)
+		}
+	}
+
+	// Entries move out of Unreleased once their commit is listed under a version
+	released := make(map[string]bool)
+	for _, section := range current.sections {
+		if !strings.EqualFold(section.version, "Unreleased") {
+			for _, hash := range shortHashRe.FindAllString(section.text, -1) {
+				released[hash] = true
+			}
+		}
+	}
+	kept := current.sections[:0]
+	for _, section := range current.sections {
+		if strings.EqualFold(section.version, "Unreleased") {
+			var empty bool
+			if section.text, empty = pruneUnreleased(section.text, released); empty {
+				continue
+			}
+		}
+		kept = append(kept, section)
+	}
+	current.sections = kept
+
+	// Unreleased first, then versions newest first; sections that are not versions keep their order at the end
+	sort.SliceStable(current.sections, func(i, j int) bool {
+		return changelogSectionRank(current.sections[i].version, current.sections[j].version)
+	})
+
+	var b strings.Builder
+	header := current.header
+	if strings.TrimSpace(header) == "" {
+		header = fresh.header
+	}
+	b.WriteString(strings.TrimRight(header, "\n") + "\n\n")
+	for _, section := range current.sections {
+		b.WriteString(strings.TrimRight(section.text, "\n") + "\n\n")
+	}
+
+	// The Unreleased link always compares the latest release, so a generated one replaces the old
+	// one and it goes when the section does
+	isUnreleasedLink := func(link string) bool { return strings.HasPrefix(strings.ToLower(link), "[unreleased]:") }
+	unreleased, freshUnreleased := false, false
+	for _, section := range current.sections {
+		unreleased = unreleased || strings.EqualFold(section.version, "Unreleased")
+	}
+	for _, link := range fresh.links {
+		freshUnreleased = freshUnreleased || isUnreleasedLink(link)
+	}
+	var links []string
+	for _, link := range current.links {
+		if !isUnreleasedLink(link) || (unreleased && !freshUnreleased) {
+			links = append(links, link)
+			continue
+		}
+		if unreleased {
+			for _, freshLink := range fresh.links {
+				if isUnreleasedLink(freshLink) {
+					links = append(links, freshLink)
+				}
+			}
+		}
+	}
+	for _, link := range fresh.links {
+		if isUnreleasedLink(link) && !unreleased {
+			continue
+		}
+		label := link[:strings.Index(link, "]")+1]
+		found := false
+		for _, existingLink := range links {
+			if strings.EqualFold(existingLink[:strings.Index(existingLink, "]")+1], label) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			links = append(links, link)
+		}
+	}
+	if len(links) > 0 {
+		b.WriteString(strings.Join(links, "\n") + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// mergeUnreleased adds the generated entries whose commit hash the existing section does not mention
+func mergeUnreleased(existing, generated string) string {
+	var added []string
+	for _, line := range strings.Split(generated, "\n") {
+		if !strings.HasPrefix(line, "- ") {
+			continue
+		}
+		match := shortHashRe.FindString(line)
+		if match != "" && !strings.Contains(existing, match) {
+			added = append(added, line)
+		}
+	}
+	if len(added) == 0 {
+		return existing
+	}
+	return strings.TrimRight(existing, "\n") + "\n" + strings.Join(added, "\n") + "\n"
+}
+
+// pruneUnreleased removes the entries whose commit hash is released, then the subsections left
+// without entries; empty reports that nothing but the heading remains
+func pruneUnreleased(text string, released map[string]bool) (string, bool) {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "- ") {
+			if hash := shortHashRe.FindString(line); hash != "" && released[hash] {
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	var kept []string
+	empty := true
+	for i, line := range lines {
+		if i > 0 && strings.HasPrefix(line, "### ") {
+			j := i + 1
+			for j < len(lines) && !strings.HasPrefix(lines[j], "### ") && strings.TrimSpace(lines[j]) == "" {
+				j++
+			}
+			if j == len(lines) || strings.HasPrefix(lines[j], "### ") {
+				continue
+			}
+		}
+		if i > 0 && strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "### ") {
+			empty = false
+		}
+		kept = append(kept, line)
+	}
+	return blankLinesRe.ReplaceAllString(strings.Join(kept, "\n"), "\n\n"), empty
+}
+
+// changelogSectionRank orders Unreleased before versions, versions newest first and other headings last
+func changelogSectionRank(a, b string) bool {
+	rank := func(version string) (int, Version) {
+		if strings.EqualFold(version, "Unreleased") {
+			return 0, Version{}
+		}
+		if v, err := ParseVersion(version); err == nil {
+			return 1, v
+		}
+		return 2, Version{}
+	}
+	rankA, versionA := rank(a)
+	rankB, versionB := rank(b)
+	if rankA != rankB {
+		return rankA < rankB
+	}
+	return rankA == 1 && versionA.Compare(versionB) > 0
+}
+
+// GenerateChangelog builds the changelog of every release and merges it into outputPath,
+// keeping manual edits; an empty outputPath only returns the generated content
+func GenerateChangelog(repoPath, outputPath string) (string, error) {
+	return GenerateChangelogWithOptions(repoPath, outputPath, DefaultChangelogOptions(), true)
+}
+
+// GenerateChangelogWithOptions builds the changelog and writes it to outputPath, merging into the
+// existing file unless merge is false
+func GenerateChangelogWithOptions(repoPath, outputPath string, options ChangelogOptions, merge bool) (string, error) {
+	changelog, err := BuildChangelog(repoPath, options)
+	if err != nil {
+		return "", err
+	}
+	content, err := RenderChangelog(changelog, options)
+	if err != nil {
+		return "", err
+	}
+	if outputPath == "" {
+		return content, nil
+	}
+	if merge {
+		existing, err := os.ReadFile(outputPath)
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		content = MergeChangelog(string(existing), content)
+	}
+	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+		return "", err
+	}
+	return content, nil
+}
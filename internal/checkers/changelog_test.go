@@ -0,0 +1,152 @@
+package checkers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		remote, web, host string
+	}{
+		{"git@github.com:acme/widget.git", "https://github.com/acme/widget", "github"},
+		{"https://github.com/acme/widget", "https://github.com/acme/widget", "github"},
+		{"ssh://git@gitlab.example.com:2222/group/sub/widget.git", "https://gitlab.example.com/group/sub/widget", "gitlab"},
+		{"https://user@bitbucket.org/acme/widget.git", "https://bitbucket.org/acme/widget", "bitbucket"},
+	}
+	for _, test := range tests {
+		links, ok := ParseRemoteURL(test.remote)
+		if !ok || links.WebURL != test.web || links.Host != test.host {
+			t.Errorf("ParseRemoteURL(%q) = %+v, %v", test.remote, links, ok)
+		}
+	}
+	if _, ok := ParseRemoteURL("/srv/git/widget.git"); ok {
+		t.Error("local paths have no web links")
+	}
+	gitlab, _ := ParseRemoteURL("git@gitlab.com:acme/widget.git")
+	if url := gitlab.CompareURL("v1.0.0", "v1.1.0"); url != "https://gitlab.com/acme/widget/-/compare/v1.0.0...v1.1.0" {
+		t.Errorf("unexpected GitLab compare URL %s", url)
+	}
+}
+
+func TestGenerateChangelog(t *testing.T) {
+	repo := createGitRepository(t)
+	runGit(t, repo, "remote", "add", "origin", "git@github.com:acme/widget.git")
+	commit := func(message string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, "work.txt"), []byte(message+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, repo, "add", ".")
+		runGit(t, repo, "commit", "-qm", message)
+	}
+	commit("feat: first feature")
+	runGit(t, repo, "tag", "-a", "v1.0.0", "-m", "v1.0.0")
+	commit("fix(parser): handle tabs (closes #12)")
+	commit("feat!: new config format\n\nBREAKING CHANGE: gphc.yaml is no longer read")
+	commit("chore: bump tooling")
+	runGit(t, repo, "tag", "-a", "v2.0.0", "-m", "v2.0.0")
+	commit("feat: unreleased work")
+
+	options := DefaultChangelogOptions()
+	changelog, err := BuildChangelog(repo, options)
+	if err != nil {
+		t.Fatalf("BuildChangelog failed: %v", err)
+	}
+	if len(changelog.Releases) != 3 || changelog.Releases[0].Version != "Unreleased" || changelog.Releases[2].Version != "1.0.0" {
+		t.Fatalf("unexpected releases %+v", changelog.Releases)
+	}
+	content, err := RenderChangelog(changelog, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"## [Unreleased]\n\n### Added\n\n- unreleased work",
+		"### BREAKING CHANGES\n\n- gphc.yaml is no longer read",
+		"### Fixed\n\n- **parser:** handle tabs (closes [#12](https://github.com/acme/widget/issues/12))",
+		"](https://github.com/acme/widget/commit/",
+		"[2.0.0]: https://github.com/acme/widget/compare/v1.0.0...v2.0.0",
+		"[1.0.0]: https://github.com/acme/widget/releases/tag/v1.0.0",
+		"[Unreleased]: https://github.com/acme/widget/compare/v2.0.0...HEAD",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("changelog is missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "bump tooling") {
+		t.Errorf("chore commits should be hidden:\n%s", content)
+	}
+	if strings.Index(content, "## [2.0.0]") > strings.Index(content, "## [1.0.0]") {
+		t.Errorf("releases should be newest first:\n%s", content)
+	}
+
+	// Manual edits and hand-written history survive a merge
+	path := filepath.Join(repo, "CHANGELOG.md")
+	existing := "# Changelog\n\nOur release history.\n\n## [1.0.0] - 2020-01-01\n\n### Added\n\n- The first feature, hand-polished\n\n## [0.9.0] - 2019-06-01\n\n- Beta\n\n[1.0.0]: https://example.com/custom\n"
+	if err := os.WriteFile(path, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+	merged, err := GenerateChangelog(repo, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Our release history.", "hand-polished", "## [0.9.0] - 2019-06-01", "[1.0.0]: https://example.com/custom", "[2.0.0]: https://github.com"} {
+		if !strings.Contains(merged, want) {
+			t.Errorf("merged changelog is missing %q:\n%s", want, merged)
+		}
+	}
+	order := []string{"## [Unreleased]", "## [2.0.0]", "## [1.0.0]", "## [0.9.0]"}
+	for i := 0; i+1 < len(order); i++ {
+		if strings.Index(merged, order[i]) > strings.Index(merged, order[i+1]) {
+			t.Errorf("%s should come before %s:\n%s", order[i], order[i+1], merged)
+		}
+	}
+	if strings.Contains(merged, "first feature (") {
+		t.Errorf("the existing 1.0.0 section should be kept as written:\n%s", merged)
+	}
+
+	// Merging again is stable
+	again, err := GenerateChangelog(repo, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != merged {
+		t.Errorf("second merge changed the changelog:\n%s", again)
+	}
+
+	options.Format = ChangelogFormatTemplate
+	options.Template = "{{range .Releases}}{{.Version}}:{{range .Sections}}{{.Title}}={{len .Entries}};{{end}}\n{{end}}"
+	content, err = RenderChangelog(changelog, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != "Unreleased:Added=1;\n2.0.0:Added=1;Fixed=1;\n1.0.0:Added=1;\n" {
+		t.Errorf("unexpected template output %q", content)
+	}
+}
+
+func TestMergeChangelogPrunesReleasedEntries(t *testing.T) {
+	existing := "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- add export (aaaa1111)\n\n### Fixed\n\n- fix crash (bbbb2222)\n- Hand-written note\n\n## [1.0.0] - 2020-01-01\n\n- First release\n"
+	generated := "# Changelog\n\n## [Unreleased]\n\n### Fixed\n\n- fix typo (cccc3333)\n\n## [1.1.0] - 2020-02-01\n\n### Added\n\n- add export (aaaa1111)\n\n### Fixed\n\n- fix crash (bbbb2222)\n"
+
+	merged := MergeChangelog(existing, generated)
+	unreleased := merged[strings.Index(merged, "## [Unreleased]"):strings.Index(merged, "## [1.1.0]")]
+	if strings.Contains(unreleased, "aaaa1111") || strings.Contains(unreleased, "bbbb2222") || strings.Contains(unreleased, "### Added") {
+		t.Errorf("released entries should leave Unreleased:\n%s", merged)
+	}
+	for _, want := range []string{"fix typo (cccc3333)", "Hand-written note", "### Fixed"} {
+		if !strings.Contains(unreleased, want) {
+			t.Errorf("Unreleased is missing %q:\n%s", want, merged)
+		}
+	}
+
+	// Once everything is released the Unreleased section goes away
+	existing = "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- add export (aaaa1111)\n"
+	generated = "# Changelog\n\n## [1.1.0] - 2020-02-01\n\n### Added\n\n- add export (aaaa1111)\n"
+	if merged := MergeChangelog(existing, generated); strings.Contains(merged, "Unreleased") {
+		t.Errorf("empty Unreleased section should be dropped:\n%s", merged)
+	}
+}
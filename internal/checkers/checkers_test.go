@@ -1,6 +1,9 @@
 package checkers
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -188,3 +191,43 @@ func TestSecurityCheckersUseSecurityCategory(t *testing.T) {
 		}
 	}
 }
+
+func TestCommitAuthorInsightsCheckerMergesAliasesAndExcludesBots(t *testing.T) {
+	var commits []types.CommitInfo
+	for i := 0; i < 3; i++ {
+		commits = append(commits,
+			types.CommitInfo{Author: "Jane Doe", AuthorEmail: "jane@example.com"},
+			types.CommitInfo{Author: "jane doe", AuthorEmail: "jane@laptop.local"},
+			types.CommitInfo{Author: "dependabot[bot]", AuthorEmail: "49699333+dependabot[bot]@users.noreply.github.com"},
+		)
+	}
+	commits = append(commits, types.CommitInfo{Author: "Sam Lee", AuthorEmail: "sam@example.com"})
+
+	result := NewCommitAuthorInsightsChecker().Check(&types.RepositoryData{Path: t.TempDir(), Commits: commits})
+	details := strings.Join(result.Details, "\n")
+	if !strings.Contains(details, "Contributors: 2") || !strings.Contains(details, "Jane Doe (6 commits, 85.7%, 2 aliases)") {
+		t.Fatalf("details = %s", details)
+	}
+	if !strings.Contains(details, "Bot excluded: dependabot[bot] (3 commits)") {
+		t.Fatalf("bots not reported separately: %s", details)
+	}
+	if !strings.Contains(details, "1 contributor(s) commit under several names or emails; list them in .mailmap") {
+		t.Fatalf("aliases merged by name should suggest .mailmap: %s", details)
+	}
+
+	// Aliases the .mailmap already joins need no advice
+	repo := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repo, ".mailmap"), []byte("Jane Doe <jane@example.com> <jane@laptop.local>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result = NewCommitAuthorInsightsChecker().Check(&types.RepositoryData{Path: repo, Commits: commits})
+	if details := strings.Join(result.Details, "\n"); strings.Contains(details, "list them in .mailmap") {
+		t.Fatalf("mapped aliases should not suggest .mailmap: %s", details)
+	}
+
+	// A history of bots alone has no contributors to score
+	result = NewCommitAuthorInsightsChecker().Check(&types.RepositoryData{Path: t.TempDir(), Commits: commits[2:3]})
+	if result.Score != 0 || result.Message != "No author information found" {
+		t.Fatalf("bot-only history = %d %q", result.Score, result.Message)
+	}
+}
@@ -0,0 +1,295 @@
+package checkers
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CODEOWNERS dialects
+const (
+	CodeOwnersSyntaxAuto   = "auto"
+	CodeOwnersSyntaxGitHub = "github"
+	CodeOwnersSyntaxGitLab = "gitlab"
+)
+
+// codeOwnersLocations are the places GitHub and GitLab look for CODEOWNERS, in lookup order
+var codeOwnersLocations = map[string][]string{
+	CodeOwnersSyntaxGitHub: {".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"},
+	CodeOwnersSyntaxGitLab: {"CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"},
+}
+
+var (
+	// [Section], ^[Optional section], [Section][2] followed by optional default owners
+	codeOwnersSectionRe = regexp.MustCompile(`^(\^)?\[([^\]]+)\](?:\[(\d+)\])?(?:\s+(.*))?$`)
+	codeOwnersUserRe    = regexp.MustCompile(`^@[A-Za-z0-9](?:[A-Za-z0-9_.-]*[A-Za-z0-9])?$`)
+	codeOwnersGroupRe   = regexp.MustCompile(`^@[A-Za-z0-9](?:[A-Za-z0-9_.-]*[A-Za-z0-9])?(?:/[A-Za-z0-9_.-]+)+$`)
+	codeOwnersRoleRe    = regexp.MustCompile(`^@@(?:developer|maintainer|owner)s?$`)
+	codeOwnersEmailRe   = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// CodeOwnersRule is one pattern line of a CODEOWNERS file
+type CodeOwnersRule struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+	// Section and Optional are set for GitLab sections
+	Section  string `json:"section,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// CodeOwnersProblem is an invalid or suspicious CODEOWNERS line
+type CodeOwnersProblem struct {
+	Line     int    `json:"line"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// CodeOwnersFile is a parsed CODEOWNERS file
+type CodeOwnersFile struct {
+	Path     string              `json:"path"`
+	Syntax   string              `json:"syntax"`
+	Rules    []CodeOwnersRule    `json:"rules"`
+	Problems []CodeOwnersProblem `json:"problems,omitempty"`
+}
+
+// FindCodeOwners locates and parses the CODEOWNERS file the hosting platform would use.
+// syntax is auto, github or gitlab; it returns nil when the repository has none.
+func FindCodeOwners(repoPath, syntax string) (*CodeOwnersFile, error) {
+	locations := codeOwnersLocations[CodeOwnersSyntaxGitHub]
+	if syntax == CodeOwnersSyntaxGitLab {
+		locations = codeOwnersLocations[CodeOwnersSyntaxGitLab]
+	} else if syntax == CodeOwnersSyntaxAuto || syntax == "" {
+		locations = append(locations, ".gitlab/CODEOWNERS")
+	}
+
+	var found []string
+	for _, location := range locations {
+		if info, err := os.Stat(filepath.Join(repoPath, location)); err == nil && !info.IsDir() {
+			found = append(found, location)
+		}
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(repoPath, found[0]))
+	if err != nil {
+		return nil, err
+	}
+	file := ParseCodeOwners(found[0], string(content), syntax)
+	for _, other := range found[1:] {
+		file.Problems = append(file.Problems, CodeOwnersProblem{
+			Severity: "medium",
+			Message:  fmt.Sprintf("%s is ignored because %s takes precedence", other, found[0]),
+		})
+	}
+	return file, nil
+}
+
+// ParseCodeOwners parses CODEOWNERS content. With auto syntax a file under .github/ is
+// read as GitHub syntax and one under .gitlab/ as GitLab syntax; elsewhere section
+// headers mark GitLab syntax.
+func ParseCodeOwners(path, content, syntax string) *CodeOwnersFile {
+	if syntax != CodeOwnersSyntaxGitHub && syntax != CodeOwnersSyntaxGitLab {
+		syntax = CodeOwnersSyntaxGitHub
+		switch {
+		case strings.HasPrefix(filepath.ToSlash(path), ".github/"):
+		case strings.HasPrefix(filepath.ToSlash(path), ".gitlab/"):
+			syntax = CodeOwnersSyntaxGitLab
+		default:
+			for _, line := range strings.Split(content, "\n") {
+				if codeOwnersSectionRe.MatchString(strings.TrimSpace(line)) {
+					syntax = CodeOwnersSyntaxGitLab
+					break
+				}
+			}
+		}
+	}
+
+	file := &CodeOwnersFile{Path: path, Syntax: syntax}
+	problem := func(line int, severity, format string, args ...interface{}) {
+		file.Problems = append(file.Problems, CodeOwnersProblem{Line: line, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	section, optional := "", false
+	var sectionOwners []string
+	seen := make(map[string]int)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(stripCodeOwnersComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if match := codeOwnersSectionRe.FindStringSubmatch(line); match != nil && syntax == CodeOwnersSyntaxGitLab {
+			section, optional = strings.TrimSpace(match[2]), match[1] == "^"
+			sectionOwners = strings.Fields(match[4])
+			for _, owner := range sectionOwners {
+				if message := validateCodeOwner(owner, syntax); message != "" {
+					problem(lineNumber, "high", "%s in section [%s]", message, section)
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			if syntax == CodeOwnersSyntaxGitHub {
+				problem(lineNumber, "high", "Section header %s is GitLab syntax and not supported by GitHub", line)
+			} else {
+				problem(lineNumber, "high", "Malformed section header %s", line)
+			}
+			continue
+		}
+
+		fields := splitCodeOwnersLine(line)
+		rule := CodeOwnersRule{Line: lineNumber, Pattern: fields[0], Owners: fields[1:], Section: section, Optional: optional}
+		if len(rule.Owners) == 0 && syntax == CodeOwnersSyntaxGitLab {
+			rule.Owners = sectionOwners
+		}
+
+		switch {
+		case strings.HasPrefix(rule.Pattern, "!"):
+			problem(lineNumber, "high", "Negated pattern %s is not supported", rule.Pattern)
+			continue
+		case syntax == CodeOwnersSyntaxGitHub && strings.Contains(strings.ReplaceAll(rule.Pattern, `\[`, ""), "["):
+			problem(lineNumber, "high", "Character range in %s is not supported by GitHub", rule.Pattern)
+		case gitPatternRegexp(rule.Pattern) == nil:
+			problem(lineNumber, "high", "Invalid pattern %s", rule.Pattern)
+			continue
+		}
+		if len(rule.Owners) == 0 {
+			problem(lineNumber, "low", "%s has no owners; matching files are left unowned", rule.Pattern)
+		}
+		for _, owner := range rule.Owners {
+			if message := validateCodeOwner(owner, syntax); message != "" {
+				problem(lineNumber, "high", "%s", message)
+			}
+		}
+
+		key := section + "\x00" + rule.Pattern
+		if previous, ok := seen[key]; ok {
+			problem(previous, "medium", "%s is overridden by line %d", rule.Pattern, lineNumber)
+		}
+		seen[key] = lineNumber
+		file.Rules = append(file.Rules, rule)
+	}
+	return file
+}
+
+// stripCodeOwnersComment removes a comment: "#" at the start of a field, unless escaped
+func stripCodeOwnersComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '#':
+			if i == 0 || line[i-1] == ' ' || line[i-1] == '\t' {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+// splitCodeOwnersLine splits a rule into its pattern and owners; "\ " escapes a space in the pattern
+func splitCodeOwnersLine(line string) []string {
+	var fields []string
+	var current strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && (line[i+1] == ' ' || line[i+1] == '#'):
+			current.WriteByte(line[i+1])
+			i++
+		case line[i] == ' ' || line[i] == '\t':
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteByte(line[i])
+		}
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+// validateCodeOwner returns a problem description for an owner the platform would not accept
+func validateCodeOwner(owner, syntax string) string {
+	switch {
+	case codeOwnersUserRe.MatchString(owner), codeOwnersEmailRe.MatchString(owner):
+		return ""
+	case codeOwnersGroupRe.MatchString(owner):
+		if syntax == CodeOwnersSyntaxGitHub && strings.Count(owner, "/") > 1 {
+			return fmt.Sprintf("Owner %s is not a valid GitHub team (@org/team)", owner)
+		}
+		return ""
+	case codeOwnersRoleRe.MatchString(owner):
+		if syntax == CodeOwnersSyntaxGitHub {
+			return fmt.Sprintf("Role owner %s is GitLab syntax and not supported by GitHub", owner)
+		}
+		return ""
+	}
+	return fmt.Sprintf("Invalid owner %s (use @user, @org/team or an email address)", owner)
+}
+
+// IsIndividualCodeOwner reports whether an owner names a single person rather than a team or role
+func IsIndividualCodeOwner(owner string) bool {
+	return codeOwnersUserRe.MatchString(owner) || codeOwnersEmailRe.MatchString(owner)
+}
+
+// MatchingRules returns the rules deciding a path's owners: the last matching rule,
+// or with GitLab syntax the last matching rule of each section
+func (f *CodeOwnersFile) MatchingRules(path string) []CodeOwnersRule {
+	path = filepath.ToSlash(path)
+	var matches []CodeOwnersRule
+	index := make(map[string]int)
+	for _, rule := range f.Rules {
+		if !codeOwnersPatternMatch(rule.Pattern, path) {
+			continue
+		}
+		section := ""
+		if f.Syntax == CodeOwnersSyntaxGitLab {
+			section = strings.ToLower(rule.Section)
+		}
+		if i, ok := index[section]; ok {
+			matches[i] = rule
+		} else {
+			index[section] = len(matches)
+			matches = append(matches, rule)
+		}
+	}
+	return matches
+}
+
+// OwnersOf returns the owners of a path; nil means the path is unowned
+func (f *CodeOwnersFile) OwnersOf(path string) []string {
+	var owners []string
+	for _, rule := range f.MatchingRules(path) {
+		for _, owner := range rule.Owners {
+			if !containsString(owners, owner) {
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners
+}
+
+// codeOwnersPatternMatch matches a CODEOWNERS pattern; unlike .gitignore, "dir/*"
+// covers only the files directly inside dir
+func codeOwnersPatternMatch(pattern, path string) bool {
+	if !matchGitPattern(pattern, path) {
+		return false
+	}
+	if dir := strings.TrimPrefix(strings.TrimSuffix(pattern, "/*"), "/"); strings.HasSuffix(pattern, "/*") && !strings.Contains(dir, "**") {
+		return strings.Count(path, "/") == strings.Count(dir, "/")+1
+	}
+	return true
+}
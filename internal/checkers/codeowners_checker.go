@@ -0,0 +1,499 @@
+package checkers
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/internal/identity"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// CodeOwnersChecker validates CODEOWNERS and compares declared ownership with commit activity
+type CodeOwnersChecker struct {
+	BaseChecker
+	options CodeOwnersOptions
+}
+
+// CodeOwnersOptions tunes the ownership analysis
+type CodeOwnersOptions struct {
+	// Syntax is auto, github or gitlab
+	Syntax string
+	// StaleDays is how long an owner may go without committing to their paths
+	StaleDays int
+	// TopContributors is how many of a directory's top contributors are compared with its owners
+	TopContributors int
+	// Now is the reference time for the activity window; zero means time.Now
+	Now time.Time
+	// Identity controls how commit authors are merged into people and which are bots
+	Identity identity.Options
+}
+
+// StaleOwner is an individual owner without recent commits to the paths they own
+type StaleOwner struct {
+	Owner    string   `json:"owner"`
+	Patterns []string `json:"patterns"`
+}
+
+// Contributor is an author's share of the commits to a directory
+type Contributor struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Commits int    `json:"commits"`
+
+	identity *identity.Identity
+}
+
+// DirectoryOwnership compares the declared owners of a directory with its top contributors
+type DirectoryOwnership struct {
+	Directory       string        `json:"directory"`
+	Owners          []string      `json:"owners"`
+	TopContributors []Contributor `json:"top_contributors"`
+	// OwnerIsContributor is false when no individual owner is among the top contributors
+	OwnerIsContributor bool `json:"owner_is_contributor"`
+}
+
+// CodeOwnersReport holds the result of an ownership analysis
+type CodeOwnersReport struct {
+	File         *CodeOwnersFile      `json:"file,omitempty"`
+	TotalFiles   int                  `json:"total_files"`
+	OwnedFiles   int                  `json:"owned_files"`
+	UnownedFiles []string             `json:"unowned_files,omitempty"`
+	DeadPatterns []CodeOwnersRule     `json:"dead_patterns,omitempty"`
+	StaleDays    int                  `json:"stale_days"`
+	StaleOwners  []StaleOwner         `json:"stale_owners,omitempty"`
+	Directories  []DirectoryOwnership `json:"directories,omitempty"`
+}
+
+// fileCommit is a commit with the files it touched
+type fileCommit struct {
+	author   string
+	email    string
+	date     time.Time
+	files    []string
+	identity *identity.Identity
+}
+
+// DefaultCodeOwnersOptions returns the ownership settings used when gphc.yml sets none
+func DefaultCodeOwnersOptions() CodeOwnersOptions {
+	return CodeOwnersOptions{
+		Syntax:          CodeOwnersSyntaxAuto,
+		StaleDays:       90,
+		TopContributors: 3,
+		Identity:        identity.DefaultOptions(),
+	}
+}
+
+// NewCodeOwnersChecker creates a CODEOWNERS checker with the default options
+func NewCodeOwnersChecker() *CodeOwnersChecker {
+	return NewCodeOwnersCheckerWithOptions(DefaultCodeOwnersOptions())
+}
+
+// NewCodeOwnersCheckerWithOptions creates a CODEOWNERS checker; unset options fall back to the defaults
+func NewCodeOwnersCheckerWithOptions(options CodeOwnersOptions) *CodeOwnersChecker {
+	defaults := DefaultCodeOwnersOptions()
+	if options.Syntax == "" {
+		options.Syntax = defaults.Syntax
+	}
+	if options.StaleDays <= 0 {
+		options.StaleDays = defaults.StaleDays
+	}
+	if options.TopContributors <= 0 {
+		options.TopContributors = defaults.TopContributors
+	}
+	return &CodeOwnersChecker{
+		BaseChecker: NewBaseChecker("Code Ownership Checker", "CODEOWNERS", types.CategoryHygiene, 3),
+		options:     options,
+	}
+}
+
+// Check validates CODEOWNERS
+func (c *CodeOwnersChecker) Check(data *types.RepositoryData) *types.CheckResult {
+	result, _ := c.CheckWithReport(data)
+	return result
+}
+
+// CheckWithReport validates CODEOWNERS and returns the full ownership report
+func (c *CodeOwnersChecker) CheckWithReport(data *types.RepositoryData) (*types.CheckResult, *CodeOwnersReport) {
+	result := &types.CheckResult{
+		ID:        "OWN-702",
+		Name:      "Code Ownership",
+		Category:  c.Category(),
+		Timestamp: time.Now(),
+	}
+
+	file, err := FindCodeOwners(data.Path, c.options.Syntax)
+	if err != nil {
+		result.Status = types.StatusWarning
+		result.Score = 0
+		result.Message = "Could not read CODEOWNERS"
+		result.Details = []string{err.Error()}
+		return result, nil
+	}
+	if file == nil {
+		result.Status = types.StatusWarning
+		result.Score = 50
+		result.Message = "No CODEOWNERS file found"
+		result.Details = []string{"Add CODEOWNERS to .github/, docs/ or the repository root to route reviews to owners"}
+		return result, nil
+	}
+
+	now := c.options.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	commits, err := commitsTouchingFiles(data.Path, now.AddDate(0, 0, -c.options.StaleDays))
+	if err == nil {
+		commits, err = resolveFileCommits(data.Path, commits, c.options.Identity)
+	}
+	if err != nil {
+		result.Status = types.StatusWarning
+		result.Score = 0
+		result.Message = "Could not read commit history"
+		result.Details = []string{err.Error()}
+		return result, nil
+	}
+
+	// Ownership only matters for files under version control, not ignored or untracked ones
+	tracked, err := trackedFiles(data.Path)
+	if err != nil {
+		result.Status = types.StatusWarning
+		result.Score = 0
+		result.Message = "Could not list tracked files"
+		result.Details = []string{err.Error()}
+		return result, nil
+	}
+
+	report := analyzeCodeOwners(file, tracked, commits, c.options)
+	result.Details = codeOwnersDetails(report)
+
+	invalid := 0
+	for _, problem := range file.Problems {
+		if problem.Severity == "high" {
+			invalid++
+		}
+	}
+	unownedShare := 0.0
+	if report.TotalFiles > 0 {
+		unownedShare = float64(len(report.UnownedFiles)) / float64(report.TotalFiles)
+	}
+	mismatched := 0
+	for _, directory := range report.Directories {
+		if !directory.OwnerIsContributor {
+			mismatched++
+		}
+	}
+
+	score := 100
+	score -= min(15*invalid, 45)
+	score -= int(unownedShare * 30)
+	score -= min(5*len(report.StaleOwners), 20)
+	score -= min(5*len(report.DeadPatterns), 15)
+	score -= min(3*mismatched, 15)
+	result.Score = max(score, 0)
+
+	switch {
+	case invalid > 0:
+		result.Status = types.StatusFail
+		result.Message = fmt.Sprintf("%s has %d invalid line(s)", file.Path, invalid)
+	case len(report.UnownedFiles) > 0 || len(report.StaleOwners) > 0 || len(report.DeadPatterns) > 0 || mismatched > 0:
+		result.Status = types.StatusWarning
+		result.Message = fmt.Sprintf("%s: %d unowned file(s), %d stale owner(s), %d pattern(s) matching nothing",
+			file.Path, len(report.UnownedFiles), len(report.StaleOwners), len(report.DeadPatterns))
+	default:
+		result.Status = types.StatusPass
+		result.Message = fmt.Sprintf("%s covers every file with active owners", file.Path)
+	}
+	return result, report
+}
+
+// analyzeCodeOwners resolves the owners of files and compares them with the commits in the activity window
+func analyzeCodeOwners(file *CodeOwnersFile, files []string, commits []fileCommit, options CodeOwnersOptions) *CodeOwnersReport {
+	report := &CodeOwnersReport{File: file, StaleDays: options.StaleDays}
+
+	matched := make(map[int]bool)
+	directoryOwners := make(map[string][]string)
+	var directories []string
+	for _, path := range files {
+		path = filepath.ToSlash(path)
+		report.TotalFiles++
+
+		rules := file.MatchingRules(path)
+		var owners []string
+		for _, rule := range rules {
+			matched[rule.Line] = true
+			for _, owner := range rule.Owners {
+				if !containsString(owners, owner) {
+					owners = append(owners, owner)
+				}
+			}
+		}
+		if len(owners) == 0 {
+			report.UnownedFiles = append(report.UnownedFiles, path)
+			continue
+		}
+		report.OwnedFiles++
+
+		directory := ownershipDirectory(path)
+		if _, ok := directoryOwners[directory]; !ok {
+			directories = append(directories, directory)
+		}
+		for _, owner := range owners {
+			if !containsString(directoryOwners[directory], owner) {
+				directoryOwners[directory] = append(directoryOwners[directory], owner)
+			}
+		}
+	}
+	sort.Strings(report.UnownedFiles)
+
+	// Rules that never decide ownership are either shadowed by later rules or match nothing
+	for _, rule := range file.Rules {
+		if matched[rule.Line] {
+			continue
+		}
+		dead := true
+		for _, path := range files {
+			if codeOwnersPatternMatch(rule.Pattern, filepath.ToSlash(path)) {
+				dead = false
+				break
+			}
+		}
+		if dead {
+			report.DeadPatterns = append(report.DeadPatterns, rule)
+		}
+	}
+
+	// An individual owner is stale when none of their commits in the window touched a path they own
+	staleIndex := make(map[string]int)
+	for _, rule := range file.Rules {
+		for _, owner := range rule.Owners {
+			if !IsIndividualCodeOwner(owner) || ownerTouchedPattern(owner, rule.Pattern, commits) {
+				continue
+			}
+			if i, ok := staleIndex[owner]; ok {
+				report.StaleOwners[i].Patterns = append(report.StaleOwners[i].Patterns, rule.Pattern)
+			} else {
+				staleIndex[owner] = len(report.StaleOwners)
+				report.StaleOwners = append(report.StaleOwners, StaleOwner{Owner: owner, Patterns: []string{rule.Pattern}})
+			}
+		}
+	}
+
+	sort.Strings(directories)
+	for _, directory := range directories {
+		ownership := DirectoryOwnership{
+			Directory:       directory,
+			Owners:          directoryOwners[directory],
+			TopContributors: topContributors(directory, commits, options.TopContributors),
+		}
+		// Teams and roles cannot be matched to commit authors, so they are taken on trust
+		individuals := 0
+		ownership.OwnerIsContributor = len(ownership.TopContributors) == 0
+		for _, owner := range ownership.Owners {
+			if !IsIndividualCodeOwner(owner) {
+				ownership.OwnerIsContributor = true
+				continue
+			}
+			individuals++
+			for _, contributor := range ownership.TopContributors {
+				if codeOwnerMatchesIdentity(owner, contributor.identity) {
+					ownership.OwnerIsContributor = true
+				}
+			}
+		}
+		if individuals == 0 {
+			ownership.OwnerIsContributor = true
+		}
+		report.Directories = append(report.Directories, ownership)
+	}
+	return report
+}
+
+// ownershipDirectory groups files by their top-level directory; root files belong to "/"
+func ownershipDirectory(path string) string {
+	if i := strings.Index(path, "/"); i >= 0 {
+		return path[:i] + "/"
+	}
+	return "/"
+}
+
+// topContributors ranks the authors of commits touching a directory
+func topContributors(directory string, commits []fileCommit, n int) []Contributor {
+	counts := make(map[string]*Contributor)
+	for _, commit := range commits {
+		for _, path := range commit.files {
+			if ownershipDirectory(path) != directory {
+				continue
+			}
+			key := strings.ToLower(commit.identity.Email)
+			if counts[key] == nil {
+				counts[key] = &Contributor{Name: commit.identity.Name, Email: commit.identity.Email, identity: commit.identity}
+			}
+			counts[key].Commits++
+			break
+		}
+	}
+
+	contributors := make([]Contributor, 0, len(counts))
+	for _, contributor := range counts {
+		contributors = append(contributors, *contributor)
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		if contributors[i].Commits != contributors[j].Commits {
+			return contributors[i].Commits > contributors[j].Commits
+		}
+		return contributors[i].Email < contributors[j].Email
+	})
+	if len(contributors) > n {
+		contributors = contributors[:n]
+	}
+	return contributors
+}
+
+func ownerTouchedPattern(owner, pattern string, commits []fileCommit) bool {
+	for _, commit := range commits {
+		if !codeOwnerMatchesIdentity(owner, commit.identity) {
+			continue
+		}
+		for _, path := range commit.files {
+			if codeOwnersPatternMatch(pattern, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var noreplyEmailRe = regexp.MustCompile(`^(?:\d+\+)?([^@]+)@users\.noreply\.github(?:usercontent)?\.com$`)
+
+// codeOwnerMatchesIdentity links a CODEOWNERS entry to a commit author through any of
+// their aliases. Email owners must match exactly; @user matches the GitHub noreply
+// address, the email's local part or the name.
+func codeOwnerMatchesIdentity(owner string, id *identity.Identity) bool {
+	if !strings.HasPrefix(owner, "@") {
+		return containsString(id.Emails(), strings.ToLower(owner))
+	}
+	user := strings.ToLower(strings.TrimPrefix(owner, "@"))
+	for _, email := range id.Emails() {
+		if match := noreplyEmailRe.FindStringSubmatch(email); match != nil {
+			if match[1] == user {
+				return true
+			}
+			continue
+		}
+		if local, _, ok := strings.Cut(email, "@"); ok && local == user {
+			return true
+		}
+	}
+	for _, name := range id.Names() {
+		if strings.EqualFold(strings.ReplaceAll(name, " ", ""), user) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveFileCommits attaches the resolved author identity to each commit, dropping bot commits when excluded
+func resolveFileCommits(repoPath string, commits []fileCommit, options identity.Options) ([]fileCommit, error) {
+	resolver, err := identity.NewResolver(repoPath, options)
+	if err != nil {
+		return nil, err
+	}
+	var people []identity.Person
+	for _, commit := range commits {
+		people = append(people, identity.Person{Name: commit.author, Email: commit.email, Commits: 1})
+	}
+	index := resolver.Resolve(people)
+
+	resolved := commits[:0]
+	for _, commit := range commits {
+		commit.identity = index.Lookup(commit.author, commit.email)
+		if commit.identity.Bot && options.ExcludeBots {
+			continue
+		}
+		resolved = append(resolved, commit)
+	}
+	return resolved, nil
+}
+
+// commitsTouchingFiles lists the non-merge commits since a time with the files each touched
+func commitsTouchingFiles(repoPath string, since time.Time) ([]fileCommit, error) {
+	// An empty repository has no history to compare against
+	if !hasHead(repoPath) {
+		return nil, nil
+	}
+	// -z keeps paths with newlines or quoting-worthy characters verbatim
+	cmd := exec.Command("git", "log", "--no-merges", "--since="+since.Format(time.RFC3339), "--format=%x1e%an%x1f%ae%x1f%aI", "--name-only", "-z")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("read commit history: %w", err)
+	}
+
+	var commits []fileCommit
+	for _, record := range strings.Split(string(output), "\x1e") {
+		entries := strings.Split(record, "\x00")
+		fields := strings.Split(entries[0], "\x1f")
+		if len(fields) != 3 {
+			continue
+		}
+		commit := fileCommit{author: fields[0], email: fields[1]}
+		commit.date, _ = time.Parse(time.RFC3339, fields[2])
+		for _, path := range entries[1:] {
+			if path = strings.TrimPrefix(path, "\n"); path != "" {
+				commit.files = append(commit.files, path)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+func hasHead(repoPath string) bool {
+	cmd := exec.Command("git", "rev-parse", "--verify", "-q", "HEAD")
+	cmd.Dir = repoPath
+	return cmd.Run() == nil
+}
+
+// codeOwnersDetails renders the report as result detail lines
+func codeOwnersDetails(report *CodeOwnersReport) []string {
+	details := []string{
+		fmt.Sprintf("CODEOWNERS: %s (%s syntax, %d rules)", report.File.Path, report.File.Syntax, len(report.File.Rules)),
+		fmt.Sprintf("Owned Files: %d of %d", report.OwnedFiles, report.TotalFiles),
+	}
+	for _, problem := range report.File.Problems {
+		if problem.Line > 0 {
+			details = append(details, fmt.Sprintf("Line %d [%s]: %s", problem.Line, problem.Severity, problem.Message))
+		} else {
+			details = append(details, fmt.Sprintf("[%s] %s", problem.Severity, problem.Message))
+		}
+	}
+	for i, path := range report.UnownedFiles {
+		if i == 10 {
+			details = append(details, fmt.Sprintf("... and %d more unowned files", len(report.UnownedFiles)-10))
+			break
+		}
+		details = append(details, "Unowned: "+path)
+	}
+	for _, rule := range report.DeadPatterns {
+		details = append(details, fmt.Sprintf("Line %d: %s matches no files", rule.Line, rule.Pattern))
+	}
+	for _, owner := range report.StaleOwners {
+		details = append(details, fmt.Sprintf("Stale owner %s: no commits to %s in %d days", owner.Owner, strings.Join(owner.Patterns, ", "), report.StaleDays))
+	}
+	for _, directory := range report.Directories {
+		if directory.OwnerIsContributor {
+			continue
+		}
+		var names []string
+		for _, contributor := range directory.TopContributors {
+			names = append(names, fmt.Sprintf("%s (%d)", contributor.Name, contributor.Commits))
+		}
+		details = append(details, fmt.Sprintf("%s owned by %s but mostly changed by %s", directory.Directory, strings.Join(directory.Owners, ", "), strings.Join(names, ", ")))
+	}
+	return details
+}
@@ -0,0 +1,152 @@
+package checkers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	github := ParseCodeOwners(".github/CODEOWNERS", strings.Join([]string{
+		"# Default owners",
+		"*            @acme/core",
+		"/docs/*      docs@example.com # inline comment",
+		"*.go         @gopher",
+		"/api/        @gopher @acme/api",
+		"*.go         @alice",
+		"!vendor/     @acme/core",
+		"/build/      @acme/core/release not-an-owner",
+		"file\\ with\\ space.txt @bob",
+		"[Backend]    @acme/core",
+		"/generated/",
+	}, "\n"), CodeOwnersSyntaxAuto)
+
+	if github.Syntax != CodeOwnersSyntaxGitHub {
+		t.Fatalf("syntax = %s", github.Syntax)
+	}
+	messages := make(map[int]string)
+	for _, problem := range github.Problems {
+		messages[problem.Line] += problem.Message + ";"
+	}
+	for line, want := range map[int]string{
+		4:  "overridden by line 6",
+		7:  "Negated pattern",
+		8:  "not a valid GitHub team",
+		10: "not supported by GitHub",
+		11: "no owners",
+	} {
+		if !strings.Contains(messages[line], want) {
+			t.Errorf("line %d problems = %q, want %q", line, messages[line], want)
+		}
+	}
+	if !strings.Contains(messages[8], "Invalid owner not-an-owner") {
+		t.Errorf("line 8 problems = %q", messages[8])
+	}
+
+	for path, want := range map[string]string{
+		"api/handler.go":       "@alice",
+		"api/openapi.yaml":     "@gopher @acme/api",
+		"docs/index.md":        "docs@example.com",
+		"docs/guide/intro.md":  "@acme/core",
+		"file with space.txt":  "@bob",
+		"generated/schema.sql": "",
+	} {
+		if got := strings.Join(github.OwnersOf(path), " "); got != want {
+			t.Errorf("OwnersOf(%s) = %q, want %q", path, got, want)
+		}
+	}
+
+	gitlab := ParseCodeOwners("CODEOWNERS", strings.Join([]string{
+		"* @acme/core",
+		"[Backend][2] @backend-lead",
+		"*.go",
+		"^[Docs] @@maintainer",
+		"*.md @acme/group/writers",
+	}, "\n"), CodeOwnersSyntaxAuto)
+	if gitlab.Syntax != CodeOwnersSyntaxGitLab || len(gitlab.Problems) != 0 {
+		t.Fatalf("gitlab = %+v", gitlab)
+	}
+	if got := strings.Join(gitlab.OwnersOf("cmd/main.go"), " "); got != "@acme/core @backend-lead" {
+		t.Errorf("sections are not combined: %q", got)
+	}
+	if rules := gitlab.MatchingRules("README.md"); len(rules) != 2 || !rules[1].Optional || rules[1].Section != "Docs" {
+		t.Errorf("README.md rules = %+v", rules)
+	}
+}
+
+func TestCodeOwnersChecker(t *testing.T) {
+	repo := createGitRepository(t)
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(repo, path)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repo, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commitAs := func(name, email, subject string) {
+		t.Helper()
+		runGit(t, repo, "add", ".")
+		runGit(t, repo, "-c", "user.name="+name, "-c", "user.email="+email, "commit", "-qm", subject)
+	}
+
+	write(".github/CODEOWNERS", "/api/ @alice\n/web/ @carol\n/legacy/ @acme/core\n")
+	write("api/server.go", "package api\n")
+	commitAs("Alice", "1234+alice@users.noreply.github.com", "feat: add api")
+	write("web/app.js", "app\n")
+	commitAs("Dave", "dave@example.com", "feat: add web")
+	write("web/app.js", "app v2\n")
+	commitAs("Dave", "dave@example.com", "feat: update web")
+	write("scripts/build.sh", "make\n")
+	commitAs("Dave", "dave@example.com", "chore: add build script")
+
+	// Untracked files are not reviewed, so they need no owner
+	write("notes.txt", "scratch\n")
+
+	files := []string{".github/CODEOWNERS", "README.md", "api/server.go", "web/app.js", "scripts/build.sh", "notes.txt"}
+	result, report := NewCodeOwnersChecker().CheckWithReport(&types.RepositoryData{Path: repo, Files: files})
+
+	if result.Status != types.StatusWarning || report == nil {
+		t.Fatalf("result = %+v", result)
+	}
+	if report.OwnedFiles != 2 || strings.Join(report.UnownedFiles, ",") != ".github/CODEOWNERS,README.md,scripts/build.sh" {
+		t.Fatalf("owned %d, unowned %v", report.OwnedFiles, report.UnownedFiles)
+	}
+	if len(report.DeadPatterns) != 1 || report.DeadPatterns[0].Pattern != "/legacy/" {
+		t.Fatalf("dead patterns = %+v", report.DeadPatterns)
+	}
+	if len(report.StaleOwners) != 1 || report.StaleOwners[0].Owner != "@carol" {
+		t.Fatalf("stale owners = %+v", report.StaleOwners)
+	}
+	for _, directory := range report.Directories {
+		switch directory.Directory {
+		case "api/":
+			if !directory.OwnerIsContributor {
+				t.Errorf("api/ ownership = %+v", directory)
+			}
+		case "web/":
+			if directory.OwnerIsContributor || directory.TopContributors[0].Email != "dave@example.com" || directory.TopContributors[0].Commits != 2 {
+				t.Errorf("web/ ownership = %+v", directory)
+			}
+		}
+	}
+
+	// Owners whose only commits fall outside the window are stale too
+	checker := NewCodeOwnersCheckerWithOptions(CodeOwnersOptions{StaleDays: 30, Now: time.Now().AddDate(1, 0, 0)})
+	if _, report := checker.CheckWithReport(&types.RepositoryData{Path: repo, Files: files}); len(report.StaleOwners) != 2 {
+		t.Fatalf("stale owners a year later = %+v", report.StaleOwners)
+	}
+
+	write(".github/CODEOWNERS", "/api/ @alice/x/y\n")
+	if result := NewCodeOwnersChecker().Check(&types.RepositoryData{Path: repo, Files: files}); result.Status != types.StatusFail {
+		t.Fatalf("invalid CODEOWNERS status = %v", result.Status)
+	}
+	if result := NewCodeOwnersChecker().Check(&types.RepositoryData{Path: t.TempDir()}); result.Message != "No CODEOWNERS file found" {
+		t.Fatalf("missing CODEOWNERS = %+v", result)
+	}
+}
@@ -2,21 +2,31 @@ package checkers
 
 import (
 	"fmt"
-	"regexp"
 	"time"
+	"unicode/utf8"
 
 	"github.com/vahidaghazadeh/gphc/pkg/types"
 )
 
-// ConventionalCommitChecker checks adherence to conventional commit format
+// ConventionalCommitChecker checks adherence to the configured commit convention
 type ConventionalCommitChecker struct {
 	BaseChecker
+	convention *CommitConvention
 }
 
 // NewConventionalCommitChecker creates a new conventional commit checker
 func NewConventionalCommitChecker() *ConventionalCommitChecker {
+	return NewConventionalCommitCheckerWithConvention(DefaultCommitConvention())
+}
+
+// NewConventionalCommitCheckerWithConvention creates a commit checker for a specific convention profile
+func NewConventionalCommitCheckerWithConvention(convention *CommitConvention) *ConventionalCommitChecker {
+	if convention == nil {
+		convention = DefaultCommitConvention()
+	}
 	return &ConventionalCommitChecker{
 		BaseChecker: NewBaseChecker("Conventional Commit Checker", "CONV", types.CategoryCommits, 7),
+		convention:  convention,
 	}
 }
 
@@ -37,18 +47,15 @@ func (ccc *ConventionalCommitChecker) Check(data *types.RepositoryData) *types.C
 		return result
 	}
 
-	// Conventional commit pattern: type(scope): subject
-	pattern := regexp.MustCompile(`^(feat|fix|docs|style|refactor|perf|test|chore|build|ci|revert)(\(.+\))?: .+`)
-
 	validCommits := 0
 	totalCommits := len(data.Commits)
 	var invalidCommits []string
 
 	for _, commit := range data.Commits {
-		if pattern.MatchString(commit.Subject) {
+		if violations := ccc.convention.Validate(commit.Subject); len(violations) == 0 {
 			validCommits++
 		} else {
-			invalidCommits = append(invalidCommits, commit.Subject)
+			invalidCommits = append(invalidCommits, fmt.Sprintf("%s (%s)", commit.Subject, violations[0].Message))
 		}
 	}
 
@@ -56,8 +63,8 @@ func (ccc *ConventionalCommitChecker) Check(data *types.RepositoryData) *types.C
 	score := int(percentage)
 
 	var details []string
-	details = append(details, fmt.Sprintf("%d of %d commits follow conventional format (%.1f%%)",
-		validCommits, totalCommits, percentage))
+	details = append(details, fmt.Sprintf("%d of %d commits follow %s format (%.1f%%)",
+		validCommits, totalCommits, ccc.convention.DisplayName(), percentage))
 
 	if len(invalidCommits) > 0 {
 		details = append(details, "Non-standard commits:")
@@ -76,13 +83,13 @@ func (ccc *ConventionalCommitChecker) Check(data *types.RepositoryData) *types.C
 
 	if percentage >= 80 {
 		result.Status = types.StatusPass
-		result.Message = "Most commits follow conventional format"
+		result.Message = fmt.Sprintf("Most commits follow %s format", ccc.convention.DisplayName())
 	} else if percentage >= 50 {
 		result.Status = types.StatusWarning
-		result.Message = "Some commits don't follow conventional format"
+		result.Message = fmt.Sprintf("Some commits don't follow %s format", ccc.convention.DisplayName())
 	} else {
 		result.Status = types.StatusFail
-		result.Message = "Many commits don't follow conventional format"
+		result.Message = fmt.Sprintf("Many commits don't follow %s format", ccc.convention.DisplayName())
 	}
 
 	return result
@@ -131,7 +138,7 @@ func (mlc *MsgLengthChecker) Check(data *types.RepositoryData) *types.CheckResul
 	var longCommits []string
 
 	for _, commit := range data.Commits {
-		length := len(commit.Subject)
+		length := utf8.RuneCountInString(commit.Subject)
 		totalLength += length
 
 		if length <= mlc.maxLength {
@@ -154,7 +161,7 @@ func (mlc *MsgLengthChecker) Check(data *types.RepositoryData) *types.CheckResul
 		details = append(details, "Long commit messages:")
 		for i, commit := range longCommits {
 			if i < 3 { // Show only first 3 long commits
-				details = append(details, fmt.Sprintf("   - %s (%d chars)", commit, len(commit)))
+				details = append(details, fmt.Sprintf("   - %s (%d chars)", commit, utf8.RuneCountInString(commit)))
 			}
 		}
 		if len(longCommits) > 3 {
@@ -200,6 +207,16 @@ func NewCommitSizeCheckerWithLimit(maxLines int) *CommitSizeChecker {
 	}
 }
 
+// MaxLines returns the most lines one commit may change
+func (csc *CommitSizeChecker) MaxLines() int {
+	return csc.maxLines
+}
+
+// IsOversized reports whether a commit changes more lines than the limit
+func (csc *CommitSizeChecker) IsOversized(commit types.CommitInfo) bool {
+	return commit.LinesAdded+commit.LinesDeleted > csc.maxLines
+}
+
 // Check performs the commit size check
 func (csc *CommitSizeChecker) Check(data *types.RepositoryData) *types.CheckResult {
 	result := &types.CheckResult{
@@ -221,7 +238,7 @@ func (csc *CommitSizeChecker) Check(data *types.RepositoryData) *types.CheckResu
 	largeCommits := 0
 	for _, commit := range data.Commits {
 		totalLines += commit.LinesAdded + commit.LinesDeleted
-		if commit.LinesAdded+commit.LinesDeleted > csc.maxLines {
+		if csc.IsOversized(commit) {
 			largeCommits++
 		}
 	}
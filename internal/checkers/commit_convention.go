@@ -0,0 +1,369 @@
+package checkers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Commit convention profiles
+const (
+	ConventionConventional = "conventional"
+	ConventionAngular      = "angular"
+	ConventionGitmoji      = "gitmoji"
+	ConventionJira         = "jira"
+	ConventionCustom       = "custom"
+)
+
+// conventionalTypes are the commit types accepted by the Conventional Commits profile by default
+var conventionalTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// angularTypes are the commit types defined by the Angular commit message guidelines
+var angularTypes = []string{"build", "ci", "docs", "feat", "fix", "perf", "refactor", "test"}
+
+var jiraProjectRe = regexp.MustCompile(`^[A-Z][A-Z0-9_]+$`)
+
+// CommitConventionOptions configures a commit convention profile
+type CommitConventionOptions struct {
+	// Profile is one of conventional, angular, gitmoji, jira or custom
+	Profile string
+	// Types overrides the allowed commit types, or the allowed gitmojis for the gitmoji profile
+	Types []string
+	// Scopes restricts the allowed scopes; empty allows any scope
+	Scopes []string
+	// RequireScope rejects subjects without a scope
+	RequireScope bool
+	// Projects restricts the allowed Jira project keys; empty allows any key
+	Projects []string
+	// Pattern is the regular expression used by the custom profile
+	Pattern string
+}
+
+// CommitHeader is the structured form of a commit subject
+type CommitHeader struct {
+	Type        string `json:"type,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	Breaking    bool   `json:"breaking,omitempty"`
+	IssueKey    string `json:"issue_key,omitempty"`
+	Description string `json:"description"`
+}
+
+// ConventionViolation is a reason a commit subject does not follow the convention.
+// Column is the 1-based character position the problem starts at.
+type ConventionViolation struct {
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+func (v ConventionViolation) String() string {
+	return fmt.Sprintf("column %d: %s", v.Column, v.Message)
+}
+
+// CommitConvention validates commit subjects against a profile
+type CommitConvention struct {
+	options CommitConventionOptions
+	pattern *regexp.Regexp
+}
+
+// NewCommitConvention creates a commit convention from its options
+func NewCommitConvention(options CommitConventionOptions) (*CommitConvention, error) {
+	options.Profile = strings.ToLower(strings.TrimSpace(options.Profile))
+	if options.Profile == "" {
+		options.Profile = ConventionConventional
+	}
+	convention := &CommitConvention{options: options}
+
+	switch options.Profile {
+	case ConventionConventional:
+		if len(options.Types) == 0 {
+			convention.options.Types = conventionalTypes
+		}
+	case ConventionAngular:
+		if len(options.Types) == 0 {
+			convention.options.Types = angularTypes
+		}
+	case ConventionGitmoji:
+	case ConventionJira:
+		for _, project := range options.Projects {
+			if !jiraProjectRe.MatchString(project) {
+				return nil, fmt.Errorf("invalid Jira project key %q", project)
+			}
+		}
+	case ConventionCustom:
+		if options.Pattern == "" {
+			return nil, fmt.Errorf("custom commit convention requires a pattern")
+		}
+		pattern, err := regexp.Compile(options.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commit convention pattern: %w", err)
+		}
+		convention.pattern = pattern
+	default:
+		return nil, fmt.Errorf("unknown commit convention profile %q (use conventional, angular, gitmoji, jira or custom)", options.Profile)
+	}
+	return convention, nil
+}
+
+// DefaultCommitConvention returns the Conventional Commits profile with the default types
+func DefaultCommitConvention() *CommitConvention {
+	convention, _ := NewCommitConvention(CommitConventionOptions{Profile: ConventionConventional})
+	return convention
+}
+
+// Profile returns the profile name
+func (c *CommitConvention) Profile() string {
+	return c.options.Profile
+}
+
+// DisplayName returns a human readable name of the profile
+func (c *CommitConvention) DisplayName() string {
+	switch c.options.Profile {
+	case ConventionAngular:
+		return "Angular"
+	case ConventionGitmoji:
+		return "gitmoji"
+	case ConventionJira:
+		return "Jira"
+	case ConventionCustom:
+		return "custom"
+	}
+	return "Conventional Commits"
+}
+
+// Types returns the allowed commit types, or the allowed gitmojis for the gitmoji profile
+func (c *CommitConvention) Types() []string {
+	return c.options.Types
+}
+
+// Format describes the expected subject format
+func (c *CommitConvention) Format() string {
+	switch c.options.Profile {
+	case ConventionGitmoji:
+		return ":gitmoji: description"
+	case ConventionJira:
+		return "KEY-123: description"
+	case ConventionCustom:
+		return c.options.Pattern
+	}
+	if c.options.RequireScope {
+		return "type(scope): description"
+	}
+	return "type(scope)?: description"
+}
+
+// Matches reports whether a subject follows the convention
+func (c *CommitConvention) Matches(subject string) bool {
+	_, violations := c.Parse(subject)
+	return len(violations) == 0
+}
+
+// Validate returns every reason a subject does not follow the convention
+func (c *CommitConvention) Validate(subject string) []ConventionViolation {
+	_, violations := c.Parse(subject)
+	return violations
+}
+
+// Parse splits a subject into its header fields and reports convention violations
+func (c *CommitConvention) Parse(subject string) (CommitHeader, []ConventionViolation) {
+	switch c.options.Profile {
+	case ConventionGitmoji:
+		return c.parseGitmoji(subject)
+	case ConventionJira:
+		return c.parseJira(subject)
+	case ConventionCustom:
+		return c.parseCustom(subject)
+	}
+	header, violations := c.parseConventional(subject)
+	if c.options.Profile == ConventionAngular {
+		violations = append(violations, angularDescriptionViolations(subject, header)...)
+	}
+	return header, violations
+}
+
+// parseConventional parses type(scope)!: description
+func (c *CommitConvention) parseConventional(subject string) (CommitHeader, []ConventionViolation) {
+	runes := []rune(subject)
+	var header CommitHeader
+	var violations []ConventionViolation
+	violate := func(index int, format string, args ...interface{}) {
+		violations = append(violations, ConventionViolation{Column: index + 1, Message: fmt.Sprintf(format, args...)})
+	}
+
+	i := 0
+	for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+		i++
+	}
+	header.Type = string(runes[:i])
+	if header.Type == "" {
+		violate(0, "missing type; expected %s", c.Format())
+		return header, violations
+	}
+	if !containsString(c.options.Types, header.Type) {
+		if !strings.Contains(subject, ":") {
+			// Plain prose rather than a mistyped header
+			violate(0, "subject does not start with a type; expected %s", c.Format())
+			return header, violations
+		}
+		violate(0, "type %q is not allowed; use one of: %s", header.Type, strings.Join(c.options.Types, ", "))
+	}
+
+	if i < len(runes) && runes[i] == '(' {
+		end := i + 1
+		for end < len(runes) && runes[end] != ')' {
+			end++
+		}
+		if end == len(runes) {
+			violate(i, "scope is missing a closing parenthesis")
+			return header, violations
+		}
+		header.Scope = string(runes[i+1 : end])
+		switch {
+		case strings.TrimSpace(header.Scope) == "":
+			violate(i, "scope is empty")
+		case len(c.options.Scopes) > 0 && !containsString(c.options.Scopes, header.Scope):
+			violate(i+1, "scope %q is not allowed; use one of: %s", header.Scope, strings.Join(c.options.Scopes, ", "))
+		}
+		i = end + 1
+	} else if c.options.RequireScope {
+		violate(i, "missing scope; expected %s", c.Format())
+	}
+
+	if i < len(runes) && runes[i] == '!' {
+		header.Breaking = true
+		i++
+	}
+	if i >= len(runes) || runes[i] != ':' {
+		violate(i, "expected \":\" after the type")
+		return header, violations
+	}
+	i++
+	if i >= len(runes) {
+		violate(i, "missing description")
+		return header, violations
+	}
+	if runes[i] != ' ' {
+		violate(i, "expected a space after \":\"")
+	}
+	header.Description = strings.TrimSpace(string(runes[i:]))
+	if header.Description == "" {
+		violate(i, "missing description")
+	}
+	return header, violations
+}
+
+// angularDescriptionViolations applies the Angular rules for the summary text
+func angularDescriptionViolations(subject string, header CommitHeader) []ConventionViolation {
+	if header.Description == "" {
+		return nil
+	}
+	start := len([]rune(subject[:strings.LastIndex(subject, header.Description)]))
+
+	var violations []ConventionViolation
+	first := []rune(header.Description)[0]
+	if unicode.IsUpper(first) {
+		violations = append(violations, ConventionViolation{Column: start + 1, Message: "description must start with a lowercase letter"})
+	}
+	if strings.HasSuffix(header.Description, ".") {
+		violations = append(violations, ConventionViolation{Column: len([]rune(strings.TrimRight(subject, " "))), Message: "description must not end with a period"})
+	}
+	return violations
+}
+
+// parseGitmoji parses ":code: description" or "<emoji> description"
+func (c *CommitConvention) parseGitmoji(subject string) (CommitHeader, []ConventionViolation) {
+	runes := []rune(subject)
+	var header CommitHeader
+
+	i := 0
+	if strings.HasPrefix(subject, ":") {
+		end := 1
+		for end < len(runes) && (unicode.IsLower(runes[end]) || unicode.IsDigit(runes[end]) || strings.ContainsRune("_+-", runes[end])) {
+			end++
+		}
+		if end < len(runes) && runes[end] == ':' && end > 1 {
+			i = end + 1
+		}
+	} else {
+		for i < len(runes) && isEmojiRune(runes[i]) {
+			i++
+		}
+	}
+	header.Type = string(runes[:i])
+	if header.Type == "" {
+		return header, []ConventionViolation{{Column: 1, Message: fmt.Sprintf("subject must start with a gitmoji; expected %s", c.Format())}}
+	}
+
+	var violations []ConventionViolation
+	if len(c.options.Types) > 0 && !containsString(c.options.Types, header.Type) {
+		violations = append(violations, ConventionViolation{Column: 1, Message: fmt.Sprintf("gitmoji %s is not allowed; use one of: %s", header.Type, strings.Join(c.options.Types, " "))})
+	}
+	if i >= len(runes) || runes[i] != ' ' {
+		violations = append(violations, ConventionViolation{Column: i + 1, Message: "expected a space after the gitmoji"})
+		return header, violations
+	}
+	header.Description = strings.TrimSpace(string(runes[i:]))
+	if header.Description == "" {
+		violations = append(violations, ConventionViolation{Column: i + 1, Message: "missing description"})
+	}
+	return header, violations
+}
+
+// isEmojiRune reports whether r is part of an emoji sequence
+func isEmojiRune(r rune) bool {
+	switch {
+	case r == 0x200d || r == 0xfe0f || r == 0x20e3:
+		return true
+	case r >= 0x1f000 && r <= 0x1faff:
+		return true
+	}
+	return unicode.Is(unicode.So, r)
+}
+
+// parseJira parses "KEY-123: description"
+func (c *CommitConvention) parseJira(subject string) (CommitHeader, []ConventionViolation) {
+	var header CommitHeader
+	key, rest, found := strings.Cut(subject, ":")
+	project, number, hasNumber := strings.Cut(key, "-")
+	if !found || !hasNumber || !jiraProjectRe.MatchString(project) || number == "" || strings.Trim(number, "0123456789") != "" {
+		return header, []ConventionViolation{{Column: 1, Message: fmt.Sprintf("subject must start with an issue key; expected %s", c.Format())}}
+	}
+	header.IssueKey = key
+
+	var violations []ConventionViolation
+	if len(c.options.Projects) > 0 && !containsString(c.options.Projects, project) {
+		violations = append(violations, ConventionViolation{Column: 1, Message: fmt.Sprintf("project %q is not allowed; use one of: %s", project, strings.Join(c.options.Projects, ", "))})
+	}
+	column := len([]rune(key)) + 2
+	if !strings.HasPrefix(rest, " ") {
+		violations = append(violations, ConventionViolation{Column: column, Message: "expected a space after \":\""})
+	}
+	header.Description = strings.TrimSpace(rest)
+	if header.Description == "" {
+		violations = append(violations, ConventionViolation{Column: column, Message: "missing description"})
+	}
+	return header, violations
+}
+
+// parseCustom matches the configured pattern; named groups type, scope, issue and description fill the header
+func (c *CommitConvention) parseCustom(subject string) (CommitHeader, []ConventionViolation) {
+	var header CommitHeader
+	match := c.pattern.FindStringSubmatch(subject)
+	if match == nil {
+		return header, []ConventionViolation{{Column: 1, Message: fmt.Sprintf("subject does not match %s", c.options.Pattern)}}
+	}
+	header.Description = subject
+	for i, name := range c.pattern.SubexpNames() {
+		switch name {
+		case "type":
+			header.Type = match[i]
+		case "scope":
+			header.Scope = match[i]
+		case "issue":
+			header.IssueKey = match[i]
+		case "description":
+			header.Description = match[i]
+		}
+	}
+	return header, nil
+}
@@ -0,0 +1,112 @@
+package checkers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestCommitConventionProfiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		options CommitConventionOptions
+		subject string
+		column  int // zero when the subject is valid
+	}{
+		{"conventional", CommitConventionOptions{}, "feat: add login", 0},
+		{"conventional scope", CommitConventionOptions{}, "feat(api): add login", 0},
+		{"conventional breaking", CommitConventionOptions{}, "refactor(api)!: drop v1", 0},
+		{"conventional unknown type", CommitConventionOptions{}, "feature: add login", 1},
+		{"conventional missing colon", CommitConventionOptions{}, "feat add login", 5},
+		{"conventional missing space", CommitConventionOptions{}, "fix:typo", 5},
+		{"conventional unclosed scope", CommitConventionOptions{}, "fix(api: typo", 4},
+		{"custom types", CommitConventionOptions{Types: []string{"change"}}, "change: tweak", 0},
+		{"allowed scopes", CommitConventionOptions{Scopes: []string{"api", "cli"}}, "fix(web): typo", 5},
+		{"required scope", CommitConventionOptions{RequireScope: true}, "fix: typo", 4},
+		{"angular", CommitConventionOptions{Profile: "angular"}, "fix(core): handle nil", 0},
+		{"angular chore", CommitConventionOptions{Profile: "angular"}, "chore: bump deps", 1},
+		{"angular uppercase", CommitConventionOptions{Profile: "angular"}, "fix: Handle nil", 6},
+		{"angular period", CommitConventionOptions{Profile: "angular"}, "fix: handle nil.", 16},
+		{"gitmoji code", CommitConventionOptions{Profile: "gitmoji"}, ":sparkles: Add login", 0},
+		{"gitmoji emoji", CommitConventionOptions{Profile: "gitmoji"}, "⚡️ Speed up parser", 0},
+		{"gitmoji missing", CommitConventionOptions{Profile: "gitmoji"}, "Add login", 1},
+		{"gitmoji not allowed", CommitConventionOptions{Profile: "gitmoji", Types: []string{":bug:"}}, ":sparkles: Add login", 1},
+		{"jira", CommitConventionOptions{Profile: "jira"}, "ABC-123: add login", 0},
+		{"jira missing key", CommitConventionOptions{Profile: "jira"}, "add login", 1},
+		{"jira project", CommitConventionOptions{Profile: "jira", Projects: []string{"ABC"}}, "XYZ-9: add login", 1},
+		{"jira missing space", CommitConventionOptions{Profile: "jira"}, "ABC-1:add login", 7},
+		{"custom", CommitConventionOptions{Profile: "custom", Pattern: `^\[[a-z]+\] .+`}, "[core] add login", 0},
+		{"custom mismatch", CommitConventionOptions{Profile: "custom", Pattern: `^\[[a-z]+\] .+`}, "add login", 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			convention, err := NewCommitConvention(test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			violations := convention.Validate(test.subject)
+			if test.column == 0 {
+				if len(violations) != 0 {
+					t.Fatalf("Validate(%q) = %v, want no violations", test.subject, violations)
+				}
+				return
+			}
+			if len(violations) == 0 {
+				t.Fatalf("Validate(%q) found no violations", test.subject)
+			}
+			if violations[0].Column != test.column {
+				t.Errorf("Validate(%q) column = %d (%s), want %d", test.subject, violations[0].Column, violations[0].Message, test.column)
+			}
+		})
+	}
+}
+
+func TestCommitConventionParse(t *testing.T) {
+	header, violations := DefaultCommitConvention().Parse("feat(api)!: drop v1 endpoints")
+	if len(violations) != 0 {
+		t.Fatal(violations)
+	}
+	if header.Type != "feat" || header.Scope != "api" || !header.Breaking || header.Description != "drop v1 endpoints" {
+		t.Errorf("Parse() = %+v", header)
+	}
+
+	convention, err := NewCommitConvention(CommitConventionOptions{Profile: "custom", Pattern: `^(?P<issue>#\d+) (?P<description>.+)$`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, _ = convention.Parse("#42 fix crash")
+	if header.IssueKey != "#42" || header.Description != "fix crash" {
+		t.Errorf("custom Parse() = %+v", header)
+	}
+}
+
+func TestCommitConventionRejectsInvalidOptions(t *testing.T) {
+	for _, options := range []CommitConventionOptions{
+		{Profile: "semantic"},
+		{Profile: "custom"},
+		{Profile: "custom", Pattern: "("},
+		{Profile: "jira", Projects: []string{"abc"}},
+	} {
+		if _, err := NewCommitConvention(options); err == nil {
+			t.Errorf("NewCommitConvention(%+v) succeeded, want error", options)
+		}
+	}
+}
+
+func TestConventionalCommitCheckerUsesConvention(t *testing.T) {
+	convention, err := NewCommitConvention(CommitConventionOptions{Profile: "jira"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := &types.RepositoryData{Commits: []types.CommitInfo{
+		{Hash: "abc123", Subject: "ABC-1: add login", Date: time.Now()},
+		{Hash: "def456", Subject: "feat: add logout", Date: time.Now()},
+	}}
+
+	result := NewConventionalCommitCheckerWithConvention(convention).Check(data)
+	if result.Score != 50 || result.Status != types.StatusWarning {
+		t.Errorf("Score = %d, Status = %v, want 50 and warning", result.Score, result.Status)
+	}
+}
@@ -0,0 +1,328 @@
+package checkers
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Commit message lint rules
+const (
+	RuleSubjectEmpty    = "subject-empty"
+	RuleSubjectLength   = "subject-length"
+	RuleConvention      = "convention"
+	RuleBlankLine       = "blank-line"
+	RuleBodyLineLength  = "body-line-length"
+	RuleRequiredTrailer = "required-trailer"
+	RuleIssueReference  = "issue-reference"
+)
+
+// defaultIssuePattern matches GitHub/GitLab references (#123, group/repo#123) and Jira keys (ABC-123)
+const defaultIssuePattern = `(?:[\w.-]+/[\w.-]+)?#\d+|\b[A-Z][A-Z0-9_]+-\d+\b`
+
+// scissorsLine follows the comment character on the line marking the start of the diff appended by git commit --verbose
+const scissorsLine = " ------------------------ >8 ------------------------"
+
+// autoCommentChars are the characters git picks from, in order, when core.commentChar is auto
+const autoCommentChars = "#;@!$%^&|:"
+
+var (
+	trailerRe         = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9-]*|BREAKING CHANGE): (.+)$`)
+	hashTrailerRe     = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9-]*) (#.+)$`)
+	autosquashRe      = regexp.MustCompile(`^(?:(?:fixup|squash|amend)! )+`)
+	generatedMergeRe  = regexp.MustCompile(`^Merge (?:branch|branches|remote-tracking branch|tag|commit|pull request) `)
+	generatedRevertRe = regexp.MustCompile(`^Revert ".*"$`)
+)
+
+// CommitMessageLine is a line of a commit message with its position in the original file
+type CommitMessageLine struct {
+	Number int    `json:"line"`
+	Text   string `json:"text"`
+}
+
+// CommitTrailer is a "Key: value" line in the final paragraph of a commit message
+type CommitTrailer struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Line  int    `json:"line"`
+}
+
+// CommitMessage is a commit message split into subject, body and trailers
+type CommitMessage struct {
+	Subject  CommitMessageLine   `json:"subject"`
+	Body     []CommitMessageLine `json:"body,omitempty"`
+	Trailers []CommitTrailer     `json:"trailers,omitempty"`
+	// separator is the line following the subject, which must be blank when a body follows
+	separator *CommitMessageLine
+}
+
+// CommitMessageRules configures LintCommitMessage
+type CommitMessageRules struct {
+	// Convention validates the subject; nil skips convention checks
+	Convention *CommitConvention
+	// MaxSubjectLength limits the subject in characters; zero disables the check
+	MaxSubjectLength int
+	// MaxBodyLineLength limits body lines in characters; zero disables the check
+	MaxBodyLineLength int
+	// RequiredTrailers lists trailer keys that must be present, such as Signed-off-by
+	RequiredTrailers []string
+	// RequireIssueReference requires an issue reference anywhere in the message
+	RequireIssueReference bool
+	// IssuePattern overrides the regular expression that recognizes issue references
+	IssuePattern string
+	// CommentChar is git's core.commentChar, which starts the lines git strips; empty means # and auto detects it
+	CommentChar string
+}
+
+// CommitMessageError is a lint finding with its 1-based line and column
+type CommitMessageError struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e CommitMessageError) String() string {
+	return fmt.Sprintf("%d:%d: %s [%s]", e.Line, e.Column, e.Message, e.Rule)
+}
+
+// ParseCommitMessage parses a commit message file the way git cleans it up:
+// comment lines and everything below the scissors line are dropped, and
+// line numbers keep pointing at the original file
+func ParseCommitMessage(content string) *CommitMessage {
+	return ParseCommitMessageWithCommentChar(content, "#")
+}
+
+// ParseCommitMessageWithCommentChar parses a commit message whose comment lines start with
+// commentChar, as set by core.commentChar; empty means # and auto detects it
+func ParseCommitMessageWithCommentChar(content, commentChar string) *CommitMessage {
+	rawLines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	switch commentChar {
+	case "":
+		commentChar = "#"
+	case "auto":
+		commentChar = detectCommentChar(rawLines)
+	}
+
+	var lines []CommitMessageLine
+	for i, text := range rawLines {
+		if text == commentChar+scissorsLine {
+			break
+		}
+		if strings.HasPrefix(text, commentChar) {
+			continue
+		}
+		lines = append(lines, CommitMessageLine{Number: i + 1, Text: strings.TrimRight(text, " \t")})
+	}
+
+	// Leading and trailing blank lines are not part of the message
+	for len(lines) > 0 && lines[0].Text == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1].Text == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	message := &CommitMessage{}
+	if len(lines) == 0 {
+		return message
+	}
+	message.Subject = lines[0]
+	if len(lines) == 1 {
+		return message
+	}
+	message.separator = &lines[1]
+
+	body := lines[1:]
+	for len(body) > 0 && body[0].Text == "" {
+		body = body[1:]
+	}
+
+	// Trailers form the last paragraph, and only when every line in it is a trailer
+	start := len(body)
+	for start > 0 && body[start-1].Text != "" {
+		start--
+	}
+	if trailers, ok := parseTrailers(body[start:]); ok && (start > 0 || message.separator.Text == "") {
+		message.Trailers = trailers
+		body = body[:start]
+		for len(body) > 0 && body[len(body)-1].Text == "" {
+			body = body[:len(body)-1]
+		}
+	}
+	message.Body = body
+	return message
+}
+
+// GitCommentChar reads core.commentChar of the repository; empty when it is not set
+func GitCommentChar(repoPath string) string {
+	cmd := exec.Command("git", "config", "--get", "core.commentChar")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(string(output), "\n")
+}
+
+// detectCommentChar finds the character git chose for core.commentChar=auto. Git appends its
+// comment block, or the scissors line, after the message, so the candidate starting the scissors
+// line or the last line of that block is the one in use; # is assumed when none is left.
+func detectCommentChar(lines []string) string {
+	for _, text := range lines {
+		if len(text) > 0 && strings.ContainsRune(autoCommentChars, rune(text[0])) && text[1:] == scissorsLine {
+			return text[:1]
+		}
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		text := strings.TrimRight(lines[i], " \t")
+		if text == "" {
+			continue
+		}
+		if strings.ContainsRune(autoCommentChars, rune(text[0])) && (len(text) == 1 || text[1] == ' ') {
+			return text[:1]
+		}
+		break
+	}
+	return "#"
+}
+
+// parseTrailers parses a paragraph of trailers, allowing indented continuation lines
+func parseTrailers(paragraph []CommitMessageLine) ([]CommitTrailer, bool) {
+	var trailers []CommitTrailer
+	for _, line := range paragraph {
+		if (strings.HasPrefix(line.Text, " ") || strings.HasPrefix(line.Text, "\t")) && len(trailers) > 0 {
+			trailers[len(trailers)-1].Value += " " + strings.TrimSpace(line.Text)
+			continue
+		}
+		match := trailerRe.FindStringSubmatch(line.Text)
+		if match == nil {
+			match = hashTrailerRe.FindStringSubmatch(line.Text)
+		}
+		if match == nil {
+			return nil, false
+		}
+		trailers = append(trailers, CommitTrailer{Key: match[1], Value: strings.TrimSpace(match[2]), Line: line.Number})
+	}
+	return trailers, len(trailers) > 0
+}
+
+// Trailer returns the values of a trailer key, compared case-insensitively
+func (m *CommitMessage) Trailer(key string) []string {
+	var values []string
+	for _, trailer := range m.Trailers {
+		if strings.EqualFold(trailer.Key, key) {
+			values = append(values, trailer.Value)
+		}
+	}
+	return values
+}
+
+// IsGenerated reports whether git wrote the subject for a merge or revert
+func (m *CommitMessage) IsGenerated() bool {
+	return generatedMergeRe.MatchString(m.Subject.Text) || generatedRevertRe.MatchString(m.Subject.Text)
+}
+
+// LintCommitMessage validates a commit message file's content against the rules
+func LintCommitMessage(content string, rules CommitMessageRules) (*CommitMessage, []CommitMessageError, error) {
+	message := ParseCommitMessageWithCommentChar(content, rules.CommentChar)
+	var errors []CommitMessageError
+	report := func(line, column int, rule, format string, args ...interface{}) {
+		errors = append(errors, CommitMessageError{Line: line, Column: column, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	subject := message.Subject
+	if subject.Text == "" {
+		report(1, 1, RuleSubjectEmpty, "commit message is empty")
+		return message, errors, nil
+	}
+
+	if length := utf8.RuneCountInString(subject.Text); rules.MaxSubjectLength > 0 && length > rules.MaxSubjectLength {
+		report(subject.Number, rules.MaxSubjectLength+1, RuleSubjectLength, "subject is %d characters long; keep it within %d", length, rules.MaxSubjectLength)
+	}
+
+	if rules.Convention != nil && !message.IsGenerated() {
+		// fixup!, squash! and amend! subjects are checked against the commit they target
+		prefix := autosquashRe.FindString(subject.Text)
+		offset := utf8.RuneCountInString(prefix)
+		for _, violation := range rules.Convention.Validate(strings.TrimPrefix(subject.Text, prefix)) {
+			report(subject.Number, violation.Column+offset, RuleConvention, "%s", violation.Message)
+		}
+	}
+
+	if message.separator != nil && message.separator.Text != "" {
+		report(message.separator.Number, 1, RuleBlankLine, "separate the subject from the body with a blank line")
+	}
+
+	if rules.MaxBodyLineLength > 0 {
+		for _, line := range message.Body {
+			if length := utf8.RuneCountInString(line.Text); length > rules.MaxBodyLineLength && !unwrappableLine(line.Text) {
+				report(line.Number, rules.MaxBodyLineLength+1, RuleBodyLineLength, "body line is %d characters long; wrap it at %d", length, rules.MaxBodyLineLength)
+			}
+		}
+	}
+
+	lastLine := subject.Number
+	if len(message.Body) > 0 {
+		lastLine = message.Body[len(message.Body)-1].Number
+	}
+	if len(message.Trailers) > 0 {
+		lastLine = message.Trailers[len(message.Trailers)-1].Line
+	}
+	for _, key := range rules.RequiredTrailers {
+		if len(message.Trailer(key)) == 0 {
+			report(lastLine+1, 1, RuleRequiredTrailer, "missing required trailer %q; add a final paragraph line \"%s: ...\"", key, key)
+		}
+	}
+
+	if rules.RequireIssueReference {
+		pattern := rules.IssuePattern
+		if pattern == "" {
+			pattern = defaultIssuePattern
+		}
+		issueRe, err := regexp.Compile(pattern)
+		if err != nil {
+			return message, errors, fmt.Errorf("invalid issue pattern: %w", err)
+		}
+		if !issueRe.MatchString(message.text()) {
+			report(subject.Number, 1, RuleIssueReference, "missing issue reference matching %s", pattern)
+		}
+	}
+
+	sort.SliceStable(errors, func(i, j int) bool {
+		if errors[i].Line != errors[j].Line {
+			return errors[i].Line < errors[j].Line
+		}
+		return errors[i].Column < errors[j].Column
+	})
+	return message, errors, nil
+}
+
+// text returns the cleaned-up message
+func (m *CommitMessage) text() string {
+	lines := []string{m.Subject.Text}
+	for _, line := range m.Body {
+		lines = append(lines, line.Text)
+	}
+	for _, trailer := range m.Trailers {
+		lines = append(lines, trailer.Key+": "+trailer.Value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// unwrappableLine reports lines that cannot be wrapped: indented code, quotes and long URLs
+func unwrappableLine(line string) bool {
+	if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, ">") {
+		return true
+	}
+	fields := strings.Fields(line)
+	for _, field := range fields {
+		if strings.Contains(field, "://") && utf8.RuneCountInString(field) > 40 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package checkers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseCommitMessage(t *testing.T) {
+	content := strings.Join([]string{
+		"# Please enter the commit message",
+		"feat(api): add login",
+		"",
+		"Explain why the change is needed.",
+		"",
+		"Signed-off-by: Jane Doe <jane@example.com>",
+		"Refs #42",
+		"BREAKING CHANGE: tokens are now required",
+		"  for every request",
+		"# ------------------------ >8 ------------------------",
+		"diff --git a/main.go b/main.go",
+	}, "\n")
+
+	message := ParseCommitMessage(content)
+	if message.Subject.Text != "feat(api): add login" || message.Subject.Number != 2 {
+		t.Errorf("Subject = %+v", message.Subject)
+	}
+	if len(message.Body) != 1 || message.Body[0].Number != 4 {
+		t.Errorf("Body = %+v", message.Body)
+	}
+	if len(message.Trailers) != 3 {
+		t.Fatalf("Trailers = %+v", message.Trailers)
+	}
+	if got := message.Trailer("signed-off-by"); len(got) != 1 || got[0] != "Jane Doe <jane@example.com>" {
+		t.Errorf("Signed-off-by = %q", got)
+	}
+	if got := message.Trailer("BREAKING CHANGE"); len(got) != 1 || got[0] != "tokens are now required for every request" {
+		t.Errorf("BREAKING CHANGE = %q", got)
+	}
+}
+
+func TestParseCommitMessageBodyIsNotTrailers(t *testing.T) {
+	message := ParseCommitMessage("fix: typo\n\nNote: this is prose,\nnot a trailer block.\n")
+	if len(message.Trailers) != 0 || len(message.Body) != 2 {
+		t.Errorf("Body = %+v, Trailers = %+v", message.Body, message.Trailers)
+	}
+}
+
+func TestParseCommitMessageCommentChar(t *testing.T) {
+	content := "fix: keep #2 out of comments\n\n#2 was reported twice.\n; Please enter the commit message\n;\n; ------------------------ >8 ------------------------\ndiff --git a/main.go b/main.go\n"
+	for _, commentChar := range []string{";", "auto"} {
+		message := ParseCommitMessageWithCommentChar(content, commentChar)
+		if len(message.Body) != 1 || message.Body[0].Text != "#2 was reported twice." {
+			t.Errorf("%s: Body = %+v", commentChar, message.Body)
+		}
+	}
+	// Without a scissors line, auto takes the character starting git's trailing comment block
+	message := ParseCommitMessageWithCommentChar("fix: typo\n\n#1 body\n\n; Please enter the commit message\n; Lines starting with ';' will be ignored\n", "auto")
+	if len(message.Body) != 1 || message.Body[0].Text != "#1 body" {
+		t.Errorf("auto: Body = %+v", message.Body)
+	}
+
+	repo := createGitRepository(t)
+	runGit(t, repo, "config", "core.commentChar", "auto")
+	if got := GitCommentChar(repo); got != "auto" {
+		t.Errorf("GitCommentChar = %q", got)
+	}
+}
+
+func TestLintCommitMessage(t *testing.T) {
+	rules := CommitMessageRules{
+		Convention:            DefaultCommitConvention(),
+		MaxSubjectLength:      50,
+		MaxBodyLineLength:     72,
+		RequiredTrailers:      []string{"Signed-off-by"},
+		RequireIssueReference: true,
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string // line:column:rule
+	}{
+		{
+			name:    "valid",
+			content: "feat(api): add login\n\nCloses #12\n\nSigned-off-by: Jane <jane@example.com>\n",
+		},
+		{
+			name:    "empty",
+			content: "# only comments\n\n",
+			want:    []string{"1:1:subject-empty"},
+		},
+		{
+			name:    "subject problems",
+			content: "Added login support for the public and the internal API\n\nRefs ABC-1\n\nSigned-off-by: Jane <jane@example.com>\n",
+			want:    []string{"1:1:convention", "1:51:subject-length"},
+		},
+		{
+			name:    "missing blank line and trailer",
+			content: "fix: handle nil\nbody without separator #3\n",
+			want:    []string{"2:1:blank-line", "3:1:required-trailer"},
+		},
+		{
+			name: "body wrapping",
+			content: "docs: explain setup\n\n" + strings.Repeat("word ", 16) + "#7\n" +
+				"    " + strings.Repeat("code", 20) + "\n\nSigned-off-by: Jane <jane@example.com>\n",
+			want: []string{"3:73:body-line-length"},
+		},
+		{
+			name:    "missing issue",
+			content: "fix: handle nil\n\nSigned-off-by: Jane <jane@example.com>\n",
+			want:    []string{"1:1:issue-reference"},
+		},
+		{
+			name:    "fixup column offset",
+			content: "fixup! feature: handle nil #1\n\nSigned-off-by: Jane <jane@example.com>\n",
+			want:    []string{"1:8:convention"},
+		},
+		{
+			name:    "generated merge",
+			content: "Merge branch 'main' into topic #1\n\nSigned-off-by: Jane <jane@example.com>\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, problems, err := LintCommitMessage(test.content, rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, problem := range problems {
+				got = append(got, fmt.Sprintf("%d:%d:%s", problem.Line, problem.Column, problem.Rule))
+			}
+			if strings.Join(got, ",") != strings.Join(test.want, ",") {
+				t.Errorf("problems = %v, want %v (%v)", got, test.want, problems)
+			}
+		})
+	}
+}
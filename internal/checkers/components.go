@@ -0,0 +1,323 @@
+package checkers
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReleaseComponent is an independently versioned part of a monorepo, such as api tagged api/v1.4.0
+type ReleaseComponent struct {
+	// Name identifies the component on the command line
+	Name string `json:"name"`
+	// TagPrefix is prepended to versions in the component's tags; empty means "<name>/v"
+	TagPrefix string `json:"tag_prefix"`
+	// Major limits the component to tags of one major version, as for a Go module in a v2
+	// subdirectory; 1 also takes v0 tags, which share the module path. Zero takes every version.
+	Major int `json:"major,omitempty"`
+	// Paths limit the component's commits to these pathspecs; empty means the whole repository
+	Paths []string `json:"paths,omitempty"`
+	// Exclude removes pathspecs from the component, such as nested Go modules
+	Exclude []string `json:"exclude,omitempty"`
+	// Changelog is the component's changelog file; empty means CHANGELOG.md in its first path
+	Changelog string `json:"changelog,omitempty"`
+	// VersionFile is updated with each release of the component
+	VersionFile string `json:"version_file,omitempty"`
+}
+
+// ComponentStatus is the release state of one component
+type ComponentStatus struct {
+	Component ReleaseComponent `json:"component"`
+	// LatestTag is the highest version tag of the component reachable from HEAD
+	LatestTag  string    `json:"latest_tag,omitempty"`
+	LatestDate time.Time `json:"latest_date,omitempty"`
+	// Unreleased counts the commits after LatestTag that touch the component's paths
+	Unreleased int `json:"unreleased"`
+	// NextTag is the suggested tag for the component's next release
+	NextTag string `json:"next_tag"`
+}
+
+// majorDirRe matches a Go major version subdirectory such as v2
+var majorDirRe = regexp.MustCompile(`^v[0-9]+$`)
+
+// Normalize fills in the defaults of unset fields
+func (c ReleaseComponent) Normalize() ReleaseComponent {
+	if c.TagPrefix == "" {
+		c.TagPrefix = c.Name + "/v"
+	}
+	if c.Changelog == "" {
+		c.Changelog = path.Join(c.dir(), "CHANGELOG.md")
+	}
+	return c
+}
+
+// Pathspecs returns the git pathspecs selecting the component's files
+func (c ReleaseComponent) Pathspecs() []string {
+	specs := append([]string(nil), c.Paths...)
+	for _, exclude := range c.Exclude {
+		specs = append(specs, ":(exclude)"+exclude)
+	}
+	return specs
+}
+
+// DefaultReleaseComponent is the whole repository tagged vX.Y.Z
+func DefaultReleaseComponent() ReleaseComponent {
+	return ReleaseComponent{TagPrefix: "v", Changelog: "CHANGELOG.md"}
+}
+
+// GoModuleComponents finds the Go modules of the repository and returns one component per module,
+// following the Go tag convention: the root module is tagged vX.Y.Z and a module in sub/dir is
+// tagged sub/dir/vX.Y.Z. A major version subdirectory such as sub/dir/v2 shares its parent's
+// prefix but only takes the v2 tags, leaving v0 and v1 to the parent module. Each module excludes
+// the modules nested inside it.
+func GoModuleComponents(repoPath string) ([]ReleaseComponent, error) {
+	files, err := trackedFiles(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, file := range files {
+		if path.Base(file) == "go.mod" && !strings.HasPrefix(file, "vendor/") && !strings.Contains(file, "/vendor/") {
+			dirs = append(dirs, path.Dir(file))
+		}
+	}
+	sort.Strings(dirs)
+
+	// Parents of major version subdirectories keep only their v0 and v1 tags
+	hasMajorDir := make(map[string]bool)
+	for _, dir := range dirs {
+		if majorDirRe.MatchString(path.Base(dir)) {
+			hasMajorDir[path.Dir(dir)] = true
+		}
+	}
+
+	var components []ReleaseComponent
+	for _, dir := range dirs {
+		component := ReleaseComponent{Name: dir, TagPrefix: "v"}
+		if hasMajorDir[dir] {
+			component.Major = 1
+		}
+		if dir == "." {
+			component.Name = "root"
+		} else {
+			component.Paths = []string{dir}
+			prefix := dir
+			if base := path.Base(dir); majorDirRe.MatchString(base) {
+				prefix = path.Dir(dir)
+				component.Major, _ = strconv.Atoi(base[1:])
+			}
+			if prefix != "." {
+				component.TagPrefix = prefix + "/v"
+			}
+		}
+		for _, nested := range dirs {
+			if nested != dir && (dir == "." || strings.HasPrefix(nested, dir+"/")) {
+				component.Exclude = append(component.Exclude, nested)
+			}
+		}
+		components = append(components, component.Normalize())
+	}
+	return components, nil
+}
+
+// ResolveComponents normalizes the configured components and, when goModules is set, adds the
+// detected Go modules whose tags no configured component already takes
+func ResolveComponents(repoPath string, configured []ReleaseComponent, goModules bool) ([]ReleaseComponent, error) {
+	var components []ReleaseComponent
+	for _, component := range configured {
+		if component.Name == "" {
+			return nil, fmt.Errorf("release component without a name")
+		}
+		component = component.Normalize()
+		for _, other := range components {
+			if other.sharesTags(component) {
+				return nil, fmt.Errorf("release components %s and %s share the tag prefix %q", other.Name, component.Name, component.TagPrefix)
+			}
+		}
+		components = append(components, component)
+	}
+	if goModules {
+		modules, err := GoModuleComponents(repoPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, module := range modules {
+			covered := false
+			for _, component := range components[:len(configured)] {
+				covered = covered || component.sharesTags(module)
+			}
+			if !covered {
+				components = append(components, module)
+			}
+		}
+	}
+	return components, nil
+}
+
+// sharesTags reports whether two components take some of the same tags
+func (c ReleaseComponent) sharesTags(other ReleaseComponent) bool {
+	return c.TagPrefix == other.TagPrefix && (c.Major == 0 || other.Major == 0 || c.Major == other.Major)
+}
+
+// inMajor reports whether a version belongs to the major version; v0 belongs to major 1 and
+// major 0 takes every version
+func inMajor(version Version, major int) bool {
+	return major == 0 || version.Major == major || (major == 1 && version.Major == 0)
+}
+
+// firstVersion is the version of a component's first release
+func firstVersion(major int) Version {
+	if major > 1 {
+		return Version{Major: major}
+	}
+	return Version{Minor: 1}
+}
+
+// FindComponent returns the component with the name
+func FindComponent(components []ReleaseComponent, name string) (ReleaseComponent, error) {
+	var names []string
+	for _, component := range components {
+		if component.Name == name {
+			return component, nil
+		}
+		names = append(names, component.Name)
+	}
+	if len(names) == 0 {
+		return ReleaseComponent{}, fmt.Errorf("unknown component %q: no release components are configured", name)
+	}
+	return ReleaseComponent{}, fmt.Errorf("unknown component %q (configured: %s)", name, strings.Join(names, ", "))
+}
+
+// latestVersionTag returns the highest version tag with the prefix and major version reachable from HEAD, or nil
+func latestVersionTag(repoPath, prefix string, major int) (*releaseTag, error) {
+	tags, err := versionTags(repoPath, prefix, major, true)
+	if err != nil {
+		return nil, err
+	}
+	var latest *releaseTag
+	for i, tag := range tags {
+		if latest == nil || tag.version.Compare(latest.version) > 0 {
+			latest = &tags[i]
+		}
+	}
+	return latest, nil
+}
+
+// countCommits counts the commits in the revision range that touch the pathspecs
+func countCommits(repoPath, revision string, pathspecs []string) (int, error) {
+	args := []string{"rev-list", "--count", revision}
+	if len(pathspecs) > 0 {
+		args = append(append(args, "--"), pathspecs...)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("count commits: %w", err)
+	}
+	return strconv.Atoi(strings.TrimSpace(string(output)))
+}
+
+// SuggestComponentTag suggests the component's next tag from the conventional commits touching
+// its paths since its latest tag, bumping as gphc release does. Without commits it suggests a
+// patch release.
+func SuggestComponentTag(repoPath string, component ReleaseComponent) (string, error) {
+	component = component.Normalize()
+	latest, err := latestVersionTag(repoPath, component.TagPrefix, component.Major)
+	if err != nil {
+		return "", err
+	}
+	if latest == nil {
+		return component.TagPrefix + firstVersion(component.Major).String(), nil
+	}
+	commits, err := releaseCommitsBetween(repoPath, latest.name, "HEAD", component.Pathspecs()...)
+	if err != nil {
+		return "", err
+	}
+	next := bumpVersion(latest.version.Core(), releaseBump(commits), false)
+	if latest.version.IsPrerelease() {
+		// The pre-release already carries the bump; releasing it drops the suffix
+		next = latest.version.Core()
+	}
+	return component.TagPrefix + next.String(), nil
+}
+
+// AnalyzeComponents reports the latest tag, unreleased commits and next tag of each component
+func AnalyzeComponents(repoPath string, components []ReleaseComponent) ([]ComponentStatus, error) {
+	if !hasHead(repoPath) {
+		return nil, fmt.Errorf("repository has no commits")
+	}
+	dates, err := tagDates(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []ComponentStatus
+	for _, component := range components {
+		component = component.Normalize()
+		status := ComponentStatus{Component: component}
+		latest, err := latestVersionTag(repoPath, component.TagPrefix, component.Major)
+		if err != nil {
+			return nil, err
+		}
+		revision := "HEAD"
+		if latest != nil {
+			status.LatestTag = latest.name
+			status.LatestDate = dates[latest.name]
+			revision = latest.name + "..HEAD"
+		}
+		if status.Unreleased, err = countCommits(repoPath, revision, component.Pathspecs()); err != nil {
+			return nil, err
+		}
+		if status.NextTag, err = SuggestComponentTag(repoPath, component); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// ReleaseOptions scopes release options to the component: its tag prefix, paths, changelog and
+// version file, detected in its first path when unset
+func (c ReleaseComponent) ReleaseOptions(options ReleaseOptions) ReleaseOptions {
+	c = c.Normalize()
+	options.Component = c.Name
+	options.TagPrefix = c.TagPrefix
+	options.Major = c.Major
+	options.Paths = c.Pathspecs()
+	options.Changelog = c.Changelog
+	options.VersionFile = c.VersionFile
+	options.VersionDir = c.dir()
+	return options
+}
+
+// ChangelogOptions scopes changelog options to the component's tag prefix and paths
+func (c ReleaseComponent) ChangelogOptions(options ChangelogOptions) ChangelogOptions {
+	c = c.Normalize()
+	options.TagPrefix = c.TagPrefix
+	options.Major = c.Major
+	options.Paths = c.Pathspecs()
+	return options
+}
+
+// dir is the component's first path when it names a directory, else the repository root
+func (c ReleaseComponent) dir() string {
+	if len(c.Paths) == 0 || strings.HasPrefix(c.Paths[0], ":") || strings.ContainsAny(c.Paths[0], "*?[") {
+		return ""
+	}
+	return strings.Trim(c.Paths[0], "/")
+}
+
+// ComponentChangelogPath returns where a component's changelog is written
+func ComponentChangelogPath(repoPath string, component ReleaseComponent) string {
+	output := component.Normalize().Changelog
+	if filepath.IsAbs(output) {
+		return output
+	}
+	return filepath.Join(repoPath, filepath.FromSlash(output))
+}
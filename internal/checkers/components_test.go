@@ -0,0 +1,169 @@
+package checkers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestReleaseComponents(t *testing.T) {
+	repo := createGitRepository(t)
+	commit := func(file, message string) {
+		t.Helper()
+		path := filepath.Join(repo, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(message+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, repo, "add", ".")
+		runGit(t, repo, "commit", "-qm", message)
+	}
+	commit("api/server.go", "feat(api): first endpoint")
+	commit("web/index.html", "feat(web): landing page")
+	runGit(t, repo, "tag", "-a", "api/v1.4.0", "-m", "api 1.4.0")
+	runGit(t, repo, "tag", "-a", "web/v2.1.3", "-m", "web 2.1.3")
+	commit("api/server.go", "fix(api): handle timeouts")
+	commit("web/index.html", "feat(web): dark mode")
+	commit("web/app.js", "fix(web): menu focus")
+
+	components, err := ResolveComponents(repo, []ReleaseComponent{
+		{Name: "api", Paths: []string{"api"}},
+		{Name: "web", Paths: []string{"web"}},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := AnalyzeComponents(repo, components)
+	if err != nil {
+		t.Fatalf("AnalyzeComponents failed: %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("unexpected statuses %+v", statuses)
+	}
+	if api := statuses[0]; api.LatestTag != "api/v1.4.0" || api.Unreleased != 1 || api.NextTag != "api/v1.4.1" {
+		t.Errorf("unexpected api status %+v", api)
+	}
+	if web := statuses[1]; web.LatestTag != "web/v2.1.3" || web.Unreleased != 2 || web.NextTag != "web/v2.2.0" {
+		t.Errorf("unexpected web status %+v", web)
+	}
+	if web := statuses[1]; web.Component.Changelog != "web/CHANGELOG.md" {
+		t.Errorf("unexpected web changelog %s", web.Component.Changelog)
+	}
+
+	// Component tags are valid semantic versions; a plain version tag is not one of them
+	runGit(t, repo, "tag", "v9.9.9")
+	result := NewTagCheckerWithOptions(TagCheckerOptions{Components: components}).Check(&types.RepositoryData{Path: repo})
+	joined := strings.Join(result.Details, "\n")
+	if !containsString(result.Details, "Invalid tags (non-semver): v9.9.9") {
+		t.Errorf("unexpected validation:\n%s", joined)
+	}
+	if !strings.Contains(joined, "Component web: last tag web/v2.1.3") {
+		t.Errorf("missing component details:\n%s", joined)
+	}
+
+	// Release notes and changelogs only cover the component's commits
+	api, _ := FindComponent(components, "api")
+	plan, err := PlanRelease(repo, api.ReleaseOptions(DefaultReleaseOptions()))
+	if err != nil {
+		t.Fatalf("PlanRelease failed: %v", err)
+	}
+	if plan.Tag != "api/v1.4.1" || plan.PreviousTag != "api/v1.4.0" || len(plan.Commits) != 1 || plan.Changelog != "api/CHANGELOG.md" {
+		t.Errorf("unexpected api plan %+v", plan)
+	}
+	web, _ := FindComponent(components, "web")
+	changelog, err := BuildChangelog(repo, web.ChangelogOptions(DefaultChangelogOptions()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := RenderChangelog(changelog, DefaultChangelogOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(content, "dark mode") || strings.Contains(content, "timeouts") || !strings.Contains(content, "## [2.1.3]") {
+		t.Errorf("unexpected web changelog:\n%s", content)
+	}
+	if _, err := FindComponent(components, "docs"); err == nil {
+		t.Error("unknown components should be rejected")
+	}
+}
+
+func TestGoModuleComponents(t *testing.T) {
+	repo := createGitRepository(t)
+	for _, dir := range []string{".", "tools", "sdk/v2"} {
+		if err := os.MkdirAll(filepath.Join(repo, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repo, dir, "go.mod"), []byte("module example.com/m\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "chore: modules")
+
+	components, err := GoModuleComponents(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefixes := map[string]string{}
+	for _, component := range components {
+		prefixes[component.Name] = component.TagPrefix
+	}
+	if prefixes["root"] != "v" || prefixes["tools"] != "tools/v" || prefixes["sdk/v2"] != "sdk/v" {
+		t.Errorf("unexpected tag prefixes %v", prefixes)
+	}
+	if root := components[0]; root.Name != "root" || strings.Join(root.Pathspecs(), " ") != ":(exclude)sdk/v2 :(exclude)tools" {
+		t.Errorf("the root module should exclude nested modules: %+v", root)
+	}
+}
+
+func TestGoModuleMajorVersionSubdirectory(t *testing.T) {
+	repo := createGitRepository(t)
+	for _, dir := range []string{".", "v2"} {
+		if err := os.MkdirAll(filepath.Join(repo, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repo, dir, "go.mod"), []byte("module example.com/m\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "chore: modules")
+	runGit(t, repo, "tag", "-a", "v1.3.0", "-m", "v1.3.0")
+	runGit(t, repo, "tag", "-a", "v2.0.0", "-m", "v2.0.0")
+	if err := os.WriteFile(filepath.Join(repo, "main.go"), []byte("package m\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "fix: root module")
+
+	// Both modules share the v prefix; neither may be dropped
+	components, err := ResolveComponents(repo, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(components) != 2 || components[0].Major != 1 || components[1].Name != "v2" || components[1].Major != 2 {
+		t.Fatalf("unexpected components %+v", components)
+	}
+
+	statuses, err := AnalyzeComponents(repo, components)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root := statuses[0]; root.LatestTag != "v1.3.0" || root.NextTag != "v1.3.1" {
+		t.Errorf("the v1 module should only see its own tags: %+v", root)
+	}
+	if v2 := statuses[1]; v2.LatestTag != "v2.0.0" || v2.Unreleased != 0 {
+		t.Errorf("unexpected v2 status %+v", v2)
+	}
+
+	// A major version subdirectory without tags starts at its major version
+	runGit(t, repo, "tag", "-d", "v2.0.0")
+	if next, err := SuggestComponentTag(repo, components[1]); err != nil || next != "v2.0.0" {
+		t.Errorf("SuggestComponentTag = %q, %v; want v2.0.0", next, err)
+	}
+}
@@ -0,0 +1,378 @@
+package checkers
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// File content categories reported by detectFileType
+const (
+	FileCategoryExecutable = "executable"
+	FileCategoryBytecode   = "bytecode"
+	FileCategoryArchive    = "archive"
+	FileCategoryImage      = "image"
+	FileCategoryDocument   = "document"
+	FileCategoryText       = "text"
+	FileCategoryScript     = "script"
+	FileCategoryBinary     = "binary"
+)
+
+// FileType is the type of a file as identified from its leading bytes
+type FileType struct {
+	Kind        string `json:"kind"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
+// extensionContent lists the content kinds each well-known extension may contain
+var extensionContent = map[string][]string{
+	".exe":   {"pe"},
+	".dll":   {"pe"},
+	".sys":   {"pe"},
+	".scr":   {"pe"},
+	".so":    {"elf"},
+	".dylib": {"macho"},
+	".jar":   {"jar", "zip"},
+	".war":   {"jar", "zip"},
+	".ear":   {"jar", "zip"},
+	".apk":   {"apk", "jar", "zip"},
+	".zip":   {"zip", "jar", "apk", "ooxml"},
+	".gz":    {"gzip"},
+	".tgz":   {"gzip"},
+	".bz2":   {"bzip2"},
+	".xz":    {"xz"},
+	".7z":    {"7z"},
+	".rar":   {"rar"},
+	".tar":   {"tar"},
+	".png":   {"png"},
+	".jpg":   {"jpeg"},
+	".jpeg":  {"jpeg"},
+	".gif":   {"gif"},
+	".bmp":   {"bmp"},
+	".webp":  {"webp"},
+	".ico":   {"ico"},
+	".tif":   {"tiff"},
+	".tiff":  {"tiff"},
+	".pdf":   {"pdf"},
+	".docx":  {"ooxml", "zip"},
+	".xlsx":  {"ooxml", "zip"},
+	".pptx":  {"ooxml", "zip"},
+	".doc":   {"ole"},
+	".xls":   {"ole"},
+	".ppt":   {"ole"},
+	".msi":   {"ole"},
+	".pyc":   {"pyc"},
+	".class": {"class"},
+	".wasm":  {"wasm"},
+}
+
+// textExtensions are extensions whose content is expected to be plain text
+var textExtensions = map[string]bool{
+	".txt": true, ".md": true, ".rst": true, ".csv": true, ".log": true,
+	".json": true, ".yml": true, ".yaml": true, ".toml": true, ".ini": true, ".cfg": true, ".conf": true,
+	".xml": true, ".html": true, ".htm": true, ".css": true, ".svg": true,
+	".go": true, ".py": true, ".js": true, ".ts": true, ".jsx": true, ".tsx": true,
+	".java": true, ".kt": true, ".rb": true, ".rs": true, ".php": true, ".cs": true,
+	".c": true, ".h": true, ".cpp": true, ".hpp": true, ".swift": true, ".sql": true,
+	".sh": true, ".bash": true, ".zsh": true, ".ps1": true, ".bat": true, ".cmd": true,
+}
+
+// detectFileType identifies content from its magic number, falling back to a text/binary heuristic
+func detectFileType(content []byte) FileType {
+	switch {
+	case bytes.HasPrefix(content, []byte("\x7fELF")):
+		return FileType{Kind: "elf", Category: FileCategoryExecutable, Description: "ELF executable"}
+	case isPortableExecutable(content):
+		return FileType{Kind: "pe", Category: FileCategoryExecutable, Description: "Windows PE executable"}
+	case isMachO(content):
+		return FileType{Kind: "macho", Category: FileCategoryExecutable, Description: "Mach-O executable"}
+	case bytes.HasPrefix(content, []byte{0xca, 0xfe, 0xba, 0xbe}):
+		return FileType{Kind: "class", Category: FileCategoryBytecode, Description: "Java class file"}
+	case isCompiledPython(content):
+		return FileType{Kind: "pyc", Category: FileCategoryBytecode, Description: "Compiled Python bytecode"}
+	case bytes.HasPrefix(content, []byte("\x00asm")):
+		return FileType{Kind: "wasm", Category: FileCategoryBytecode, Description: "WebAssembly module"}
+	case bytes.HasPrefix(content, []byte("PK\x03\x04")), bytes.HasPrefix(content, []byte("PK\x05\x06")):
+		return detectZipType(content)
+	case bytes.HasPrefix(content, []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}):
+		return FileType{Kind: "ole", Category: FileCategoryDocument, Description: "OLE compound document (legacy Office or MSI)"}
+	case bytes.HasPrefix(content, []byte("%PDF-")):
+		return FileType{Kind: "pdf", Category: FileCategoryDocument, Description: "PDF document"}
+	case bytes.HasPrefix(content, []byte{0x1f, 0x8b}):
+		return FileType{Kind: "gzip", Category: FileCategoryArchive, Description: "gzip archive"}
+	case isBzip2(content):
+		return FileType{Kind: "bzip2", Category: FileCategoryArchive, Description: "bzip2 archive"}
+	case bytes.HasPrefix(content, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}):
+		return FileType{Kind: "xz", Category: FileCategoryArchive, Description: "xz archive"}
+	case bytes.HasPrefix(content, []byte{'7', 'z', 0xbc, 0xaf, 0x27, 0x1c}):
+		return FileType{Kind: "7z", Category: FileCategoryArchive, Description: "7-Zip archive"}
+	case bytes.HasPrefix(content, []byte("Rar!\x1a\x07")):
+		return FileType{Kind: "rar", Category: FileCategoryArchive, Description: "RAR archive"}
+	case len(content) >= 262 && bytes.Equal(content[257:262], []byte("ustar")):
+		return FileType{Kind: "tar", Category: FileCategoryArchive, Description: "tar archive"}
+	case bytes.HasPrefix(content, []byte("\x89PNG\r\n\x1a\n")):
+		return FileType{Kind: "png", Category: FileCategoryImage, Description: "PNG image"}
+	case bytes.HasPrefix(content, []byte{0xff, 0xd8, 0xff}):
+		return FileType{Kind: "jpeg", Category: FileCategoryImage, Description: "JPEG image"}
+	case bytes.HasPrefix(content, []byte("GIF87a")), bytes.HasPrefix(content, []byte("GIF89a")):
+		return FileType{Kind: "gif", Category: FileCategoryImage, Description: "GIF image"}
+	case len(content) >= 12 && bytes.HasPrefix(content, []byte("RIFF")) && bytes.Equal(content[8:12], []byte("WEBP")):
+		return FileType{Kind: "webp", Category: FileCategoryImage, Description: "WebP image"}
+	case isBitmap(content):
+		return FileType{Kind: "bmp", Category: FileCategoryImage, Description: "BMP image"}
+	case bytes.HasPrefix(content, []byte("II*\x00")), bytes.HasPrefix(content, []byte("MM\x00*")):
+		return FileType{Kind: "tiff", Category: FileCategoryImage, Description: "TIFF image"}
+	case len(content) >= 6 && bytes.HasPrefix(content, []byte{0x00, 0x00, 0x01, 0x00}) && content[4] > 0:
+		return FileType{Kind: "ico", Category: FileCategoryImage, Description: "ICO image"}
+	case bytes.HasPrefix(content, []byte("#!")):
+		return FileType{Kind: "script", Category: FileCategoryScript, Description: "Script with interpreter line"}
+	}
+
+	if looksLikeText(content) {
+		return FileType{Kind: "text", Category: FileCategoryText, Description: "Text"}
+	}
+	return FileType{Kind: "binary", Category: FileCategoryBinary, Description: "Unrecognized binary data"}
+}
+
+// isPortableExecutable checks the MZ header and, when it is within reach, the PE signature it points to
+func isPortableExecutable(content []byte) bool {
+	if len(content) < 64 || !bytes.HasPrefix(content, []byte("MZ")) {
+		return false
+	}
+	offset := int(binary.LittleEndian.Uint32(content[0x3c:0x40]))
+	if offset+4 <= len(content) {
+		return bytes.Equal(content[offset:offset+4], []byte("PE\x00\x00"))
+	}
+	// The PE header lies beyond the sniffed prefix; a DOS stub always contains NUL bytes
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+// isMachO recognizes thin Mach-O binaries in either byte order and fat binaries,
+// which share 0xcafebabe with Java class files but carry a small architecture count
+func isMachO(content []byte) bool {
+	if len(content) < 8 {
+		return false
+	}
+	switch binary.BigEndian.Uint32(content[:4]) {
+	case 0xfeedface, 0xfeedfacf, 0xcefaedfe, 0xcffaedfe:
+		return true
+	case 0xcafebabe:
+		return binary.BigEndian.Uint32(content[4:8]) < 30
+	}
+	return false
+}
+
+// isCompiledPython recognizes the two-byte magic number followed by \r\n and a flags field
+func isCompiledPython(content []byte) bool {
+	if len(content) < 16 || content[2] != '\r' || content[3] != '\n' {
+		return false
+	}
+	magic := binary.LittleEndian.Uint16(content[:2])
+	return magic >= 2900 && magic < 4000 && bytes.IndexByte(content[4:16], 0) >= 0
+}
+
+func isBzip2(content []byte) bool {
+	if len(content) < 10 || !bytes.HasPrefix(content, []byte("BZh")) || content[3] < '1' || content[3] > '9' {
+		return false
+	}
+	block := content[4:10]
+	return bytes.Equal(block, []byte{0x31, 0x41, 0x59, 0x26, 0x53, 0x59}) || bytes.Equal(block, []byte{0x17, 0x72, 0x45, 0x38, 0x50, 0x90})
+}
+
+func isBitmap(content []byte) bool {
+	if len(content) < 26 || !bytes.HasPrefix(content, []byte("BM")) {
+		return false
+	}
+	switch binary.LittleEndian.Uint32(content[14:18]) {
+	case 12, 40, 56, 108, 124:
+		return true
+	}
+	return false
+}
+
+// detectZipType tells JARs, APKs and Office Open XML documents apart from plain zip archives
+// by the names of their entries
+func detectZipType(content []byte) FileType {
+	names := zipEntryNames(content)
+	switch {
+	case containsString(names, "AndroidManifest.xml"):
+		return FileType{Kind: "apk", Category: FileCategoryExecutable, Description: "Android package"}
+	case containsString(names, "META-INF/MANIFEST.MF"):
+		return FileType{Kind: "jar", Category: FileCategoryBytecode, Description: "Java archive"}
+	case containsString(names, "[Content_Types].xml"):
+		return FileType{Kind: "ooxml", Category: FileCategoryDocument, Description: "Office Open XML document"}
+	}
+	return FileType{Kind: "zip", Category: FileCategoryArchive, Description: "zip archive"}
+}
+
+// zipEntryNames lists the entries of a zip archive. A sniffed prefix usually lacks the central
+// directory at the end of the archive, so the local file headers it holds are read instead.
+func zipEntryNames(content []byte) []string {
+	var names []string
+	if archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content))); err == nil {
+		for _, file := range archive.File {
+			names = append(names, file.Name)
+		}
+		return names
+	}
+
+	// Local file header: signature, version, flags, method, time, date, crc, sizes, name and extra lengths
+	for offset := 0; offset+30 <= len(content) && bytes.HasPrefix(content[offset:], []byte("PK\x03\x04")); {
+		header := content[offset : offset+30]
+		flags := binary.LittleEndian.Uint16(header[6:])
+		compressed := int(binary.LittleEndian.Uint32(header[18:]))
+		nameLength := int(binary.LittleEndian.Uint16(header[26:]))
+		extraLength := int(binary.LittleEndian.Uint16(header[28:]))
+		if offset+30+nameLength > len(content) {
+			break
+		}
+		names = append(names, string(content[offset+30:offset+30+nameLength]))
+		// With a data descriptor the size follows the data, so the next header cannot be located
+		if flags&0x08 != 0 {
+			break
+		}
+		offset += 30 + nameLength + extraLength + compressed
+	}
+	return names
+}
+
+// looksLikeText applies git's NUL-byte heuristic and rejects content dominated by control characters
+func looksLikeText(content []byte) bool {
+	if bytes.HasPrefix(content, []byte{0xff, 0xfe}) || bytes.HasPrefix(content, []byte{0xfe, 0xff}) {
+		return true
+	}
+	if bytes.IndexByte(content, 0) >= 0 {
+		return false
+	}
+	if len(content) == 0 {
+		return true
+	}
+
+	control := 0
+	for _, b := range content {
+		if b < 0x20 && b != '\n' && b != '\r' && b != '\t' && b != '\f' && b != 0x1b {
+			control++
+		}
+	}
+	if control*10 > len(content) {
+		return false
+	}
+	// Tolerate a multi-byte sequence cut at the end of the sniffed prefix
+	trimmed := content
+	for i := 0; i < utf8.UTFMax-1 && len(trimmed) > 0 && !utf8.Valid(trimmed); i++ {
+		trimmed = trimmed[:len(trimmed)-1]
+	}
+	return utf8.Valid(trimmed) || control == 0
+}
+
+// isContentExecutable reports whether detected content can be run directly or by a runtime
+func isContentExecutable(fileType FileType) bool {
+	return fileType.Category == FileCategoryExecutable || fileType.Category == FileCategoryBytecode
+}
+
+// extensionMismatch reports whether a file's extension contradicts its detected content.
+// Only recognized signatures count; generic text or binary content never produces a mismatch.
+func extensionMismatch(fileExt string, fileType FileType) bool {
+	switch fileType.Category {
+	case FileCategoryText, FileCategoryScript, FileCategoryBinary:
+		return false
+	}
+	if textExtensions[fileExt] {
+		return true
+	}
+	expected, ok := extensionContent[fileExt]
+	if !ok {
+		return false
+	}
+	return !containsString(expected, fileType.Kind)
+}
+
+// contentSeverity rates a file by what its content is
+func contentSeverity(fileType FileType) string {
+	switch fileType.Category {
+	case FileCategoryExecutable:
+		return "critical"
+	case FileCategoryBytecode, FileCategoryArchive, FileCategoryDocument:
+		return "high"
+	}
+	return "medium"
+}
+
+// mismatchDescription explains an extension that lies about its content
+func mismatchDescription(fileExt string, fileType FileType) string {
+	if fileExt == "" {
+		return fmt.Sprintf("Content is %s", fileType.Description)
+	}
+	return fmt.Sprintf("Content is %s but extension is %s", fileType.Description, fileExt)
+}
+
+// sniffFile reads the prefix of a file used for content detection
+func sniffFile(filePath string) []byte {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	buffer := make([]byte, binarySniffSize)
+	n, _ := io.ReadFull(file, buffer)
+	return buffer[:n]
+}
+
+// sniffBlobs streams blobs through git cat-file and hands the prefix of each to fn,
+// discarding the rest so that large historical blobs are never held in memory
+func sniffBlobs(repoPath string, oids []string, fn func(oid string, head []byte)) error {
+	if len(oids) == 0 {
+		return nil
+	}
+
+	cmd := exec.Command("git", "cat-file", "--batch")
+	cmd.Dir = repoPath
+	cmd.Stdin = strings.NewReader(strings.Join(oids, "\n") + "\n")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(stdout)
+	head := make([]byte, binarySniffSize)
+	for {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			break
+		}
+		// <oid> <type> <size>, or "<oid> missing"
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			continue
+		}
+		size, _ := strconv.ParseInt(fields[2], 10, 64)
+		n := int64(len(head))
+		if size < n {
+			n = size
+		}
+		if _, err := io.ReadFull(reader, head[:n]); err != nil {
+			break
+		}
+		// Skip the remainder and the newline that terminates the content
+		if _, err := io.CopyN(io.Discard, reader, size-n+1); err != nil {
+			break
+		}
+		if fields[1] == "blob" {
+			fn(fields[0], head[:n])
+		}
+	}
+	return cmd.Wait()
+}
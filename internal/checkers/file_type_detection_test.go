@@ -0,0 +1,219 @@
+package checkers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// zipArchive builds a zip holding the files, stored in name order
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, name := range names {
+		// Stored entries with their size in the local header, as most archivers write them
+		content := []byte(files[name])
+		entry, err := writer.CreateRaw(&zip.FileHeader{
+			Name:               name,
+			Method:             zip.Store,
+			CRC32:              crc32.ChecksumIEEE(content),
+			CompressedSize64:   uint64(len(content)),
+			UncompressedSize64: uint64(len(content)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := entry.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestDetectFileType(t *testing.T) {
+	pe := make([]byte, 256)
+	copy(pe, "MZ")
+	binary.LittleEndian.PutUint32(pe[0x3c:], 0x80)
+	copy(pe[0x80:], "PE\x00\x00")
+
+	tar := make([]byte, 512)
+	copy(tar, "file.txt")
+	copy(tar[257:], "ustar\x0000")
+
+	bmp := make([]byte, 32)
+	copy(bmp, "BM")
+	binary.LittleEndian.PutUint32(bmp[14:], 40)
+
+	// A jar cut to the sniffed prefix keeps its first local headers but loses the central directory
+	jar := zipArchive(t, map[string]string{"A.class": "\xca\xfe\xba\xbe", "META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n", "z.bin": strings.Repeat("x", 2*binarySniffSize)})
+	truncated := jar[:binarySniffSize]
+
+	tests := []struct {
+		name    string
+		content []byte
+		kind    string
+	}{
+		{"elf", []byte("\x7fELF\x02\x01\x01\x00\x00\x00"), "elf"},
+		{"pe", pe, "pe"},
+		{"text starting with MZ", []byte("MZ is a nice prefix for a line of prose that is definitely long enough to pass\n"), "text"},
+		{"mach-o", []byte{0xcf, 0xfa, 0xed, 0xfe, 0x07, 0x00, 0x00, 0x01}, "macho"},
+		{"mach-o fat", []byte{0xca, 0xfe, 0xba, 0xbe, 0x00, 0x00, 0x00, 0x02}, "macho"},
+		{"java class", []byte{0xca, 0xfe, 0xba, 0xbe, 0x00, 0x00, 0x00, 0x41}, "class"},
+		{"pyc", append([]byte{0xa7, 0x0d, '\r', '\n'}, make([]byte, 12)...), "pyc"},
+		{"zip", []byte("PK\x03\x04\x14\x00\x00\x00data.csv"), "zip"},
+		{"jar", jar, "jar"},
+		{"apk", zipArchive(t, map[string]string{"AndroidManifest.xml": "", "META-INF/MANIFEST.MF": "", "classes.dex": ""}), "apk"},
+		{"docx", zipArchive(t, map[string]string{"[Content_Types].xml": "<Types/>", "word/document.xml": "<w:document/>"}), "ooxml"},
+		{"truncated jar", truncated, "jar"},
+		{"zip naming a manifest", zipArchive(t, map[string]string{"notes.txt": "see META-INF/MANIFEST.MF and AndroidManifest.xml"}), "zip"},
+		{"zip with a nested manifest", zipArchive(t, map[string]string{"vendor/META-INF/MANIFEST.MF": ""}), "zip"},
+		{"ole", []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}, "ole"},
+		{"pdf", []byte("%PDF-1.7\n"), "pdf"},
+		{"gzip", []byte{0x1f, 0x8b, 0x08, 0x00}, "gzip"},
+		{"tar", tar, "tar"},
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"), "png"},
+		{"jpeg", []byte{0xff, 0xd8, 0xff, 0xe0}, "jpeg"},
+		{"bmp", bmp, "bmp"},
+		{"text starting with BM", []byte("BMW and other cars\n"), "text"},
+		{"script", []byte("#!/bin/sh\necho hi\n"), "script"},
+		{"utf-8 text", []byte("héllo wörld\n"), "text"},
+		{"binary", []byte{0x01, 0x02, 0x00, 0x03, 0x04}, "binary"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := detectFileType(test.content).Kind; got != test.kind {
+				t.Errorf("detectFileType() = %q, want %q", got, test.kind)
+			}
+		})
+	}
+}
+
+func TestExtensionMismatch(t *testing.T) {
+	elf := detectFileType([]byte("\x7fELF\x02\x01\x01\x00"))
+	zip := detectFileType([]byte("PK\x03\x04\x14\x00\x00\x00data.csv"))
+	png := detectFileType([]byte("\x89PNG\r\n\x1a\n"))
+	text := detectFileType([]byte("plain text\n"))
+
+	tests := []struct {
+		ext      string
+		content  FileType
+		mismatch bool
+	}{
+		{".txt", elf, true},
+		{".png", zip, true},
+		{".jpg", png, true},
+		{".so", elf, false},
+		{".zip", zip, false},
+		{"", elf, false},
+		{".dat", zip, false},
+		{".png", text, false},
+	}
+	for _, test := range tests {
+		if got := extensionMismatch(test.ext, test.content); got != test.mismatch {
+			t.Errorf("extensionMismatch(%q, %s) = %v, want %v", test.ext, test.content.Kind, got, test.mismatch)
+		}
+	}
+}
+
+func TestBinaryFileCheckerDetectsContentInTreeAndHistory(t *testing.T) {
+	repo := createGitRepository(t)
+	elf := append([]byte("\x7fELF\x02\x01\x01\x00"), make([]byte, 64)...)
+	files := map[string][]byte{
+		"notes.txt":   elf,
+		"README.md":   []byte("# sample\n"),
+		"archive.png": []byte("PK\x03\x04\x14\x00\x00\x00data.csv"),
+		"tool.md":     append(append([]byte{}, elf...), "tool"...),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(repo, name), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "chore: add files")
+	runGit(t, repo, "rm", "-q", "archive.png", "tool.md")
+	runGit(t, repo, "commit", "-qm", "chore: remove archive")
+
+	checker := NewBinaryFileChecker()
+	checker.SetLFSThreshold(0)
+	result := checker.CheckWithOptions(&types.RepositoryData{Path: repo}, true, false, true, true, 10)
+
+	if result.Status != types.StatusFail {
+		t.Fatalf("Status = %v, want fail", result.Status)
+	}
+	details := bytes.NewBufferString("")
+	for _, detail := range result.Details {
+		details.WriteString(detail + "\n")
+	}
+	for _, want := range []string{
+		// Renamed executables are counted once, as executables, in the tree and in history
+		"Executable Files: 2",
+		"Extension Mismatches: 1",
+		"• notes.txt [critical] 0.0 MB - Content is ELF executable but extension is .txt",
+		"• tool.md [critical] 0.0 MB (in history) - Content is ELF executable but extension is .md",
+		"archive.png [high] Content is zip archive but extension is .png (in history)",
+	} {
+		if !bytes.Contains(details.Bytes(), []byte(want)) {
+			t.Errorf("details missing %q:\n%s", want, details.String())
+		}
+	}
+}
+
+func TestBinaryFileCheckerScanCommits(t *testing.T) {
+	repo := createGitRepository(t)
+	base := gitOutput(t, repo, "rev-parse", "HEAD")
+	elf := append([]byte("\x7fELF\x02\x01\x01\x00"), make([]byte, 64)...)
+	if err := os.WriteFile(filepath.Join(repo, "logo.png"), elf, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "notes.md"), []byte("notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "chore: add logo")
+	head := gitOutput(t, repo, "rev-parse", "HEAD")
+
+	// The working tree no longer holds the file; the committed blob is what gets pushed
+	if err := os.Remove(filepath.Join(repo, "logo.png")); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := NewBinaryFileChecker().ScanCommits(repo, []string{head}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A renamed executable is one finding that notes the mismatch, not an executable and a mismatch
+	if len(report.ExecutableFiles) != 1 || len(report.MismatchedFiles) != 0 || report.FileCount != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	if file := report.ExecutableFiles[0]; file.Path != "logo.png" || file.Commit != head || file.ContentType != "elf" || !file.ExtensionMismatch {
+		t.Fatalf("executable finding = %+v", file)
+	}
+
+	report, err = NewBinaryFileChecker().ScanCommits(repo, []string{base}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.FileCount != 0 {
+		t.Fatalf("initial commit findings = %+v", report)
+	}
+}
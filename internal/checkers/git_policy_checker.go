@@ -15,6 +15,9 @@ import (
 // GitPolicyChecker validates Git security policies and configurations
 type GitPolicyChecker struct {
 	BaseChecker
+	policy       *PolicyDocument
+	policyFile   string
+	verification SignatureVerificationOptions
 }
 
 // PolicyViolation represents a security policy violation
@@ -35,6 +38,22 @@ type SignatureStats struct {
 	SignatureRate     float64 `json:"signature_rate"`
 	ValidSignatures   int     `json:"valid_signatures"`
 	InvalidSignatures int     `json:"invalid_signatures"`
+
+	GoodSignatures       int `json:"good_signatures"`
+	BadSignatures        int `json:"bad_signatures"`
+	RevokedSignatures    int `json:"revoked_signatures"`
+	UnknownKeySignatures int `json:"unknown_key_signatures"`
+	ExpiredSignatures    int `json:"expired_signatures"`
+	MismatchedSigners    int `json:"mismatched_signers"`
+}
+
+// GoodSignatureRate is the percentage of commits whose signature verifies; bad, revoked,
+// unknown-key and expired signatures count as unsigned
+func (s SignatureStats) GoodSignatureRate() float64 {
+	if s.TotalCommits == 0 {
+		return 0
+	}
+	return float64(s.GoodSignatures) / float64(s.TotalCommits) * 100
 }
 
 // SensitiveFile represents a detected sensitive file
@@ -49,12 +68,15 @@ type SensitiveFile struct {
 
 // GitPolicyReport represents the complete policy validation report
 type GitPolicyReport struct {
-	Violations       []PolicyViolation `json:"violations"`
-	SignatureStats   SignatureStats    `json:"signature_stats"`
-	SensitiveFiles   []SensitiveFile   `json:"sensitive_files"`
-	PushPolicies     []string          `json:"push_policies"`
-	BranchProtection []string          `json:"branch_protection"`
-	Score            int               `json:"score"`
+	Violations       []PolicyViolation  `json:"violations"`
+	SignatureStats   SignatureStats     `json:"signature_stats"`
+	SensitiveFiles   []SensitiveFile    `json:"sensitive_files"`
+	PushPolicies     []string           `json:"push_policies"`
+	BranchProtection []string           `json:"branch_protection"`
+	SigningKeys      []SigningKey       `json:"signing_keys,omitempty"`
+	PolicyFile       string             `json:"policy_file,omitempty"`
+	RuleResults      []PolicyRuleResult `json:"rule_results,omitempty"`
+	Score            int                `json:"score"`
 }
 
 // NewGitPolicyChecker creates a new GitPolicyChecker
@@ -64,6 +86,37 @@ func NewGitPolicyChecker() *GitPolicyChecker {
 	}
 }
 
+// NewGitPolicyCheckerWithPolicy creates a GitPolicyChecker that evaluates a
+// declarative policy document instead of the built-in thresholds
+func NewGitPolicyCheckerWithPolicy(doc *PolicyDocument, policyFile string) *GitPolicyChecker {
+	checker := NewGitPolicyChecker()
+	checker.policy = doc
+	checker.policyFile = policyFile
+	if checker.policyFile == "" {
+		checker.policyFile = PolicyDocumentFile
+	}
+	return checker
+}
+
+// SetSignatureVerification configures the allowed signers file and GPG keyring used to verify signatures
+func (c *GitPolicyChecker) SetSignatureVerification(options SignatureVerificationOptions) {
+	c.verification = options
+}
+
+// signatureOptions merges explicit verification options with those declared in the policy document
+func (c *GitPolicyChecker) signatureOptions() SignatureVerificationOptions {
+	options := c.verification
+	if c.declaresSigning() {
+		if options.AllowedSignersFile == "" {
+			options.AllowedSignersFile = c.policy.Signing.AllowedSignersFile
+		}
+		if options.GPGHome == "" {
+			options.GPGHome = c.policy.Signing.GPGHome
+		}
+	}
+	return options
+}
+
 // Check performs Git security policy validation
 func (c *GitPolicyChecker) Check(data *types.RepositoryData) *types.CheckResult {
 	return c.CheckWithOptions(data, true, true, true, true, "low")
@@ -71,6 +124,14 @@ func (c *GitPolicyChecker) Check(data *types.RepositoryData) *types.CheckResult
 
 // CheckWithOptions performs selected policy checks and applies a severity threshold.
 func (c *GitPolicyChecker) CheckWithOptions(data *types.RepositoryData, checkSigning, checkFiles, checkPush, checkBranches bool, minSeverity string) *types.CheckResult {
+	result, _ := c.CheckWithReport(data, checkSigning, checkFiles, checkPush, checkBranches, minSeverity)
+	return result
+}
+
+// CheckWithReport performs selected policy checks and also returns the full policy report.
+// When a policy document is configured, its rules replace the built-in push, branch
+// protection and signature rate thresholds.
+func (c *GitPolicyChecker) CheckWithReport(data *types.RepositoryData, checkSigning, checkFiles, checkPush, checkBranches bool, minSeverity string) (*types.CheckResult, *GitPolicyReport) {
 	result := &types.CheckResult{
 		ID:        c.ID(),
 		Name:      c.Name(),
@@ -89,6 +150,7 @@ func (c *GitPolicyChecker) CheckWithOptions(data *types.RepositoryData, checkSig
 		SensitiveFiles:   []SensitiveFile{},
 		PushPolicies:     []string{},
 		BranchProtection: []string{},
+		PolicyFile:       c.policyFile,
 	}
 
 	// Check Git configuration
@@ -104,14 +166,19 @@ func (c *GitPolicyChecker) CheckWithOptions(data *types.RepositoryData, checkSig
 		c.checkSensitiveFiles(data.Path, report)
 	}
 
-	// Check push policies
-	if checkPush {
-		c.checkPushPolicies(data.Path, report)
-	}
+	if c.policy != nil {
+		// Declared rules take the place of the built-in push and branch policies
+		c.evaluatePolicyDocument(data.Path, c.policy, checkSigning, checkFiles, checkPush, checkBranches, report)
+	} else {
+		// Check push policies
+		if checkPush {
+			c.checkPushPolicies(data.Path, report)
+		}
 
-	// Check branch protection
-	if checkBranches {
-		c.checkBranchProtection(data.Path, report)
+		// Check branch protection
+		if checkBranches {
+			c.checkBranchProtection(data.Path, report)
+		}
 	}
 
 	report.Violations = filterPolicyViolations(report.Violations, minSeverity)
@@ -133,11 +200,53 @@ func (c *GitPolicyChecker) CheckWithOptions(data *types.RepositoryData, checkSig
 	// Add detailed information
 	result.Details = append(result.Details, fmt.Sprintf("Total Violations: %d", len(report.Violations)))
 	result.Details = append(result.Details, fmt.Sprintf("Signature Rate: %.1f%%", report.SignatureStats.SignatureRate))
+	if checkSigning {
+		stats := report.SignatureStats
+		result.Details = append(result.Details, fmt.Sprintf("Signatures: %d good, %d bad, %d revoked, %d unknown key, %d expired, %d unsigned",
+			stats.GoodSignatures, stats.BadSignatures, stats.RevokedSignatures, stats.UnknownKeySignatures, stats.ExpiredSignatures, stats.UnsignedCommits))
+		if stats.MismatchedSigners > 0 {
+			result.Details = append(result.Details, fmt.Sprintf("Signer/Committer Mismatches: %d", stats.MismatchedSigners))
+		}
+		for _, key := range report.SigningKeys {
+			result.Details = append(result.Details, fmt.Sprintf("  %s key %s (%s): %d commit(s) by %s",
+				key.Format, key.Key, key.Status, key.Commits, strings.Join(key.AuthorEmails, ", ")))
+		}
+		for _, violation := range report.Violations {
+			if strings.HasPrefix(violation.Type, "signature_") && violation.Type != "signature_policy" {
+				result.Details = append(result.Details, fmt.Sprintf("  [%s] %s", violation.Severity, violation.Description))
+			}
+		}
+	}
 	result.Details = append(result.Details, fmt.Sprintf("Sensitive Files: %d", len(report.SensitiveFiles)))
 	result.Details = append(result.Details, fmt.Sprintf("Push Policies: %d", len(report.PushPolicies)))
 	result.Details = append(result.Details, fmt.Sprintf("Branch Protection: %d", len(report.BranchProtection)))
 
-	return result
+	if c.policy != nil {
+		passed := 0
+		for _, rule := range report.RuleResults {
+			if rule.Passed {
+				passed++
+			}
+		}
+		result.Details = append(result.Details, fmt.Sprintf("Policy Rules (%s): %d/%d passed", filepath.Base(report.PolicyFile), passed, len(report.RuleResults)))
+		for _, rule := range report.RuleResults {
+			status := "PASS"
+			if !rule.Passed {
+				status = "FAIL"
+			}
+			result.Details = append(result.Details, fmt.Sprintf("  [%s] %s (%s): %s", status, rule.Rule, rule.Severity, rule.Message))
+			for i, detail := range rule.Details {
+				if i == 5 {
+					result.Details = append(result.Details, fmt.Sprintf("      ... and %d more", len(rule.Details)-i))
+					break
+				}
+				result.Details = append(result.Details, "      - "+detail)
+			}
+		}
+	}
+
+	report.Score = score
+	return result, report
 }
 
 func filterPolicyViolations(violations []PolicyViolation, minSeverity string) []PolicyViolation {
@@ -242,85 +351,69 @@ func (c *GitPolicyChecker) checkGitConfig(repoPath string, report *GitPolicyRepo
 	}
 }
 
-// checkCommitSignatures checks commit signature verification
+// checkCommitSignatures verifies commit signatures against the allowed signers file and GPG keyring
 func (c *GitPolicyChecker) checkCommitSignatures(repoPath string, report *GitPolicyReport) {
-	// Get commit signature statistics
-	cmd := exec.Command("git", "log", "--pretty=format:%G?%n", "--all")
-	cmd.Dir = repoPath
-	output, err := cmd.Output()
-	if err != nil {
+	signatures, err := verifyCommitSignatures(repoPath, c.signatureOptions())
+	if err != nil || len(signatures) == 0 {
 		return
 	}
 
-	lines := strings.Split(string(output), "\n")
-	totalCommits := 0
-	signedCommits := 0
-	validSignatures := 0
-	invalidSignatures := 0
-
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-		totalCommits++
-
-		switch line {
-		case "G": // Good signature
-			signedCommits++
-			validSignatures++
-		case "B": // Bad signature
-			signedCommits++
-			invalidSignatures++
-		case "U": // Good signature with unknown validity
-			signedCommits++
-		case "X": // Good signature that expired
-			signedCommits++
-		case "Y": // Good signature made by an expired key
-			signedCommits++
-		case "R": // Good signature made by a revoked key
-			signedCommits++
-		case "E": // Signature could not be checked
-			signedCommits++
-		case "N": // No signature
-			// Unsigned commit
-		}
-	}
-
-	report.SignatureStats = SignatureStats{
-		TotalCommits:      totalCommits,
-		SignedCommits:     signedCommits,
-		UnsignedCommits:   totalCommits - signedCommits,
-		SignatureRate:     float64(signedCommits) / float64(totalCommits) * 100,
-		ValidSignatures:   validSignatures,
-		InvalidSignatures: invalidSignatures,
-	}
+	stats := classifySignatures(signatures)
+	report.SignatureStats = stats
+	report.SigningKeys = summarizeSigningKeys(signatures)
 
-	// Add violations based on signature rate
-	if report.SignatureStats.SignatureRate < 50.0 {
+	// Add violations based on signature rate unless a policy document declares its own threshold
+	switch {
+	case c.declaresSigning():
+		// Evaluated by the policy document
+	case stats.SignatureRate < 50.0:
 		report.Violations = append(report.Violations, PolicyViolation{
 			Type:           "signature_policy",
 			Severity:       "high",
-			Description:    fmt.Sprintf("Low signature rate: %.1f%%", report.SignatureStats.SignatureRate),
+			Description:    fmt.Sprintf("Low signature rate: %.1f%%", stats.SignatureRate),
 			Recommendation: "Enable commit signing and require signatures for important commits",
 		})
-	} else if report.SignatureStats.SignatureRate < 80.0 {
+	case stats.SignatureRate < 80.0:
 		report.Violations = append(report.Violations, PolicyViolation{
 			Type:           "signature_policy",
 			Severity:       "medium",
-			Description:    fmt.Sprintf("Moderate signature rate: %.1f%%", report.SignatureStats.SignatureRate),
+			Description:    fmt.Sprintf("Moderate signature rate: %.1f%%", stats.SignatureRate),
 			Recommendation: "Consider enabling commit signing for more commits",
 		})
 	}
 
-	// Check for invalid signatures
-	if invalidSignatures > 0 {
-		report.Violations = append(report.Violations, PolicyViolation{
-			Type:           "signature_validation",
-			Severity:       "high",
-			Description:    fmt.Sprintf("Found %d invalid signatures", invalidSignatures),
-			Recommendation: "Review and fix invalid signatures",
-		})
+	// Bad signatures and unknown, expired, revoked and mismatched signing keys
+	report.Violations = append(report.Violations, signatureViolations(signatures)...)
+}
+
+// classifySignatures counts each commit under exactly one signature status
+func classifySignatures(signatures []CommitSignature) SignatureStats {
+	stats := SignatureStats{TotalCommits: len(signatures)}
+	for _, signature := range signatures {
+		switch signature.Status {
+		case SignatureGood:
+			stats.GoodSignatures++
+			if !signerMatchesCommitter(signature) {
+				stats.MismatchedSigners++
+			}
+		case SignatureBad:
+			stats.BadSignatures++
+		case SignatureRevoked:
+			stats.RevokedSignatures++
+		case SignatureUnknownKey:
+			stats.UnknownKeySignatures++
+		case SignatureExpired:
+			stats.ExpiredSignatures++
+		}
 	}
+	stats.SignedCommits = stats.GoodSignatures + stats.BadSignatures + stats.RevokedSignatures + stats.UnknownKeySignatures + stats.ExpiredSignatures
+	stats.UnsignedCommits = stats.TotalCommits - stats.SignedCommits
+	if stats.TotalCommits > 0 {
+		stats.SignatureRate = float64(stats.SignedCommits) / float64(stats.TotalCommits) * 100
+	}
+	stats.ValidSignatures = stats.GoodSignatures
+	stats.InvalidSignatures = stats.BadSignatures + stats.RevokedSignatures
+	return stats
 }
 
 // checkSensitiveFiles checks for sensitive files in repository
@@ -630,6 +723,11 @@ func (c *GitPolicyChecker) checkBranchProtection(repoPath string, report *GitPol
 	}
 }
 
+// declaresSigning reports whether the policy document overrides the built-in signing thresholds
+func (c *GitPolicyChecker) declaresSigning() bool {
+	return c.policy != nil && c.policy.Signing != nil
+}
+
 // calculateScore calculates security score based on violations
 func (c *GitPolicyChecker) calculateScore(report *GitPolicyReport) int {
 	score := 100
@@ -649,9 +747,12 @@ func (c *GitPolicyChecker) calculateScore(report *GitPolicyReport) int {
 	}
 
 	// Deduct points for low signature rate
-	if report.SignatureStats.SignatureRate < 50.0 {
+	switch {
+	case c.declaresSigning():
+		// Signing requirements are scored through their policy rule violations
+	case report.SignatureStats.SignatureRate < 50.0:
 		score -= 15
-	} else if report.SignatureStats.SignatureRate < 80.0 {
+	case report.SignatureStats.SignatureRate < 80.0:
 		score -= 10
 	}
 
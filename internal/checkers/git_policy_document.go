@@ -0,0 +1,468 @@
+package checkers
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// PolicyDocumentFile is the default name of the declarative policy file
+const PolicyDocumentFile = ".gphc-policy.yml"
+
+// PolicyDocument declares the Git policies a repository must satisfy
+type PolicyDocument struct {
+	Version           int                    `yaml:"version" json:"version"`
+	GitConfig         []GitConfigRule        `yaml:"git_config" json:"git_config,omitempty"`
+	Signing           *SigningRule           `yaml:"signing" json:"signing,omitempty"`
+	ProtectedBranches *ProtectedBranchesRule `yaml:"protected_branches" json:"protected_branches,omitempty"`
+	ForbiddenFiles    []ForbiddenFileRule    `yaml:"forbidden_files" json:"forbidden_files,omitempty"`
+}
+
+// GitConfigRule requires a git config key to hold (or avoid) specific values
+type GitConfigRule struct {
+	Key       string   `yaml:"key" json:"key"`
+	Value     string   `yaml:"value" json:"value,omitempty"`
+	OneOf     []string `yaml:"one_of" json:"one_of,omitempty"`
+	Forbidden []string `yaml:"forbidden" json:"forbidden,omitempty"`
+	Severity  string   `yaml:"severity" json:"severity"`
+}
+
+// SigningRule declares commit signing requirements
+type SigningRule struct {
+	RequiredPercentage float64  `yaml:"required_percentage" json:"required_percentage"`
+	AllowedKeyIDs      []string `yaml:"allowed_key_ids" json:"allowed_key_ids,omitempty"`
+	AllowedSignersFile string   `yaml:"allowed_signers_file" json:"allowed_signers_file,omitempty"`
+	GPGHome            string   `yaml:"gpg_home" json:"gpg_home,omitempty"`
+	Severity           string   `yaml:"severity" json:"severity"`
+}
+
+// ProtectedBranchesRule lists branch name patterns that must carry protection rules.
+// Pushes may not rewrite or delete them unless the pusher's user.email is allowed.
+type ProtectedBranchesRule struct {
+	Patterns []string `yaml:"patterns" json:"patterns"`
+	// AllowedPushers are the emails allowed to force-push to or delete a protected branch
+	AllowedPushers []string `yaml:"allowed_pushers" json:"allowed_pushers,omitempty"`
+	Severity       string   `yaml:"severity" json:"severity"`
+}
+
+// ForbiddenFileRule forbids tracked files matching a glob
+type ForbiddenFileRule struct {
+	Pattern        string `yaml:"pattern" json:"pattern"`
+	Severity       string `yaml:"severity" json:"severity"`
+	Description    string `yaml:"description" json:"description,omitempty"`
+	IncludeHistory bool   `yaml:"include_history" json:"include_history,omitempty"`
+}
+
+// PolicyRuleResult is the outcome of evaluating a single policy document rule
+type PolicyRuleResult struct {
+	Rule     string   `json:"rule" yaml:"rule"`
+	Type     string   `json:"type" yaml:"type"`
+	Severity string   `json:"severity" yaml:"severity"`
+	Passed   bool     `json:"passed" yaml:"passed"`
+	Message  string   `json:"message" yaml:"message"`
+	Details  []string `json:"details,omitempty" yaml:"details,omitempty"`
+}
+
+// LoadPolicyDocument reads and validates a policy document
+func LoadPolicyDocument(path string) (*PolicyDocument, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc PolicyDocument
+	if err := yaml.UnmarshalStrict(content, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", filepath.Base(path), err)
+	}
+	return &doc, nil
+}
+
+// FindPolicyDocument loads the repository policy document if one exists.
+// It returns a nil document and empty path when the repository has none.
+func FindPolicyDocument(repoPath string) (*PolicyDocument, string, error) {
+	path := filepath.Join(repoPath, PolicyDocumentFile)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	doc, err := LoadPolicyDocument(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return doc, path, nil
+}
+
+// Validate checks the document for rules that cannot be evaluated
+func (d *PolicyDocument) Validate() error {
+	if d.Version != 0 && d.Version != 1 {
+		return fmt.Errorf("unsupported version %d", d.Version)
+	}
+	for i, rule := range d.GitConfig {
+		if strings.TrimSpace(rule.Key) == "" {
+			return fmt.Errorf("git_config[%d]: key is required", i)
+		}
+		if rule.Value == "" && len(rule.OneOf) == 0 && len(rule.Forbidden) == 0 {
+			return fmt.Errorf("git_config[%d] (%s): one of value, one_of or forbidden is required", i, rule.Key)
+		}
+		if err := validatePolicySeverity(rule.Severity); err != nil {
+			return fmt.Errorf("git_config[%d] (%s): %w", i, rule.Key, err)
+		}
+	}
+	if d.Signing != nil {
+		if d.Signing.RequiredPercentage < 0 || d.Signing.RequiredPercentage > 100 {
+			return fmt.Errorf("signing: required_percentage must be between 0 and 100")
+		}
+		if err := validatePolicySeverity(d.Signing.Severity); err != nil {
+			return fmt.Errorf("signing: %w", err)
+		}
+	}
+	if d.ProtectedBranches != nil {
+		if len(d.ProtectedBranches.Patterns) == 0 {
+			return fmt.Errorf("protected_branches: at least one pattern is required")
+		}
+		if err := validatePolicySeverity(d.ProtectedBranches.Severity); err != nil {
+			return fmt.Errorf("protected_branches: %w", err)
+		}
+	}
+	for i, rule := range d.ForbiddenFiles {
+		if strings.TrimSpace(rule.Pattern) == "" {
+			return fmt.Errorf("forbidden_files[%d]: pattern is required", i)
+		}
+		if err := validatePolicySeverity(rule.Severity); err != nil {
+			return fmt.Errorf("forbidden_files[%d] (%s): %w", i, rule.Pattern, err)
+		}
+	}
+	return nil
+}
+
+func validatePolicySeverity(severity string) error {
+	if severity == "" || policySeverityLevel(severity) > 0 {
+		return nil
+	}
+	return fmt.Errorf("unknown severity %q", severity)
+}
+
+// policyRuleSeverity returns the declared severity, defaulting to medium
+func policyRuleSeverity(severity string) string {
+	if severity == "" {
+		return "medium"
+	}
+	return strings.ToLower(severity)
+}
+
+// evaluatePolicyDocument evaluates every rule of the document that is enabled by the given options
+func (c *GitPolicyChecker) evaluatePolicyDocument(repoPath string, doc *PolicyDocument, checkSigning, checkFiles, checkPush, checkBranches bool, report *GitPolicyReport) {
+	if checkPush {
+		for _, rule := range doc.GitConfig {
+			report.RuleResults = append(report.RuleResults, c.evaluateGitConfigRule(repoPath, rule))
+		}
+	}
+	if checkSigning && doc.Signing != nil {
+		report.RuleResults = append(report.RuleResults, c.evaluateSigningRules(doc.Signing, report.SignatureStats, report.SigningKeys)...)
+	}
+	if checkBranches && doc.ProtectedBranches != nil {
+		report.RuleResults = append(report.RuleResults, c.evaluateProtectedBranchesRule(repoPath, doc.ProtectedBranches))
+	}
+	if checkFiles {
+		for _, rule := range doc.ForbiddenFiles {
+			report.RuleResults = append(report.RuleResults, c.evaluateForbiddenFileRule(repoPath, rule))
+		}
+	}
+
+	policyFile := filepath.Base(report.PolicyFile)
+	for _, rule := range report.RuleResults {
+		if rule.Passed {
+			continue
+		}
+		report.Violations = append(report.Violations, PolicyViolation{
+			Type:           "policy_" + rule.Type,
+			Severity:       rule.Severity,
+			Description:    rule.Message,
+			File:           policyFile,
+			Recommendation: fmt.Sprintf("Bring the repository in line with rule %q", rule.Rule),
+		})
+	}
+}
+
+func (c *GitPolicyChecker) evaluateGitConfigRule(repoPath string, rule GitConfigRule) PolicyRuleResult {
+	result := PolicyRuleResult{
+		Rule:     "git_config:" + rule.Key,
+		Type:     "git_config",
+		Severity: policyRuleSeverity(rule.Severity),
+	}
+
+	cmd := exec.Command("git", "config", "--get", rule.Key)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	value := strings.TrimSpace(string(output))
+	set := err == nil
+
+	switch {
+	case rule.Value != "" && value != rule.Value:
+		result.Message = fmt.Sprintf("%s must be %q (found %s)", rule.Key, rule.Value, describeConfigValue(value, set))
+	case len(rule.OneOf) > 0 && !containsFold(rule.OneOf, value):
+		result.Message = fmt.Sprintf("%s must be one of %s (found %s)", rule.Key, strings.Join(rule.OneOf, ", "), describeConfigValue(value, set))
+	case set && containsFold(rule.Forbidden, value):
+		result.Message = fmt.Sprintf("%s must not be %q", rule.Key, value)
+	default:
+		result.Passed = true
+		result.Message = fmt.Sprintf("%s is %s", rule.Key, describeConfigValue(value, set))
+	}
+	return result
+}
+
+func describeConfigValue(value string, set bool) string {
+	if !set {
+		return "unset"
+	}
+	return fmt.Sprintf("%q", value)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *GitPolicyChecker) evaluateSigningRules(rule *SigningRule, stats SignatureStats, signingKeys []SigningKey) []PolicyRuleResult {
+	severity := policyRuleSeverity(rule.Severity)
+	var results []PolicyRuleResult
+
+	// Only signatures that verify count towards the required percentage
+	goodRate := stats.GoodSignatureRate()
+	rate := PolicyRuleResult{
+		Rule:     "signing:required_percentage",
+		Type:     "signing",
+		Severity: severity,
+		Passed:   stats.TotalCommits == 0 || goodRate >= rule.RequiredPercentage,
+	}
+	if stats.TotalCommits == 0 {
+		rate.Message = "No commits to evaluate"
+	} else if rate.Passed {
+		rate.Message = fmt.Sprintf("Good signature rate %.1f%% meets the required %.1f%%", goodRate, rule.RequiredPercentage)
+	} else {
+		rate.Message = fmt.Sprintf("Good signature rate %.1f%% is below the required %.1f%%", goodRate, rule.RequiredPercentage)
+	}
+	results = append(results, rate)
+
+	if len(rule.AllowedKeyIDs) == 0 {
+		return results
+	}
+
+	keys := PolicyRuleResult{
+		Rule:     "signing:allowed_key_ids",
+		Type:     "signing",
+		Severity: severity,
+		Passed:   true,
+	}
+	disallowed := 0
+	for _, key := range signingKeys {
+		if signingKeyAllowed(key.Key, rule.AllowedKeyIDs) {
+			continue
+		}
+		disallowed += key.Commits
+		keys.Details = append(keys.Details, fmt.Sprintf("key %s signed %d commit(s) by %s", displaySigningKey(key.Key), key.Commits, strings.Join(key.AuthorEmails, ", ")))
+	}
+	if disallowed > 0 {
+		keys.Passed = false
+		keys.Message = fmt.Sprintf("%d signed commit(s) use keys outside the allowed list", disallowed)
+	} else {
+		keys.Message = "All signed commits use allowed keys"
+	}
+	return append(results, keys)
+}
+
+// signingKeyAllowed matches short key IDs against long IDs and fingerprints
+func signingKeyAllowed(key string, allowed []string) bool {
+	key = strings.ToUpper(strings.TrimSpace(key))
+	if key == "" {
+		return false
+	}
+	for _, candidate := range allowed {
+		candidate = strings.ToUpper(strings.TrimSpace(candidate))
+		if candidate == "" {
+			continue
+		}
+		if strings.HasSuffix(key, candidate) || strings.HasSuffix(candidate, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func displaySigningKey(key string) string {
+	if key == "" {
+		return "(unknown)"
+	}
+	return key
+}
+
+func (c *GitPolicyChecker) evaluateProtectedBranchesRule(repoPath string, rule *ProtectedBranchesRule) PolicyRuleResult {
+	result := PolicyRuleResult{
+		Rule:     "protected_branches",
+		Type:     "branch_protection",
+		Severity: policyRuleSeverity(rule.Severity),
+		Passed:   true,
+	}
+
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		result.Passed = false
+		result.Message = "Could not list branches: " + err.Error()
+		return result
+	}
+	remotes := policyRemotes(repoPath)
+
+	seen := make(map[string]bool)
+	matched := 0
+	for _, ref := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		branch := policyBranchName(ref, remotes)
+		if branch == "" || branch == "HEAD" || seen[branch] {
+			continue
+		}
+		seen[branch] = true
+		if !branchMatchesAny(branch, rule.Patterns) {
+			continue
+		}
+		matched++
+
+		protection := exec.Command("git", "config", "--get", fmt.Sprintf("branch.%s.protection", branch))
+		protection.Dir = repoPath
+		if err := protection.Run(); err != nil {
+			result.Passed = false
+			result.Details = append(result.Details, fmt.Sprintf("%s has no protection rules", branch))
+		}
+	}
+
+	switch {
+	case !result.Passed:
+		result.Message = fmt.Sprintf("%d of %d protected branch(es) lack protection rules", len(result.Details), matched)
+	case matched == 0:
+		result.Message = "No branches match the protected branch patterns"
+	default:
+		result.Message = fmt.Sprintf("All %d protected branch(es) have protection rules", matched)
+	}
+	return result
+}
+
+// policyRemotes lists the configured remotes, longest first so a remote named "a/b" wins over "a"
+func policyRemotes(repoPath string) []string {
+	cmd := exec.Command("git", "remote")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	remotes := strings.Fields(string(output))
+	sort.Slice(remotes, func(i, j int) bool { return len(remotes[i]) > len(remotes[j]) })
+	return remotes
+}
+
+// policyBranchName turns a full ref into a branch name, stripping the remote from remote-tracking
+// branches; a remote that is not configured any more is taken to be the first path component
+func policyBranchName(ref string, remotes []string) string {
+	ref = strings.TrimSpace(ref)
+	if branch, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+		return branch
+	}
+	tracking, ok := strings.CutPrefix(ref, "refs/remotes/")
+	if !ok {
+		return ref
+	}
+	for _, remote := range remotes {
+		if branch, ok := strings.CutPrefix(tracking, remote+"/"); ok {
+			return branch
+		}
+	}
+	if _, branch, ok := strings.Cut(tracking, "/"); ok {
+		return branch
+	}
+	return ""
+}
+
+func branchMatchesAny(branch string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, branch); matched || pattern == branch {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *GitPolicyChecker) evaluateForbiddenFileRule(repoPath string, rule ForbiddenFileRule) PolicyRuleResult {
+	result := PolicyRuleResult{
+		Rule:     "forbidden_files:" + rule.Pattern,
+		Type:     "forbidden_file",
+		Severity: policyRuleSeverity(rule.Severity),
+		Passed:   true,
+	}
+
+	tracked := gitPathList(repoPath, "ls-files", "-z")
+	for _, file := range tracked {
+		if matchGitPattern(rule.Pattern, file) {
+			result.Details = append(result.Details, file)
+		}
+	}
+	if rule.IncludeHistory {
+		seen := make(map[string]bool, len(result.Details))
+		for _, file := range result.Details {
+			seen[file] = true
+		}
+		for _, file := range gitPathList(repoPath, "log", "-z", "--all", "--name-only", "--pretty=format:") {
+			if !seen[file] && matchGitPattern(rule.Pattern, file) {
+				seen[file] = true
+				result.Details = append(result.Details, file+" (history)")
+			}
+		}
+	}
+
+	if len(result.Details) > 0 {
+		result.Passed = false
+		description := rule.Description
+		if description == "" {
+			description = "Forbidden files"
+		}
+		result.Message = fmt.Sprintf("%s matching %s: %d found", description, rule.Pattern, len(result.Details))
+	} else {
+		result.Message = fmt.Sprintf("No files match %s", rule.Pattern)
+	}
+	return result
+}
+
+// gitPathList runs a git command that prints NUL-terminated paths (-z) and returns the unique paths;
+// unlike line output, -z leaves non-ASCII and otherwise quoted paths as they are
+func gitPathList(repoPath string, args ...string) []string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var paths []string
+	for _, path := range strings.Split(string(output), "\x00") {
+		// The empty --pretty=format: header leaves a blank record before each commit's paths
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+	return paths
+}
@@ -0,0 +1,274 @@
+package checkers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestGitPolicyCheckerEvaluatesPolicyDocument(t *testing.T) {
+	repo := createGitRepository(t)
+	runGit(t, repo, "config", "push.default", "simple")
+	if err := os.MkdirAll(filepath.Join(repo, "certs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "certs", "server.pem"), []byte("cert\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "chore: add certificate")
+
+	policy := `version: 1
+git_config:
+  - key: push.default
+    one_of: [simple, current]
+  - key: receive.denyNonFastForwards
+    value: "true"
+    severity: high
+forbidden_files:
+  - pattern: "*.pem"
+    severity: critical
+`
+	policyPath := filepath.Join(repo, PolicyDocumentFile)
+	if err := os.WriteFile(policyPath, []byte(policy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, path, err := FindPolicyDocument(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc == nil || path != policyPath {
+		t.Fatalf("FindPolicyDocument() = %v, %q, want document at %q", doc, path, policyPath)
+	}
+
+	result, report := NewGitPolicyCheckerWithPolicy(doc, path).CheckWithReport(&types.RepositoryData{Path: repo}, false, true, true, false, "low")
+	if result.Status != types.StatusFail {
+		t.Fatalf("status = %v, want fail", result.Status)
+	}
+
+	want := map[string]bool{
+		"git_config:push.default":                true,
+		"git_config:receive.denyNonFastForwards": false,
+		"forbidden_files:*.pem":                  false,
+	}
+	if len(report.RuleResults) != len(want) {
+		t.Fatalf("got %d rule results, want %d: %+v", len(report.RuleResults), len(want), report.RuleResults)
+	}
+	for _, rule := range report.RuleResults {
+		passed, ok := want[rule.Rule]
+		if !ok {
+			t.Errorf("unexpected rule %q", rule.Rule)
+			continue
+		}
+		if rule.Passed != passed {
+			t.Errorf("rule %q passed = %v, want %v (%s)", rule.Rule, rule.Passed, passed, rule.Message)
+		}
+	}
+}
+
+func TestEvaluatePushPolicy(t *testing.T) {
+	repo := createGitRepository(t)
+	base := gitOutput(t, repo, "rev-parse", "HEAD")
+	if err := os.WriteFile(filepath.Join(repo, "server.pem"), []byte("key\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "feat: add key")
+	head := gitOutput(t, repo, "rev-parse", "HEAD")
+	// A commit the pushed history does not contain, as after a rebase
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "chore: diverged")
+	diverged := gitOutput(t, repo, "rev-parse", "HEAD")
+	runGit(t, repo, "reset", "-q", "--hard", head)
+	zero := strings.Repeat("0", 40)
+
+	doc := &PolicyDocument{
+		ProtectedBranches: &ProtectedBranchesRule{Patterns: []string{"main", "release/*"}},
+		ForbiddenFiles:    []ForbiddenFileRule{{Pattern: "*.pem", Severity: "critical"}, {Pattern: "*.env"}},
+	}
+	results, err := EvaluatePushPolicy(repo, doc, []PushedRef{
+		{RemoteRef: "refs/heads/main", LocalOID: head, RemoteOID: base},
+		{RemoteRef: "refs/heads/release/1.0", LocalOID: head, RemoteOID: zero},
+	}, []string{head})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("results = %+v", results)
+	}
+	if !results[0].Passed {
+		t.Fatalf("fast-forwarding and creating protected branches should pass: %+v", results[0])
+	}
+	if results[1].Passed || results[1].Severity != "critical" || results[1].Details[0] != "server.pem (commit "+types.ShortHash(head)+")" {
+		t.Fatalf("forbidden file result = %+v", results[1])
+	}
+	if !results[2].Passed {
+		t.Fatalf("unmatched pattern should pass: %+v", results[2])
+	}
+
+	rewrites := []PushedRef{
+		{RemoteRef: "refs/heads/main", LocalOID: head, RemoteOID: diverged},
+		{RemoteRef: "refs/heads/release/1.0", LocalOID: zero, RemoteOID: head},
+		{RemoteRef: "refs/heads/feature", LocalOID: head, RemoteOID: diverged},
+	}
+	results, err = EvaluatePushPolicy(repo, doc, rewrites, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Passed || len(results[0].Details) != 2 || results[0].Details[0] != "main (force-push)" || results[0].Details[1] != "release/1.0 (deleted)" {
+		t.Fatalf("force-push and deletion of protected branches = %+v", results[0])
+	}
+	if !results[1].Passed {
+		t.Fatalf("push without commits = %+v", results)
+	}
+
+	// Allowed pushers may rewrite protected branches
+	runGit(t, repo, "config", "user.email", "Release@Example.com")
+	doc.ProtectedBranches.AllowedPushers = []string{"release@example.com"}
+	results, err = EvaluatePushPolicy(repo, doc, rewrites, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Passed {
+		t.Fatalf("allowed pusher = %+v", results[0])
+	}
+}
+
+func TestEvaluatePushPolicyQuotedPaths(t *testing.T) {
+	repo := createGitRepository(t)
+	write := func(name string) {
+		t.Helper()
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("secrets/clé.pem")
+	write("my key.pem")
+	write("notes.txt")
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "feat: add keys")
+	first := gitOutput(t, repo, "rev-parse", "HEAD")
+	runGit(t, repo, "mv", "notes.txt", "old notes.pem")
+	runGit(t, repo, "commit", "-qm", "chore: rename notes")
+	second := gitOutput(t, repo, "rev-parse", "HEAD")
+
+	files, err := commitChangedFiles(repo, []string{first, second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := map[string]bool{}
+	for _, file := range files {
+		paths[file.path] = true
+	}
+	for _, want := range []string{"secrets/clé.pem", "my key.pem", "old notes.pem"} {
+		if !paths[want] {
+			t.Errorf("missing %q in %+v", want, files)
+		}
+	}
+
+	doc := &PolicyDocument{ForbiddenFiles: []ForbiddenFileRule{{Pattern: "*.pem"}}}
+	results, err := EvaluatePushPolicy(repo, doc, []PushedRef{{RemoteRef: "refs/heads/feature", LocalOID: second}}, []string{first, second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Passed || len(results[0].Details) != 3 {
+		t.Fatalf("every .pem file should be caught: %+v", results)
+	}
+}
+
+func TestForbiddenFileRuleNonASCIIPaths(t *testing.T) {
+	repo := createGitRepository(t)
+	for _, name := range []string{"clé.pem", "old key.pem"} {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte("key\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "chore: add keys")
+	runGit(t, repo, "rm", "-q", "old key.pem")
+	runGit(t, repo, "commit", "-qm", "chore: remove old key")
+
+	// Line output would quote clé.pem as "cl\303\251.pem", which no longer matches *.pem
+	result := NewGitPolicyChecker().evaluateForbiddenFileRule(repo, ForbiddenFileRule{Pattern: "*.pem", IncludeHistory: true})
+	want := []string{"clé.pem", "old key.pem (history)"}
+	if result.Passed || strings.Join(result.Details, "|") != strings.Join(want, "|") {
+		t.Errorf("forbidden file details = %q, want %q", result.Details, want)
+	}
+}
+
+func TestLoadPolicyDocumentRejectsInvalidRules(t *testing.T) {
+	cases := map[string]string{
+		"unknown field":    "git_config:\n  - key: push.default\n    valeu: simple\n",
+		"missing value":    "git_config:\n  - key: push.default\n",
+		"bad severity":     "forbidden_files:\n  - pattern: '*.pem'\n    severity: urgent\n",
+		"bad percentage":   "signing:\n  required_percentage: 120\n",
+		"no branch target": "protected_branches:\n  patterns: []\n",
+	}
+	for name, content := range cases {
+		path := filepath.Join(t.TempDir(), PolicyDocumentFile)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadPolicyDocument(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMatchGitPattern(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.pem", "certs/server.pem", true},
+		{"/*.pem", "certs/server.pem", false},
+		{"certs/*.pem", "certs/server.pem", true},
+		{"secrets/**", "secrets/prod/key.txt", true},
+		{"**/id_rsa", "home/.ssh/id_rsa", true},
+		{"docs/", "docs/readme.md", true},
+		{"*.pem", "server.pem.txt", false},
+	}
+	for _, tc := range cases {
+		if got := matchGitPattern(tc.pattern, tc.path); got != tc.want {
+			t.Errorf("matchGitPattern(%q, %q) = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestPolicyBranchName(t *testing.T) {
+	remotes := []string{"fork/alice", "gitlab", "origin"}
+	for ref, want := range map[string]string{
+		"refs/heads/main":                     "main",
+		"refs/heads/origin/main":              "origin/main",
+		"refs/remotes/origin/release/1.0":     "release/1.0",
+		"refs/remotes/gitlab/main":            "main",
+		"refs/remotes/fork/alice/release/2.0": "release/2.0",
+		"refs/remotes/removed/main":           "main",
+		"refs/remotes/origin/HEAD":            "HEAD",
+	} {
+		if got := policyBranchName(ref, remotes); got != want {
+			t.Errorf("policyBranchName(%s) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestSigningRuleCountsGoodSignaturesOnly(t *testing.T) {
+	checker := &GitPolicyChecker{}
+	rule := &SigningRule{RequiredPercentage: 50}
+	invalid := SignatureStats{TotalCommits: 4, SignedCommits: 4, SignatureRate: 100, BadSignatures: 1, UnknownKeySignatures: 2, ExpiredSignatures: 1}
+	if results := checker.evaluateSigningRules(rule, invalid, nil); results[0].Passed {
+		t.Errorf("invalid signatures should not meet the required percentage: %s", results[0].Message)
+	}
+	good := SignatureStats{TotalCommits: 4, SignedCommits: 3, GoodSignatures: 2, BadSignatures: 1}
+	if results := checker.evaluateSigningRules(rule, good, nil); !results[0].Passed {
+		t.Errorf("two good signatures of four meet 50%%: %s", results[0].Message)
+	}
+}
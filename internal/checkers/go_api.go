@@ -0,0 +1,432 @@
+package checkers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// API change kinds
+const (
+	APIAdded   = "added"
+	APIRemoved = "removed"
+	APIChanged = "changed"
+)
+
+// APIChange is a change to an exported identifier between two releases
+type APIChange struct {
+	// Package is the import path relative to the module, "." for its root package
+	Package string `json:"package"`
+	// Name is the identifier, Type.Member for fields and methods, or empty for a whole package
+	Name     string `json:"name,omitempty"`
+	Change   string `json:"change"`
+	Breaking bool   `json:"breaking"`
+	Old      string `json:"old,omitempty"`
+	New      string `json:"new,omitempty"`
+}
+
+// QualifiedName names the identifier with its package; identifiers of the root package go unqualified
+func (c APIChange) QualifiedName() string {
+	switch {
+	case c.Name == "":
+		return c.Package
+	case c.Package == ".":
+		return c.Name
+	}
+	return c.Package + "." + c.Name
+}
+
+// goAPI maps each package, relative to its module, to its exported identifiers and their descriptions
+type goAPI map[string]map[string]string
+
+// API descriptions are computed for linux/amd64, the platform pkg.go.dev documents
+var (
+	apiGOOS   = "linux"
+	apiGOARCH = "amd64"
+	knownGOOS = map[string]bool{"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+		"hurd": true, "illumos": true, "ios": true, "js": true, "linux": true, "netbsd": true, "openbsd": true,
+		"plan9": true, "solaris": true, "wasip1": true, "windows": true, "zos": true}
+	knownGOARCH = map[string]bool{"386": true, "amd64": true, "arm": true, "arm64": true, "loong64": true,
+		"mips": true, "mipsle": true, "mips64": true, "mips64le": true, "ppc64": true, "ppc64le": true,
+		"riscv64": true, "s390x": true, "wasm": true}
+)
+
+// moduleAPI type-checks the packages of the module in dir at the revision and returns its exported API.
+// Packages of the module and the standard library are resolved; other dependencies are stand-ins,
+// so types from them compare equal on both sides.
+func moduleAPI(repoPath, rev, dir, modulePath string) (goAPI, error) {
+	args := []string{"ls-tree", "-r", "-z", "--name-only", rev}
+	if dir != "." {
+		args = append(args, "--", dir)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list files of %s: %w", rev, err)
+	}
+
+	var all, nested []string
+	for _, file := range strings.Split(string(output), "\x00") {
+		if file == "" {
+			continue
+		}
+		all = append(all, file)
+		if path.Base(file) == "go.mod" && path.Dir(file) != dir {
+			nested = append(nested, path.Dir(file)+"/")
+		}
+	}
+	var sources []string
+	for _, file := range all {
+		relative := strings.TrimPrefix(file, dir+"/")
+		if dir == "." {
+			relative = file
+		}
+		if !strings.HasSuffix(file, ".go") || strings.HasSuffix(file, "_test.go") || !apiSourcePath(relative) {
+			continue
+		}
+		inNested := false
+		for _, prefix := range nested {
+			inNested = inNested || strings.HasPrefix(file, prefix)
+		}
+		if !inNested {
+			sources = append(sources, file)
+		}
+	}
+	contents, err := readRevisionFiles(repoPath, rev, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	loader := &moduleImporter{
+		fset:     fset,
+		files:    make(map[string][]*ast.File),
+		packages: make(map[string]*types.Package),
+		loading:  make(map[string]bool),
+		std:      importer.ForCompiler(fset, "source", nil),
+	}
+	for _, file := range sources {
+		if !goFileMatches(file, contents[file]) {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file, contents[file], parser.SkipObjectResolution)
+		if err != nil || parsed.Name.Name == "main" || strings.HasSuffix(parsed.Name.Name, "_test") {
+			continue
+		}
+		relative := path.Dir(strings.TrimPrefix(file, dir+"/"))
+		if dir == "." {
+			relative = path.Dir(file)
+		}
+		importPath := modulePath
+		if relative != "." {
+			importPath += "/" + relative
+		}
+		loader.files[importPath] = append(loader.files[importPath], parsed)
+	}
+
+	qualifier := func(pkg *types.Package) string {
+		if pkg.Path() == modulePath || strings.HasPrefix(pkg.Path(), modulePath+"/") {
+			return "~" + strings.TrimPrefix(pkg.Path(), modulePath)
+		}
+		return pkg.Path()
+	}
+	api := make(goAPI)
+	for importPath := range loader.files {
+		relative := "."
+		if importPath != modulePath {
+			relative = strings.TrimPrefix(importPath, modulePath+"/")
+		}
+		if containsString(strings.Split(relative, "/"), "internal") {
+			continue
+		}
+		pkg, _ := loader.Import(importPath)
+		if pkg != nil {
+			api[relative] = packageAPI(pkg, qualifier)
+		}
+	}
+	return api, nil
+}
+
+// apiSourcePath reports whether a path inside a module holds public sources: testdata, vendor and
+// directories starting with . or _ are ignored by the go command
+func apiSourcePath(relative string) bool {
+	for _, element := range strings.Split(path.Dir(relative), "/") {
+		if element == "testdata" || element == "vendor" || strings.HasPrefix(element, ".") && element != "." || strings.HasPrefix(element, "_") {
+			return false
+		}
+	}
+	base := path.Base(relative)
+	return !strings.HasPrefix(base, ".") && !strings.HasPrefix(base, "_")
+}
+
+// goFileMatches applies the file name and //go:build constraints for linux/amd64
+func goFileMatches(name string, content []byte) bool {
+	parts := strings.Split(strings.TrimSuffix(path.Base(name), ".go"), "_")
+	if n := len(parts); n >= 2 {
+		last := parts[n-1]
+		switch {
+		case knownGOARCH[last]:
+			if last != apiGOARCH || n >= 3 && knownGOOS[parts[n-2]] && parts[n-2] != apiGOOS {
+				return false
+			}
+		case knownGOOS[last]:
+			if last != apiGOOS {
+				return false
+			}
+		}
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		if !constraint.IsGoBuild(line) {
+			continue
+		}
+		expression, err := constraint.Parse(line)
+		if err != nil {
+			continue
+		}
+		return expression.Eval(func(tag string) bool {
+			return tag == apiGOOS || tag == apiGOARCH || tag == "unix" || tag == "gc" || strings.HasPrefix(tag, "go1.")
+		})
+	}
+	return true
+}
+
+// readRevisionFiles reads files at a revision with one git cat-file process
+func readRevisionFiles(repoPath, rev string, files []string) (map[string][]byte, error) {
+	contents := make(map[string][]byte)
+	if len(files) == 0 {
+		return contents, nil
+	}
+	var input strings.Builder
+	for _, file := range files {
+		input.WriteString(rev + ":" + file + "\n")
+	}
+	cmd := exec.Command("git", "cat-file", "--batch")
+	cmd.Dir = repoPath
+	cmd.Stdin = strings.NewReader(input.String())
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("read files of %s: %w", rev, err)
+	}
+	reader := bufio.NewReader(bytes.NewReader(output))
+	for _, file := range files {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("read files of %s: %w", rev, err)
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			// "<object> missing"
+			continue
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("read files of %s: unexpected header %q", rev, header)
+		}
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, content); err != nil {
+			return nil, fmt.Errorf("read files of %s: %w", rev, err)
+		}
+		contents[file] = content[:size]
+	}
+	return contents, nil
+}
+
+// moduleImporter type-checks the module's packages on demand, resolves the standard library from
+// source and stands in empty packages for other dependencies
+type moduleImporter struct {
+	fset     *token.FileSet
+	files    map[string][]*ast.File
+	packages map[string]*types.Package
+	loading  map[string]bool
+	std      types.Importer
+}
+
+func (m *moduleImporter) Import(importPath string) (*types.Package, error) {
+	if pkg, ok := m.packages[importPath]; ok {
+		return pkg, nil
+	}
+	files, ok := m.files[importPath]
+	if !ok {
+		var pkg *types.Package
+		if first, _, _ := strings.Cut(importPath, "/"); !strings.Contains(first, ".") {
+			pkg, _ = m.std.Import(importPath)
+		}
+		if pkg == nil {
+			pkg = types.NewPackage(importPath, standInPackageName(importPath))
+			pkg.MarkComplete()
+		}
+		m.packages[importPath] = pkg
+		return pkg, nil
+	}
+	if m.loading[importPath] {
+		return nil, fmt.Errorf("import cycle through %s", importPath)
+	}
+	m.loading[importPath] = true
+	config := types.Config{Importer: m, Error: func(error) {}, FakeImportC: true}
+	pkg, _ := config.Check(importPath, m.fset, files, nil)
+	m.packages[importPath] = pkg
+	return pkg, nil
+}
+
+// standInPackageName guesses a package name from its import path, skipping a major version suffix
+func standInPackageName(importPath string) string {
+	elements := strings.Split(importPath, "/")
+	name := elements[len(elements)-1]
+	if len(elements) > 1 && majorDirRe.MatchString(name) {
+		name = elements[len(elements)-2]
+	}
+	name, _, _ = strings.Cut(name, ".")
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// packageAPI describes every exported identifier of a package, with fields, interface methods and
+// methods as Type.Member entries
+func packageAPI(pkg *types.Package, qualifier types.Qualifier) map[string]string {
+	api := make(map[string]string)
+	typeString := func(t types.Type) string { return types.TypeString(t, qualifier) }
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		object := scope.Lookup(name)
+		if !object.Exported() {
+			continue
+		}
+		switch object := object.(type) {
+		case *types.Const:
+			api[name] = "const " + typeString(object.Type())
+		case *types.Var:
+			api[name] = "var " + typeString(object.Type())
+		case *types.Func:
+			api[name] = typeString(object.Type())
+		case *types.TypeName:
+			if object.IsAlias() {
+				api[name] = "type = " + typeString(object.Type())
+				continue
+			}
+			named, ok := object.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+			head := "type"
+			if params := named.TypeParams(); params != nil && params.Len() > 0 {
+				var list []string
+				for i := 0; i < params.Len(); i++ {
+					list = append(list, params.At(i).Obj().Name()+" "+typeString(params.At(i).Constraint()))
+				}
+				head += "[" + strings.Join(list, ", ") + "]"
+			}
+			switch underlying := named.Underlying().(type) {
+			case *types.Struct:
+				api[name] = head + " struct"
+				for i := 0; i < underlying.NumFields(); i++ {
+					if field := underlying.Field(i); field.Exported() {
+						kind := "field "
+						if field.Embedded() {
+							kind = "embedded field "
+						}
+						api[name+"."+field.Name()] = kind + typeString(field.Type())
+					}
+				}
+			case *types.Interface:
+				sealed := false
+				for i := 0; i < underlying.NumMethods(); i++ {
+					method := underlying.Method(i)
+					if !method.Exported() {
+						sealed = true
+						continue
+					}
+					api[name+"."+method.Name()] = "interface method " + typeString(method.Type())
+				}
+				api[name] = head + " interface"
+				if sealed {
+					api[name] += " (sealed)"
+				}
+				continue
+			default:
+				api[name] = head + " " + typeString(underlying)
+			}
+			values := types.NewMethodSet(named)
+			pointers := types.NewMethodSet(types.NewPointer(named))
+			for i := 0; i < pointers.Len(); i++ {
+				method := pointers.At(i).Obj()
+				if !method.Exported() {
+					continue
+				}
+				kind := "pointer method "
+				if values.Lookup(method.Pkg(), method.Name()) != nil {
+					kind = "method "
+				}
+				if _, field := api[name+"."+method.Name()]; !field {
+					api[name+"."+method.Name()] = kind + typeString(method.Type())
+				}
+			}
+		}
+	}
+	return api
+}
+
+// diffGoAPI compares two API snapshots. Removed and changed identifiers break callers; additions
+// only break when they add a method to an interface other packages can implement.
+func diffGoAPI(old, new goAPI) []APIChange {
+	var changes []APIChange
+	packages := make(map[string]bool)
+	for pkg := range old {
+		packages[pkg] = true
+	}
+	for pkg := range new {
+		packages[pkg] = true
+	}
+	for pkg := range packages {
+		before, existed := old[pkg]
+		after, exists := new[pkg]
+		switch {
+		case !exists:
+			changes = append(changes, APIChange{Package: pkg, Change: APIRemoved, Breaking: true, Old: "package"})
+			continue
+		case !existed:
+			changes = append(changes, APIChange{Package: pkg, Change: APIAdded, New: "package"})
+			continue
+		}
+		for name, description := range before {
+			if now, ok := after[name]; !ok {
+				changes = append(changes, APIChange{Package: pkg, Name: name, Change: APIRemoved, Breaking: true, Old: description})
+			} else if now != description {
+				changes = append(changes, APIChange{Package: pkg, Name: name, Change: APIChanged, Breaking: true, Old: description, New: now})
+			}
+		}
+		for name, description := range after {
+			if _, ok := before[name]; ok {
+				continue
+			}
+			breaking := false
+			if owner, _, member := strings.Cut(name, "."); member && strings.HasPrefix(description, "interface method ") {
+				breaking = strings.HasSuffix(before[owner], " interface")
+			}
+			changes = append(changes, APIChange{Package: pkg, Name: name, Change: APIAdded, Breaking: breaking, New: description})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Package != changes[j].Package {
+			return changes[i].Package < changes[j].Package
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
@@ -0,0 +1,589 @@
+package checkers
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// Go module release finding types
+const (
+	GoModFindingMissing        = "gomod_missing"
+	GoModFindingUnparsable     = "gomod_unparsable"
+	GoModFindingMajorMismatch  = "gomod_major_mismatch"
+	GoModFindingReplace        = "gomod_replace"
+	GoModFindingRetractInvalid = "gomod_retract_invalid"
+	GoModFindingPathChanged    = "gomod_path_changed"
+	GoModFindingStaleImport    = "gomod_stale_import"
+	GoModFindingBreakingChange = "gomod_breaking_change"
+	GoModFindingAPIInPatch     = "gomod_api_in_patch"
+)
+
+// GoModuleChecker verifies that tagged Go module releases resolve and stay compatible
+type GoModuleChecker struct {
+	BaseChecker
+	options GoModuleOptions
+}
+
+// GoModuleOptions configures the Go module release checks
+type GoModuleOptions struct {
+	// MaxTags is the number of most recent tags of each module whose go.mod is checked
+	MaxTags int
+	// APIDiff compares the exported API of the last two stable tags of each module
+	APIDiff bool
+}
+
+// GoModuleFinding is one Go module release problem
+type GoModuleFinding struct {
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	// Module is the module path at the tag
+	Module string `json:"module"`
+	Tag    string `json:"tag"`
+	// Related is the other tag involved, such as the previous release of a breaking change
+	Related        string `json:"related,omitempty"`
+	File           string `json:"file,omitempty"`
+	Line           int    `json:"line,omitempty"`
+	Description    string `json:"description"`
+	Recommendation string `json:"recommendation"`
+}
+
+// GoModuleRelease is the release state of the modules sharing one tag prefix
+type GoModuleRelease struct {
+	// Dir is the module directory without a major version subdirectory
+	Dir string `json:"dir"`
+	// Path is the module path declared at the latest tag, or at HEAD when untagged
+	Path        string `json:"path"`
+	TagPrefix   string `json:"tag_prefix"`
+	Tags        int    `json:"tags"`
+	LatestTag   string `json:"latest_tag,omitempty"`
+	PreviousTag string `json:"previous_tag,omitempty"`
+	// APIChanges compares the exported API of the last two stable tags
+	APIChanges []APIChange `json:"api_changes,omitempty"`
+}
+
+// GoModuleReport is the result of the Go module release checks
+type GoModuleReport struct {
+	Modules  []GoModuleRelease `json:"modules"`
+	Findings []GoModuleFinding `json:"findings"`
+}
+
+// goModuleTag is a release tag with the go.mod found in its tree
+type goModuleTag struct {
+	releaseTag
+	file   string
+	module *GoModFile
+}
+
+// DefaultGoModuleOptions returns the settings of gphc gomod: recent tags and the API comparison
+func DefaultGoModuleOptions() GoModuleOptions {
+	return GoModuleOptions{MaxTags: 20, APIDiff: true}
+}
+
+// NewGoModuleChecker creates a Go module release checker with default options
+func NewGoModuleChecker() *GoModuleChecker {
+	return NewGoModuleCheckerWithOptions(DefaultGoModuleOptions())
+}
+
+// NewGoModuleCheckerWithOptions creates a Go module release checker with custom options
+func NewGoModuleCheckerWithOptions(options GoModuleOptions) *GoModuleChecker {
+	if options.MaxTags <= 0 {
+		options.MaxTags = DefaultGoModuleOptions().MaxTags
+	}
+	return &GoModuleChecker{
+		BaseChecker: NewBaseChecker("Go Module Release Hygiene", "GOMOD", types.CategoryHygiene, 5),
+		options:     options,
+	}
+}
+
+func (c *GoModuleChecker) Check(data *types.RepositoryData) *types.CheckResult {
+	result, _ := c.CheckWithReport(data)
+	return result
+}
+
+// CheckWithReport checks the tagged go.mod files and also returns the report, nil when there is no Go module
+func (c *GoModuleChecker) CheckWithReport(data *types.RepositoryData) (*types.CheckResult, *GoModuleReport) {
+	result := &types.CheckResult{
+		ID:        "GOMOD-902",
+		Name:      "Go Module Release Hygiene",
+		Category:  types.CategoryHygiene,
+		Timestamp: time.Now(),
+	}
+
+	report, err := AnalyzeGoModules(data.Path, c.options)
+	if err != nil {
+		result.Status = types.StatusWarning
+		result.Score = 50
+		result.Message = "Could not analyze Go modules"
+		result.Details = []string{err.Error()}
+		return result, nil
+	}
+	if len(report.Modules) == 0 {
+		result.Status = types.StatusPass
+		result.Score = 100
+		result.Message = "No Go module found"
+		return result, nil
+	}
+
+	// Each kind of problem costs once per module, so old tags repeating it do not sink the score
+	score := 100
+	counted := make(map[string]bool)
+	for _, finding := range report.Findings {
+		if result.Severities == nil {
+			result.Severities = make(map[string]int)
+		}
+		result.Severities[finding.Severity]++
+		key := finding.Module + " " + finding.Type
+		if !counted[key] {
+			counted[key] = true
+			score -= severityPenalty[finding.Severity]
+		}
+	}
+	result.Score = max(score, 0)
+	result.Details = goModuleDetails(report)
+
+	// A high finding breaks consumers of the release, however few other problems there are
+	switch {
+	case result.Score >= 80 && result.Severities["high"] == 0:
+		result.Status = types.StatusPass
+		result.Message = "Go module releases are well-formed"
+	case result.Score >= 50:
+		result.Status = types.StatusWarning
+		result.Message = "Some Go module releases need attention"
+	default:
+		result.Status = types.StatusFail
+		result.Message = "Go module releases are broken for consumers"
+	}
+	return result, report
+}
+
+// goModuleDetails summarizes each module and lists the findings
+func goModuleDetails(report *GoModuleReport) []string {
+	var details []string
+	for _, module := range report.Modules {
+		if module.Tags == 0 {
+			details = append(details, fmt.Sprintf("Module %s: no %sX.Y.Z tags yet", module.Path, module.TagPrefix))
+			continue
+		}
+		line := fmt.Sprintf("Module %s: %d tags, latest %s", module.Path, module.Tags, module.LatestTag)
+		if module.PreviousTag != "" {
+			breaking := 0
+			for _, change := range module.APIChanges {
+				if change.Breaking {
+					breaking++
+				}
+			}
+			line += fmt.Sprintf(", %d API changes since %s (%d breaking)", len(module.APIChanges), module.PreviousTag, breaking)
+		}
+		details = append(details, line)
+	}
+	for _, finding := range report.Findings {
+		details = append(details, fmt.Sprintf("[%s] %s", finding.Severity, finding.Description))
+	}
+	return details
+}
+
+// AnalyzeGoModules checks the go.mod of the recent release tags of every Go module in the repository:
+// the major version suffix matches the tag, no replace directives are released, retractions are
+// well-formed and the module path stays stable. With APIDiff it also compares the exported API of the
+// last two stable tags of each module.
+func AnalyzeGoModules(repoPath string, options GoModuleOptions) (*GoModuleReport, error) {
+	report := &GoModuleReport{Modules: []GoModuleRelease{}, Findings: []GoModuleFinding{}}
+	components, err := GoModuleComponents(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	if options.MaxTags <= 0 {
+		options.MaxTags = DefaultGoModuleOptions().MaxTags
+	}
+
+	// Major version subdirectories share their parent's prefix, so group the modules by prefix
+	headPaths := make(map[string]string)
+	var prefixes []string
+	for _, component := range components {
+		prefix := component.TagPrefix
+		if _, seen := headPaths[prefix]; !seen {
+			prefixes = append(prefixes, prefix)
+			headPaths[prefix] = ""
+		}
+		dir := "."
+		if len(component.Paths) > 0 {
+			dir = component.Paths[0]
+		}
+		content, err := os.ReadFile(filepath.Join(repoPath, dir, "go.mod"))
+		if err != nil {
+			continue
+		}
+		if module, err := ParseGoMod(string(content)); err == nil && headPaths[prefix] == "" {
+			headPaths[prefix], _ = SplitModulePath(module.Module)
+		}
+	}
+
+	for _, prefix := range prefixes {
+		module, findings, err := analyzeGoModule(repoPath, prefix, headPaths[prefix], options)
+		if err != nil {
+			return nil, err
+		}
+		report.Modules = append(report.Modules, module)
+		report.Findings = append(report.Findings, findings...)
+	}
+	return report, nil
+}
+
+// analyzeGoModule checks the tags of one tag prefix; base is the module path without its major suffix at HEAD
+func analyzeGoModule(repoPath, prefix, base string, options GoModuleOptions) (GoModuleRelease, []GoModuleFinding, error) {
+	dir := strings.TrimSuffix(strings.TrimSuffix(prefix, "v"), "/")
+	if dir == "" {
+		dir = "."
+	}
+	module := GoModuleRelease{Dir: dir, Path: base, TagPrefix: prefix}
+	var findings []GoModuleFinding
+
+	tags, err := versionTags(repoPath, prefix, 0, false)
+	if err != nil {
+		return module, nil, err
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].version.Compare(tags[j].version) < 0 })
+	module.Tags = len(tags)
+	if len(tags) == 0 {
+		return module, nil, nil
+	}
+	module.LatestTag = tags[len(tags)-1].name
+	if len(tags) > options.MaxTags {
+		tags = tags[len(tags)-options.MaxTags:]
+	}
+
+	var released []goModuleTag
+	for _, tag := range tags {
+		tagged, finding, err := readTaggedGoMod(repoPath, dir, tag)
+		if err != nil {
+			return module, nil, err
+		}
+		if finding != nil {
+			findings = append(findings, *finding)
+			continue
+		}
+		if tagged == nil {
+			continue
+		}
+		released = append(released, *tagged)
+		findings = append(findings, checkTaggedGoMod(*tagged, base)...)
+	}
+	if len(released) == 0 {
+		return module, findings, nil
+	}
+
+	latest := released[len(released)-1]
+	if latest.name == module.LatestTag {
+		module.Path = latest.module.Module
+		findings = append(findings, checkRetractions(repoPath, prefix, latest)...)
+		findings = append(findings, checkStaleImports(repoPath, latest)...)
+	}
+
+	if options.APIDiff {
+		var stable []goModuleTag
+		for _, tag := range released {
+			if !tag.version.IsPrerelease() {
+				stable = append(stable, tag)
+			}
+		}
+		if len(stable) >= 2 {
+			previous, current := stable[len(stable)-2], stable[len(stable)-1]
+			changes, finding, err := compareGoModuleAPI(repoPath, previous, current)
+			if err != nil {
+				return module, nil, err
+			}
+			module.PreviousTag = previous.name
+			module.APIChanges = changes
+			findings = append(findings, finding...)
+		}
+	}
+	return module, findings, nil
+}
+
+// readTaggedGoMod finds the go.mod of the tag's module: vN/go.mod for a v2+ tag when the major
+// version subdirectory exists, otherwise go.mod in the module directory. It returns nil without a
+// finding for a v0 or v1 tag made before the module had a go.mod.
+func readTaggedGoMod(repoPath, dir string, tag releaseTag) (*goModuleTag, *GoModuleFinding, error) {
+	var candidates []string
+	if tag.version.Major >= 2 {
+		candidates = append(candidates, path.Join(dir, "v"+strconv.Itoa(tag.version.Major), "go.mod"))
+	}
+	candidates = append(candidates, path.Join(dir, "go.mod"))
+	contents, err := readRevisionFiles(repoPath, tag.name, candidates)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, file := range candidates {
+		content, ok := contents[file]
+		if !ok {
+			continue
+		}
+		module, err := ParseGoMod(string(content))
+		if err != nil {
+			return nil, &GoModuleFinding{
+				Type:           GoModFindingUnparsable,
+				Severity:       "high",
+				Tag:            tag.name,
+				File:           file,
+				Description:    fmt.Sprintf("%s at %s cannot be parsed: %v", file, tag.name, err),
+				Recommendation: "Fix go.mod and release a new version; the go command cannot use this one",
+			}, nil
+		}
+		return &goModuleTag{releaseTag: tag, file: file, module: module}, nil, nil
+	}
+	if tag.version.Major < 2 {
+		return nil, nil, nil
+	}
+	return nil, &GoModuleFinding{
+		Type:           GoModFindingMissing,
+		Severity:       "high",
+		Tag:            tag.name,
+		File:           candidates[0],
+		Description:    fmt.Sprintf("%s has no go.mod, so Go only offers it as %s+incompatible", tag.name, strings.TrimPrefix(tag.name, path.Dir(tag.name)+"/")),
+		Recommendation: "Release v2+ versions from a module whose path ends in the major version suffix",
+	}, nil
+}
+
+// checkTaggedGoMod checks the major version suffix, replace directives and module path of one tag
+func checkTaggedGoMod(tag goModuleTag, base string) []GoModuleFinding {
+	var findings []GoModuleFinding
+	modulePath := tag.module.Module
+	prefix, suffix := SplitModulePath(modulePath)
+	expected := ""
+	if tag.version.Major >= 2 {
+		expected = "v" + strconv.Itoa(tag.version.Major)
+	}
+	if suffix != expected {
+		want := prefix
+		if expected != "" {
+			want = goModulePathWithMajor(prefix, expected)
+		}
+		findings = append(findings, GoModuleFinding{
+			Type:           GoModFindingMajorMismatch,
+			Severity:       "high",
+			Module:         modulePath,
+			Tag:            tag.name,
+			File:           tag.file,
+			Line:           1,
+			Description:    fmt.Sprintf("%s is major version %d but %s declares module %s", tag.name, tag.version.Major, tag.file, modulePath),
+			Recommendation: fmt.Sprintf("Declare module %s for %s releases and update imports to match", want, majorLabel(tag.version.Major)),
+		})
+	}
+	if base != "" && prefix != base {
+		findings = append(findings, GoModuleFinding{
+			Type:           GoModFindingPathChanged,
+			Severity:       "medium",
+			Module:         modulePath,
+			Tag:            tag.name,
+			File:           tag.file,
+			Description:    fmt.Sprintf("%s builds module %s but the module is %s at HEAD", tag.name, modulePath, base),
+			Recommendation: "Keep the module path stable across releases, or retract the releases published under the old path",
+		})
+	}
+	for _, replace := range tag.module.Replaces {
+		severity := "medium"
+		target := replace.NewPath
+		if replace.NewVersion != "" {
+			target += " " + replace.NewVersion
+		}
+		if replace.IsLocal() {
+			severity = "high"
+		}
+		findings = append(findings, GoModuleFinding{
+			Type:           GoModFindingReplace,
+			Severity:       severity,
+			Module:         modulePath,
+			Tag:            tag.name,
+			File:           tag.file,
+			Line:           replace.Line,
+			Description:    fmt.Sprintf("%s releases replace %s => %s, which consumers ignore", tag.name, replace.OldPath, target),
+			Recommendation: "Remove replace directives before tagging; require a released version of the dependency instead",
+		})
+	}
+	return findings
+}
+
+// checkRetractions checks the retract directives of the latest release
+func checkRetractions(repoPath, prefix string, latest goModuleTag) []GoModuleFinding {
+	var findings []GoModuleFinding
+	tags, _ := versionTags(repoPath, prefix, 0, false)
+	tagged := make(map[string]bool)
+	for _, tag := range tags {
+		tagged["v"+tag.version.String()] = true
+	}
+	_, suffix := SplitModulePath(latest.module.Module)
+
+	for _, retract := range latest.module.Retracts {
+		finding := GoModuleFinding{
+			Type:     GoModFindingRetractInvalid,
+			Module:   latest.module.Module,
+			Tag:      latest.name,
+			File:     latest.file,
+			Line:     retract.Line,
+			Severity: "medium",
+		}
+		label := retract.Low
+		if retract.High != retract.Low {
+			label = "[" + retract.Low + ", " + retract.High + "]"
+		}
+		low, lowErr := ParseVersion(retract.Low)
+		high, highErr := ParseVersion(retract.High)
+		switch {
+		case lowErr != nil || highErr != nil || !strings.HasPrefix(retract.Low, "v") || !strings.HasPrefix(retract.High, "v"):
+			finding.Description = fmt.Sprintf("retract %s is not a canonical vX.Y.Z version", label)
+			finding.Recommendation = "Write retracted versions as the go command does, with a leading v"
+		case low.Compare(high) > 0:
+			finding.Description = fmt.Sprintf("retract %s has its lower bound above its upper bound", label)
+			finding.Recommendation = "Swap the bounds of the interval"
+		case !retractMajorMatches(low.Major, suffix) || !retractMajorMatches(high.Major, suffix):
+			finding.Description = fmt.Sprintf("retract %s names versions outside module %s", label, latest.module.Module)
+			finding.Recommendation = "Only retract versions of this major version; each major version is a separate module"
+		case retract.Low == retract.High && !tagged[retract.Low]:
+			finding.Severity = "low"
+			finding.Description = fmt.Sprintf("retract %s names a version that was never tagged", label)
+			finding.Recommendation = "Check the version; retracting an unpublished version has no effect"
+		case retract.Rationale == "":
+			finding.Severity = "low"
+			finding.Description = fmt.Sprintf("retract %s has no rationale comment", label)
+			finding.Recommendation = "Add a comment explaining the retraction; go list -m -u shows it to consumers"
+		default:
+			continue
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// retractMajorMatches reports whether a retracted major version belongs to a module with the suffix
+func retractMajorMatches(major int, suffix string) bool {
+	if suffix == "" {
+		return major <= 1
+	}
+	return "v"+strconv.Itoa(major) == suffix
+}
+
+// checkStaleImports finds Go files of a v2+ release that still import the module's packages
+// without the major version suffix, which pulls in the old major version
+func checkStaleImports(repoPath string, latest goModuleTag) []GoModuleFinding {
+	base, suffix := SplitModulePath(latest.module.Module)
+	if suffix == "" || strings.HasPrefix(base, "gopkg.in/") {
+		return nil
+	}
+	dir := path.Dir(latest.file)
+	pathspec := "*.go"
+	if dir != "." {
+		pathspec = dir + "/*.go"
+	}
+	pattern := `"` + regexp.QuoteMeta(base) + `(/[^"]*)?"`
+	cmd := exec.Command("git", "grep", "-n", "-o", "-E", pattern, latest.name, "--", pathspec)
+	cmd.Dir = repoPath
+	output, _ := cmd.Output()
+
+	var findings []GoModuleFinding
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		// <tag>:<file>:<line>:<match>
+		parts := strings.SplitN(strings.TrimPrefix(line, latest.name+":"), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		imported := strings.Trim(parts[2], `"`)
+		if first, _, _ := strings.Cut(strings.TrimPrefix(imported, base+"/"), "/"); imported != base && majorDirRe.MatchString(first) {
+			continue
+		}
+		number, _ := strconv.Atoi(parts[1])
+		findings = append(findings, GoModuleFinding{
+			Type:           GoModFindingStaleImport,
+			Severity:       "high",
+			Module:         latest.module.Module,
+			Tag:            latest.name,
+			File:           parts[0],
+			Line:           number,
+			Description:    fmt.Sprintf("%s:%d imports %s, a package of the previous major version", parts[0], number, imported),
+			Recommendation: fmt.Sprintf("Import %s instead", latest.module.Module+strings.TrimPrefix(imported, base)),
+		})
+	}
+	return findings
+}
+
+// compareGoModuleAPI diffs the exported API of two releases and reports breaking changes within a
+// major version and additions in a patch release
+func compareGoModuleAPI(repoPath string, previous, current goModuleTag) ([]APIChange, []GoModuleFinding, error) {
+	before, err := moduleAPI(repoPath, previous.name, path.Dir(previous.file), previous.module.Module)
+	if err != nil {
+		return nil, nil, err
+	}
+	after, err := moduleAPI(repoPath, current.name, path.Dir(current.file), current.module.Module)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes := diffGoAPI(before, after)
+	var breaking, added []string
+	for _, change := range changes {
+		name := change.QualifiedName()
+		if change.Breaking {
+			breaking = append(breaking, name)
+		} else if change.Change == APIAdded {
+			added = append(added, name)
+		}
+	}
+
+	var findings []GoModuleFinding
+	sameMajor := previous.version.Major == current.version.Major
+	if sameMajor && current.version.Major >= 1 && len(breaking) > 0 {
+		findings = append(findings, GoModuleFinding{
+			Type:           GoModFindingBreakingChange,
+			Severity:       "high",
+			Module:         current.module.Module,
+			Tag:            current.name,
+			Related:        previous.name,
+			Description:    fmt.Sprintf("%s breaks the API of %s: %s", current.name, previous.name, summarizeNames(breaking)),
+			Recommendation: "Restore the removed or changed identifiers, or release the change as a new major version",
+		})
+	}
+	if sameMajor && previous.version.Minor == current.version.Minor && len(added) > 0 {
+		findings = append(findings, GoModuleFinding{
+			Type:           GoModFindingAPIInPatch,
+			Severity:       "low",
+			Module:         current.module.Module,
+			Tag:            current.name,
+			Related:        previous.name,
+			Description:    fmt.Sprintf("Patch release %s adds API: %s", current.name, summarizeNames(added)),
+			Recommendation: "Release new API in a minor version",
+		})
+	}
+	return changes, findings, nil
+}
+
+// summarizeNames lists the first few names and counts the rest
+func summarizeNames(names []string) string {
+	if len(names) <= 5 {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:5], ", "), len(names)-5)
+}
+
+// goModulePathWithMajor appends a major version suffix in the style of the module path
+func goModulePathWithMajor(prefix, major string) string {
+	if strings.HasPrefix(prefix, "gopkg.in/") {
+		return prefix + "." + major
+	}
+	return prefix + "/" + major
+}
+
+// majorLabel names a major version the way module paths do
+func majorLabel(major int) string {
+	if major < 2 {
+		return "v0 and v1"
+	}
+	return "v" + strconv.Itoa(major)
+}
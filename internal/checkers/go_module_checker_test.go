@@ -0,0 +1,259 @@
+package checkers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestParseGoMod(t *testing.T) {
+	content := `// The library
+module "example.com/lib/v2"
+
+go 1.22
+
+require (
+	example.com/dep v1.2.3
+	example.com/other v0.4.0 // indirect
+)
+
+replace example.com/dep v1.2.3 => ../dep
+
+exclude example.com/dep v1.2.2
+
+// Published with a broken build.
+retract v2.0.1
+
+retract [v2.0.3, v2.0.5] // data race
+`
+	file, err := ParseGoMod(content)
+	if err != nil {
+		t.Fatalf("ParseGoMod failed: %v", err)
+	}
+	if file.Module != "example.com/lib/v2" || file.Go != "1.22" {
+		t.Errorf("unexpected module %+v", file)
+	}
+	if len(file.Requires) != 2 || file.Requires[0].Indirect || !file.Requires[1].Indirect || file.Requires[1].Line != 8 {
+		t.Errorf("unexpected requires %+v", file.Requires)
+	}
+	if len(file.Replaces) != 1 || !file.Replaces[0].IsLocal() || file.Replaces[0].OldVersion != "v1.2.3" {
+		t.Errorf("unexpected replaces %+v", file.Replaces)
+	}
+	if len(file.Excludes) != 1 {
+		t.Errorf("unexpected excludes %+v", file.Excludes)
+	}
+	want := []GoModRetract{
+		{Low: "v2.0.1", High: "v2.0.1", Rationale: "Published with a broken build.", Line: 16},
+		{Low: "v2.0.3", High: "v2.0.5", Rationale: "data race", Line: 18},
+	}
+	if len(file.Retracts) != len(want) || file.Retracts[0] != want[0] || file.Retracts[1] != want[1] {
+		t.Errorf("expected retracts %+v, got %+v", want, file.Retracts)
+	}
+
+	for _, invalid := range []string{"go 1.22\n", "module a\nrequire (\n", "module a\nretract [v1.0.0]\n", "module a\nfrobnicate x\n"} {
+		if _, err := ParseGoMod(invalid); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestSplitModulePath(t *testing.T) {
+	tests := []struct {
+		path, prefix, major string
+	}{
+		{"example.com/lib", "example.com/lib", ""},
+		{"example.com/lib/v2", "example.com/lib", "v2"},
+		{"example.com/lib/v1", "example.com/lib/v1", ""},
+		{"gopkg.in/yaml.v3", "gopkg.in/yaml", "v3"},
+	}
+	for _, test := range tests {
+		if prefix, major := SplitModulePath(test.path); prefix != test.prefix || major != test.major {
+			t.Errorf("SplitModulePath(%q) = %q, %q", test.path, prefix, major)
+		}
+	}
+}
+
+func TestAnalyzeGoModules(t *testing.T) {
+	repo := createGitRepository(t)
+	write := func(name, content string) {
+		t.Helper()
+		file := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	release := func(tag string) {
+		t.Helper()
+		runGit(t, repo, "add", "-A")
+		runGit(t, repo, "commit", "-qm", "release "+tag)
+		runGit(t, repo, "tag", "-a", tag, "-m", tag)
+	}
+
+	write("go.mod", "module example.com/lib\n\ngo 1.22\n")
+	write("lib.go", `package lib
+
+import "io"
+
+// Hello greets
+func Hello() string { return "hello" }
+
+type Greeter interface{ Greet() string }
+
+type Config struct {
+	Name   string
+	Output io.Writer
+}
+
+func (c *Config) Validate() error { return nil }
+`)
+	write("cmd/tool/main.go", "package main\n\nfunc Exported() {}\n\nfunc main() {}\n")
+	write("internal/impl/impl.go", "package impl\n\nfunc Hidden() {}\n")
+	release("v1.0.0")
+
+	write("go.mod", "module example.com/lib\n\ngo 1.22\n\nreplace example.com/dep => ../dep\n")
+	write("lib.go", `package lib
+
+import "io"
+
+type Greeter interface {
+	Greet() string
+	Wave()
+}
+
+type Config struct {
+	Name   int
+	Output io.Writer
+}
+
+func (c *Config) Validate() error { return nil }
+
+func Bye() {}
+`)
+	write("lib_windows.go", "package lib\n\nfunc WindowsOnly() {}\n")
+	release("v1.1.0")
+
+	// A v2 tag whose go.mod still declares the v1 path
+	write("go.mod", "module example.com/lib\n\ngo 1.22\n")
+	release("v2.0.0")
+
+	write("go.mod", `module example.com/lib/v2
+
+go 1.22
+
+retract v2.0.0
+
+// Never published.
+retract v2.0.7
+
+retract [v2.0.3, v2.0.2] // wrong order
+
+retract v1.0.0 // previous major
+`)
+	write("sub/sub.go", "package sub\n\nconst Version = 2\n")
+	write("lib_sub.go", "package lib\n\nimport \"example.com/lib/sub\"\n\nvar SubVersion = sub.Version\n")
+	release("v2.0.1")
+
+	// The API of the two v1 releases
+	before, err := moduleAPI(repo, "v1.0.0", ".", "example.com/lib")
+	if err != nil {
+		t.Fatalf("moduleAPI failed: %v", err)
+	}
+	after, err := moduleAPI(repo, "v1.1.0", ".", "example.com/lib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(before) != 1 || before["."]["Config.Output"] != "field io.Writer" || before["."]["Config.Validate"] != "pointer method func() error" {
+		t.Errorf("unexpected API %+v", before)
+	}
+	changes := map[string]APIChange{}
+	for _, change := range diffGoAPI(before, after) {
+		changes[change.Name] = change
+	}
+	expected := map[string]string{"Hello": APIRemoved, "Config.Name": APIChanged, "Greeter.Wave": APIAdded, "Bye": APIAdded}
+	for name, kind := range expected {
+		if change, ok := changes[name]; !ok || change.Change != kind || change.Breaking != (name != "Bye") {
+			t.Errorf("expected %s to be %s: %+v", name, kind, changes)
+		}
+	}
+	if len(changes) != len(expected) {
+		t.Errorf("unexpected changes %+v", changes)
+	}
+
+	report, err := AnalyzeGoModules(repo, DefaultGoModuleOptions())
+	if err != nil {
+		t.Fatalf("AnalyzeGoModules failed: %v", err)
+	}
+	if len(report.Modules) != 1 {
+		t.Fatalf("expected one module, got %+v", report.Modules)
+	}
+	module := report.Modules[0]
+	if module.Path != "example.com/lib/v2" || module.LatestTag != "v2.0.1" || module.PreviousTag != "v2.0.0" || module.Tags != 4 {
+		t.Errorf("unexpected module %+v", module)
+	}
+	counts := map[string]int{}
+	findings := map[string]GoModuleFinding{}
+	for _, finding := range report.Findings {
+		counts[finding.Type]++
+		findings[finding.Type+" "+finding.Tag] = finding
+	}
+	if finding, ok := findings[GoModFindingReplace+" v1.1.0"]; !ok || finding.Severity != "high" || finding.Line != 5 {
+		t.Errorf("expected the local replace of v1.1.0: %+v", report.Findings)
+	}
+	if _, ok := findings[GoModFindingMajorMismatch+" v2.0.0"]; !ok {
+		t.Errorf("expected v2.0.0 to declare the wrong major version: %+v", report.Findings)
+	}
+	if finding, ok := findings[GoModFindingStaleImport+" v2.0.1"]; !ok || finding.File != "lib_sub.go" || finding.Line != 3 {
+		t.Errorf("expected lib_sub.go to import the v1 package: %+v", report.Findings)
+	}
+	if _, ok := findings[GoModFindingAPIInPatch+" v2.0.1"]; !ok {
+		t.Errorf("expected new API in a patch release: %+v", report.Findings)
+	}
+	if counts[GoModFindingRetractInvalid] != 4 || counts[GoModFindingBreakingChange] != 0 {
+		t.Errorf("unexpected findings %+v", report.Findings)
+	}
+
+	result, _ := NewGoModuleChecker().CheckWithReport(&types.RepositoryData{Path: repo})
+	if result.ID != "GOMOD-902" || result.Status != types.StatusWarning {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestGoModuleCheckerBreakingChange(t *testing.T) {
+	repo := createGitRepository(t)
+	release := func(source, tag string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, "go.mod"), []byte("module example.com/lib\n\ngo 1.22\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repo, "lib.go"), []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, repo, "add", "-A")
+		runGit(t, repo, "commit", "-qm", "release "+tag)
+		runGit(t, repo, "tag", "-a", tag, "-m", tag)
+	}
+	release("package lib\n\nfunc H() {}\n\nfunc Keep() {}\n", "v1.0.0")
+	release("package lib\n\nfunc Keep() {}\n", "v1.1.0")
+
+	result, report := NewGoModuleChecker().CheckWithReport(&types.RepositoryData{Path: repo})
+	if report == nil || len(report.Findings) != 1 || report.Findings[0].Description != "v1.1.0 breaks the API of v1.0.0: H" {
+		t.Fatalf("unexpected findings %+v", report)
+	}
+	// A single high finding leaves the score above 80 but must not pass
+	if result.Score < 80 || result.Status != types.StatusWarning {
+		t.Errorf("unexpected result %d %s", result.Score, result.Status)
+	}
+}
+
+func TestGoModuleCheckerWithoutModule(t *testing.T) {
+	repo := createGitRepository(t)
+	result := NewGoModuleChecker().Check(&types.RepositoryData{Path: repo})
+	if result.Status != types.StatusPass || result.Message != "No Go module found" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
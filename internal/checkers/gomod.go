@@ -0,0 +1,247 @@
+package checkers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GoModFile is a parsed go.mod file
+type GoModFile struct {
+	Module    string         `json:"module"`
+	Go        string         `json:"go,omitempty"`
+	Toolchain string         `json:"toolchain,omitempty"`
+	Requires  []GoModRequire `json:"requires,omitempty"`
+	Excludes  []GoModRequire `json:"excludes,omitempty"`
+	Replaces  []GoModReplace `json:"replaces,omitempty"`
+	Retracts  []GoModRetract `json:"retracts,omitempty"`
+}
+
+// GoModRequire is a require or exclude directive
+type GoModRequire struct {
+	Path     string `json:"path"`
+	Version  string `json:"version"`
+	Indirect bool   `json:"indirect,omitempty"`
+	Line     int    `json:"line"`
+}
+
+// GoModReplace is a replace directive; OldVersion and NewVersion are empty when not given
+type GoModReplace struct {
+	OldPath    string `json:"old_path"`
+	OldVersion string `json:"old_version,omitempty"`
+	NewPath    string `json:"new_path"`
+	NewVersion string `json:"new_version,omitempty"`
+	Line       int    `json:"line"`
+}
+
+// IsLocal reports whether the replacement is a filesystem path, which only exists in this checkout
+func (r GoModReplace) IsLocal() bool {
+	return strings.HasPrefix(r.NewPath, "./") || strings.HasPrefix(r.NewPath, "../") ||
+		strings.HasPrefix(r.NewPath, "/") || r.NewPath == "." || r.NewPath == ".."
+}
+
+// GoModRetract is a retract directive of a single version (Low == High) or an interval
+type GoModRetract struct {
+	Low  string `json:"low"`
+	High string `json:"high"`
+	// Rationale is the comment above or beside the directive
+	Rationale string `json:"rationale,omitempty"`
+	Line      int    `json:"line"`
+}
+
+var (
+	// modulePathMajorRe splits the /vN suffix of a module path
+	modulePathMajorRe = regexp.MustCompile(`^(.+)/(v[0-9]+)$`)
+	// gopkgPathMajorRe splits the .vN suffix of a gopkg.in module path
+	gopkgPathMajorRe = regexp.MustCompile(`^(gopkg\.in/.+)\.(v[0-9]+)(?:-unstable)?$`)
+)
+
+// ParseGoMod parses the content of a go.mod file. It understands every directive, blocks,
+// quoted paths and comments, and keeps retraction rationales.
+func ParseGoMod(content string) (*GoModFile, error) {
+	file := &GoModFile{}
+	var block string
+	var comments []string
+	for number, raw := range strings.Split(content, "\n") {
+		line := number + 1
+		tokens, comment, err := goModTokens(raw)
+		if err != nil {
+			return nil, fmt.Errorf("go.mod:%d: %w", line, err)
+		}
+		if len(tokens) == 0 {
+			if comment != "" {
+				comments = append(comments, comment)
+			} else {
+				comments = nil
+			}
+			continue
+		}
+		if block != "" {
+			if len(tokens) == 1 && tokens[0] == ")" {
+				block = ""
+				comments = nil
+				continue
+			}
+			if err := file.directive(block, tokens, goModRationale(comments, comment), line); err != nil {
+				return nil, fmt.Errorf("go.mod:%d: %w", line, err)
+			}
+			comments = nil
+			continue
+		}
+		if len(tokens) == 2 && tokens[1] == "(" {
+			block = tokens[0]
+			comments = nil
+			continue
+		}
+		if len(tokens) == 3 && tokens[1] == "(" && tokens[2] == ")" {
+			comments = nil
+			continue
+		}
+		if err := file.directive(tokens[0], tokens[1:], goModRationale(comments, comment), line); err != nil {
+			return nil, fmt.Errorf("go.mod:%d: %w", line, err)
+		}
+		comments = nil
+	}
+	if block != "" {
+		return nil, fmt.Errorf("go.mod: unterminated %s block", block)
+	}
+	if file.Module == "" {
+		return nil, fmt.Errorf("go.mod: no module directive")
+	}
+	return file, nil
+}
+
+// directive applies one directive with its arguments
+func (f *GoModFile) directive(verb string, args []string, comment string, line int) error {
+	switch verb {
+	case "module":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: module module/path")
+		}
+		f.Module = args[0]
+	case "go":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: go 1.23")
+		}
+		f.Go = args[0]
+	case "toolchain":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: toolchain go1.23.1")
+		}
+		f.Toolchain = args[0]
+	case "require", "exclude":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: %s module/path v1.2.3", verb)
+		}
+		requirement := GoModRequire{Path: args[0], Version: args[1], Indirect: comment == "indirect" || strings.HasPrefix(comment, "indirect;"), Line: line}
+		if verb == "require" {
+			f.Requires = append(f.Requires, requirement)
+		} else {
+			f.Excludes = append(f.Excludes, requirement)
+		}
+	case "replace":
+		arrow := -1
+		for i, arg := range args {
+			if arg == "=>" {
+				arrow = i
+			}
+		}
+		if arrow < 1 || arrow > 2 || len(args)-arrow-1 < 1 || len(args)-arrow-1 > 2 {
+			return fmt.Errorf("usage: replace module/path [v1.2.3] => other/module [v1.4.5] | ../local/dir")
+		}
+		replace := GoModReplace{OldPath: args[0], NewPath: args[arrow+1], Line: line}
+		if arrow == 2 {
+			replace.OldVersion = args[1]
+		}
+		if len(args)-arrow-1 == 2 {
+			replace.NewVersion = args[arrow+2]
+		}
+		f.Replaces = append(f.Replaces, replace)
+	case "retract":
+		retract := GoModRetract{Rationale: comment, Line: line}
+		switch {
+		case len(args) == 1:
+			retract.Low, retract.High = args[0], args[0]
+		case len(args) == 5 && args[0] == "[" && args[2] == "," && args[4] == "]":
+			retract.Low, retract.High = args[1], args[3]
+		default:
+			return fmt.Errorf("usage: retract v1.2.3 | retract [v1.2.3, v1.2.5]")
+		}
+		f.Retracts = append(f.Retracts, retract)
+	case "godebug", "tool", "ignore":
+		// Not relevant to release hygiene
+	default:
+		return fmt.Errorf("unknown directive %q", verb)
+	}
+	return nil
+}
+
+// goModTokens splits a line into tokens and its trailing comment
+func goModTokens(line string) ([]string, string, error) {
+	var tokens []string
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(line[i:], "//"):
+			return tokens, strings.TrimSpace(line[i+2:]), nil
+		case strings.HasPrefix(line[i:], "=>"):
+			tokens = append(tokens, "=>")
+			i += 2
+		case c == '(' || c == ')' || c == '[' || c == ']' || c == ',':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"' || c == '`':
+			end := i + 1
+			for end < len(line) && line[end] != c {
+				if c == '"' && line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil, "", fmt.Errorf("unterminated string")
+			}
+			token, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid quoted string: %w", err)
+			}
+			tokens = append(tokens, token)
+			i = end + 1
+		default:
+			start := i
+			for i < len(line) && !strings.ContainsRune(" \t\r()[],\"`", rune(line[i])) && !strings.HasPrefix(line[i:], "//") && !strings.HasPrefix(line[i:], "=>") {
+				i++
+			}
+			tokens = append(tokens, line[start:i])
+		}
+	}
+	return tokens, "", nil
+}
+
+// goModRationale joins the comments above a directive with its trailing comment
+func goModRationale(above []string, trailing string) string {
+	parts := append([]string(nil), above...)
+	if trailing != "" {
+		parts = append(parts, trailing)
+	}
+	return strings.Join(parts, " ")
+}
+
+// SplitModulePath splits a module path into its prefix and major version suffix:
+// example.com/m/v2 gives example.com/m and v2, gopkg.in/yaml.v3 gives gopkg.in/yaml and v3,
+// and a path without a suffix gives itself and ""
+func SplitModulePath(path string) (string, string) {
+	if strings.HasPrefix(path, "gopkg.in/") {
+		if match := gopkgPathMajorRe.FindStringSubmatch(path); match != nil {
+			return match[1], match[2]
+		}
+		return path, ""
+	}
+	if match := modulePathMajorRe.FindStringSubmatch(path); match != nil && match[2] != "v0" && match[2] != "v1" {
+		return match[1], match[2]
+	}
+	return path, ""
+}
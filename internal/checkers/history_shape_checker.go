@@ -0,0 +1,388 @@
+package checkers
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// History strategies a repository can enforce on its main branch
+const (
+	HistoryStrategyLinear     = "linear"
+	HistoryStrategyMergeOnly  = "merge-only"
+	HistoryStrategySquashOnly = "squash-only"
+)
+
+var (
+	wipSubjectRe    = regexp.MustCompile(`(?i)^\s*(?:\[wip\]|\(wip\)|wip\b|work in progress\b|do not merge\b)`)
+	revertsCommitRe = regexp.MustCompile(`This reverts commit ([0-9a-f]{7,40})`)
+	reapplySubject  = regexp.MustCompile(`^Reapply "(.*)"$`)
+)
+
+// HistoryShapeChecker analyzes merge structure and commits that should not have reached the main branch
+type HistoryShapeChecker struct {
+	BaseChecker
+	strategy   string
+	mainBranch string
+}
+
+// HistoryCommit identifies a commit in the history shape report
+type HistoryCommit struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+}
+
+// RevertChain is a commit that was reverted, with the reverts of reverts that followed
+type RevertChain struct {
+	Original HistoryCommit   `json:"original"`
+	Reverts  []HistoryCommit `json:"reverts"`
+}
+
+// ReappliedChange is a commit that was reverted and later applied again
+type ReappliedChange struct {
+	Original HistoryCommit `json:"original"`
+	Revert   HistoryCommit `json:"revert"`
+	Reapply  HistoryCommit `json:"reapply"`
+}
+
+// HistoryShapeReport is the complete history shape analysis
+type HistoryShapeReport struct {
+	Strategy   string `json:"strategy,omitempty"`
+	MainBranch string `json:"main_branch"`
+	// TotalCommits and MergeCommits cover every analyzed commit
+	TotalCommits int     `json:"total_commits"`
+	MergeCommits int     `json:"merge_commits"`
+	MergeRatio   float64 `json:"merge_ratio"`
+	// Mainline counts cover the first-parent chain of the main branch
+	MainlineCommits    int               `json:"mainline_commits"`
+	MainlineMerges     int               `json:"mainline_merges"`
+	AutosquashCommits  []HistoryCommit   `json:"autosquash_commits"`
+	WIPCommits         []HistoryCommit   `json:"wip_commits"`
+	RevertChains       []RevertChain     `json:"revert_chains"`
+	Reapplied          []ReappliedChange `json:"reapplied"`
+	BackMerges         []HistoryCommit   `json:"back_merges"`
+	StrategyViolations []string          `json:"strategy_violations"`
+	Score              int               `json:"score"`
+}
+
+// NewHistoryShapeChecker creates a history shape checker that only reports findings
+func NewHistoryShapeChecker() *HistoryShapeChecker {
+	return &HistoryShapeChecker{
+		BaseChecker: NewBaseChecker("History Shape Checker", "HISTORY", types.CategoryCommits, 5),
+	}
+}
+
+// NewHistoryShapeCheckerWithStrategy creates a history shape checker that validates the main branch
+// against a strategy (linear, merge-only or squash-only). An empty main branch is detected.
+func NewHistoryShapeCheckerWithStrategy(strategy, mainBranch string) (*HistoryShapeChecker, error) {
+	strategy = strings.ToLower(strings.TrimSpace(strategy))
+	switch strategy {
+	case "", HistoryStrategyLinear, HistoryStrategyMergeOnly, HistoryStrategySquashOnly:
+	default:
+		return nil, fmt.Errorf("unknown history strategy %q (use %s, %s or %s)", strategy, HistoryStrategyLinear, HistoryStrategyMergeOnly, HistoryStrategySquashOnly)
+	}
+	checker := NewHistoryShapeChecker()
+	checker.strategy = strategy
+	checker.mainBranch = mainBranch
+	return checker, nil
+}
+
+// Check analyzes the history shape of the analyzed commits
+func (c *HistoryShapeChecker) Check(data *types.RepositoryData) *types.CheckResult {
+	result, _ := c.CheckWithReport(data)
+	return result
+}
+
+// CheckWithReport analyzes the history shape and also returns the full report
+func (c *HistoryShapeChecker) CheckWithReport(data *types.RepositoryData) (*types.CheckResult, *HistoryShapeReport) {
+	result := &types.CheckResult{
+		ID:        "CHQ-304",
+		Name:      "History Shape Analysis",
+		Category:  c.Category(),
+		Status:    types.StatusPass,
+		Details:   []string{},
+		Timestamp: time.Now(),
+	}
+
+	if len(data.Commits) == 0 {
+		result.Status = types.StatusWarning
+		result.Score = 0
+		result.Message = "No commits found to analyze"
+		result.Details = []string{"No commits available for analysis"}
+		return result, nil
+	}
+
+	mainBranch := resolveMainBranch(data.Path, c.mainBranch)
+	mainline := revisionSet(data.Path, "--first-parent", mainBranch)
+	onMain := revisionSet(data.Path, mainBranch)
+	report := analyzeHistoryShape(data.Commits, mainline, onMain)
+	report.MainBranch = mainBranch
+	report.Strategy = c.strategy
+	c.evaluate(data.Commits, mainline, report)
+
+	result.Score = report.Score
+	switch {
+	case len(report.StrategyViolations) > 0:
+		result.Status = types.StatusFail
+		result.Message = fmt.Sprintf("History of %s does not follow the %s strategy", mainBranch, c.strategy)
+	case historyFindings(report) > 0:
+		result.Status = types.StatusWarning
+		result.Message = fmt.Sprintf("History shape needs attention (%d finding(s))", historyFindings(report))
+	default:
+		result.Message = "History shape is clean"
+	}
+	result.Details = historyShapeDetails(report)
+	return result, report
+}
+
+// analyzeHistoryShape classifies the commits; mainline holds the first-parent chain of the
+// main branch and onMain every commit reachable from it
+func analyzeHistoryShape(commits []types.CommitInfo, mainline, onMain map[string]bool) *HistoryShapeReport {
+	report := &HistoryShapeReport{
+		TotalCommits:       len(commits),
+		AutosquashCommits:  []HistoryCommit{},
+		WIPCommits:         []HistoryCommit{},
+		RevertChains:       []RevertChain{},
+		Reapplied:          []ReappliedChange{},
+		BackMerges:         []HistoryCommit{},
+		StrategyViolations: []string{},
+	}
+
+	for _, commit := range commits {
+		if commit.IsMerge {
+			report.MergeCommits++
+		}
+		if mainline[commit.Hash] {
+			report.MainlineCommits++
+			if commit.IsMerge {
+				report.MainlineMerges++
+			}
+		}
+		if onMain[commit.Hash] {
+			if autosquashRe.MatchString(commit.Subject) {
+				report.AutosquashCommits = append(report.AutosquashCommits, historyCommit(commit))
+			}
+			if wipSubjectRe.MatchString(commit.Subject) {
+				report.WIPCommits = append(report.WIPCommits, historyCommit(commit))
+			}
+		}
+		// A merge that brings main into another branch: the merged-in side is on the mainline
+		if commit.IsMerge && !mainline[commit.Hash] && mainline[commit.Parents[1]] {
+			report.BackMerges = append(report.BackMerges, historyCommit(commit))
+		}
+	}
+	report.MergeRatio = float64(report.MergeCommits) / float64(len(commits))
+
+	report.RevertChains, report.Reapplied = analyzeReverts(commits)
+	return report
+}
+
+// analyzeReverts follows "This reverts commit <hash>" references to find reverts of reverts
+// and changes that were applied again after being reverted
+func analyzeReverts(commits []types.CommitInfo) ([]RevertChain, []ReappliedChange) {
+	chains := []RevertChain{}
+	reapplied := []ReappliedChange{}
+
+	// Commits are listed newest first; process them oldest first
+	reverted := make(map[string]string)
+	var reverts []types.CommitInfo
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		if match := revertsCommitRe.FindStringSubmatch(commit.Message); match != nil {
+			if target := findCommit(commits, match[1]); target != nil {
+				reverted[commit.Hash] = target.Hash
+				reverts = append(reverts, commit)
+			}
+		}
+	}
+	byHash := make(map[string]types.CommitInfo, len(commits))
+	position := make(map[string]int, len(commits))
+	for i, commit := range commits {
+		byHash[commit.Hash] = commit
+		position[commit.Hash] = i
+	}
+
+	// Revert chains: two or more reverts stacked on the same original commit
+	revertedBy := make(map[string]string)
+	for revert, target := range reverted {
+		revertedBy[target] = revert
+	}
+	for _, revert := range reverts {
+		target := reverted[revert.Hash]
+		if _, isRevert := reverted[target]; isRevert {
+			continue // not the first revert of a chain
+		}
+		chain := RevertChain{Original: historyCommit(byHash[target])}
+		for next := revert.Hash; next != ""; next = revertedBy[next] {
+			chain.Reverts = append(chain.Reverts, historyCommit(byHash[next]))
+		}
+
+		// The change is back when it has been reverted an even number of times
+		if len(chain.Reverts) >= 2 {
+			chains = append(chains, chain)
+			reapplied = append(reapplied, ReappliedChange{Original: chain.Original, Revert: chain.Reverts[0], Reapply: chain.Reverts[1]})
+			continue
+		}
+
+		// Otherwise look for a later commit applying the same change again
+		for i := position[revert.Hash] - 1; i >= 0; i-- {
+			later := commits[i]
+			subject := later.Subject
+			if match := reapplySubject.FindStringSubmatch(subject); match != nil {
+				subject = match[1]
+			}
+			if subject == chain.Original.Subject {
+				reapplied = append(reapplied, ReappliedChange{Original: chain.Original, Revert: historyCommit(revert), Reapply: historyCommit(later)})
+				break
+			}
+		}
+	}
+	return chains, reapplied
+}
+
+// evaluate validates the report against the strategy and scores it
+func (c *HistoryShapeChecker) evaluate(commits []types.CommitInfo, mainline map[string]bool, report *HistoryShapeReport) {
+	switch c.strategy {
+	case HistoryStrategyLinear:
+		if report.MainlineMerges > 0 {
+			report.StrategyViolations = append(report.StrategyViolations, fmt.Sprintf("%d merge commit(s) on %s; rebase instead of merging", report.MainlineMerges, report.MainBranch))
+		}
+	case HistoryStrategyMergeOnly:
+		direct := 0
+		for _, commit := range commits {
+			if mainline[commit.Hash] && !commit.IsMerge && len(commit.Parents) > 0 {
+				direct++
+			}
+		}
+		if direct > 0 {
+			report.StrategyViolations = append(report.StrategyViolations, fmt.Sprintf("%d commit(s) added to %s without a merge", direct, report.MainBranch))
+		}
+	case HistoryStrategySquashOnly:
+		if report.MainlineMerges > 0 {
+			report.StrategyViolations = append(report.StrategyViolations, fmt.Sprintf("%d merge commit(s) on %s; squash pull requests instead", report.MainlineMerges, report.MainBranch))
+		}
+		if leaked := len(report.AutosquashCommits) + len(report.WIPCommits); leaked > 0 {
+			report.StrategyViolations = append(report.StrategyViolations, fmt.Sprintf("%d fixup, squash or WIP commit(s) reached %s unsquashed", leaked, report.MainBranch))
+		}
+	}
+
+	score := 100
+	score -= min(5*len(report.AutosquashCommits), 20)
+	score -= min(5*len(report.WIPCommits), 20)
+	score -= min(5*len(report.RevertChains), 15)
+	score -= min(5*len(report.Reapplied), 15)
+	score -= min(3*len(report.BackMerges), 15)
+	if len(report.StrategyViolations) > 0 {
+		score -= 30
+	}
+	report.Score = max(score, 0)
+}
+
+func historyFindings(report *HistoryShapeReport) int {
+	return len(report.AutosquashCommits) + len(report.WIPCommits) + len(report.RevertChains) + len(report.Reapplied) + len(report.BackMerges)
+}
+
+// historyShapeDetails renders the report as result detail lines
+func historyShapeDetails(report *HistoryShapeReport) []string {
+	details := []string{
+		fmt.Sprintf("Merge Commits: %d of %d (%.1f%%)", report.MergeCommits, report.TotalCommits, report.MergeRatio*100),
+		fmt.Sprintf("Mainline (%s): %d commits, %d merges", report.MainBranch, report.MainlineCommits, report.MainlineMerges),
+	}
+	if report.Strategy != "" {
+		details = append(details, "Strategy: "+report.Strategy)
+	}
+	for _, violation := range report.StrategyViolations {
+		details = append(details, "❌ "+violation)
+	}
+
+	list := func(title string, commits []HistoryCommit) {
+		if len(commits) == 0 {
+			return
+		}
+		details = append(details, "", title)
+		for i, commit := range commits {
+			if i == 5 {
+				details = append(details, fmt.Sprintf("  ... and %d more", len(commits)-5))
+				break
+			}
+			details = append(details, fmt.Sprintf("  • %s %s", types.ShortHash(commit.Hash), commit.Subject))
+		}
+	}
+	list("Fixup/Squash Commits on Main:", report.AutosquashCommits)
+	list("WIP Commits on Main:", report.WIPCommits)
+	list("Back-merges from Main:", report.BackMerges)
+
+	if len(report.RevertChains) > 0 {
+		details = append(details, "", "Revert Chains:")
+		for _, chain := range report.RevertChains {
+			details = append(details, fmt.Sprintf("  • %s %s reverted %d times", types.ShortHash(chain.Original.Hash), chain.Original.Subject, len(chain.Reverts)))
+		}
+	}
+	if len(report.Reapplied) > 0 {
+		details = append(details, "", "Reverted Then Reapplied:")
+		for _, change := range report.Reapplied {
+			details = append(details, fmt.Sprintf("  • %s %s (reverted in %s, reapplied in %s)", types.ShortHash(change.Original.Hash), change.Original.Subject, types.ShortHash(change.Revert.Hash), types.ShortHash(change.Reapply.Hash)))
+		}
+	}
+	return details
+}
+
+func historyCommit(commit types.CommitInfo) HistoryCommit {
+	return HistoryCommit{Hash: commit.Hash, Subject: commit.Subject, Author: commit.Author, Date: commit.CommitDate}
+}
+
+// findCommit finds an analyzed commit by full or abbreviated hash
+func findCommit(commits []types.CommitInfo, hash string) *types.CommitInfo {
+	for i := range commits {
+		if strings.HasPrefix(commits[i].Hash, hash) {
+			return &commits[i]
+		}
+	}
+	return nil
+}
+
+// resolveMainBranch returns the configured main branch, the remote's default branch,
+// main or master, falling back to HEAD
+func resolveMainBranch(repoPath, configured string) string {
+	candidates := []string{}
+	if configured != "" {
+		candidates = append(candidates, configured)
+	}
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	cmd.Dir = repoPath
+	if output, err := cmd.Output(); err == nil {
+		remoteDefault := strings.TrimSpace(string(output))
+		candidates = append(candidates, strings.TrimPrefix(remoteDefault, "origin/"), remoteDefault)
+	}
+	candidates = append(candidates, "main", "master")
+
+	for _, candidate := range candidates {
+		cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", candidate+"^{commit}")
+		cmd.Dir = repoPath
+		if cmd.Run() == nil {
+			return candidate
+		}
+	}
+	return "HEAD"
+}
+
+// revisionSet lists the commits git rev-list selects
+func revisionSet(repoPath string, args ...string) map[string]bool {
+	cmd := exec.Command("git", append([]string{"rev-list"}, args...)...)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	set := make(map[string]bool)
+	if err != nil {
+		return set
+	}
+	for _, hash := range strings.Fields(string(output)) {
+		set[hash] = true
+	}
+	return set
+}
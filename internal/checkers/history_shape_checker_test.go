@@ -0,0 +1,87 @@
+package checkers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vahidaghazadeh/gphc/internal/git"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestHistoryShapeChecker(t *testing.T) {
+	repo := createGitRepository(t)
+	runGit(t, repo, "branch", "-M", "main")
+	commit := func(file, subject string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, file), []byte(subject+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, repo, "add", ".")
+		runGit(t, repo, "commit", "-qm", subject)
+	}
+
+	// A feature branch with a fixup and a WIP commit that is merged with main before landing
+	runGit(t, repo, "checkout", "-q", "-b", "feature")
+	commit("feature.txt", "feat: add feature")
+	commit("feature.txt", "fixup! feat: add feature")
+	commit("notes.txt", "WIP notes")
+	runGit(t, repo, "checkout", "-q", "main")
+	commit("main.txt", "fix: on main")
+	runGit(t, repo, "checkout", "-q", "feature")
+	runGit(t, repo, "merge", "-q", "--no-ff", "-m", "Merge branch 'main' into feature", "main")
+	runGit(t, repo, "checkout", "-q", "main")
+	runGit(t, repo, "merge", "-q", "--no-ff", "-m", "Merge branch 'feature'", "feature")
+
+	// A change reverted twice, and another reverted and then committed again
+	commit("flag.txt", "feat: enable flag")
+	runGit(t, repo, "revert", "--no-edit", "HEAD")
+	runGit(t, repo, "revert", "--no-edit", "HEAD")
+	commit("cache.txt", "perf: cache results")
+	runGit(t, repo, "revert", "--no-edit", "HEAD")
+	commit("cache.txt", "perf: cache results")
+
+	analyzer, err := git.NewRepositoryAnalyzer(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyzer.SetCommitSelection(git.CommitSelection{AllRefs: true})
+	data, err := analyzer.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, report := NewHistoryShapeChecker().CheckWithReport(data)
+	if result.Status != types.StatusWarning {
+		t.Fatalf("Status = %v, want warning", result.Status)
+	}
+	if report.MainBranch != "main" || report.MergeCommits != 2 || report.MainlineMerges != 1 {
+		t.Fatalf("merge counts = %+v", report)
+	}
+	if len(report.AutosquashCommits) != 1 || len(report.WIPCommits) != 1 {
+		t.Fatalf("leaked commits = %+v %+v", report.AutosquashCommits, report.WIPCommits)
+	}
+	if len(report.BackMerges) != 1 || report.BackMerges[0].Subject != "Merge branch 'main' into feature" {
+		t.Fatalf("back-merges = %+v", report.BackMerges)
+	}
+	if len(report.RevertChains) != 1 || report.RevertChains[0].Original.Subject != "feat: enable flag" || len(report.RevertChains[0].Reverts) != 2 {
+		t.Fatalf("revert chains = %+v", report.RevertChains)
+	}
+	if len(report.Reapplied) != 2 || report.Reapplied[1].Original.Subject != "perf: cache results" {
+		t.Fatalf("reapplied = %+v", report.Reapplied)
+	}
+
+	for _, strategy := range []string{HistoryStrategyLinear, HistoryStrategyMergeOnly, HistoryStrategySquashOnly} {
+		checker, err := NewHistoryShapeCheckerWithStrategy(strategy, "main")
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, report := checker.CheckWithReport(data)
+		if result.Status != types.StatusFail || len(report.StrategyViolations) == 0 {
+			t.Errorf("%s: status %v, violations %v", strategy, result.Status, report.StrategyViolations)
+		}
+	}
+	if _, err := NewHistoryShapeCheckerWithStrategy("rebase-only", ""); err == nil {
+		t.Fatal("expected an error for an unknown strategy")
+	}
+}
@@ -0,0 +1,434 @@
+package checkers
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// HotspotChecker ranks files by how much and how recently they change
+type HotspotChecker struct {
+	BaseChecker
+	options HotspotOptions
+}
+
+// HotspotOptions tunes hotspot ranking and temporal coupling detection
+type HotspotOptions struct {
+	// TopN limits the hotspots and coupled pairs reported
+	TopN int
+	// HalfLifeDays is the age at which a change counts half as much as one made today
+	HalfLifeDays int
+	// MinSharedCommits is the number of commits two files must share to be coupled
+	MinSharedCommits int
+	// MinCoupling is the share of the less frequently changed file's commits that include the other file
+	MinCoupling float64
+	// MaxFilesPerCommit skips larger commits, such as mass renames or formatting, for coupling
+	MaxFilesPerCommit int
+	// Now is the reference time for recency; zero means time.Now
+	Now time.Time
+}
+
+// Hotspot is a file ranked by churn weighted by recency
+type Hotspot struct {
+	Path         string    `json:"path"`
+	Commits      int       `json:"commits"`
+	LinesAdded   int       `json:"lines_added"`
+	LinesDeleted int       `json:"lines_deleted"`
+	Authors      int       `json:"authors"`
+	LastChanged  time.Time `json:"last_changed"`
+	Score        float64   `json:"score"`
+	HasTests     bool      `json:"has_tests"`
+	IsTest       bool      `json:"is_test,omitempty"`
+}
+
+// CoupledFiles is a pair of files that tend to change in the same commits
+type CoupledFiles struct {
+	FileA         string  `json:"file_a"`
+	FileB         string  `json:"file_b"`
+	SharedCommits int     `json:"shared_commits"`
+	CommitsA      int     `json:"commits_a"`
+	CommitsB      int     `json:"commits_b"`
+	Coupling      float64 `json:"coupling"`
+}
+
+// HotspotReport holds the result of a hotspot analysis
+type HotspotReport struct {
+	CommitsAnalyzed  int            `json:"commits_analyzed"`
+	FilesChanged     int            `json:"files_changed"`
+	HalfLifeDays     int            `json:"half_life_days"`
+	Hotspots         []Hotspot      `json:"hotspots"`
+	Coupled          []CoupledFiles `json:"coupled,omitempty"`
+	UntestedHotspots []Hotspot      `json:"untested_hotspots,omitempty"`
+}
+
+// DefaultHotspotOptions returns the hotspot settings used when gphc.yml sets none
+func DefaultHotspotOptions() HotspotOptions {
+	return HotspotOptions{
+		TopN:              10,
+		HalfLifeDays:      90,
+		MinSharedCommits:  3,
+		MinCoupling:       0.7,
+		MaxFilesPerCommit: 30,
+	}
+}
+
+// NewHotspotChecker creates a hotspot checker with the default options
+func NewHotspotChecker() *HotspotChecker {
+	return NewHotspotCheckerWithOptions(DefaultHotspotOptions())
+}
+
+// NewHotspotCheckerWithOptions creates a hotspot checker; unset options fall back to the defaults
+func NewHotspotCheckerWithOptions(options HotspotOptions) *HotspotChecker {
+	defaults := DefaultHotspotOptions()
+	if options.TopN <= 0 {
+		options.TopN = defaults.TopN
+	}
+	if options.HalfLifeDays <= 0 {
+		options.HalfLifeDays = defaults.HalfLifeDays
+	}
+	if options.MinSharedCommits <= 0 {
+		options.MinSharedCommits = defaults.MinSharedCommits
+	}
+	if options.MinCoupling <= 0 || options.MinCoupling > 1 {
+		options.MinCoupling = defaults.MinCoupling
+	}
+	if options.MaxFilesPerCommit <= 0 {
+		options.MaxFilesPerCommit = defaults.MaxFilesPerCommit
+	}
+	return &HotspotChecker{
+		BaseChecker: NewBaseChecker("Hotspot Checker", "HOTSPOTS", types.CategoryStructure, 4),
+		options:     options,
+	}
+}
+
+// Check ranks the files changed by the analyzed commits
+func (c *HotspotChecker) Check(data *types.RepositoryData) *types.CheckResult {
+	result, _ := c.CheckWithReport(data)
+	return result
+}
+
+// CheckWithReport ranks hotspots and returns the full report
+func (c *HotspotChecker) CheckWithReport(data *types.RepositoryData) (*types.CheckResult, *HotspotReport) {
+	result := &types.CheckResult{
+		ID:        "CBS-802",
+		Name:      "Change Hotspots",
+		Category:  c.Category(),
+		Timestamp: time.Now(),
+	}
+
+	if len(data.Commits) == 0 {
+		result.Status = types.StatusWarning
+		result.Score = 0
+		result.Message = "No commits found to analyze"
+		return result, nil
+	}
+
+	tracked, err := trackedFiles(data.Path)
+	if err != nil {
+		result.Status = types.StatusWarning
+		result.Score = 0
+		result.Message = "Could not list tracked files"
+		result.Details = []string{err.Error()}
+		return result, nil
+	}
+
+	report := AnalyzeHotspots(data.Commits, tracked, c.options)
+	result.Details = hotspotDetails(report)
+
+	score := 100
+	score -= min(10*len(report.UntestedHotspots), 50)
+	score -= min(5*len(report.Coupled), 25)
+	result.Score = max(score, 0)
+
+	switch {
+	case len(report.UntestedHotspots) > 0:
+		result.Status = types.StatusWarning
+		result.Message = fmt.Sprintf("%d high-churn file(s) have no tests next to them", len(report.UntestedHotspots))
+	case len(report.Coupled) > 0:
+		result.Status = types.StatusWarning
+		result.Message = fmt.Sprintf("%d file pair(s) keep changing together", len(report.Coupled))
+	default:
+		result.Status = types.StatusPass
+		result.Message = "Hotspots are covered by tests and loosely coupled"
+	}
+	return result, report
+}
+
+// AnalyzeHotspots ranks the tracked files changed by commits and finds temporally coupled pairs.
+// Commits are expected newest first so renames can be followed back to older paths.
+func AnalyzeHotspots(commits []types.CommitInfo, tracked []string, options HotspotOptions) *HotspotReport {
+	now := options.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	trackedSet := make(map[string]bool, len(tracked))
+	goTestDirs := make(map[string]bool)
+	for _, file := range tracked {
+		trackedSet[file] = true
+		if strings.HasSuffix(file, "_test.go") {
+			goTestDirs[path.Dir(file)] = true
+		}
+	}
+
+	// current maps a historical path to the path the file has today
+	current := make(map[string]string)
+	resolve := func(p string) string {
+		if renamed, ok := current[p]; ok {
+			return renamed
+		}
+		return p
+	}
+
+	stats := make(map[string]*Hotspot)
+	authors := make(map[string]map[string]bool)
+	commitFiles := make([][]string, 0, len(commits))
+	for _, commit := range commits {
+		// A merge's first-parent diff repeats every change of the merged branch, whose commits are analyzed themselves
+		if commit.IsMerge {
+			continue
+		}
+		var files []string
+		seen := make(map[string]bool)
+		for _, change := range commit.Files {
+			file := resolve(change.Path)
+			if change.OldPath != "" {
+				current[change.OldPath] = file
+			}
+			if !trackedSet[file] || change.Binary {
+				continue
+			}
+
+			hotspot := stats[file]
+			if hotspot == nil {
+				hotspot = &Hotspot{Path: file}
+				stats[file] = hotspot
+				authors[file] = make(map[string]bool)
+			}
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+				hotspot.Commits++
+				authors[file][strings.ToLower(commit.AuthorEmail)] = true
+				if commit.Date.After(hotspot.LastChanged) {
+					hotspot.LastChanged = commit.Date
+				}
+			}
+			hotspot.LinesAdded += change.LinesAdded
+			hotspot.LinesDeleted += change.LinesDeleted
+
+			// Each changed line counts fully today and half as much every half-life before that
+			ageDays := math.Max(now.Sub(commit.Date).Hours()/24, 0)
+			decay := math.Pow(0.5, ageDays/float64(options.HalfLifeDays))
+			hotspot.Score += float64(change.LinesAdded+change.LinesDeleted) * decay
+		}
+		commitFiles = append(commitFiles, files)
+	}
+
+	report := &HotspotReport{
+		CommitsAnalyzed: len(commitFiles),
+		FilesChanged:    len(stats),
+		HalfLifeDays:    options.HalfLifeDays,
+	}
+
+	var ranked []Hotspot
+	for file, hotspot := range stats {
+		hotspot.Authors = len(authors[file])
+		hotspot.Score = math.Round(hotspot.Score*10) / 10
+		hotspot.IsTest = isTestFile(file)
+		hotspot.HasTests = hotspot.IsTest || hasCompanionTest(file, trackedSet, goTestDirs)
+		ranked = append(ranked, *hotspot)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Path < ranked[j].Path
+	})
+	if len(ranked) > options.TopN {
+		ranked = ranked[:options.TopN]
+	}
+	report.Hotspots = ranked
+
+	// A hotspot needs tests when it is source code that changed more than once
+	for _, hotspot := range ranked {
+		if !hotspot.HasTests && hotspot.Commits > 1 && isSourceFile(hotspot.Path) {
+			report.UntestedHotspots = append(report.UntestedHotspots, hotspot)
+		}
+	}
+
+	report.Coupled = temporalCoupling(commitFiles, stats, options)
+	return report
+}
+
+// temporalCoupling finds file pairs that share most of their commits
+func temporalCoupling(commitFiles [][]string, stats map[string]*Hotspot, options HotspotOptions) []CoupledFiles {
+	shared := make(map[[2]string]int)
+	changes := make(map[string]int)
+	for _, files := range commitFiles {
+		if len(files) > options.MaxFilesPerCommit {
+			continue
+		}
+		sorted := append([]string(nil), files...)
+		sort.Strings(sorted)
+		for i, a := range sorted {
+			changes[a]++
+			for _, b := range sorted[i+1:] {
+				shared[[2]string{a, b}]++
+			}
+		}
+	}
+
+	var coupled []CoupledFiles
+	for pair, count := range shared {
+		if count < options.MinSharedCommits || isCompanionTest(pair[0], pair[1]) {
+			continue
+		}
+		coupling := float64(count) / float64(min(changes[pair[0]], changes[pair[1]]))
+		if coupling < options.MinCoupling {
+			continue
+		}
+		coupled = append(coupled, CoupledFiles{
+			FileA:         pair[0],
+			FileB:         pair[1],
+			SharedCommits: count,
+			CommitsA:      stats[pair[0]].Commits,
+			CommitsB:      stats[pair[1]].Commits,
+			Coupling:      math.Round(coupling*100) / 100,
+		})
+	}
+	sort.Slice(coupled, func(i, j int) bool {
+		if coupled[i].SharedCommits != coupled[j].SharedCommits {
+			return coupled[i].SharedCommits > coupled[j].SharedCommits
+		}
+		if coupled[i].FileA != coupled[j].FileA {
+			return coupled[i].FileA < coupled[j].FileA
+		}
+		return coupled[i].FileB < coupled[j].FileB
+	})
+	if len(coupled) > options.TopN {
+		coupled = coupled[:options.TopN]
+	}
+	return coupled
+}
+
+// hotspotDetails renders the report as result detail lines
+func hotspotDetails(report *HotspotReport) []string {
+	details := []string{fmt.Sprintf("Commits Analyzed: %d, Files Changed: %d", report.CommitsAnalyzed, report.FilesChanged)}
+	for i, hotspot := range report.Hotspots {
+		tests := ""
+		if !hotspot.HasTests && isSourceFile(hotspot.Path) {
+			tests = ", no tests"
+		}
+		details = append(details, fmt.Sprintf("%d. %s: score %.1f, %d commits, +%d/-%d lines, %d authors%s",
+			i+1, hotspot.Path, hotspot.Score, hotspot.Commits, hotspot.LinesAdded, hotspot.LinesDeleted, hotspot.Authors, tests))
+	}
+	for _, pair := range report.Coupled {
+		details = append(details, fmt.Sprintf("Coupled: %s <-> %s (%d shared commits, %.0f%%)", pair.FileA, pair.FileB, pair.SharedCommits, pair.Coupling*100))
+	}
+	return details
+}
+
+// trackedFiles lists the files at HEAD
+func trackedFiles(repoPath string) ([]string, error) {
+	cmd := exec.Command("git", "ls-files", "-z")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list tracked files: %w", err)
+	}
+	var files []string
+	for _, file := range strings.Split(string(output), "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// sourceExtensions are the file types expected to have tests
+var sourceExtensions = map[string]bool{
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true,
+	".java": true, ".kt": true, ".rb": true, ".php": true, ".rs": true, ".cs": true,
+	".c": true, ".cc": true, ".cpp": true, ".swift": true, ".scala": true,
+}
+
+func isSourceFile(file string) bool {
+	return sourceExtensions[strings.ToLower(path.Ext(file))] && !isTestFile(file)
+}
+
+// isTestFile recognises common test file names and test directories
+func isTestFile(file string) bool {
+	base := path.Base(file)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	for _, dir := range strings.Split(path.Dir(file), "/") {
+		if dir == "test" || dir == "tests" || dir == "__tests__" || dir == "spec" {
+			return true
+		}
+	}
+	return strings.HasSuffix(stem, "_test") || strings.HasPrefix(stem, "test_") ||
+		strings.HasSuffix(stem, ".test") || strings.HasSuffix(stem, ".spec") ||
+		strings.HasSuffix(stem, "Test") || strings.HasSuffix(stem, "Tests") || strings.HasSuffix(stem, "_spec")
+}
+
+// companionTests lists the test files that would cover a source file
+func companionTests(file string) []string {
+	dir, base := path.Dir(file), path.Base(file)
+	ext := path.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+	names := []string{
+		stem + "_test" + ext,
+		"test_" + stem + ext,
+		stem + ".test" + ext,
+		stem + ".spec" + ext,
+		stem + "Test" + ext,
+		stem + "Tests" + ext,
+		stem + "_spec" + ext,
+	}
+
+	var candidates []string
+	for _, name := range names {
+		candidates = append(candidates, path.Join(dir, name))
+		for _, testDir := range []string{"test", "tests", "__tests__"} {
+			candidates = append(candidates, path.Join(dir, testDir, name), path.Join(testDir, dir, name))
+		}
+	}
+	return candidates
+}
+
+// hasCompanionTest reports whether a tracked test file sits next to the source file;
+// goTestDirs holds the directories with a tracked _test.go file
+func hasCompanionTest(file string, tracked, goTestDirs map[string]bool) bool {
+	for _, candidate := range companionTests(file) {
+		if tracked[candidate] {
+			return true
+		}
+	}
+	// Go tests cover the whole package rather than a single file
+	return path.Ext(file) == ".go" && goTestDirs[path.Dir(file)]
+}
+
+// isCompanionTest reports whether one file of a pair is the test of the other;
+// such pairs are expected to change together
+func isCompanionTest(a, b string) bool {
+	if isTestFile(a) == isTestFile(b) {
+		return false
+	}
+	if isTestFile(a) {
+		a, b = b, a
+	}
+	if path.Ext(a) == ".go" && path.Dir(a) == path.Dir(b) {
+		return true
+	}
+	for _, candidate := range companionTests(a) {
+		if candidate == b {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package checkers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestAnalyzeHotspots(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(daysAgo int, files ...types.FileChange) types.CommitInfo {
+		return types.CommitInfo{AuthorEmail: "dev@example.com", Date: now.AddDate(0, 0, -daysAgo), Files: files}
+	}
+	change := func(path string, lines int) types.FileChange {
+		return types.FileChange{Path: path, LinesAdded: lines}
+	}
+
+	// Newest first, as the analyzer returns them
+	merge := commit(0, change("api/handler.go", 100), change("web/app.ts", 100))
+	merge.IsMerge = true
+	commits := []types.CommitInfo{
+		merge,
+		commit(1, change("api/handler.go", 10), change("api/routes.go", 2)),
+		commit(2, change("api/handler.go", 10), change("api/routes.go", 2)),
+		commit(3, change("api/handler.go", 10), change("api/routes.go", 2), change("web/app.ts", 5)),
+		commit(10, types.FileChange{Path: "web/app.ts", OldPath: "web/main.ts"}),
+		commit(300, change("web/main.ts", 200)),
+		commit(400, change("web/main.ts", 200), change("web/app.test.ts", 20)),
+		commit(5, change("api/handler_test.go", 4), change("api/handler.go", 1)),
+		commit(5, change("old.go", 500)),
+		commit(5, types.FileChange{Path: "logo.png", Binary: true}),
+	}
+	tracked := []string{"api/handler.go", "api/handler_test.go", "api/routes.go", "web/app.ts", "web/app.test.ts", "logo.png", "scripts/deploy.py"}
+	commits = append(commits, commit(20, change("scripts/deploy.py", 3)), commit(30, change("scripts/deploy.py", 3)))
+
+	report := AnalyzeHotspots(commits, tracked, HotspotOptions{TopN: 10, HalfLifeDays: 30, MinSharedCommits: 3, MinCoupling: 0.7, MaxFilesPerCommit: 30, Now: now})
+
+	if report.CommitsAnalyzed != len(commits)-1 {
+		t.Fatalf("merges should not be analyzed: %d commits", report.CommitsAnalyzed)
+	}
+	if report.Hotspots[0].Path != "api/handler.go" || report.Hotspots[0].Commits != 4 || report.Hotspots[0].LinesAdded != 31 || !report.Hotspots[0].HasTests {
+		t.Fatalf("top hotspot = %+v", report.Hotspots[0])
+	}
+	byPath := make(map[string]Hotspot)
+	for _, hotspot := range report.Hotspots {
+		byPath[hotspot.Path] = hotspot
+	}
+	// Renamed history is attributed to the current path but old changes have decayed
+	app := byPath["web/app.ts"]
+	if app.Commits != 4 || app.LinesAdded != 405 || app.Score >= byPath["api/handler.go"].Score {
+		t.Fatalf("web/app.ts = %+v", app)
+	}
+	if !app.HasTests {
+		t.Fatalf("web/app.ts should find web/app.test.ts: %+v", app)
+	}
+	if _, ok := byPath["old.go"]; ok {
+		t.Fatal("deleted files must not be ranked")
+	}
+	if _, ok := byPath["logo.png"]; ok {
+		t.Fatal("binary files must not be ranked")
+	}
+
+	if len(report.UntestedHotspots) != 1 || report.UntestedHotspots[0].Path != "scripts/deploy.py" {
+		t.Fatalf("untested hotspots = %+v", report.UntestedHotspots)
+	}
+	if len(report.Coupled) != 1 || report.Coupled[0].FileA != "api/handler.go" || report.Coupled[0].FileB != "api/routes.go" || report.Coupled[0].SharedCommits != 3 || report.Coupled[0].Coupling != 1 {
+		t.Fatalf("coupled = %+v", report.Coupled)
+	}
+}
+
+func TestHotspotTestDetection(t *testing.T) {
+	tracked := map[string]bool{"src/util.py": true, "tests/src/test_util.py": true, "lib/Parser.java": true, "lib/ParserTest.java": true,
+		"pkg/a.go": true, "pkg/b.go": true, "pkg/a_test.go": true, "cmd/main.go": true}
+	goTestDirs := map[string]bool{"pkg": true}
+	for file, want := range map[string]bool{"src/util.py": true, "lib/Parser.java": true, "lib/Lexer.java": false, "pkg/b.go": true, "cmd/main.go": false} {
+		if got := hasCompanionTest(file, tracked, goTestDirs); got != want {
+			t.Errorf("hasCompanionTest(%s) = %v, want %v", file, got, want)
+		}
+	}
+	for file, want := range map[string]bool{"a_test.go": true, "x/__tests__/a.js": true, "a.spec.ts": true, "contest.go": false, "main.go": false} {
+		if got := isTestFile(file); got != want {
+			t.Errorf("isTestFile(%s) = %v, want %v", file, got, want)
+		}
+	}
+}
@@ -0,0 +1,478 @@
+package checkers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+	// lfsPointerMaxSize is the largest blob Git LFS treats as a pointer file
+	lfsPointerMaxSize = 1024
+	// binarySniffSize matches the prefix git inspects when deciding whether content is binary
+	binarySniffSize = 8000
+)
+
+// LFSPointer is a parsed Git LFS pointer file
+type LFSPointer struct {
+	Oid  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// LFSFile is a tracked file involved in a Git LFS finding
+type LFSFile struct {
+	Path     string  `json:"path"`
+	Oid      string  `json:"oid,omitempty"`
+	Size     int64   `json:"size"`
+	SizeMB   float64 `json:"size_mb"`
+	Pattern  string  `json:"pattern,omitempty"`
+	Severity string  `json:"severity"`
+}
+
+// LFSReport describes how the repository uses Git LFS
+type LFSReport struct {
+	Patterns       []string  `json:"patterns"`
+	PointerFiles   int       `json:"pointer_files"`
+	PointerSize    int64     `json:"pointer_size"`
+	NotLFSTracked  []LFSFile `json:"not_lfs_tracked"`
+	NotPointers    []LFSFile `json:"not_pointers"`
+	MissingObjects []LFSFile `json:"missing_objects"`
+	MigrateCommand string    `json:"migrate_command,omitempty"`
+
+	pointerPaths map[string]bool
+}
+
+// gitAttributeRule is a single .gitattributes line that sets or unsets the filter attribute
+type gitAttributeRule struct {
+	pattern string
+	lfs     bool
+}
+
+// trackedBlob is an entry of the index
+type trackedBlob struct {
+	path string
+	oid  string
+	size int64
+}
+
+// parseLFSPointer parses a Git LFS pointer file, reporting false for regular content
+func parseLFSPointer(content []byte) (LFSPointer, bool) {
+	if len(content) > lfsPointerMaxSize || !bytes.HasPrefix(content, []byte(lfsPointerVersion)) {
+		return LFSPointer{}, false
+	}
+
+	var pointer LFSPointer
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), " ")
+		if !found {
+			continue
+		}
+		switch key {
+		case "oid":
+			pointer.Oid = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			pointer.Size, _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+	if len(pointer.Oid) != 64 {
+		return LFSPointer{}, false
+	}
+	return pointer, true
+}
+
+// loadLFSAttributes reads filter attributes from every tracked .gitattributes file.
+// Patterns in nested files are rewritten relative to the repository root.
+func loadLFSAttributes(repoPath string, tracked []trackedBlob) []gitAttributeRule {
+	var files []string
+	for _, blob := range tracked {
+		if path.Base(blob.path) == ".gitattributes" {
+			files = append(files, blob.path)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, ".gitattributes")); err == nil && !containsString(files, ".gitattributes") {
+		files = append(files, ".gitattributes")
+	}
+	// Deeper files take precedence, so they are applied last
+	sort.SliceStable(files, func(i, j int) bool {
+		return strings.Count(files[i], "/") < strings.Count(files[j], "/")
+	})
+
+	var rules []gitAttributeRule
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(repoPath, filepath.FromSlash(file)))
+		if err != nil {
+			continue
+		}
+		dir := path.Dir(file)
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+				continue
+			}
+			for _, attribute := range fields[1:] {
+				var lfs bool
+				switch {
+				case attribute == "filter=lfs":
+					lfs = true
+				case strings.HasPrefix(attribute, "filter="), attribute == "-filter", attribute == "!filter":
+					lfs = false
+				default:
+					continue
+				}
+				rules = append(rules, gitAttributeRule{pattern: scopeAttributePattern(dir, fields[0]), lfs: lfs})
+			}
+		}
+	}
+	return rules
+}
+
+// scopeAttributePattern anchors a pattern from a nested .gitattributes file to its directory
+func scopeAttributePattern(dir, pattern string) string {
+	if dir == "." || dir == "" {
+		return pattern
+	}
+	if strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+		return dir + "/" + strings.TrimPrefix(pattern, "/")
+	}
+	return dir + "/**/" + pattern
+}
+
+// lfsPattern returns the pattern that routes a path through Git LFS, or "" when it is not LFS tracked
+func lfsPattern(rules []gitAttributeRule, filePath string) string {
+	matched := ""
+	for _, rule := range rules {
+		if !matchGitPattern(rule.pattern, filePath) {
+			continue
+		}
+		if rule.lfs {
+			matched = rule.pattern
+		} else {
+			matched = ""
+		}
+	}
+	return matched
+}
+
+// auditLFS compares the index against .gitattributes and the local LFS object store.
+// Binaries at or above thresholdMB that are not LFS tracked are reported; zero disables that check.
+func auditLFS(repoPath string, thresholdMB float64) (*LFSReport, error) {
+	tracked, err := trackedBlobs(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := loadLFSAttributes(repoPath, tracked)
+	report := &LFSReport{
+		Patterns:       []string{},
+		NotLFSTracked:  []LFSFile{},
+		NotPointers:    []LFSFile{},
+		MissingObjects: []LFSFile{},
+		pointerPaths:   make(map[string]bool),
+	}
+	for _, rule := range rules {
+		if rule.lfs && !containsString(report.Patterns, rule.pattern) {
+			report.Patterns = append(report.Patterns, rule.pattern)
+		}
+	}
+
+	objectsDir := lfsObjectsDir(repoPath)
+	threshold := int64(thresholdMB * 1024 * 1024)
+
+	var pointerCandidates []trackedBlob
+	for _, blob := range tracked {
+		if blob.size <= lfsPointerMaxSize {
+			pointerCandidates = append(pointerCandidates, blob)
+		}
+	}
+	pointers, err := readLFSPointers(repoPath, pointerCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blob := range tracked {
+		pattern := lfsPattern(rules, blob.path)
+		if pointer, ok := pointers[blob.oid]; ok {
+			report.PointerFiles++
+			report.PointerSize += pointer.Size
+			report.pointerPaths[blob.path] = true
+			objectPath := filepath.Join(objectsDir, pointer.Oid[0:2], pointer.Oid[2:4], pointer.Oid)
+			if _, err := os.Stat(objectPath); err != nil {
+				report.MissingObjects = append(report.MissingObjects, newLFSFile(blob.path, pointer.Oid, pointer.Size, pattern, "medium"))
+			}
+			continue
+		}
+
+		if pattern != "" {
+			// Matches an LFS pattern but the blob holds the real content
+			if blob.size > 0 {
+				report.NotPointers = append(report.NotPointers, newLFSFile(blob.path, "", blob.size, pattern, "high"))
+			}
+			continue
+		}
+
+		if threshold > 0 && blob.size >= threshold && isBinaryFile(filepath.Join(repoPath, filepath.FromSlash(blob.path))) {
+			severity := "low"
+			if blob.size >= 10*1024*1024 {
+				severity = "medium"
+			}
+			report.NotLFSTracked = append(report.NotLFSTracked, newLFSFile(blob.path, "", blob.size, "", severity))
+		}
+	}
+
+	sort.Slice(report.NotLFSTracked, func(i, j int) bool {
+		return report.NotLFSTracked[i].Size > report.NotLFSTracked[j].Size
+	})
+	report.MigrateCommand = lfsMigrateCommand(report)
+	return report, nil
+}
+
+// issueCount returns the number of LFS findings
+func (r *LFSReport) issueCount() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.NotLFSTracked) + len(r.NotPointers) + len(r.MissingObjects)
+}
+
+// excludeLFSPointers drops large working tree files that are stored in Git as LFS pointers
+func excludeLFSPointers(files []BinaryFile, report *LFSReport) []BinaryFile {
+	if report.PointerFiles == 0 {
+		return files
+	}
+	kept := make([]BinaryFile, 0, len(files))
+	for _, file := range files {
+		if !report.pointerPaths[filepath.ToSlash(file.Path)] {
+			kept = append(kept, file)
+		}
+	}
+	return kept
+}
+
+func filterLFSFiles(files []LFSFile, minSeverity string) []LFSFile {
+	filtered := make([]LFSFile, 0, len(files))
+	for _, file := range files {
+		if binarySeverityLevel(file.Severity) >= binarySeverityLevel(minSeverity) {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered
+}
+
+// lfsDetails renders the LFS section of the binary audit
+func lfsDetails(report *LFSReport) []string {
+	if report == nil || (len(report.Patterns) == 0 && report.PointerFiles == 0 && report.issueCount() == 0) {
+		return nil
+	}
+
+	details := []string{
+		"",
+		"🗄️ Git LFS:",
+		fmt.Sprintf("  LFS Patterns: %s", strings.Join(report.Patterns, ", ")),
+		fmt.Sprintf("  Pointer Files: %d (%.1f MB in LFS)", report.PointerFiles, float64(report.PointerSize)/(1024*1024)),
+	}
+	if len(report.Patterns) == 0 {
+		details[2] = "  LFS Patterns: none (.gitattributes has no filter=lfs entries)"
+	}
+	sections := []struct {
+		title string
+		files []LFSFile
+	}{
+		{"Matching LFS patterns but committed as regular blobs", report.NotPointers},
+		{"Binaries that should be tracked by LFS", report.NotLFSTracked},
+		{"Pointers whose objects are missing from the local LFS store", report.MissingObjects},
+	}
+	for _, section := range sections {
+		if len(section.files) == 0 {
+			continue
+		}
+		details = append(details, fmt.Sprintf("  %s: %d", section.title, len(section.files)))
+		for i, file := range section.files {
+			if i == 10 {
+				details = append(details, fmt.Sprintf("    ... and %d more files (use --format json for complete list)", len(section.files)-i))
+				break
+			}
+			details = append(details, fmt.Sprintf("    • %s [%s] %.1f MB", file.Path, file.Severity, file.SizeMB))
+		}
+	}
+	if len(report.MissingObjects) > 0 {
+		details = append(details, "  Fetch missing objects with: git lfs fetch --all && git lfs checkout")
+	}
+	if report.MigrateCommand != "" {
+		details = append(details, "  Move these files into LFS across history with: "+report.MigrateCommand)
+	}
+	return details
+}
+
+func newLFSFile(filePath, oid string, size int64, pattern, severity string) LFSFile {
+	return LFSFile{
+		Path:     filePath,
+		Oid:      oid,
+		Size:     size,
+		SizeMB:   float64(size) / (1024 * 1024),
+		Pattern:  pattern,
+		Severity: severity,
+	}
+}
+
+// lfsMigrateCommand builds the git lfs migrate invocation that moves offending blobs into LFS across history
+func lfsMigrateCommand(report *LFSReport) string {
+	var includes []string
+	add := func(pattern string) {
+		if pattern != "" && !containsString(includes, pattern) {
+			includes = append(includes, pattern)
+		}
+	}
+	for _, file := range report.NotPointers {
+		add(file.Pattern)
+	}
+	for _, file := range report.NotLFSTracked {
+		if ext := path.Ext(file.Path); ext != "" {
+			add("*" + ext)
+		} else {
+			add(file.Path)
+		}
+	}
+	if len(includes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("git lfs migrate import --everything --include=%q", strings.Join(includes, ","))
+}
+
+// trackedBlobs lists the blobs in the index with their sizes
+func trackedBlobs(repoPath string) ([]trackedBlob, error) {
+	cmd := exec.Command("git", "ls-files", "-s", "-z")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var blobs []trackedBlob
+	var oids []string
+	for _, entry := range strings.Split(string(output), "\x00") {
+		// <mode> <oid> <stage>\t<path>
+		meta, filePath, found := strings.Cut(entry, "\t")
+		fields := strings.Fields(meta)
+		if !found || len(fields) != 3 || fields[0] == "160000" || fields[2] != "0" {
+			continue
+		}
+		blobs = append(blobs, trackedBlob{path: filePath, oid: fields[1]})
+		oids = append(oids, fields[1])
+	}
+	if len(blobs) == 0 {
+		return blobs, nil
+	}
+
+	sizes, err := blobSizes(repoPath, oids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range blobs {
+		blobs[i].size = sizes[blobs[i].oid]
+	}
+	return blobs, nil
+}
+
+// blobSizes returns the size of each object, keyed by oid
+func blobSizes(repoPath string, oids []string) (map[string]int64, error) {
+	sizes := make(map[string]int64, len(oids))
+	if len(oids) == 0 {
+		return sizes, nil
+	}
+
+	cmd := exec.Command("git", "cat-file", "--batch-check=%(objectname) %(objectsize)")
+	cmd.Dir = repoPath
+	cmd.Stdin = strings.NewReader(strings.Join(oids, "\n") + "\n")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		oid, size, found := strings.Cut(line, " ")
+		if !found {
+			continue
+		}
+		sizes[oid], _ = strconv.ParseInt(size, 10, 64)
+	}
+	return sizes, nil
+}
+
+// readLFSPointers reads small blobs and returns the ones that are LFS pointers, keyed by blob oid
+func readLFSPointers(repoPath string, blobs []trackedBlob) (map[string]LFSPointer, error) {
+	pointers := make(map[string]LFSPointer)
+	if len(blobs) == 0 {
+		return pointers, nil
+	}
+
+	var input bytes.Buffer
+	for _, blob := range blobs {
+		input.WriteString(blob.oid + "\n")
+	}
+	cmd := exec.Command("git", "cat-file", "--batch")
+	cmd.Dir = repoPath
+	cmd.Stdin = &input
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(bytes.NewReader(output))
+	for {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			break
+		}
+		// <oid> <type> <size>
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			continue
+		}
+		size, _ := strconv.ParseInt(fields[2], 10, 64)
+		content := make([]byte, size+1) // content is followed by a newline
+		if _, err := io.ReadFull(reader, content); err != nil {
+			break
+		}
+		if pointer, ok := parseLFSPointer(content[:size]); ok {
+			pointers[fields[0]] = pointer
+		}
+	}
+	return pointers, nil
+}
+
+// lfsObjectsDir returns the local LFS object store of the repository
+func lfsObjectsDir(repoPath string) string {
+	cmd := exec.Command("git", "rev-parse", "--git-common-dir")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return filepath.Join(repoPath, ".git", "lfs", "objects")
+	}
+	gitDir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(repoPath, gitDir)
+	}
+	return filepath.Join(gitDir, "lfs", "objects")
+}
+
+// isBinaryFile applies git's heuristic: content with a NUL byte in its first 8000 bytes is binary
+func isBinaryFile(filePath string) bool {
+	return bytes.IndexByte(sniffFile(filePath), 0) >= 0
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package checkers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditLFSClassifiesTrackedFiles(t *testing.T) {
+	repo := createGitRepository(t)
+	oid := strings.Repeat("a1", 32)
+	pointer := fmt.Sprintf("%s\noid sha256:%s\nsize 2048\n", lfsPointerVersion, oid)
+	binary := append([]byte{0x00, 0x01, 0x02}, make([]byte, 64*1024)...)
+
+	files := map[string][]byte{
+		".gitattributes":          []byte("*.psd filter=lfs diff=lfs merge=lfs -text\n"),
+		"assets/model.psd":        []byte(pointer),
+		"assets/raw.psd":          []byte("not a pointer\n"),
+		"dist/bundle.zip":         binary,
+		"docs/notes.txt":          []byte(strings.Repeat("text\n", 20000)),
+		"vendor/.gitattributes":   []byte("*.bin filter=lfs\n"),
+		"vendor/nested/asset.bin": []byte(pointer),
+	}
+	for name, content := range files {
+		path := filepath.Join(repo, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "chore: add assets")
+
+	report, err := auditLFS(repo, 0.05)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.PointerFiles != 2 {
+		t.Errorf("PointerFiles = %d, want 2", report.PointerFiles)
+	}
+	if got := lfsPaths(report.NotPointers); got != "assets/raw.psd" {
+		t.Errorf("NotPointers = %q, want assets/raw.psd", got)
+	}
+	if got := lfsPaths(report.NotLFSTracked); got != "dist/bundle.zip" {
+		t.Errorf("NotLFSTracked = %q, want dist/bundle.zip", got)
+	}
+	if got := lfsPaths(report.MissingObjects); got != "assets/model.psd,vendor/nested/asset.bin" {
+		t.Errorf("MissingObjects = %q", got)
+	}
+	if !strings.Contains(report.MigrateCommand, `--include="*.psd,*.zip"`) {
+		t.Errorf("MigrateCommand = %q", report.MigrateCommand)
+	}
+
+	objectDir := filepath.Join(repo, ".git", "lfs", "objects", oid[0:2], oid[2:4])
+	if err := os.MkdirAll(objectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(objectDir, oid), make([]byte, 2048), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report, err = auditLFS(repo, 0.05)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.MissingObjects) != 0 {
+		t.Errorf("MissingObjects = %q after fetching the object", lfsPaths(report.MissingObjects))
+	}
+}
+
+func lfsPaths(files []LFSFile) string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	return strings.Join(paths, ",")
+}
@@ -0,0 +1,100 @@
+package checkers
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	gitPatternCache   = make(map[string]*regexp.Regexp)
+	gitPatternCacheMu sync.Mutex
+)
+
+// matchGitPattern reports whether a repository-relative path matches a
+// gitignore-style pattern. Patterns without a slash match at any depth,
+// patterns with a slash are anchored to the repository root, and "**"
+// matches any number of directories.
+func matchGitPattern(pattern, path string) bool {
+	re := gitPatternRegexp(pattern)
+	if re == nil {
+		return false
+	}
+	return re.MatchString(filepath.ToSlash(path))
+}
+
+// gitPatternRegexp compiles a gitignore-style pattern, caching the result
+func gitPatternRegexp(pattern string) *regexp.Regexp {
+	gitPatternCacheMu.Lock()
+	defer gitPatternCacheMu.Unlock()
+
+	if re, ok := gitPatternCache[pattern]; ok {
+		return re
+	}
+	re, err := regexp.Compile(translateGitPattern(pattern))
+	if err != nil {
+		re = nil
+	}
+	gitPatternCache[pattern] = re
+	return re
+}
+
+// translateGitPattern converts a gitignore-style pattern into a regular expression
+func translateGitPattern(pattern string) string {
+	p := strings.TrimSpace(pattern)
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		ch := p[i]
+		switch ch {
+		case '*':
+			if i+1 < len(p) && p[i+1] == '*' {
+				// "**/" matches zero or more directories, a trailing "**" matches everything
+				if i+2 < len(p) && p[i+2] == '/' {
+					b.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					b.WriteString(".*")
+					i++
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(p[i+1:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta(string(ch)))
+				continue
+			}
+			class := p[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(p) {
+				b.WriteString(regexp.QuoteMeta(string(p[i+1])))
+				i++
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	// A pattern naming a directory also covers everything beneath it
+	b.WriteString("(?:/.*)?$")
+	return b.String()
+}
@@ -0,0 +1,185 @@
+package checkers
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// PushedRef is one ref update of a push as the pre-push hook receives it
+type PushedRef struct {
+	// RemoteRef is the destination ref, such as refs/heads/main
+	RemoteRef string
+	// LocalOID is the commit pushed, all zeros for a deletion
+	LocalOID string
+	// RemoteOID is the commit the remote ref points to, all zeros for a new ref
+	RemoteOID string
+}
+
+// EvaluatePushPolicy applies a policy document to an outgoing push: protected branches may
+// not be force-pushed or deleted, except by their allowed pushers, and the pushed commits may
+// not add forbidden files. Fast-forward pushes to protected branches are allowed, as with
+// branch.<name>.protection on the server.
+func EvaluatePushPolicy(repoPath string, doc *PolicyDocument, refs []PushedRef, commits []string) ([]PolicyRuleResult, error) {
+	var results []PolicyRuleResult
+
+	if doc.ProtectedBranches != nil {
+		result := PolicyRuleResult{
+			Rule:     "protected_branches",
+			Type:     "branch_protection",
+			Severity: policyRuleSeverity(doc.ProtectedBranches.Severity),
+			Passed:   true,
+			Message:  "No protected branch is rewritten or deleted",
+		}
+		pusher := ""
+		cmd := exec.Command("git", "config", "--get", "user.email")
+		cmd.Dir = repoPath
+		if output, err := cmd.Output(); err == nil {
+			pusher = strings.TrimSpace(string(output))
+		}
+		allowed := false
+		for _, email := range doc.ProtectedBranches.AllowedPushers {
+			allowed = allowed || (pusher != "" && strings.EqualFold(email, pusher))
+		}
+		for _, ref := range refs {
+			branch, ok := strings.CutPrefix(ref.RemoteRef, "refs/heads/")
+			if !ok || !branchMatchesAny(branch, doc.ProtectedBranches.Patterns) {
+				continue
+			}
+			switch {
+			case isZeroOID(ref.LocalOID):
+				result.Details = append(result.Details, branch+" (deleted)")
+			case isZeroOID(ref.RemoteOID):
+				// Creating the branch rewrites nothing
+			case !isAncestor(repoPath, ref.RemoteOID, ref.LocalOID):
+				// A remote commit missing locally cannot be an ancestor, so overwriting it is a force-push too
+				result.Details = append(result.Details, branch+" (force-push)")
+			}
+		}
+		if len(result.Details) > 0 {
+			if allowed {
+				result.Message = fmt.Sprintf("%s may rewrite protected branches: %s", pusher, strings.Join(result.Details, ", "))
+				result.Details = nil
+			} else {
+				result.Passed = false
+				result.Message = fmt.Sprintf("Push rewrites or deletes protected branch %s", strings.Join(result.Details, ", "))
+			}
+		}
+		results = append(results, result)
+	}
+
+	if len(doc.ForbiddenFiles) == 0 {
+		return results, nil
+	}
+	changed, err := commitChangedFiles(repoPath, commits)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range doc.ForbiddenFiles {
+		result := PolicyRuleResult{
+			Rule:     "forbidden_files:" + rule.Pattern,
+			Type:     "forbidden_file",
+			Severity: policyRuleSeverity(rule.Severity),
+			Passed:   true,
+		}
+		for _, file := range changed {
+			if matchGitPattern(rule.Pattern, file.path) {
+				result.Details = append(result.Details, fmt.Sprintf("%s (commit %s)", file.path, types.ShortHash(file.commit)))
+			}
+		}
+		if len(result.Details) > 0 {
+			result.Passed = false
+			description := rule.Description
+			if description == "" {
+				description = "Forbidden files"
+			}
+			result.Message = fmt.Sprintf("%s matching %s added by pushed commits: %d found", description, rule.Pattern, len(result.Details))
+		} else {
+			result.Message = fmt.Sprintf("No pushed files match %s", rule.Pattern)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// isZeroOID reports whether oid is the all-zero name Git uses for a missing ref
+func isZeroOID(oid string) bool {
+	return strings.Trim(oid, "0") == ""
+}
+
+// changedFile is a path added or modified by a commit
+type changedFile struct {
+	commit string
+	path   string
+	oid    string
+}
+
+// commitChangedFiles lists the paths each commit adds, copies, modifies or renames
+// together with the blob they hold after the commit
+func commitChangedFiles(repoPath string, commits []string) ([]changedFile, error) {
+	if len(commits) == 0 {
+		return nil, nil
+	}
+
+	cmd := exec.Command("git", "log", "-z", "--stdin", "--no-walk=unsorted", "--format=%x1e%H", "--raw", "--no-abbrev", "--diff-filter=ACMR", "--diff-merges=first-parent")
+	cmd.Dir = repoPath
+	cmd.Stdin = revisionInput(commits)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list files of pushed commits: %w", err)
+	}
+
+	var files []changedFile
+	for _, change := range parseRawLog(string(output)) {
+		if change.newMode == "160000" {
+			continue
+		}
+		files = append(files, changedFile{commit: change.header, path: change.path, oid: change.newOid})
+	}
+	return files, nil
+}
+
+// rawChange is one entry of git log -z --raw output
+type rawChange struct {
+	// header is the text of the commit's "%x1e..." format line
+	header  string
+	newMode string
+	oldOid  string
+	newOid  string
+	status  string
+	// oldPath is set for renames and copies
+	oldPath string
+	path    string
+}
+
+// parseRawLog parses git log -z --raw output whose format starts each commit with \x1e.
+// With -z paths are NUL-terminated and unquoted, so any character survives:
+// :<old mode> <new mode> <old oid> <new oid> <status>\0<path>\0, with two paths for renames and copies.
+func parseRawLog(output string) []rawChange {
+	var changes []rawChange
+	header := ""
+	tokens := strings.Split(output, "\x00")
+	for i := 0; i < len(tokens); i++ {
+		token := strings.TrimLeft(tokens[i], "\n")
+		switch {
+		case strings.HasPrefix(token, "\x1e"):
+			header = strings.TrimSpace(strings.TrimPrefix(token, "\x1e"))
+		case strings.HasPrefix(token, ":"):
+			meta := strings.Fields(token)
+			if len(meta) < 5 || i+1 >= len(tokens) {
+				continue
+			}
+			change := rawChange{header: header, newMode: meta[1], oldOid: meta[2], newOid: meta[3], status: meta[4]}
+			i++
+			change.path = tokens[i]
+			if (change.status[0] == 'R' || change.status[0] == 'C') && i+1 < len(tokens) {
+				i++
+				change.oldPath, change.path = change.path, tokens[i]
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
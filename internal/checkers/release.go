@@ -0,0 +1,500 @@
+package checkers
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ReleaseOptions configures how the next release is computed and written
+type ReleaseOptions struct {
+	// TagPrefix is prepended to the version in tag names, usually "v" or "api/v" for a monorepo component
+	TagPrefix string
+	// Major limits the release to tags of one major version, as for a Go module in a v2 subdirectory
+	Major int
+	// Paths limit the release to commits touching these pathspecs; empty means the whole repository
+	Paths []string
+	// Component names the monorepo component released; empty for the whole repository
+	Component string
+	// Prerelease is a pre-release channel such as rc, beta or alpha; the tag gets -<channel>.N
+	Prerelease string
+	// Build is semver build metadata appended as +<build>
+	Build string
+	// Bump forces a major, minor or patch bump instead of deriving it from the commits
+	Bump string
+	// VersionFile is updated with the new version; empty detects VERSION, version.txt or package.json
+	VersionFile string
+	// VersionDir is the directory, relative to the repository, the version file is detected in
+	VersionDir string
+	// Changelog is the file the release notes are merged into; empty skips it
+	Changelog string
+	// ChangelogTypes maps commit types to changelog sections as in ChangelogOptions; empty uses DefaultChangelogTypes
+	ChangelogTypes map[string]string
+	// Sign creates a signed tag instead of an annotated one
+	Sign bool
+	// Now is the release date; zero means time.Now
+	Now time.Time
+}
+
+// DefaultReleaseOptions returns the settings used when gphc.yml sets none
+func DefaultReleaseOptions() ReleaseOptions {
+	return ReleaseOptions{TagPrefix: "v", Changelog: "CHANGELOG.md"}
+}
+
+// ReleaseCommit is a commit as it appears in release notes
+type ReleaseCommit struct {
+	Hash        string `json:"hash"`
+	Type        string `json:"type,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	Description string `json:"description"`
+	Author      string `json:"author,omitempty"`
+	Breaking    bool   `json:"breaking,omitempty"`
+	// BreakingNote is the text of a BREAKING CHANGE footer
+	BreakingNote string `json:"breaking_note,omitempty"`
+}
+
+// ReleasePlan is everything a release will do, computed before anything is written
+type ReleasePlan struct {
+	// Component is the monorepo component released; empty for the whole repository
+	Component string `json:"component,omitempty"`
+	// PreviousTag is the tag the release notes start from; empty for a first release
+	PreviousTag string          `json:"previous_tag,omitempty"`
+	Version     Version         `json:"version"`
+	Tag         string          `json:"tag"`
+	Bump        string          `json:"bump"`
+	Date        time.Time       `json:"date"`
+	Commits     []ReleaseCommit `json:"commits"`
+	// Notes are the release notes used for the changelog entry and tag message
+	Notes       string `json:"notes"`
+	VersionFile string `json:"version_file,omitempty"`
+	Changelog   string `json:"changelog,omitempty"`
+	Sign        bool   `json:"sign"`
+	// Files holds the new content of each file the release rewrites
+	Files map[string]string `json:"-"`
+}
+
+var (
+	releaseHeaderRe   = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+	breakingFooterRe  = regexp.MustCompile(`^BREAKING[ -]CHANGE:\s*(.*)$`)
+	trailerLineRe     = regexp.MustCompile(`^[\w-]+: `)
+	packageVersionRe  = regexp.MustCompile(`("version"\s*:\s*")[^"]*(")`)
+	releaseChannelRe  = regexp.MustCompile(`^[A-Za-z][0-9A-Za-z-]*$`)
+	releaseBuildRe    = regexp.MustCompile(`^[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*$`)
+	embeddedVersionRe = regexp.MustCompile(`v?\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?`)
+)
+
+// versionFileCandidates are checked in order when no version file is configured
+var versionFileCandidates = []string{"VERSION", "VERSION.txt", "version.txt", "package.json"}
+
+// ParseReleaseCommit reads the conventional commit type, scope and breaking changes of a commit
+func ParseReleaseCommit(hash, subject, body string) ReleaseCommit {
+	commit := ReleaseCommit{Hash: hash, Description: strings.TrimSpace(subject)}
+	if match := releaseHeaderRe.FindStringSubmatch(commit.Description); match != nil {
+		commit.Type = strings.ToLower(match[1])
+		commit.Scope = match[2]
+		commit.Breaking = match[3] == "!"
+		commit.Description = strings.TrimSpace(match[4])
+	}
+	// The footer runs until a blank line or the next trailer
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		match := breakingFooterRe.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		note := []string{match[1]}
+		for _, next := range lines[i+1:] {
+			if strings.TrimSpace(next) == "" || trailerLineRe.MatchString(next) {
+				break
+			}
+			note = append(note, next)
+		}
+		commit.Breaking = true
+		commit.BreakingNote = strings.Join(strings.Fields(strings.Join(note, " ")), " ")
+		break
+	}
+	return commit
+}
+
+// releaseBump returns the bump level the commits call for
+func releaseBump(commits []ReleaseCommit) string {
+	level := BumpPatch
+	for _, commit := range commits {
+		if commit.Breaking {
+			return BumpMajor
+		}
+		if commit.Type == "feat" {
+			level = BumpMinor
+		}
+	}
+	return level
+}
+
+// bumpVersion applies a bump level to a release. Before 1.0.0 a breaking change bumps the minor
+// version unless forced asks for the major bump explicitly.
+func bumpVersion(current Version, level string, forced bool) Version {
+	if current.Major == 0 && level == BumpMajor && !forced {
+		level = BumpMinor
+	}
+	return current.Bump(level)
+}
+
+// releaseTag is a tag parsed as a version
+type releaseTag struct {
+	name    string
+	version Version
+}
+
+// versionTags lists the tags with the prefix that parse as semantic versions of the major version
+// (see inMajor); merged limits them to tags reachable from HEAD
+func versionTags(repoPath, prefix string, major int, merged bool) ([]releaseTag, error) {
+	args := []string{"tag", "--list"}
+	if merged {
+		args = append(args, "--merged", "HEAD")
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	var tags []releaseTag
+	for _, name := range strings.Fields(string(output)) {
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok || strings.HasPrefix(rest, "v") {
+			continue
+		}
+		if version, err := ParseVersion(rest); err == nil && inMajor(version, major) {
+			tags = append(tags, releaseTag{name: name, version: version})
+		}
+	}
+	return tags, nil
+}
+
+// releaseCommitsSince lists the non-merge commits after from up to HEAD; an empty from means all history
+func releaseCommitsSince(repoPath, from string, pathspecs ...string) ([]ReleaseCommit, error) {
+	return releaseCommitsBetween(repoPath, from, "HEAD", pathspecs...)
+}
+
+// releaseCommitsBetween lists the non-merge commits after from up to and including to, newest first,
+// limited to the commits touching the pathspecs when any are given
+func releaseCommitsBetween(repoPath, from, to string, pathspecs ...string) ([]ReleaseCommit, error) {
+	revision := to
+	if from != "" {
+		revision = from + ".." + to
+	}
+	args := []string{"log", "--no-merges", "--format=%x1e%H%x1f%an%x1f%s%x1f%b", revision}
+	if len(pathspecs) > 0 {
+		args = append(append(args, "--"), pathspecs...)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("read commits: %w", err)
+	}
+	var commits []ReleaseCommit
+	for _, record := range strings.Split(string(output), "\x1e") {
+		fields := strings.SplitN(record, "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		commit := ParseReleaseCommit(fields[0], fields[2], fields[3])
+		commit.Author = fields[1]
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// PlanRelease computes the next version from the conventional commits since the last
+// stable release and prepares the notes, changelog and version file without writing them.
+//
+// A breaking change bumps the major version (the minor one before 1.0.0), a feature the minor
+// and anything else the patch. A pre-release channel numbers releases of the same version:
+// 1.3.0-rc.1, 1.3.0-rc.2, and later pre-releases never fall back below an earlier one.
+func PlanRelease(repoPath string, options ReleaseOptions) (*ReleasePlan, error) {
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+	if !hasHead(repoPath) {
+		return nil, fmt.Errorf("repository has no commits")
+	}
+	switch options.Bump {
+	case "", BumpMajor, BumpMinor, BumpPatch:
+	default:
+		return nil, fmt.Errorf("unknown bump %q (use major, minor or patch)", options.Bump)
+	}
+	if options.Prerelease != "" && !releaseChannelRe.MatchString(options.Prerelease) {
+		return nil, fmt.Errorf("invalid pre-release channel %q", options.Prerelease)
+	}
+	if options.Build != "" && !releaseBuildRe.MatchString(options.Build) {
+		return nil, fmt.Errorf("invalid build metadata %q", options.Build)
+	}
+
+	reachable, err := versionTags(repoPath, options.TagPrefix, options.Major, true)
+	if err != nil {
+		return nil, err
+	}
+	all, err := versionTags(repoPath, options.TagPrefix, options.Major, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var stable, latest *releaseTag
+	for i, tag := range reachable {
+		if !tag.version.IsPrerelease() && (stable == nil || tag.version.Compare(stable.version) > 0) {
+			stable = &reachable[i]
+		}
+		if latest == nil || tag.version.Compare(latest.version) > 0 {
+			latest = &reachable[i]
+		}
+	}
+
+	since := ""
+	if stable != nil {
+		since = stable.name
+	}
+	commits, err := releaseCommitsSince(repoPath, since, options.Paths...)
+	if err != nil {
+		return nil, err
+	}
+	plan := &ReleasePlan{Component: options.Component, Date: options.Now, Sign: options.Sign, Bump: options.Bump}
+	if plan.Bump == "" {
+		plan.Bump = releaseBump(commits)
+	}
+	var next Version
+	if stable == nil {
+		next = firstVersion(options.Major)
+		if plan.Bump == BumpMajor && options.Bump != "" && options.Major <= 1 {
+			next = Version{Major: 1}
+		}
+	} else {
+		next = bumpVersion(stable.version, plan.Bump, options.Bump != "")
+	}
+	// Pre-releases already cut for a later version keep that version
+	if latest != nil && latest.version.IsPrerelease() && latest.version.Core().Compare(next) > 0 {
+		next = latest.version.Core()
+	}
+
+	notesFrom := since
+	if options.Prerelease != "" {
+		number := 0
+		for _, tag := range all {
+			channel, n := tag.version.Channel()
+			if tag.version.Core() == next && channel == options.Prerelease && n > number {
+				number = n
+			}
+		}
+		next.Prerelease = fmt.Sprintf("%s.%d", options.Prerelease, number+1)
+		// A pre-release lists the changes since the previous tag of any kind
+		if latest != nil {
+			notesFrom = latest.name
+		}
+	}
+	next.Build = options.Build
+
+	plan.Version = next
+	plan.Tag = options.TagPrefix + next.String()
+	for _, tag := range all {
+		if tag.name == plan.Tag {
+			return nil, fmt.Errorf("tag %s already exists", plan.Tag)
+		}
+	}
+	plan.PreviousTag = notesFrom
+	if notesFrom != since {
+		if commits, err = releaseCommitsSince(repoPath, notesFrom, options.Paths...); err != nil {
+			return nil, err
+		}
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits since %s", notesFrom)
+	}
+	plan.Commits = commits
+	plan.Notes = ReleaseNotes(commits, options.ChangelogTypes)
+
+	plan.Files = make(map[string]string)
+	if err := plan.prepareVersionFile(repoPath, options.VersionDir, options.VersionFile); err != nil {
+		return nil, err
+	}
+	if options.Changelog != "" {
+		existing, err := os.ReadFile(filepath.Join(repoPath, options.Changelog))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		plan.Changelog = options.Changelog
+		content, err := plan.changelogSection(repoPath, options.ChangelogTypes)
+		if err != nil {
+			return nil, err
+		}
+		plan.Files[options.Changelog] = MergeChangelog(string(existing), content)
+	}
+	return plan, nil
+}
+
+// prepareVersionFile computes the new content of the configured version file or the one detected in dir
+func (p *ReleasePlan) prepareVersionFile(repoPath, dir, versionFile string) error {
+	if versionFile == "" {
+		for _, candidate := range versionFileCandidates {
+			candidate = path.Join(dir, candidate)
+			if _, err := os.Stat(filepath.Join(repoPath, candidate)); err == nil {
+				versionFile = candidate
+				break
+			}
+		}
+		if versionFile == "" {
+			return nil
+		}
+	}
+	content, err := os.ReadFile(filepath.Join(repoPath, versionFile))
+	if err != nil {
+		return fmt.Errorf("read version file: %w", err)
+	}
+	updated, err := UpdateVersionContent(versionFile, string(content), p.Version)
+	if err != nil {
+		return err
+	}
+	p.VersionFile = versionFile
+	p.Files[versionFile] = updated
+	return nil
+}
+
+// UpdateVersionContent writes a version into a version file: the "version" field of
+// package.json, the whole of an empty file, or else the first version found in the file,
+// keeping its "v" prefix
+func UpdateVersionContent(path, content string, version Version) (string, error) {
+	if filepath.Base(path) == "package.json" {
+		if !packageVersionRe.MatchString(content) {
+			return "", fmt.Errorf("%s has no version field", path)
+		}
+		replaced := false
+		return packageVersionRe.ReplaceAllStringFunc(content, func(match string) string {
+			if replaced {
+				return match
+			}
+			replaced = true
+			return packageVersionRe.ReplaceAllString(match, "${1}"+version.String()+"${2}")
+		}), nil
+	}
+	if strings.TrimSpace(content) == "" {
+		return version.String() + "\n", nil
+	}
+	location := embeddedVersionRe.FindStringIndex(content)
+	if location == nil {
+		return "", fmt.Errorf("%s contains no version to update", path)
+	}
+	replacement := version.String()
+	if strings.HasPrefix(content[location[0]:], "v") {
+		replacement = "v" + replacement
+	}
+	return content[:location[0]] + replacement + content[location[1]:], nil
+}
+
+// ReleaseNotes groups commits into the Keep a Changelog sections gphc changelog writes, so both commands
+// keep one heading scheme in CHANGELOG.md; breaking changes come first
+func ReleaseNotes(commits []ReleaseCommit, sections map[string]string) string {
+	if len(sections) == 0 {
+		sections = DefaultChangelogTypes()
+	}
+	release := buildChangelogRelease("", "", "", commits, ChangelogOptions{Types: sections}, RemoteLinks{}, false)
+
+	var b strings.Builder
+	if len(release.Breaking) > 0 {
+		b.WriteString("### BREAKING CHANGES\n\n")
+		for _, entry := range release.Breaking {
+			b.WriteString("- " + entry.Text + "\n")
+		}
+		b.WriteString("\n")
+	}
+	for _, section := range release.Sections {
+		b.WriteString("### " + section.Title + "\n\n")
+		for _, entry := range section.Entries {
+			b.WriteString("- " + entry.Text + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if b.Len() == 0 {
+		return "No notable changes.\n"
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// changelogSection renders the release as a Keep a Changelog document with the remote's commit, issue
+// and compare links, ready to be merged into the existing changelog
+func (p *ReleasePlan) changelogSection(repoPath string, sections map[string]string) (string, error) {
+	if len(sections) == 0 {
+		sections = DefaultChangelogTypes()
+	}
+	options := ChangelogOptions{Format: ChangelogFormatKeepAChangelog, Types: sections}
+	links, linked := changelogRemoteLinks(repoPath, "")
+	release := buildChangelogRelease(p.Version.String(), p.Tag, p.PreviousTag, p.Commits, options, links, linked)
+	release.Date = p.Date
+	changelog := &Changelog{Releases: []ChangelogRelease{release}}
+	if linked {
+		changelog.RemoteURL = links.WebURL
+	}
+	return RenderChangelog(changelog, options)
+}
+
+// TagMessage is the annotated tag message: the tag name followed by the release notes
+func (p *ReleasePlan) TagMessage() string {
+	return p.Tag + "\n\n" + p.Notes
+}
+
+// ApplyRelease writes the version file and changelog, commits them and creates the tag.
+// The working tree must be clean so the release commit holds only the release changes.
+func ApplyRelease(repoPath string, plan *ReleasePlan) error {
+	status := exec.Command("git", "status", "--porcelain", "--untracked-files=no")
+	status.Dir = repoPath
+	output, err := status.Output()
+	if err != nil {
+		return fmt.Errorf("read working tree status: %w", err)
+	}
+	if strings.TrimSpace(string(output)) != "" {
+		return fmt.Errorf("working tree has uncommitted changes; commit or stash them first")
+	}
+
+	if len(plan.Files) > 0 {
+		args := []string{"add", "--"}
+		for path, content := range plan.Files {
+			if err := os.WriteFile(filepath.Join(repoPath, path), []byte(content), 0644); err != nil {
+				return err
+			}
+			args = append(args, path)
+		}
+		if err := runReleaseGit(repoPath, "", args...); err != nil {
+			return err
+		}
+		message := "chore(release): " + plan.Version.String()
+		if plan.Component != "" {
+			message = "chore(release): " + plan.Tag
+		}
+		if err := runReleaseGit(repoPath, "", "commit", "-m", message); err != nil {
+			return err
+		}
+	}
+
+	kind := "-a"
+	if plan.Sign {
+		kind = "-s"
+	}
+	// Verbatim cleanup keeps the "###" section headings, which git would strip as comments
+	return runReleaseGit(repoPath, plan.TagMessage(), "tag", kind, "--cleanup=verbatim", "-F", "-", plan.Tag)
+}
+
+func runReleaseGit(repoPath, stdin string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
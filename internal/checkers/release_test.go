@@ -0,0 +1,198 @@
+package checkers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVersionPrecedence(t *testing.T) {
+	// Ordered as in the semver 2.0.0 specification
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1+build.5", "1.1.0", "2.0.0"}
+	for i := 0; i+1 < len(ordered); i++ {
+		a, err := ParseVersion(ordered[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ParseVersion(ordered[i+1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Compare(b) >= 0 || b.Compare(a) <= 0 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+
+	for _, invalid := range []string{"1.0", "01.0.0", "1.0.0-", "1.0.0-01", "1.0.0+"} {
+		if _, err := ParseVersion(invalid); err == nil {
+			t.Errorf("ParseVersion(%q) should fail", invalid)
+		}
+	}
+	if v, _ := ParseVersion("v2.1.0-rc.3+sha.abc"); v.String() != "2.1.0-rc.3+sha.abc" {
+		t.Errorf("unexpected round trip %s", v)
+	}
+}
+
+func TestParseReleaseCommit(t *testing.T) {
+	commit := ParseReleaseCommit("abc", "feat(api): add paging", "Adds cursors.\n\nBREAKING CHANGE: list endpoints\nreturn pages\nRefs: #12\n")
+	if commit.Type != "feat" || commit.Scope != "api" || !commit.Breaking || commit.BreakingNote != "list endpoints return pages" {
+		t.Errorf("unexpected commit %+v", commit)
+	}
+	if commit := ParseReleaseCommit("def", "fix!: drop v1 config", ""); !commit.Breaking || commit.Description != "drop v1 config" {
+		t.Errorf("unexpected commit %+v", commit)
+	}
+	if commit := ParseReleaseCommit("123", "Update readme", ""); commit.Type != "" || commit.Description != "Update readme" {
+		t.Errorf("unexpected commit %+v", commit)
+	}
+}
+
+func TestPlanAndApplyRelease(t *testing.T) {
+	repo := createGitRepository(t)
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit := func(message string) {
+		t.Helper()
+		write("work.txt", message+"\n")
+		runGit(t, repo, "add", ".")
+		runGit(t, repo, "commit", "-qm", message)
+	}
+	write("VERSION", "v1.2.0\n")
+	write("CHANGELOG.md", "# Changelog\n\nAll notable changes.\n\n## [1.2.0] - 2024-01-01\n\n- Older\n")
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "chore: release 1.2.0")
+	runGit(t, repo, "tag", "-a", "v1.2.0", "-m", "v1.2.0")
+
+	commit("feat(cli): add release command")
+	commit("ci: cache modules")
+
+	options := DefaultReleaseOptions()
+	options.Prerelease = "rc"
+	options.Now = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	plan, err := PlanRelease(repo, options)
+	if err != nil {
+		t.Fatalf("PlanRelease failed: %v", err)
+	}
+	if plan.Tag != "v1.3.0-rc.1" || plan.Bump != BumpMinor || plan.VersionFile != "VERSION" {
+		t.Fatalf("unexpected plan %+v", plan)
+	}
+	if !strings.Contains(plan.Notes, "### Added\n\n- **cli:** add release command") || strings.Contains(plan.Notes, "cache modules") {
+		t.Errorf("unexpected notes:\n%s", plan.Notes)
+	}
+	if err := ApplyRelease(repo, plan); err != nil {
+		t.Fatalf("ApplyRelease failed: %v", err)
+	}
+
+	if content, _ := os.ReadFile(filepath.Join(repo, "VERSION")); string(content) != "v1.3.0-rc.1\n" {
+		t.Errorf("VERSION = %q", content)
+	}
+	changelog, _ := os.ReadFile(filepath.Join(repo, "CHANGELOG.md"))
+	if !strings.HasPrefix(string(changelog), "# Changelog\n\nAll notable changes.\n\n## [1.3.0-rc.1] - 2024-03-01\n") ||
+		!strings.Contains(string(changelog), "## [1.2.0] - 2024-01-01") {
+		t.Errorf("unexpected changelog:\n%s", changelog)
+	}
+	if kind := gitOutput(t, repo, "cat-file", "-t", "v1.3.0-rc.1"); kind != "tag" {
+		t.Errorf("expected an annotated tag, got %s", kind)
+	}
+	if message := gitOutput(t, repo, "tag", "-l", "--format=%(contents)", "v1.3.0-rc.1"); !strings.Contains(message, "### Added") {
+		t.Errorf("tag message lost its headings:\n%s", message)
+	}
+
+	// A second candidate is numbered after the first and lists only the new commits
+	commit("fix: handle empty history")
+	plan, err = PlanRelease(repo, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Tag != "v1.3.0-rc.2" || plan.PreviousTag != "v1.3.0-rc.1" || len(plan.Commits) != 1 {
+		t.Errorf("unexpected second candidate %s from %s with %d commits", plan.Tag, plan.PreviousTag, len(plan.Commits))
+	}
+
+	// The stable release covers everything since the last stable one
+	options.Prerelease = ""
+	options.Build = "ci.42"
+	plan, err = PlanRelease(repo, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Tag != "v1.3.0+ci.42" || plan.PreviousTag != "v1.2.0" {
+		t.Errorf("unexpected stable release %s from %s", plan.Tag, plan.PreviousTag)
+	}
+
+	runGit(t, repo, "tag", "-a", "v1.3.0", "-m", "v1.3.0")
+	options.Build = ""
+	if _, err := PlanRelease(repo, options); err == nil {
+		t.Error("releasing without new commits should fail")
+	}
+}
+
+func TestReleaseMergesGeneratedChangelog(t *testing.T) {
+	repo := createGitRepository(t)
+	runGit(t, repo, "remote", "add", "origin", "git@github.com:acme/widget.git")
+	commit := func(message string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, "work.txt"), []byte(message+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, repo, "add", ".")
+		runGit(t, repo, "commit", "-qm", message)
+	}
+	commit("feat: first feature")
+	runGit(t, repo, "tag", "-a", "v0.1.0", "-m", "v0.1.0")
+	commit("fix: handle tabs (closes #12)")
+
+	path := filepath.Join(repo, "CHANGELOG.md")
+	if _, err := GenerateChangelog(repo, path); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "docs: update changelog")
+
+	options := DefaultReleaseOptions()
+	options.Now = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	plan, err := PlanRelease(repo, options)
+	if err != nil {
+		t.Fatalf("PlanRelease failed: %v", err)
+	}
+	if plan.Tag != "v0.1.1" {
+		t.Fatalf("unexpected tag %s", plan.Tag)
+	}
+	content := plan.Files["CHANGELOG.md"]
+	for _, want := range []string{
+		"## [0.1.1] - 2024-03-01\n\n### Fixed\n\n- handle tabs (closes [#12](https://github.com/acme/widget/issues/12)) ([",
+		"](https://github.com/acme/widget/commit/",
+		"[0.1.1]: https://github.com/acme/widget/compare/v0.1.0...v0.1.1",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("changelog is missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "[Unreleased]") {
+		t.Errorf("released entries should leave the Unreleased section:\n%s", content)
+	}
+	if strings.Index(content, "## [0.1.1]") > strings.Index(content, "## [0.1.0]") {
+		t.Errorf("the release should come before 0.1.0:\n%s", content)
+	}
+
+	// Work after the release keeps Unreleased above it
+	if err := ApplyRelease(repo, plan); err != nil {
+		t.Fatalf("ApplyRelease failed: %v", err)
+	}
+	commit("feat: unreleased work")
+	merged, err := GenerateChangelog(repo, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Index(merged, "## [Unreleased]") > strings.Index(merged, "## [0.1.1]") {
+		t.Errorf("Unreleased should come first:\n%s", merged)
+	}
+	if !strings.Contains(merged, "[Unreleased]: https://github.com/acme/widget/compare/v0.1.1...HEAD") {
+		t.Errorf("the Unreleased link should compare the new release:\n%s", merged)
+	}
+}
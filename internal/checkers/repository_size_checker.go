@@ -0,0 +1,550 @@
+package checkers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// RepositorySizeChecker measures repository weight: packs, loose objects and large blobs
+type RepositorySizeChecker struct {
+	BaseChecker
+	thresholds  SizeThresholds
+	scanHistory bool
+}
+
+// SizeThresholds are the limits above which a clone is considered too heavy
+type SizeThresholds struct {
+	MaxPackSizeMB   float64 `json:"max_pack_size_mb"`
+	MaxLooseObjects int     `json:"max_loose_objects"`
+	MaxBlobSizeMB   float64 `json:"max_blob_size_mb"`
+}
+
+// SizeAnalysisOptions controls how much of the history is analyzed
+type SizeAnalysisOptions struct {
+	// TopN is the number of largest blobs and trees to report
+	TopN int
+	// IncludeOrigins looks up the commit that introduced each large blob
+	IncludeOrigins bool
+	// GrowthMonths is the number of recent months of per-directory growth to report; zero disables it
+	GrowthMonths int
+	// MaxBlobSizeMB counts the blobs above it in the whole history, not only among the TopN; zero disables it
+	MaxBlobSizeMB float64
+	// SkipHistory measures only the object storage, without walking every reachable object
+	SkipHistory bool
+}
+
+// ObjectStats mirrors the output of git count-objects -v
+type ObjectStats struct {
+	LooseObjects  int   `json:"loose_objects"`
+	LooseSize     int64 `json:"loose_size"`
+	PackedObjects int   `json:"packed_objects"`
+	Packs         int   `json:"packs"`
+	PackSize      int64 `json:"pack_size"`
+	PrunePackable int   `json:"prune_packable"`
+	Garbage       int   `json:"garbage"`
+	GarbageSize   int64 `json:"garbage_size"`
+}
+
+// LargeObject is a blob or tree ranked by size
+type LargeObject struct {
+	Oid      string  `json:"oid"`
+	Path     string  `json:"path"`
+	Size     int64   `json:"size"`
+	SizeMB   float64 `json:"size_mb"`
+	DiskSize int64   `json:"disk_size"`
+	Commit   string  `json:"commit,omitempty"`
+	Author   string  `json:"author,omitempty"`
+	Date     string  `json:"date,omitempty"`
+}
+
+// MonthlyGrowth is the size of new blobs added in a month
+type MonthlyGrowth struct {
+	Month string `json:"month"`
+	Bytes int64  `json:"bytes"`
+}
+
+// DirectoryGrowth tracks how much new content a top-level directory accumulated
+type DirectoryGrowth struct {
+	Directory  string          `json:"directory"`
+	TotalBytes int64           `json:"total_bytes"`
+	Months     []MonthlyGrowth `json:"months"`
+}
+
+// RepositorySizeReport is the complete repository weight analysis
+type RepositorySizeReport struct {
+	Objects     ObjectStats `json:"objects"`
+	TotalSize   int64       `json:"total_size"`
+	TotalSizeMB float64     `json:"total_size_mb"`
+	CommitCount int         `json:"commit_count"`
+	TreeCount   int         `json:"tree_count"`
+	BlobCount   int         `json:"blob_count"`
+	BlobSize    int64       `json:"blob_size"`
+	// HistoryScanned is false when only the object storage was measured
+	HistoryScanned bool          `json:"history_scanned"`
+	LargestBlobs   []LargeObject `json:"largest_blobs"`
+	// OversizedBlobs counts every reachable blob above MaxBlobSizeMB, however many are listed
+	OversizedBlobs int               `json:"oversized_blobs"`
+	LargestTrees   []LargeObject     `json:"largest_trees"`
+	Growth         []DirectoryGrowth `json:"growth,omitempty"`
+	Thresholds     SizeThresholds    `json:"thresholds"`
+	Warnings       []string          `json:"warnings"`
+	Exceeded       bool              `json:"exceeded"`
+	Score          int               `json:"score"`
+}
+
+// reachableObject is an object listed by git rev-list --objects
+type reachableObject struct {
+	oid        string
+	path       string
+	objectType string
+	size       int64
+	diskSize   int64
+}
+
+// DefaultSizeThresholds returns the default repository size limits
+func DefaultSizeThresholds() SizeThresholds {
+	return SizeThresholds{
+		MaxPackSizeMB:   500,
+		MaxLooseObjects: 1000,
+		MaxBlobSizeMB:   50,
+	}
+}
+
+// NewRepositorySizeChecker creates a repository size checker with default thresholds
+func NewRepositorySizeChecker() *RepositorySizeChecker {
+	return NewRepositorySizeCheckerWithThresholds(DefaultSizeThresholds())
+}
+
+// NewRepositorySizeCheckerWithThresholds creates a repository size checker with custom thresholds
+func NewRepositorySizeCheckerWithThresholds(thresholds SizeThresholds) *RepositorySizeChecker {
+	defaults := DefaultSizeThresholds()
+	if thresholds.MaxPackSizeMB <= 0 {
+		thresholds.MaxPackSizeMB = defaults.MaxPackSizeMB
+	}
+	if thresholds.MaxLooseObjects <= 0 {
+		thresholds.MaxLooseObjects = defaults.MaxLooseObjects
+	}
+	if thresholds.MaxBlobSizeMB <= 0 {
+		thresholds.MaxBlobSizeMB = defaults.MaxBlobSizeMB
+	}
+	return &RepositorySizeChecker{
+		BaseChecker: NewBaseChecker("Repository Size Checker", "REPO-SIZE", types.CategoryHygiene, 5),
+		thresholds:  thresholds,
+	}
+}
+
+// SetScanHistory makes Check walk every reachable object for large blobs, as gphc size does.
+// The walk reads the whole history, so by default Check only measures the object storage.
+func (c *RepositorySizeChecker) SetScanHistory(enabled bool) {
+	c.scanHistory = enabled
+}
+
+// Check measures the repository and compares it with the configured thresholds
+func (c *RepositorySizeChecker) Check(data *types.RepositoryData) *types.CheckResult {
+	result, _ := c.CheckWithOptions(data, SizeAnalysisOptions{TopN: 5, SkipHistory: !c.scanHistory})
+	return result
+}
+
+// CheckWithOptions runs the size analysis and also returns the full report
+func (c *RepositorySizeChecker) CheckWithOptions(data *types.RepositoryData, options SizeAnalysisOptions) (*types.CheckResult, *RepositorySizeReport) {
+	result := &types.CheckResult{
+		ID:        "CLEAN-404",
+		Name:      "Repository Size & Packfile Health",
+		Category:  c.Category(),
+		Status:    types.StatusPass,
+		Score:     100,
+		Details:   []string{},
+		Timestamp: time.Now(),
+	}
+
+	options.MaxBlobSizeMB = c.thresholds.MaxBlobSizeMB
+	report, err := AnalyzeRepositorySize(data.Path, options)
+	if err != nil {
+		result.Status = types.StatusWarning
+		result.Score = 0
+		result.Message = "Could not analyze repository size"
+		result.Details = append(result.Details, err.Error())
+		return result, nil
+	}
+	report.Thresholds = c.thresholds
+
+	failed := c.evaluate(report)
+	result.Score = report.Score
+	switch {
+	case failed:
+		result.Status = types.StatusFail
+		result.Message = fmt.Sprintf("Repository is %.1f MB, far above the %.0f MB limit", report.TotalSizeMB, c.thresholds.MaxPackSizeMB)
+	case len(report.Warnings) > 0:
+		result.Status = types.StatusWarning
+		result.Message = fmt.Sprintf("Repository size needs attention (%d warning(s))", len(report.Warnings))
+	default:
+		result.Message = fmt.Sprintf("Repository size is healthy (%.1f MB)", report.TotalSizeMB)
+	}
+
+	result.Details = append(result.Details, repositorySizeDetails(report)...)
+	return result, report
+}
+
+// evaluate applies the thresholds to the report, reporting whether the size is a failure
+func (c *RepositorySizeChecker) evaluate(report *RepositorySizeReport) bool {
+	score := 100
+	failed := false
+	report.Warnings = []string{}
+
+	if report.TotalSizeMB > c.thresholds.MaxPackSizeMB {
+		report.Exceeded = true
+		if report.TotalSizeMB > 2*c.thresholds.MaxPackSizeMB {
+			failed = true
+			score -= 40
+		} else {
+			score -= 25
+		}
+		report.Warnings = append(report.Warnings, fmt.Sprintf("Repository size %.1f MB exceeds %g MB", report.TotalSizeMB, c.thresholds.MaxPackSizeMB))
+	}
+	if report.Objects.LooseObjects > c.thresholds.MaxLooseObjects {
+		score -= 10
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%d loose objects exceed %d; run git gc", report.Objects.LooseObjects, c.thresholds.MaxLooseObjects))
+	}
+
+	if report.OversizedBlobs > 0 {
+		score -= 15
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%d blob(s) in history exceed %g MB", report.OversizedBlobs, c.thresholds.MaxBlobSizeMB))
+	}
+	if report.Objects.Garbage > 0 {
+		score -= 5
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%d garbage file(s) in the object directory", report.Objects.Garbage))
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	report.Score = score
+	return failed
+}
+
+// repositorySizeDetails renders the report as result detail lines
+func repositorySizeDetails(report *RepositorySizeReport) []string {
+	details := []string{
+		fmt.Sprintf("Total Size: %s (packs %s in %d pack(s), loose %s)", formatBytes(report.TotalSize), formatBytes(report.Objects.PackSize), report.Objects.Packs, formatBytes(report.Objects.LooseSize)),
+		fmt.Sprintf("Objects: %d packed, %d loose", report.Objects.PackedObjects, report.Objects.LooseObjects),
+	}
+	if report.HistoryScanned {
+		details = append(details, fmt.Sprintf("Reachable: %d commits, %d trees, %d blobs (%s uncompressed)", report.CommitCount, report.TreeCount, report.BlobCount, formatBytes(report.BlobSize)))
+	} else {
+		details = append(details, "History not scanned for large blobs; run gphc size for the full analysis")
+	}
+	for _, warning := range report.Warnings {
+		details = append(details, "⚠️ "+warning)
+	}
+
+	if len(report.LargestBlobs) > 0 {
+		details = append(details, "", "Largest Blobs:")
+		for _, blob := range report.LargestBlobs {
+			line := fmt.Sprintf("  • %s %s (%s on disk)", blob.Path, formatBytes(blob.Size), formatBytes(blob.DiskSize))
+			if blob.Commit != "" {
+				line += fmt.Sprintf(" added in %s by %s on %s", types.ShortHash(blob.Commit), blob.Author, blob.Date)
+			}
+			details = append(details, line)
+		}
+	}
+	if len(report.LargestTrees) > 0 {
+		details = append(details, "", "Largest Trees:")
+		for _, tree := range report.LargestTrees {
+			details = append(details, fmt.Sprintf("  • %s %s", displayTreePath(tree.Path), formatBytes(tree.Size)))
+		}
+	}
+	if len(report.Growth) > 0 {
+		details = append(details, "", "Directory Growth:")
+		for _, growth := range report.Growth {
+			var months []string
+			for _, month := range growth.Months {
+				if month.Bytes > 0 {
+					months = append(months, fmt.Sprintf("%s +%s", month.Month, formatBytes(month.Bytes)))
+				}
+			}
+			line := fmt.Sprintf("  • %s %s total", displayTreePath(growth.Directory), formatBytes(growth.TotalBytes))
+			if len(months) > 0 {
+				line += " (" + strings.Join(months, ", ") + ")"
+			}
+			details = append(details, line)
+		}
+	}
+	return details
+}
+
+func displayTreePath(path string) string {
+	if path == "" || path == "." {
+		return "(root)"
+	}
+	return path
+}
+
+// formatBytes formats a byte count using binary units
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// AnalyzeRepositorySize measures object storage and ranks the heaviest history content
+func AnalyzeRepositorySize(repoPath string, options SizeAnalysisOptions) (*RepositorySizeReport, error) {
+	if options.TopN <= 0 {
+		options.TopN = 10
+	}
+
+	stats, err := countObjects(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	report := &RepositorySizeReport{
+		Objects:      stats,
+		TotalSize:    stats.PackSize + stats.LooseSize,
+		LargestBlobs: []LargeObject{},
+		LargestTrees: []LargeObject{},
+	}
+	report.TotalSizeMB = float64(report.TotalSize) / (1024 * 1024)
+	if options.SkipHistory {
+		return report, nil
+	}
+	report.HistoryScanned = true
+
+	objects, err := reachableObjects(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	blobSizes := make(map[string]int64)
+	var blobs, trees []reachableObject
+	for _, object := range objects {
+		switch object.objectType {
+		case "commit":
+			report.CommitCount++
+		case "tree":
+			report.TreeCount++
+			trees = append(trees, object)
+		case "blob":
+			report.BlobCount++
+			report.BlobSize += object.size
+			blobSizes[object.oid] = object.size
+			blobs = append(blobs, object)
+			if options.MaxBlobSizeMB > 0 && float64(object.size)/(1024*1024) > options.MaxBlobSizeMB {
+				report.OversizedBlobs++
+			}
+		}
+	}
+
+	report.LargestBlobs = largestObjects(blobs, options.TopN)
+	report.LargestTrees = largestObjects(trees, options.TopN)
+
+	if options.IncludeOrigins {
+		for i := range report.LargestBlobs {
+			blob := &report.LargestBlobs[i]
+			blob.Commit, blob.Author, blob.Date = introducingCommit(repoPath, blob.Oid)
+		}
+	}
+
+	if options.GrowthMonths > 0 {
+		growth, err := directoryGrowth(repoPath, blobSizes, options.GrowthMonths, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		report.Growth = growth
+	}
+
+	return report, nil
+}
+
+// countObjects parses git count-objects -v (sizes are reported in KiB)
+func countObjects(repoPath string) (ObjectStats, error) {
+	cmd := exec.Command("git", "count-objects", "-v")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return ObjectStats{}, fmt.Errorf("git count-objects: %w", err)
+	}
+
+	var stats ObjectStats
+	for _, line := range strings.Split(string(output), "\n") {
+		key, value, found := strings.Cut(line, ": ")
+		if !found {
+			continue
+		}
+		n, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		switch key {
+		case "count":
+			stats.LooseObjects = int(n)
+		case "size":
+			stats.LooseSize = n * 1024
+		case "in-pack":
+			stats.PackedObjects = int(n)
+		case "packs":
+			stats.Packs = int(n)
+		case "size-pack":
+			stats.PackSize = n * 1024
+		case "prune-packable":
+			stats.PrunePackable = int(n)
+		case "garbage":
+			stats.Garbage = int(n)
+		case "size-garbage":
+			stats.GarbageSize = n * 1024
+		}
+	}
+	return stats, nil
+}
+
+// reachableObjects lists every object reachable from any ref with its type and sizes
+func reachableObjects(repoPath string) ([]reachableObject, error) {
+	cmd := exec.Command("git", "rev-list", "--objects", "--all")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git rev-list: %w", err)
+	}
+
+	var objects []reachableObject
+	var input bytes.Buffer
+	for _, line := range strings.Split(string(output), "\n") {
+		oid, path, _ := strings.Cut(line, " ")
+		if oid == "" {
+			continue
+		}
+		objects = append(objects, reachableObject{oid: oid, path: path})
+		input.WriteString(oid + "\n")
+	}
+	if len(objects) == 0 {
+		return objects, nil
+	}
+
+	cmd = exec.Command("git", "cat-file", "--batch-check=%(objecttype) %(objectsize) %(objectsize:disk)")
+	cmd.Dir = repoPath
+	cmd.Stdin = &input
+	output, err = cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for i := 0; scanner.Scan() && i < len(objects); i++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+		objects[i].objectType = fields[0]
+		objects[i].size, _ = strconv.ParseInt(fields[1], 10, 64)
+		objects[i].diskSize, _ = strconv.ParseInt(fields[2], 10, 64)
+	}
+	return objects, nil
+}
+
+func largestObjects(objects []reachableObject, topN int) []LargeObject {
+	sorted := make([]reachableObject, len(objects))
+	copy(sorted, objects)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].size != sorted[j].size {
+			return sorted[i].size > sorted[j].size
+		}
+		return sorted[i].path < sorted[j].path
+	})
+	if len(sorted) > topN {
+		sorted = sorted[:topN]
+	}
+
+	largest := make([]LargeObject, 0, len(sorted))
+	for _, object := range sorted {
+		largest = append(largest, LargeObject{
+			Oid:      object.oid,
+			Path:     object.path,
+			Size:     object.size,
+			SizeMB:   float64(object.size) / (1024 * 1024),
+			DiskSize: object.diskSize,
+		})
+	}
+	return largest
+}
+
+// introducingCommit finds the oldest commit that added a blob
+func introducingCommit(repoPath, oid string) (hash, author, date string) {
+	cmd := exec.Command("git", "log", "--all", "--reverse", "--format=%H%x1f%an%x1f%as", "--find-object="+oid)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", "", ""
+	}
+	first, _, _ := strings.Cut(string(output), "\n")
+	fields := strings.Split(first, "\x1f")
+	if len(fields) != 3 {
+		return "", "", ""
+	}
+	return fields[0], fields[1], fields[2]
+}
+
+// directoryGrowth sums the size of new blobs per top-level directory and month
+func directoryGrowth(repoPath string, blobSizes map[string]int64, months int, now time.Time) ([]DirectoryGrowth, error) {
+	cmd := exec.Command("git", "log", "-z", "--all", "--reverse", "--format=%x1e%ct", "--raw", "--no-abbrev", "--no-renames", "--diff-filter=AM")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+
+	recent := make([]string, months)
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < months; i++ {
+		recent[months-1-i] = start.AddDate(0, -i, 0).Format("2006-01")
+	}
+
+	totals := make(map[string]int64)
+	byMonth := make(map[string]map[string]int64)
+	seen := make(map[string]bool)
+	for _, change := range parseRawLog(string(output)) {
+		if seen[change.newOid] {
+			continue
+		}
+		seen[change.newOid] = true
+
+		timestamp, _ := strconv.ParseInt(change.header, 10, 64)
+		month := time.Unix(timestamp, 0).UTC().Format("2006-01")
+
+		directory := "."
+		if top, _, nested := strings.Cut(change.path, "/"); nested {
+			directory = top
+		}
+		size := blobSizes[change.newOid]
+		totals[directory] += size
+		if byMonth[directory] == nil {
+			byMonth[directory] = make(map[string]int64)
+		}
+		byMonth[directory][month] += size
+	}
+
+	growth := make([]DirectoryGrowth, 0, len(totals))
+	for directory, total := range totals {
+		entry := DirectoryGrowth{Directory: directory, TotalBytes: total, Months: make([]MonthlyGrowth, 0, months)}
+		for _, m := range recent {
+			entry.Months = append(entry.Months, MonthlyGrowth{Month: m, Bytes: byMonth[directory][m]})
+		}
+		growth = append(growth, entry)
+	}
+	sort.Slice(growth, func(i, j int) bool {
+		if growth[i].TotalBytes != growth[j].TotalBytes {
+			return growth[i].TotalBytes > growth[j].TotalBytes
+		}
+		return growth[i].Directory < growth[j].Directory
+	})
+	return growth, nil
+}
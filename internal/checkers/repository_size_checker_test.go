@@ -0,0 +1,138 @@
+package checkers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestRepositorySizeReportsLargestBlobsAndGrowth(t *testing.T) {
+	repo := createGitRepository(t)
+	files := map[string]string{
+		"README.md":        "# sample\n",
+		"assets/large.bin": strings.Repeat("x", 256*1024),
+		"src/main.go":      "package main\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repo, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "feat: add assets")
+
+	checker := NewRepositorySizeCheckerWithThresholds(SizeThresholds{MaxBlobSizeMB: 0.1})
+	result, report := checker.CheckWithOptions(&types.RepositoryData{Path: repo}, SizeAnalysisOptions{
+		TopN:           2,
+		IncludeOrigins: true,
+		GrowthMonths:   1,
+	})
+	if report == nil {
+		t.Fatalf("no report: %s", result.Message)
+	}
+
+	if len(report.LargestBlobs) != 2 {
+		t.Fatalf("LargestBlobs = %d, want 2", len(report.LargestBlobs))
+	}
+	largest := report.LargestBlobs[0]
+	if largest.Path != "assets/large.bin" || largest.Size != 256*1024 {
+		t.Errorf("largest blob = %s (%d bytes)", largest.Path, largest.Size)
+	}
+	if largest.Commit == "" || largest.Author == "" {
+		t.Errorf("largest blob has no origin: %+v", largest)
+	}
+
+	if len(report.Growth) == 0 || report.Growth[0].Directory != "assets" {
+		t.Fatalf("Growth = %+v, want assets first", report.Growth)
+	}
+	month := time.Now().UTC().Format("2006-01")
+	if got := report.Growth[0].Months; len(got) != 1 || got[0].Month != month || got[0].Bytes != 256*1024 {
+		t.Errorf("assets growth = %+v", got)
+	}
+
+	if result.Status != types.StatusWarning {
+		t.Errorf("Status = %v, want warning for blob above threshold", result.Status)
+	}
+	if report.Exceeded {
+		t.Error("Exceeded = true, want false for a repository under the size threshold")
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "exceed 0.1 MB") {
+		t.Errorf("Warnings = %q", report.Warnings)
+	}
+}
+
+func TestRepositorySizeCountsOversizedBlobsBeyondTopN(t *testing.T) {
+	repo := createGitRepository(t)
+	for i, name := range []string{"a.bin", "b.bin", "c.bin"} {
+		content := strings.Repeat(string(rune('a'+i)), (200+i)*1024)
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "feat: add binaries")
+
+	checker := NewRepositorySizeCheckerWithThresholds(SizeThresholds{MaxBlobSizeMB: 0.1})
+	_, report := checker.CheckWithOptions(&types.RepositoryData{Path: repo}, SizeAnalysisOptions{TopN: 1})
+	if report == nil {
+		t.Fatal("no report")
+	}
+	if len(report.LargestBlobs) != 1 || report.OversizedBlobs != 3 {
+		t.Fatalf("listed %d blobs, counted %d oversized, want 1 and 3", len(report.LargestBlobs), report.OversizedBlobs)
+	}
+	if len(report.Warnings) != 1 || !strings.HasPrefix(report.Warnings[0], "3 blob(s)") {
+		t.Errorf("Warnings = %q", report.Warnings)
+	}
+}
+
+func TestRepositorySizeCheckSkipsHistoryByDefault(t *testing.T) {
+	repo := createGitRepository(t)
+	if err := os.WriteFile(filepath.Join(repo, "a.bin"), []byte(strings.Repeat("a", 200*1024)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "feat: add binary")
+
+	checker := NewRepositorySizeCheckerWithThresholds(SizeThresholds{MaxBlobSizeMB: 0.1})
+	result := checker.Check(&types.RepositoryData{Path: repo})
+	details := strings.Join(result.Details, "\n")
+	if strings.Contains(details, "Reachable:") || !strings.Contains(details, "History not scanned") || result.Status != types.StatusPass {
+		t.Errorf("the default check should only measure the object storage: %s", details)
+	}
+
+	checker.SetScanHistory(true)
+	if result := checker.Check(&types.RepositoryData{Path: repo}); result.Status != types.StatusWarning {
+		t.Errorf("scanning history should find the oversized blob: %+v", result)
+	}
+}
+
+func TestDirectoryGrowthNonASCIIPaths(t *testing.T) {
+	repo := createGitRepository(t)
+	path := filepath.Join(repo, "médias", "photo d'été.bin")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", 2048)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "feat: add photo")
+
+	// Without -z git quotes the path as "m\303\251dias/..." and the directory would be "\"m\\303\\251dias"
+	oid := gitOutput(t, repo, "rev-parse", "HEAD:médias/photo d'été.bin")
+	growth, err := directoryGrowth(repo, map[string]int64{oid: 2048}, 1, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(growth) == 0 || growth[0].Directory != "médias" || growth[0].TotalBytes != 2048 {
+		t.Errorf("growth = %+v, want médias first", growth)
+	}
+}
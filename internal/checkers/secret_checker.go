@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -102,6 +103,10 @@ func (c *SecretChecker) CheckWithOptions(data *types.RepositoryData, scanHistory
 		result.Status = types.StatusFail
 		result.Score = 0
 		result.Message = fmt.Sprintf("Found %d potential secrets", len(secrets))
+		result.Severities = make(map[string]int)
+		for _, secret := range secrets {
+			result.Severities[strings.ToLower(secret.Severity)]++
+		}
 
 		// Add details about found secrets
 		details := []string{
@@ -158,6 +163,57 @@ func (c *SecretChecker) scanGitHistory(repoPath string, scanHistory, scanStashes
 	return secrets, nil
 }
 
+// ScanCommits scans the lines added by specific commits, such as the commits of a push
+func (c *SecretChecker) ScanCommits(repoPath string, commits []string, scanEntropy bool, minSeverity string, minConfidence float64) ([]Secret, error) {
+	c.scanEntropy = scanEntropy
+	c.minSeverity = minSeverity
+	c.minConfidence = minConfidence
+
+	var secrets []Secret
+	for _, commit := range commits {
+		commitSecrets, err := c.scanCommitDiff(repoPath, commit)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, commitSecrets...)
+	}
+	return secrets, nil
+}
+
+// scanCommitDiff scans only the lines a commit adds; merges are compared with their first parent
+func (c *SecretChecker) scanCommitDiff(repoPath string, commitHash string) ([]Secret, error) {
+	cmd := exec.Command("git", "show", "--format=", "--unified=0", "--no-color", "--no-ext-diff", "--diff-merges=first-parent", commitHash)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git show %s: %w", commitHash, err)
+	}
+
+	var secrets []Secret
+	filePath := ""
+	lineNum := 0
+	for _, line := range strings.Split(string(output), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			filePath = strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+		case strings.HasPrefix(line, "@@ "):
+			// @@ -<old>[,<count>] +<new>[,<count>] @@
+			fields := strings.Fields(line)
+			if len(fields) >= 3 {
+				start, _, _ := strings.Cut(strings.TrimPrefix(fields[2], "+"), ",")
+				lineNum, _ = strconv.Atoi(start)
+			}
+		case strings.HasPrefix(line, "+") && filePath != "/dev/null":
+			secrets = append(secrets, c.checkPatterns(line[1:], filePath, commitHash, "commit", lineNum)...)
+			if c.scanEntropy {
+				secrets = append(secrets, c.checkEntropy(line[1:], filePath, commitHash, "commit", lineNum)...)
+			}
+			lineNum++
+		}
+	}
+	return c.filterSecrets(secrets), nil
+}
+
 func (c *SecretChecker) scanWorkingTree(repoPath string) ([]Secret, error) {
 	cmd := exec.Command("git", "ls-files", "-z")
 	cmd.Dir = repoPath
@@ -417,11 +473,19 @@ func (c *SecretChecker) getSecretPatterns() []SecretPattern {
 		{
 			Name:        "AWS Access Key",
 			Pattern:     regexp.MustCompile(`AKIA[0-9A-Z]{16}`), // Exact length
-			Severity:    "high",
+			Severity:    "critical",
 			Confidence:  0.95,
 			Description: "AWS Access Key ID",
 			Remediation: "Rotate AWS access key immediately and rewrite Git history using git filter-repo or BFG.",
 		},
+		{
+			Name:        "Private Key",
+			Pattern:     regexp.MustCompile(`-----BEGIN (?:RSA |DSA |EC |OPENSSH |ENCRYPTED |PGP )?PRIVATE KEY(?: BLOCK)?-----`),
+			Severity:    "critical",
+			Confidence:  0.95,
+			Description: "PEM, OpenSSH or PGP private key",
+			Remediation: "Revoke the key pair, issue a new one and rewrite Git history using git filter-repo or BFG.",
+		},
 	}
 }
 
@@ -555,11 +619,11 @@ func (c *SecretChecker) isCommonPattern(s string) bool {
 	return false
 }
 
-// countHighSeveritySecrets counts high severity secrets
+// countHighSeveritySecrets counts high and critical severity secrets
 func (c *SecretChecker) countHighSeveritySecrets(secrets []Secret) int {
 	count := 0
 	for _, secret := range secrets {
-		if secret.Severity == "high" {
+		if severityLevel(secret.Severity) >= severityLevel("high") {
 			count++
 		}
 	}
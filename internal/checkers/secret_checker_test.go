@@ -0,0 +1,35 @@
+package checkers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vahidaghazadeh/gphc/internal/scorer"
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestSecretCheckerCriticalSecretCapsGrade(t *testing.T) {
+	repo := createGitRepository(t)
+	// Split the header so this file does not itself look like a committed key
+	key := "-----BEGIN " + "RSA PRIVATE KEY-----\nMIIEowIBAAKCAQEA\n-----END " + "RSA PRIVATE KEY-----\n"
+	if err := os.WriteFile(filepath.Join(repo, "deploy.pem"), []byte(key), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "chore: add deploy key")
+
+	result := NewSecretChecker().Check(&types.RepositoryData{Path: repo})
+	if result.Severities["critical"] == 0 {
+		t.Fatalf("expected the private key to be a critical secret, got %+v", result)
+	}
+
+	// Weight the secret scan lightly so the score would earn an A without the penalty
+	s := scorer.NewScorerWithOptions(scorer.DefaultOptions())
+	s.AddWeightedResult(types.CheckResult{ID: "SEC-002", Name: "Binary Files", Status: types.StatusPass, Score: 100, Category: types.CategorySecurity}, 50)
+	s.AddWeightedResult(*result, 1)
+	report := s.CalculateHealthReport()
+	if report.Grade != "D" || report.Breakdown == nil || len(report.Breakdown.Penalties) != 1 || report.Breakdown.Penalties[0].Rule != "critical secret" {
+		t.Errorf("expected the critical secret penalty to cap the grade at D, got %s (score %d, penalties %+v)", report.Grade, report.OverallScore, report.Breakdown.Penalties)
+	}
+}
@@ -0,0 +1,147 @@
+package checkers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// strictSemverRe is the semver 2.0.0 grammar with an optional leading "v"
+var strictSemverRe = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[A-Za-z-][0-9A-Za-z-]*)(?:\.(?:0|[1-9]\d*|\d*[A-Za-z-][0-9A-Za-z-]*))*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+
+// Version is a semantic version
+type Version struct {
+	Major      int    `json:"major"`
+	Minor      int    `json:"minor"`
+	Patch      int    `json:"patch"`
+	Prerelease string `json:"prerelease,omitempty"`
+	Build      string `json:"build,omitempty"`
+}
+
+// ParseVersion parses a semantic version such as 1.4.0, v2.0.0-rc.1 or 1.0.0+build.7
+func ParseVersion(s string) (Version, error) {
+	match := strictSemverRe.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return Version{}, fmt.Errorf("%q is not a semantic version", s)
+	}
+	var v Version
+	v.Major, _ = strconv.Atoi(match[1])
+	v.Minor, _ = strconv.Atoi(match[2])
+	v.Patch, _ = strconv.Atoi(match[3])
+	v.Prerelease, v.Build = match[4], match[5]
+	return v, nil
+}
+
+// String formats the version without a "v" prefix
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Core returns the version without pre-release and build metadata
+func (v Version) Core() Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+// IsPrerelease reports whether the version has a pre-release part
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// Channel returns the pre-release channel, e.g. "rc" for 1.0.0-rc.2, and its number (0 when absent)
+func (v Version) Channel() (string, int) {
+	if v.Prerelease == "" {
+		return "", 0
+	}
+	channel, number, found := strings.Cut(v.Prerelease, ".")
+	if !found {
+		return channel, 0
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return v.Prerelease, 0
+	}
+	return channel, n
+}
+
+// Compare orders versions by semver precedence, ignoring build metadata: -1, 0 or 1
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	}
+
+	a, b := strings.Split(v.Prerelease, "."), strings.Split(other.Prerelease, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		x, errX := strconv.Atoi(a[i])
+		y, errY := strconv.Atoi(b[i])
+		switch {
+		case errX == nil && errY == nil:
+			if x < y {
+				return -1
+			}
+			return 1
+		case errX == nil:
+			// Numeric identifiers sort before alphanumeric ones
+			return -1
+		case errY == nil:
+			return 1
+		case a[i] < b[i]:
+			return -1
+		default:
+			return 1
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+// Bump returns the next version for a major, minor or patch change; pre-release and build are dropped
+func (v Version) Bump(level string) Version {
+	next := v.Core()
+	switch level {
+	case BumpMajor:
+		next.Major++
+		next.Minor, next.Patch = 0, 0
+	case BumpMinor:
+		next.Minor++
+		next.Patch = 0
+	default:
+		next.Patch++
+	}
+	return next
+}
+
+// Version bump levels
+const (
+	BumpMajor = "major"
+	BumpMinor = "minor"
+	BumpPatch = "patch"
+)
@@ -0,0 +1,390 @@
+package checkers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// Signature verification outcomes
+const (
+	SignatureGood       = "good"
+	SignatureBad        = "bad"
+	SignatureUnknownKey = "unknown_key"
+	SignatureExpired    = "expired"
+	SignatureRevoked    = "revoked"
+	SignatureUnsigned   = "unsigned"
+)
+
+// allowedSignersCandidates are the repository locations checked for an allowed signers file
+var allowedSignersCandidates = []string{
+	".github/allowed_signers",
+	".gitsigners",
+	"allowed_signers",
+}
+
+// SignatureVerificationOptions configures where trusted signing keys come from
+type SignatureVerificationOptions struct {
+	// AllowedSignersFile verifies SSH signatures; defaults to gpg.ssh.allowedSignersFile
+	// or an allowed_signers file provided by the repository
+	AllowedSignersFile string `json:"allowed_signers_file,omitempty"`
+	// GPGHome is a keyring directory used to verify GPG signatures instead of the user's keyring
+	GPGHome string `json:"gpg_home,omitempty"`
+}
+
+// CommitSignature is the verification result of a single commit
+type CommitSignature struct {
+	Hash           string `json:"hash"`
+	Status         string `json:"status"`
+	Format         string `json:"format,omitempty"`
+	Key            string `json:"key,omitempty"`
+	Signer         string `json:"signer,omitempty"`
+	AuthorEmail    string `json:"author_email"`
+	CommitterEmail string `json:"committer_email"`
+}
+
+// SigningKey maps a signing key to the identities that used it
+type SigningKey struct {
+	Key          string   `json:"key"`
+	Format       string   `json:"format"`
+	Signer       string   `json:"signer,omitempty"`
+	Status       string   `json:"status"`
+	AuthorEmails []string `json:"author_emails"`
+	Commits      int      `json:"commits"`
+}
+
+// resolveAllowedSignersFile picks the allowed signers file used for SSH verification
+func resolveAllowedSignersFile(repoPath, configured string) string {
+	if configured != "" {
+		if !filepath.IsAbs(configured) {
+			configured = filepath.Join(repoPath, configured)
+		}
+		return configured
+	}
+
+	cmd := exec.Command("git", "config", "--get", "gpg.ssh.allowedSignersFile")
+	cmd.Dir = repoPath
+	if output, err := cmd.Output(); err == nil {
+		path := strings.TrimSpace(string(output))
+		if strings.HasPrefix(path, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, path[2:])
+			}
+		}
+		if path != "" && !filepath.IsAbs(path) {
+			path = filepath.Join(repoPath, path)
+		}
+		return path
+	}
+
+	for _, candidate := range allowedSignersCandidates {
+		path := filepath.Join(repoPath, candidate)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// verifyCommitSignatures verifies the signature of every commit reachable from any ref
+func verifyCommitSignatures(repoPath string, options SignatureVerificationOptions) ([]CommitSignature, error) {
+	return verifySignatures(repoPath, options, nil)
+}
+
+// VerifyCommitSignatures verifies the signatures of specific commits, such as the commits of a push
+func VerifyCommitSignatures(repoPath string, commits []string, options SignatureVerificationOptions) ([]CommitSignature, error) {
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	return verifySignatures(repoPath, options, commits)
+}
+
+// verifySignatures verifies the given commits, or all commits when commits is nil
+func verifySignatures(repoPath string, options SignatureVerificationOptions, commits []string) ([]CommitSignature, error) {
+	signedFormats, err := commitSignatureFormats(repoPath, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{}
+	if signers := resolveAllowedSignersFile(repoPath, options.AllowedSignersFile); signers != "" {
+		args = append(args, "-c", "gpg.ssh.allowedSignersFile="+signers)
+	}
+	args = append(args, "log")
+	args = append(args, revisionArgs(commits)...)
+	args = append(args, "--pretty=format:%H%x1f%G?%x1f%GK%x1f%GS%x1f%ae%x1f%ce")
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	cmd.Stdin = revisionInput(commits)
+	if options.GPGHome != "" {
+		cmd.Env = append(os.Environ(), "GNUPGHOME="+options.GPGHome)
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var signatures []CommitSignature
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) < 6 {
+			continue
+		}
+		signature := CommitSignature{
+			Hash:           fields[0],
+			Format:         signedFormats[fields[0]],
+			Key:            fields[2],
+			Signer:         fields[3],
+			AuthorEmail:    fields[4],
+			CommitterEmail: fields[5],
+		}
+		signature.Status = classifySignature(fields[1], signature)
+		signatures = append(signatures, signature)
+	}
+	return signatures, nil
+}
+
+// classifySignature maps git's %G? code to a verification outcome
+func classifySignature(code string, signature CommitSignature) string {
+	switch code {
+	case "G":
+		return SignatureGood
+	case "U":
+		// GPG reports keys of unknown trust with a signer, SSH reports keys missing from allowed signers without one
+		if signature.Signer == "" {
+			return SignatureUnknownKey
+		}
+		return SignatureGood
+	case "B":
+		return SignatureBad
+	case "X", "Y":
+		return SignatureExpired
+	case "R":
+		return SignatureRevoked
+	case "E":
+		return SignatureUnknownKey
+	default:
+		// git reports N when no verifier is configured for the signature format
+		if signature.Format != "" {
+			return SignatureUnknownKey
+		}
+		return SignatureUnsigned
+	}
+}
+
+// commitSignatureFormats returns the signature format (gpg, ssh, x509) of every signed commit
+func commitSignatureFormats(repoPath string, commits []string) (map[string]string, error) {
+	args := append([]string{"rev-list", "--header"}, revisionArgs(commits)...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	cmd.Stdin = revisionInput(commits)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	formats := make(map[string]string)
+	for _, record := range bytes.Split(output, []byte{0}) {
+		lines := strings.Split(strings.TrimLeft(string(record), "\n"), "\n")
+		if len(lines) < 2 {
+			continue
+		}
+		hash := strings.TrimSpace(lines[0])
+		for i, header := range lines[1:] {
+			if header == "" {
+				break
+			}
+			if strings.HasPrefix(header, "gpgsig ") || strings.HasPrefix(header, "gpgsig-sha256 ") {
+				formats[hash] = signatureFormat(header, lines[i+2:])
+				break
+			}
+		}
+	}
+	return formats, nil
+}
+
+// revisionArgs selects exactly the given commits, read from stdin, or every ref when commits is nil
+func revisionArgs(commits []string) []string {
+	if commits == nil {
+		return []string{"--all"}
+	}
+	return []string{"--no-walk=unsorted", "--stdin"}
+}
+
+func revisionInput(commits []string) io.Reader {
+	if commits == nil {
+		return nil
+	}
+	return strings.NewReader(strings.Join(commits, "\n") + "\n")
+}
+
+func signatureFormat(header string, continuation []string) string {
+	armor := header
+	if len(continuation) > 0 {
+		armor += "\n" + strings.Join(continuation[:min(len(continuation), 2)], "\n")
+	}
+	switch {
+	case strings.Contains(armor, "BEGIN SSH SIGNATURE"):
+		return "ssh"
+	case strings.Contains(armor, "BEGIN SIGNED MESSAGE"):
+		return "x509"
+	default:
+		return "gpg"
+	}
+}
+
+// signerEmail extracts the email address from a signer identity ("Name <email>" or a bare principal)
+func signerEmail(signer string) string {
+	signer = strings.TrimSpace(signer)
+	if signer == "" {
+		return ""
+	}
+	if address, err := mail.ParseAddress(signer); err == nil {
+		return address.Address
+	}
+	if strings.Contains(signer, "@") && !strings.ContainsAny(signer, " <>*") {
+		return signer
+	}
+	return ""
+}
+
+// signerMatchesCommitter reports whether a verified signer identity belongs to the committer
+func signerMatchesCommitter(signature CommitSignature) bool {
+	email := signerEmail(signature.Signer)
+	if email == "" {
+		return true
+	}
+	return strings.EqualFold(email, signature.CommitterEmail)
+}
+
+// summarizeSigningKeys groups commit signatures by signing key
+func summarizeSigningKeys(signatures []CommitSignature) []SigningKey {
+	byKey := make(map[string]*SigningKey)
+	emails := make(map[string]map[string]bool)
+	for _, signature := range signatures {
+		if signature.Status == SignatureUnsigned || signature.Key == "" {
+			continue
+		}
+		key, ok := byKey[signature.Key]
+		if !ok {
+			key = &SigningKey{Key: signature.Key, Format: signature.Format, Status: signature.Status}
+			byKey[signature.Key] = key
+			emails[signature.Key] = make(map[string]bool)
+		}
+		key.Commits++
+		if key.Signer == "" {
+			key.Signer = signature.Signer
+		}
+		if !emails[signature.Key][signature.AuthorEmail] {
+			emails[signature.Key][signature.AuthorEmail] = true
+			key.AuthorEmails = append(key.AuthorEmails, signature.AuthorEmail)
+		}
+	}
+
+	keys := make([]SigningKey, 0, len(byKey))
+	for _, key := range byKey {
+		sort.Strings(key.AuthorEmails)
+		keys = append(keys, *key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Commits != keys[j].Commits {
+			return keys[i].Commits > keys[j].Commits
+		}
+		return keys[i].Key < keys[j].Key
+	})
+	return keys
+}
+
+// signatureViolations reports unknown, expired, revoked, bad and mismatched signatures grouped by key
+func signatureViolations(signatures []CommitSignature) []PolicyViolation {
+	type group struct {
+		status  string
+		key     string
+		detail  string
+		commits []string
+	}
+	var order []string
+	groups := make(map[string]*group)
+	add := func(id, status, key, detail, hash string) {
+		g, ok := groups[id]
+		if !ok {
+			g = &group{status: status, key: key, detail: detail}
+			groups[id] = g
+			order = append(order, id)
+		}
+		g.commits = append(g.commits, types.ShortHash(hash))
+	}
+
+	for _, signature := range signatures {
+		switch signature.Status {
+		case SignatureBad, SignatureUnknownKey, SignatureExpired, SignatureRevoked:
+			add(signature.Status+"|"+signature.Key, signature.Status, signature.Key, "", signature.Hash)
+		case SignatureGood:
+			if !signerMatchesCommitter(signature) {
+				detail := fmt.Sprintf("signer %s does not match committer %s", signerEmail(signature.Signer), signature.CommitterEmail)
+				add("mismatch|"+signature.Key+"|"+detail, "mismatch", signature.Key, detail, signature.Hash)
+			}
+		}
+	}
+
+	violations := make([]PolicyViolation, 0, len(order))
+	for _, id := range order {
+		g := groups[id]
+		key := displaySigningKey(g.key)
+		commits := sampleCommits(g.commits)
+		switch g.status {
+		case SignatureBad:
+			violations = append(violations, PolicyViolation{
+				Type:           "signature_bad",
+				Severity:       "critical",
+				Description:    fmt.Sprintf("%d commit(s) with a signature by key %s that does not verify (%s)", len(g.commits), key, commits),
+				Recommendation: "Investigate the commits; a bad signature means the commit was altered after signing",
+			})
+		case SignatureUnknownKey:
+			violations = append(violations, PolicyViolation{
+				Type:           "signature_unknown_key",
+				Severity:       "high",
+				Description:    fmt.Sprintf("%d commit(s) signed by unknown key %s (%s)", len(g.commits), key, commits),
+				Recommendation: "Add the key to the allowed signers file or keyring, or re-sign the commits with a trusted key",
+			})
+		case SignatureExpired:
+			violations = append(violations, PolicyViolation{
+				Type:           "signature_expired_key",
+				Severity:       "medium",
+				Description:    fmt.Sprintf("%d commit(s) signed by expired key %s (%s)", len(g.commits), key, commits),
+				Recommendation: "Extend or rotate the expired signing key",
+			})
+		case SignatureRevoked:
+			violations = append(violations, PolicyViolation{
+				Type:           "signature_revoked_key",
+				Severity:       "critical",
+				Description:    fmt.Sprintf("%d commit(s) signed by revoked key %s (%s)", len(g.commits), key, commits),
+				Recommendation: "Investigate commits signed with the revoked key",
+			})
+		case "mismatch":
+			violations = append(violations, PolicyViolation{
+				Type:           "signature_signer_mismatch",
+				Severity:       "high",
+				Description:    fmt.Sprintf("%d commit(s) signed by key %s where %s (%s)", len(g.commits), key, g.detail, commits),
+				Recommendation: "Map the signing key to the committer's email in the allowed signers file or keyring",
+			})
+		}
+	}
+	return violations
+}
+
+func sampleCommits(commits []string) string {
+	if len(commits) <= 3 {
+		return strings.Join(commits, ", ")
+	}
+	return fmt.Sprintf("%s, ... %d more", strings.Join(commits[:3], ", "), len(commits)-3)
+}
@@ -0,0 +1,111 @@
+package checkers
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestCheckCommitSignaturesVerifiesAllowedSigners(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+
+	repo := createGitRepository(t)
+	trusted := generateSSHKey(t, "trusted")
+	untrusted := generateSSHKey(t, "untrusted")
+	runGit(t, repo, "config", "gpg.format", "ssh")
+
+	commitFile := func(name, key string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, repo, "add", ".")
+		runGit(t, repo, "-c", "user.signingkey="+key+".pub", "commit", "-qS", "-m", "chore: add "+name)
+	}
+	commitFile("trusted.txt", trusted)
+	commitFile("untrusted.txt", untrusted)
+
+	publicKey, err := os.ReadFile(trusted + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := strings.Fields(string(publicKey))
+	signers := filepath.Join(t.TempDir(), "allowed_signers")
+	if err := os.WriteFile(signers, []byte("test@example.com "+fields[0]+" "+fields[1]+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	checker := NewGitPolicyChecker()
+	checker.SetSignatureVerification(SignatureVerificationOptions{AllowedSignersFile: signers})
+	_, report := checker.CheckWithReport(&types.RepositoryData{Path: repo}, true, false, false, false, "low")
+
+	stats := report.SignatureStats
+	if stats.TotalCommits != 3 || stats.GoodSignatures != 1 || stats.UnknownKeySignatures != 1 || stats.UnsignedCommits != 1 {
+		t.Fatalf("unexpected signature stats: %+v", stats)
+	}
+	if !hasViolation(report.Violations, "signature_unknown_key") {
+		t.Errorf("expected an unknown key violation, got %+v", report.Violations)
+	}
+
+	// Verifying specific commits, as the pre-push hook does, only reports those commits
+	trustedCommit := gitOutput(t, repo, "rev-parse", "HEAD~1")
+	signatures, err := VerifyCommitSignatures(repo, []string{trustedCommit}, SignatureVerificationOptions{AllowedSignersFile: signers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signatures) != 1 || signatures[0].Hash != trustedCommit || signatures[0].Status != SignatureGood || signatures[0].Format != "ssh" {
+		t.Fatalf("VerifyCommitSignatures = %+v", signatures)
+	}
+
+	// A principal that differs from the committer is reported as a mismatch
+	if err := os.WriteFile(signers, []byte("someone@example.com "+fields[0]+" "+fields[1]+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, report = checker.CheckWithReport(&types.RepositoryData{Path: repo}, true, false, false, false, "low")
+	if report.SignatureStats.MismatchedSigners != 1 || !hasViolation(report.Violations, "signature_signer_mismatch") {
+		t.Errorf("expected a signer mismatch, got %+v %+v", report.SignatureStats, report.Violations)
+	}
+}
+
+func TestSignatureViolationsClassifyEachCommitOnce(t *testing.T) {
+	signatures := []CommitSignature{
+		{Hash: "1111111111111111111111111111111111111111", Status: SignatureBad, Key: "SHA256:tampered"},
+		{Hash: "2222222222222222222222222222222222222222", Status: SignatureRevoked, Key: "SHA256:revoked"},
+		{Hash: "3333333333333333333333333333333333333333", Status: SignatureUnsigned},
+	}
+
+	stats := classifySignatures(signatures)
+	if stats.BadSignatures != 1 || stats.RevokedSignatures != 1 || stats.SignedCommits != 2 || stats.UnsignedCommits != 1 {
+		t.Fatalf("unexpected signature stats: %+v", stats)
+	}
+
+	violations := signatureViolations(signatures)
+	if len(violations) != 2 || !hasViolation(violations, "signature_bad") || !hasViolation(violations, "signature_revoked_key") {
+		t.Errorf("expected one bad and one revoked key violation, got %+v", violations)
+	}
+}
+
+func generateSSHKey(t *testing.T, name string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	cmd := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", name, "-f", path)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen failed: %v\n%s", err, output)
+	}
+	return path
+}
+
+func hasViolation(violations []PolicyViolation, violationType string) bool {
+	for _, violation := range violations {
+		if violation.Type == violationType {
+			return true
+		}
+	}
+	return false
+}
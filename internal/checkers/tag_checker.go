@@ -2,10 +2,7 @@ package checkers
 
 import (
 	"fmt"
-	"os"
 	"os/exec"
-	"regexp"
-	"sort"
 	"strings"
 	"time"
 
@@ -20,6 +17,17 @@ type TagChecker struct {
 	maxDaysSinceLastTag  int
 	maxUnreleasedCommits int
 	requireAnnotatedTags bool
+	// components are the independently tagged parts of a monorepo; empty means one vX.Y.Z stream
+	components []ReleaseComponent
+	integrity  TagIntegrityOptions
+}
+
+// TagCheckerOptions configures the tag checker
+type TagCheckerOptions struct {
+	// Components are the independently tagged parts of a monorepo; empty means one vX.Y.Z stream
+	Components []ReleaseComponent
+	// Integrity configures signature, ordering, reachability, shadowing and moved tag checks
+	Integrity TagIntegrityOptions
 }
 
 func NewTagChecker() *TagChecker {
@@ -28,10 +36,26 @@ func NewTagChecker() *TagChecker {
 		maxDaysSinceLastTag:  45,
 		maxUnreleasedCommits: 3,
 		requireAnnotatedTags: true,
+		integrity:            DefaultTagIntegrityOptions(),
 	}
 }
 
+// NewTagCheckerWithOptions creates a tag checker that tracks each monorepo component's tags separately
+// and runs the configured integrity checks
+func NewTagCheckerWithOptions(options TagCheckerOptions) *TagChecker {
+	tc := NewTagChecker()
+	tc.components = options.Components
+	tc.integrity = options.Integrity
+	return tc
+}
+
 func (tc *TagChecker) Check(data *types.RepositoryData) *types.CheckResult {
+	result, _ := tc.CheckWithReport(data)
+	return result
+}
+
+// CheckWithReport checks the tags and also returns the integrity report, nil when the tags could not be read
+func (tc *TagChecker) CheckWithReport(data *types.RepositoryData) (*types.CheckResult, *TagIntegrityReport) {
 	result := &types.CheckResult{
 		ID:        "TAGS-901",
 		Name:      "Tag & Release Health",
@@ -50,7 +74,7 @@ func (tc *TagChecker) Check(data *types.RepositoryData) *types.CheckResult {
 		result.Score = 10
 		result.Message = "Could not read git tags"
 		result.Details = []string{"git tags not available: " + err.Error()}
-		return result
+		return result, nil
 	}
 
 	if len(tags) == 0 {
@@ -58,7 +82,7 @@ func (tc *TagChecker) Check(data *types.RepositoryData) *types.CheckResult {
 		result.Score = 20
 		result.Message = "No tags found in repository"
 		result.Details = []string{"Consider creating your first release tag (vX.Y.Z)"}
-		return result
+		return result, nil
 	}
 
 	// 2) List all tags
@@ -76,7 +100,14 @@ func (tc *TagChecker) Check(data *types.RepositoryData) *types.CheckResult {
 	}
 
 	// 3) Semantic version validation
-	semverOK, invalid := validateSemanticTags(tags)
+	prefixes := []string{"v"}
+	if len(tc.components) > 0 {
+		prefixes = nil
+		for _, component := range tc.components {
+			prefixes = append(prefixes, component.Normalize().TagPrefix)
+		}
+	}
+	semverOK, invalid := validateSemanticTags(tags, prefixes)
 	if semverOK {
 		details = append(details, "Semantic Versioning: OK")
 		score += 30
@@ -84,31 +115,42 @@ func (tc *TagChecker) Check(data *types.RepositoryData) *types.CheckResult {
 		details = append(details, "Invalid tags (non-semver): "+strings.Join(invalid, ", "))
 	}
 
-	// 4) Get latest tag and date
-	latestTag, latestDate, err := latestTagAndDate(data.Path)
-	if err == nil {
-		days := int(time.Since(latestDate).Hours() / 24)
-		details = append(details, fmt.Sprintf("Last tag: %s (%d days ago)", latestTag, days))
-		if days <= tc.maxDaysSinceLastTag {
+	if len(tc.components) > 0 {
+		// 4-5) Latest tag and unreleased commits of every component
+		fresh, released := tc.checkComponents(data.Path, &details)
+		if fresh {
+			score += 25
+		}
+		if released {
 			score += 25
-		} else {
-			details = append(details, fmt.Sprintf("Last tag older than %d days", tc.maxDaysSinceLastTag))
 		}
 	} else {
-		details = append(details, "Could not determine last tag date: "+err.Error())
-	}
+		// 4) Get latest tag and date
+		latestTag, latestDate, err := latestTagAndDate(data.Path)
+		if err == nil {
+			days := int(time.Since(latestDate).Hours() / 24)
+			details = append(details, fmt.Sprintf("Last tag: %s (%d days ago)", latestTag, days))
+			if days <= tc.maxDaysSinceLastTag {
+				score += 25
+			} else {
+				details = append(details, fmt.Sprintf("Last tag older than %d days", tc.maxDaysSinceLastTag))
+			}
+		} else {
+			details = append(details, "Could not determine last tag date: "+err.Error())
+		}
 
-	// 5) Unreleased commits
-	unreleased, err := unreleasedCommitCount(data.Path)
-	if err == nil {
-		details = append(details, fmt.Sprintf("Unreleased commits since last tag: %d", unreleased))
-		if unreleased <= tc.maxUnreleasedCommits {
-			score += 25
+		// 5) Unreleased commits
+		unreleased, err := unreleasedCommitCount(data.Path, DefaultReleaseComponent())
+		if err == nil {
+			details = append(details, fmt.Sprintf("Unreleased commits since last tag: %d", unreleased))
+			if unreleased <= tc.maxUnreleasedCommits {
+				score += 25
+			} else {
+				details = append(details, fmt.Sprintf("Too many unreleased commits (>%d)", tc.maxUnreleasedCommits))
+			}
 		} else {
-			details = append(details, fmt.Sprintf("Too many unreleased commits (>%d)", tc.maxUnreleasedCommits))
+			details = append(details, "Could not count unreleased commits: "+err.Error())
 		}
-	} else {
-		details = append(details, "Could not count unreleased commits: "+err.Error())
 	}
 
 	// 6) Annotated vs Lightweight tags ratio
@@ -124,11 +166,36 @@ func (tc *TagChecker) Check(data *types.RepositoryData) *types.CheckResult {
 		details = append(details, "Could not determine tag types: "+err.Error())
 	}
 
+	// 7) Integrity: signatures, ordering, reachability, shadowing and moved tags
+	integrity := tc.integrity
+	if len(integrity.TagPrefixes) == 0 {
+		integrity.TagPrefixes = prefixes
+	}
+	report, err := AnalyzeTagIntegrity(data.Path, integrity)
+	critical := false
+	if err == nil {
+		details = append(details, tagIntegrityDetails(report)...)
+		for _, finding := range report.Findings {
+			score -= severityPenalty[finding.Severity]
+			critical = critical || finding.Severity == "critical"
+			if result.Severities == nil {
+				result.Severities = make(map[string]int)
+			}
+			result.Severities[finding.Severity]++
+		}
+		score = max(score, 0)
+	} else {
+		details = append(details, "Could not check tag integrity: "+err.Error())
+	}
+
 	// Finalize result
 	result.Score = score
 	result.Details = details
 
 	switch {
+	case critical:
+		result.Status = types.StatusFail
+		result.Message = "Tag integrity compromised"
 	case score >= 80:
 		result.Status = types.StatusPass
 		result.Message = "Tags and releases look healthy"
@@ -140,7 +207,42 @@ func (tc *TagChecker) Check(data *types.RepositoryData) *types.CheckResult {
 		result.Message = "Tagging strategy needs attention"
 	}
 
-	return result
+	return result, report
+}
+
+// severityPenalty is the score a finding costs by severity
+var severityPenalty = map[string]int{"critical": 30, "high": 15, "medium": 5}
+
+// checkComponents reports the latest tag and unreleased commits of each component; fresh and
+// released hold when every component meets the tag age and unreleased commit thresholds.
+// A component without unreleased commits is fresh however old its last tag is.
+func (tc *TagChecker) checkComponents(repoPath string, details *[]string) (fresh, released bool) {
+	statuses, err := AnalyzeComponents(repoPath, tc.components)
+	if err != nil {
+		*details = append(*details, "Could not analyze components: "+err.Error())
+		return false, false
+	}
+	fresh, released = true, true
+	for _, status := range statuses {
+		name := status.Component.Name
+		if status.LatestTag == "" {
+			fresh = false
+			*details = append(*details, fmt.Sprintf("Component %s: no %s tags yet", name, status.Component.TagPrefix+"X.Y.Z"))
+			continue
+		}
+		days := int(time.Since(status.LatestDate).Hours() / 24)
+		*details = append(*details, fmt.Sprintf("Component %s: last tag %s (%d days ago), %d unreleased commits, next %s",
+			name, status.LatestTag, days, status.Unreleased, status.NextTag))
+		if days > tc.maxDaysSinceLastTag && status.Unreleased > 0 {
+			fresh = false
+			*details = append(*details, fmt.Sprintf("Component %s: last tag older than %d days", name, tc.maxDaysSinceLastTag))
+		}
+		if status.Unreleased > tc.maxUnreleasedCommits {
+			released = false
+			*details = append(*details, fmt.Sprintf("Component %s: too many unreleased commits (>%d)", name, tc.maxUnreleasedCommits))
+		}
+	}
+	return fresh, released
 }
 
 // gitTags returns tag names sorted by version/date (as per git order)
@@ -162,19 +264,31 @@ func gitTags(repoPath string) ([]string, error) {
 	return tags, nil
 }
 
-var semverRe = regexp.MustCompile(`^v\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?$`)
-var conventionalTypeRe = regexp.MustCompile(`^(feat|fix|docs|refactor)(?:\([^)]*\))?(!)?:`)
-
-func validateSemanticTags(tags []string) (bool, []string) {
+// validateSemanticTags reports the tags that are not a semantic version after one of the prefixes
+func validateSemanticTags(tags []string, prefixes []string) (bool, []string) {
 	invalid := make([]string, 0)
 	for _, t := range tags {
-		if !semverRe.MatchString(t) {
+		if !isVersionTag(t, prefixes) {
 			invalid = append(invalid, t)
 		}
 	}
 	return len(invalid) == 0, invalid
 }
 
+// isVersionTag reports whether the tag is a prefix followed by a semantic version
+func isVersionTag(tag string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		rest, ok := strings.CutPrefix(tag, prefix)
+		if !ok || strings.HasPrefix(rest, "v") {
+			continue
+		}
+		if _, err := ParseVersion(rest); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 func latestTagAndDate(repoPath string) (string, time.Time, error) {
 	cmd := exec.Command("git", "for-each-ref", "--sort=-creatordate", "--count=1",
 		"--format=%(refname:short)|%(creatordate:iso8601-strict)", "refs/tags")
@@ -200,32 +314,17 @@ func latestTagAndDate(repoPath string) (string, time.Time, error) {
 	return tag, when, nil
 }
 
-func unreleasedCommitCount(repoPath string) (int, error) {
-	// Determine latest tag
-	describeCmd := exec.Command("git", "describe", "--tags", "--abbrev=0")
-	describeCmd.Dir = repoPath
-	tagOut, _ := describeCmd.CombinedOutput()
-	latest := strings.TrimSpace(string(tagOut))
-	if latest == "" {
-		// no tags → consider all commits unreleased? Return 0 to avoid noise
-		return 0, nil
-	}
-	countCmd := exec.Command("git", "rev-list", latest+"..HEAD", "--count")
-	countCmd.Dir = repoPath
-	out, err := countCmd.CombinedOutput()
+// unreleasedCommitCount counts the commits touching the component since its latest version tag
+func unreleasedCommitCount(repoPath string, component ReleaseComponent) (int, error) {
+	latest, err := latestVersionTag(repoPath, component.TagPrefix, component.Major)
 	if err != nil {
 		return 0, err
 	}
-	s := strings.TrimSpace(string(out))
-	// simple atoi
-	var n int
-	for _, ch := range s {
-		if ch < '0' || ch > '9' {
-			continue
-		}
-		n = n*10 + int(ch-'0')
+	if latest == nil {
+		// no tags → consider all commits unreleased? Return 0 to avoid noise
+		return 0, nil
 	}
-	return n, nil
+	return countCommits(repoPath, latest.name+"..HEAD", component.Pathspecs())
 }
 
 func annotatedTagStats(repoPath string) (bool, int, error) {
@@ -260,132 +359,5 @@ func annotatedTagStats(repoPath string) (bool, int, error) {
 
 // SuggestNextTag suggests the next semantic version based on commit messages since last tag
 func SuggestNextTag(repoPath string) (string, error) {
-	// Get latest tag
-	describeCmd := exec.Command("git", "describe", "--tags", "--abbrev=0")
-	describeCmd.Dir = repoPath
-	tagOut, _ := describeCmd.CombinedOutput()
-	latest := strings.TrimSpace(string(tagOut))
-	if latest == "" {
-		return "v0.1.0", nil
-	}
-	logCmd := exec.Command("git", "log", latest+"..HEAD", "--pretty=format:%s")
-	logCmd.Dir = repoPath
-	commitsOut, _ := logCmd.CombinedOutput()
-	messages := strings.Split(strings.TrimSpace(string(commitsOut)), "\n")
-
-	bumpMajor := false
-	bumpMinor := false
-	bumpPatch := false
-	for _, m := range messages {
-		mm := strings.ToLower(m)
-		match := conventionalTypeRe.FindStringSubmatch(mm)
-		if strings.Contains(mm, "breaking change") || (len(match) > 2 && match[2] == "!") {
-			bumpMajor = true
-			break
-		}
-		if len(match) > 1 && match[1] == "feat" {
-			bumpMinor = true
-		} else if len(match) > 1 && match[1] == "fix" {
-			bumpPatch = true
-		}
-	}
-
-	// Parse latest semver
-	base := strings.TrimPrefix(latest, "v")
-	parts := strings.Split(base, ".")
-	if len(parts) != 3 {
-		return "v0.1.0", nil
-	}
-	toInt := func(s string) int {
-		n := 0
-		for _, ch := range s {
-			if ch < '0' || ch > '9' {
-				continue
-			}
-			n = n*10 + int(ch-'0')
-		}
-		return n
-	}
-	major, minor, patch := toInt(parts[0]), toInt(parts[1]), toInt(parts[2])
-
-	switch {
-	case bumpMajor:
-		major++
-		minor = 0
-		patch = 0
-	case bumpMinor:
-		minor++
-		patch = 0
-	case bumpPatch:
-		patch++
-	default:
-		patch++
-	}
-	return fmt.Sprintf("v%d.%d.%d", major, minor, patch), nil
-}
-
-// GenerateChangelog creates a simple CHANGELOG.md between last tag and HEAD using conventional commit groups
-func GenerateChangelog(repoPath, outputPath string) (string, error) {
-	// Determine range
-	describeCmd := exec.Command("git", "describe", "--tags", "--abbrev=0")
-	describeCmd.Dir = repoPath
-	tagOut, _ := describeCmd.CombinedOutput()
-	latest := strings.TrimSpace(string(tagOut))
-	rangeSpec := ""
-	if latest != "" {
-		rangeSpec = latest + "..HEAD"
-	} else {
-		rangeSpec = "HEAD"
-	}
-
-	logCmd := exec.Command("git", "log", rangeSpec, "--pretty=format:%s")
-	logCmd.Dir = repoPath
-	out, err := logCmd.CombinedOutput()
-	if err != nil {
-		return "", err
-	}
-	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
-
-	groups := map[string][]string{"Features": {}, "Fixes": {}, "Docs": {}, "Refactors": {}, "Others": {}}
-	order := []string{"Features", "Fixes", "Docs", "Refactors", "Others"}
-	for _, l := range lines {
-		ll := strings.TrimSpace(l)
-		match := conventionalTypeRe.FindStringSubmatch(strings.ToLower(ll))
-		if len(match) > 1 && match[1] == "feat" {
-			groups["Features"] = append(groups["Features"], ll)
-		} else if len(match) > 1 && match[1] == "fix" {
-			groups["Fixes"] = append(groups["Fixes"], ll)
-		} else if len(match) > 1 && match[1] == "docs" {
-			groups["Docs"] = append(groups["Docs"], ll)
-		} else if len(match) > 1 && match[1] == "refactor" {
-			groups["Refactors"] = append(groups["Refactors"], ll)
-		} else if ll != "" {
-			groups["Others"] = append(groups["Others"], ll)
-		}
-	}
-
-	var b strings.Builder
-	b.WriteString("# Changelog\n\n")
-	if latest != "" {
-		b.WriteString(fmt.Sprintf("Changes since %s\n\n", latest))
-	}
-	for _, g := range order {
-		if len(groups[g]) == 0 {
-			continue
-		}
-		b.WriteString("## " + g + "\n")
-		sort.Strings(groups[g])
-		for _, item := range groups[g] {
-			b.WriteString("- " + item + "\n")
-		}
-		b.WriteString("\n")
-	}
-
-	content := b.String()
-	if outputPath != "" {
-		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
-			return "", err
-		}
-	}
-	return content, nil
+	return SuggestComponentTag(repoPath, DefaultReleaseComponent())
 }
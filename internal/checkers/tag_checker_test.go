@@ -59,6 +59,25 @@ func TestSuggestNextTagRecognizesScopedFeatures(t *testing.T) {
 	}
 }
 
+func TestSuggestNextTagMatchesReleaseBeforeOneZero(t *testing.T) {
+	repo := createGitRepository(t)
+	runGit(t, repo, "tag", "-a", "v0.3.0", "-m", "release")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "feat!: drop the legacy API")
+
+	next, err := SuggestNextTag(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := PlanRelease(repo, DefaultReleaseOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A breaking change before 1.0.0 bumps the minor version, as gphc release does
+	if next != "v0.4.0" || plan.Tag != next {
+		t.Fatalf("SuggestNextTag() = %q and PlanRelease tag %q, want v0.4.0", next, plan.Tag)
+	}
+}
+
 func createGitRepository(t *testing.T) string {
 	t.Helper()
 	repo := t.TempDir()
@@ -81,3 +100,14 @@ func runGit(t *testing.T, dir string, args ...string) {
 		t.Fatalf("git %v failed: %v\n%s", args, err, output)
 	}
 }
+
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %v failed: %v", args, err)
+	}
+	return strings.TrimSpace(string(output))
+}
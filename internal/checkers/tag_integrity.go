@@ -0,0 +1,580 @@
+package checkers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// Tag integrity finding types
+const (
+	TagFindingUnsigned         = "tag_unsigned"
+	TagFindingBadSignature     = "tag_bad_signature"
+	TagFindingUnknownKey       = "tag_unknown_key"
+	TagFindingExpiredKey       = "tag_expired_key"
+	TagFindingRevokedKey       = "tag_revoked_key"
+	TagFindingSemverRegression = "tag_semver_regression"
+	TagFindingUnreachable      = "tag_unreachable"
+	TagFindingShadowed         = "tag_lightweight_shadow"
+	TagFindingMoved            = "tag_moved"
+)
+
+// TagHistoryFile is the tag history store, kept in the repository's git directory
+const TagHistoryFile = "gphc/tag-history.json"
+
+// TagIntegrityOptions configures the tag integrity checks
+type TagIntegrityOptions struct {
+	// ReleaseBranch is the branch every release tag must be reachable from; empty resolves the main branch
+	ReleaseBranch string
+	// MaintenanceBranches are branch patterns whose tags are also released, such as release/*
+	MaintenanceBranches []string
+	// TagPrefixes select the release tags; empty means "v"
+	TagPrefixes []string
+	// RequireSigned reports unsigned release tags
+	RequireSigned bool
+	// Signatures configures where trusted signing keys come from
+	Signatures SignatureVerificationOptions
+	// TrackMoves compares tags with the history store and reports tags that moved
+	TrackMoves bool
+	// RecordTags saves the observed tags to the history store. Only gphc tags sets it, so that
+	// health checks, scans and the dashboard never write to the repository
+	RecordTags bool
+	// AcknowledgedMoves are moved tags whose new commit is accepted; recording makes it the tag's baseline
+	AcknowledgedMoves []string
+	// Now is when tags are observed; zero means time.Now
+	Now time.Time
+}
+
+// DefaultTagIntegrityOptions returns the settings used when gphc.yml sets none
+func DefaultTagIntegrityOptions() TagIntegrityOptions {
+	return TagIntegrityOptions{
+		MaintenanceBranches: []string{"release/*", "release-*", "support/*"},
+		TagPrefixes:         []string{"v"},
+		TrackMoves:          true,
+	}
+}
+
+// TagFinding is one tag integrity problem
+type TagFinding struct {
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	Tag      string `json:"tag"`
+	// Commit is the commit the tag points at
+	Commit string `json:"commit,omitempty"`
+	// Related is the other tag or previous commit involved, such as the annotated tag shadowed
+	Related        string `json:"related,omitempty"`
+	Description    string `json:"description"`
+	Recommendation string `json:"recommendation"`
+}
+
+// TagSignature is the verification result of one tag
+type TagSignature struct {
+	Tag    string `json:"tag"`
+	Status string `json:"status"`
+	Format string `json:"format,omitempty"`
+	Key    string `json:"key,omitempty"`
+	Signer string `json:"signer,omitempty"`
+}
+
+// TagIntegrityReport is the result of the tag integrity checks
+type TagIntegrityReport struct {
+	ReleaseBranch string         `json:"release_branch"`
+	Tags          int            `json:"tags"`
+	Annotated     int            `json:"annotated"`
+	Signed        int            `json:"signed"`
+	Signatures    []TagSignature `json:"signatures,omitempty"`
+	// Observed is the previous run the history store recorded; nil on the first run
+	Observed *time.Time   `json:"observed,omitempty"`
+	Findings []TagFinding `json:"findings"`
+}
+
+// tagRef is a tag with the object it points at
+type tagRef struct {
+	name      string
+	object    string
+	commit    string
+	annotated bool
+	signature string
+}
+
+// tagHistory is the history store: every tag's original object and commit, and any move since
+type tagHistory struct {
+	Observed time.Time                  `json:"observed"`
+	Tags     map[string]tagHistoryEntry `json:"tags"`
+}
+
+// tagHistoryEntry keeps the object and commit a tag was first observed at, or last acknowledged at.
+// LastSeen is the last run that found the tag there.
+type tagHistoryEntry struct {
+	Object    string    `json:"object"`
+	Commit    string    `json:"commit"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	// Moved is where the tag points now, until the move is acknowledged or the tag is restored
+	Moved *tagMove `json:"moved,omitempty"`
+}
+
+// tagMove is the object and commit a moved tag was found at, and when the move was first seen
+type tagMove struct {
+	Object   string    `json:"object"`
+	Commit   string    `json:"commit"`
+	Detected time.Time `json:"detected"`
+}
+
+var (
+	sshGoodSignatureRe = regexp.MustCompile(`Good "git" signature for (\S+) with (\S+) key (\S+)`)
+	gpgGoodSignatureRe = regexp.MustCompile(`\[GNUPG:\] GOODSIG (\S+) (.*)`)
+)
+
+// AnalyzeTagIntegrity verifies tag signatures and finds semver regressions, release tags missing
+// from the release branch, lightweight tags shadowing annotated ones and tags that moved since the
+// history store last observed them
+func AnalyzeTagIntegrity(repoPath string, options TagIntegrityOptions) (*TagIntegrityReport, error) {
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+	if len(options.TagPrefixes) == 0 {
+		options.TagPrefixes = []string{"v"}
+	}
+	tags, err := tagRefs(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	report := &TagIntegrityReport{
+		ReleaseBranch: resolveMainBranch(repoPath, options.ReleaseBranch),
+		Tags:          len(tags),
+		Findings:      []TagFinding{},
+	}
+
+	var releases []tagRef
+	for _, tag := range tags {
+		if tag.annotated {
+			report.Annotated++
+		}
+		if tag.signature != "" {
+			report.Signed++
+		}
+		if isVersionTag(tag.name, options.TagPrefixes) {
+			releases = append(releases, tag)
+		}
+	}
+
+	report.checkSignatures(repoPath, releases, options)
+	report.checkRegressions(repoPath, releases, options.TagPrefixes)
+	if err := report.checkReachability(repoPath, releases, options.MaintenanceBranches); err != nil {
+		return nil, err
+	}
+	report.checkShadowing(tags, options.TagPrefixes)
+	if options.TrackMoves || options.RecordTags {
+		if err := report.checkMoves(repoPath, tags, options); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// tagRefs lists every tag with the commit it points at; tags of trees and blobs are skipped
+func tagRefs(repoPath string) ([]tagRef, error) {
+	cmd := exec.Command("git", "for-each-ref", "refs/tags",
+		"--format=%(refname:strip=2)%1f%(objecttype)%1f%(objectname)%1f%(*objecttype)%1f%(*objectname)%1f%(contents:signature)%1e")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	var tags []tagRef
+	for _, record := range strings.Split(string(output), "\x1e") {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), "\x1f")
+		if len(fields) != 6 {
+			continue
+		}
+		tag := tagRef{name: fields[0], object: fields[2], signature: strings.TrimSpace(fields[5])}
+		switch {
+		case fields[1] == "commit":
+			tag.commit = fields[2]
+		case fields[1] == "tag" && fields[3] == "commit":
+			tag.annotated = true
+			tag.commit = fields[4]
+		default:
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// checkSignatures verifies the signed release tags and reports unsigned ones when signing is required
+func (r *TagIntegrityReport) checkSignatures(repoPath string, releases []tagRef, options TagIntegrityOptions) {
+	for _, tag := range releases {
+		if tag.signature == "" {
+			if options.RequireSigned {
+				r.Findings = append(r.Findings, TagFinding{
+					Type:           TagFindingUnsigned,
+					Severity:       "medium",
+					Tag:            tag.name,
+					Commit:         tag.commit,
+					Description:    fmt.Sprintf("Release tag %s is not signed", tag.name),
+					Recommendation: "Sign release tags with git tag -s (or gphc release --sign)",
+				})
+			}
+			continue
+		}
+		signature := verifyTagSignature(repoPath, tag, options.Signatures)
+		r.Signatures = append(r.Signatures, signature)
+		finding := TagFinding{Tag: tag.name, Commit: tag.commit, Related: signature.Key}
+		key := displaySigningKey(signature.Key)
+		switch signature.Status {
+		case SignatureGood:
+			continue
+		case SignatureBad:
+			finding.Type, finding.Severity = TagFindingBadSignature, "critical"
+			finding.Description = fmt.Sprintf("Tag %s has a signature that does not verify", tag.name)
+			finding.Recommendation = "Investigate the tag: its message or target changed after it was signed"
+		case SignatureExpired:
+			finding.Type, finding.Severity = TagFindingExpiredKey, "medium"
+			finding.Description = fmt.Sprintf("Tag %s is signed by expired key %s", tag.name, key)
+			finding.Recommendation = "Extend or rotate the expired signing key"
+		case SignatureRevoked:
+			finding.Type, finding.Severity = TagFindingRevokedKey, "critical"
+			finding.Description = fmt.Sprintf("Tag %s is signed by revoked key %s", tag.name, key)
+			finding.Recommendation = "Investigate the tag and re-sign the release with a trusted key"
+		default:
+			finding.Type, finding.Severity = TagFindingUnknownKey, "high"
+			finding.Description = fmt.Sprintf("Tag %s is signed by a key that could not be verified (%s)", tag.name, key)
+			finding.Recommendation = "Add the key to the allowed signers file or keyring"
+		}
+		r.Findings = append(r.Findings, finding)
+	}
+}
+
+// verifyTagSignature runs git verify-tag and classifies its raw output
+func verifyTagSignature(repoPath string, tag tagRef, options SignatureVerificationOptions) TagSignature {
+	signature := TagSignature{Tag: tag.name, Format: signatureFormat(tag.signature, nil)}
+	args := []string{}
+	if signers := resolveAllowedSignersFile(repoPath, options.AllowedSignersFile); signers != "" {
+		args = append(args, "-c", "gpg.ssh.allowedSignersFile="+signers)
+	}
+	args = append(args, "verify-tag", "--raw", tag.name)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	if options.GPGHome != "" {
+		cmd.Env = append(os.Environ(), "GNUPGHOME="+options.GPGHome)
+	}
+	output, err := cmd.CombinedOutput()
+	text := string(output)
+
+	if match := sshGoodSignatureRe.FindStringSubmatch(text); match != nil {
+		signature.Signer, signature.Key = match[1], match[3]
+	} else if match := gpgGoodSignatureRe.FindStringSubmatch(text); match != nil {
+		signature.Key, signature.Signer = match[1], strings.TrimSpace(match[2])
+	}
+	switch {
+	case strings.Contains(text, "REVKEYSIG"):
+		signature.Status = SignatureRevoked
+	case strings.Contains(text, "EXPKEYSIG") || strings.Contains(text, "EXPSIG"):
+		signature.Status = SignatureExpired
+	case strings.Contains(text, "BADSIG") || strings.Contains(text, "incorrect signature"):
+		signature.Status = SignatureBad
+	case err == nil:
+		signature.Status = SignatureGood
+	default:
+		// No principal matched, a missing allowed signers file or a missing public key
+		signature.Status = SignatureUnknownKey
+	}
+	return signature
+}
+
+// checkRegressions reports a higher version tagged on an ancestor of a lower version's commit
+func (r *TagIntegrityReport) checkRegressions(repoPath string, releases []tagRef, prefixes []string) {
+	for _, prefix := range prefixes {
+		type versioned struct {
+			tag     tagRef
+			version Version
+		}
+		var stream []versioned
+		for _, tag := range releases {
+			rest, ok := strings.CutPrefix(tag.name, prefix)
+			if !ok || strings.HasPrefix(rest, "v") {
+				continue
+			}
+			if version, err := ParseVersion(rest); err == nil {
+				stream = append(stream, versioned{tag: tag, version: version})
+			}
+		}
+		sort.Slice(stream, func(i, j int) bool { return stream[i].version.Compare(stream[j].version) < 0 })
+		for i := 0; i+1 < len(stream); i++ {
+			lower, higher := stream[i], stream[i+1]
+			if lower.tag.commit == higher.tag.commit || !isAncestor(repoPath, higher.tag.commit, lower.tag.commit) {
+				continue
+			}
+			r.Findings = append(r.Findings, TagFinding{
+				Type:     TagFindingSemverRegression,
+				Severity: "high",
+				Tag:      higher.tag.name,
+				Commit:   higher.tag.commit,
+				Related:  lower.tag.name,
+				Description: fmt.Sprintf("%s is tagged on %s, an older commit than %s of the lower %s",
+					higher.tag.name, types.ShortHash(higher.tag.commit), types.ShortHash(lower.tag.commit), lower.tag.name),
+				Recommendation: "Check which release is correct; tools resolving the latest version will pick the older code",
+			})
+		}
+	}
+}
+
+// isAncestor reports whether ancestor is a proper ancestor of commit
+func isAncestor(repoPath, ancestor, commit string) bool {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", ancestor, commit)
+	cmd.Dir = repoPath
+	return cmd.Run() == nil
+}
+
+// checkReachability reports release tags reachable from neither the release branch nor a maintenance branch
+func (r *TagIntegrityReport) checkReachability(repoPath string, releases []tagRef, maintenance []string) error {
+	if len(releases) == 0 {
+		return nil
+	}
+	branches := []string{r.ReleaseBranch}
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("list branches: %w", err)
+	}
+	// Remote-tracking maintenance branches count on every remote, not only origin
+	remotes := policyRemotes(repoPath)
+	for _, ref := range strings.Fields(string(output)) {
+		branch := policyBranchName(ref, remotes)
+		for _, pattern := range maintenance {
+			if matchGitPattern(pattern, branch) {
+				branches = append(branches, ref)
+				break
+			}
+		}
+	}
+
+	args := []string{"tag", "--list"}
+	for _, branch := range branches {
+		args = append(args, "--merged", branch)
+	}
+	cmd = exec.Command("git", args...)
+	cmd.Dir = repoPath
+	output, err = cmd.Output()
+	if err != nil {
+		return fmt.Errorf("list merged tags: %w", err)
+	}
+	merged := strings.Fields(string(output))
+	for _, tag := range releases {
+		if containsString(merged, tag.name) {
+			continue
+		}
+		// Pre-releases are often cut from feature branches
+		severity := "medium"
+		if tagVersionIsPrerelease(tag.name) {
+			severity = "low"
+		}
+		r.Findings = append(r.Findings, TagFinding{
+			Type:           TagFindingUnreachable,
+			Severity:       severity,
+			Tag:            tag.name,
+			Commit:         tag.commit,
+			Related:        r.ReleaseBranch,
+			Description:    fmt.Sprintf("Release tag %s is not reachable from %s or a maintenance branch", tag.name, r.ReleaseBranch),
+			Recommendation: "Merge the tagged commit into the release branch, or move the tag to a released commit",
+		})
+	}
+	return nil
+}
+
+// tagVersionIsPrerelease reports whether the version at the end of a tag has a pre-release suffix
+func tagVersionIsPrerelease(name string) bool {
+	rest := name[strings.LastIndex(name, "/")+1:]
+	version, err := ParseVersion(rest)
+	return err == nil && version.IsPrerelease()
+}
+
+// checkShadowing reports lightweight tags on the commit of an annotated release tag, or naming the same
+// version; git describe --tags and tools that sort by name may pick them over the annotated release
+func (r *TagIntegrityReport) checkShadowing(tags []tagRef, prefixes []string) {
+	annotatedByCommit := make(map[string]string)
+	annotatedByVersion := make(map[string]string)
+	for _, tag := range tags {
+		if tag.annotated && isVersionTag(tag.name, prefixes) {
+			annotatedByCommit[tag.commit] = tag.name
+			annotatedByVersion[tagVersionKey(tag.name, prefixes)] = tag.name
+		}
+	}
+	for _, tag := range tags {
+		if tag.annotated {
+			continue
+		}
+		shadowed, ok := annotatedByVersion[tagVersionKey(tag.name, prefixes)]
+		if !ok {
+			shadowed, ok = annotatedByCommit[tag.commit]
+		}
+		if !ok {
+			continue
+		}
+		r.Findings = append(r.Findings, TagFinding{
+			Type:           TagFindingShadowed,
+			Severity:       "medium",
+			Tag:            tag.name,
+			Commit:         tag.commit,
+			Related:        shadowed,
+			Description:    fmt.Sprintf("Lightweight tag %s shadows the annotated release %s", tag.name, shadowed),
+			Recommendation: fmt.Sprintf("Delete %s or recreate it as an annotated tag", tag.name),
+		})
+	}
+}
+
+// tagVersionKey identifies the release a tag names, ignoring a "v" and build metadata; empty for other tags
+func tagVersionKey(name string, prefixes []string) string {
+	for _, prefix := range prefixes {
+		base := strings.TrimSuffix(prefix, "v")
+		rest, ok := strings.CutPrefix(name, base)
+		if !ok {
+			continue
+		}
+		version, err := ParseVersion(rest)
+		if err != nil {
+			continue
+		}
+		version.Build = ""
+		return base + version.String()
+	}
+	return "\x00" + name
+}
+
+// checkMoves compares the tags with the history store and reports every tag that points elsewhere
+// than its recorded object. A move keeps being reported until it is acknowledged or the tag is
+// restored. The store is only written when options.RecordTags is set.
+func (r *TagIntegrityReport) checkMoves(repoPath string, tags []tagRef, options TagIntegrityOptions) error {
+	path, err := tagHistoryPath(repoPath)
+	if err != nil {
+		return err
+	}
+	history, err := loadTagHistory(path)
+	if err != nil {
+		return err
+	}
+	if !history.Observed.IsZero() {
+		observed := history.Observed
+		r.Observed = &observed
+	}
+	acknowledged := make(map[string]bool, len(options.AcknowledgedMoves))
+	for _, name := range options.AcknowledgedMoves {
+		acknowledged[name] = true
+	}
+
+	now := options.Now
+	for _, tag := range tags {
+		entry, seen := history.Tags[tag.name]
+		switch {
+		case !seen:
+			entry = tagHistoryEntry{Object: tag.object, Commit: tag.commit, FirstSeen: now}
+		case entry.Object == tag.object:
+			// Unchanged, or restored to the recorded object
+			entry.Moved = nil
+		case acknowledged[tag.name]:
+			entry.Object, entry.Commit, entry.Moved = tag.object, tag.commit, nil
+		default:
+			if entry.Moved == nil || entry.Moved.Object != tag.object {
+				entry.Moved = &tagMove{Object: tag.object, Commit: tag.commit, Detected: now}
+			}
+			if options.TrackMoves {
+				r.Findings = append(r.Findings, movedTagFinding(tag, entry))
+			}
+		}
+		if entry.Moved == nil {
+			entry.LastSeen = now
+		}
+		history.Tags[tag.name] = entry
+	}
+	if !options.RecordTags {
+		return nil
+	}
+	history.Observed = now
+	return saveTagHistory(path, history)
+}
+
+// movedTagFinding reports a tag that no longer points at its recorded object
+func movedTagFinding(tag tagRef, entry tagHistoryEntry) TagFinding {
+	since := entry.LastSeen.Format("2006-01-02")
+	finding := TagFinding{
+		Type:           TagFindingMoved,
+		Severity:       "high",
+		Tag:            tag.name,
+		Commit:         tag.commit,
+		Related:        entry.Commit,
+		Description:    fmt.Sprintf("Tag %s moved from %s to %s since %s", tag.name, types.ShortHash(entry.Commit), types.ShortHash(tag.commit), since),
+		Recommendation: "Never move published tags; restore the tag or release a new version, verify who re-tagged, then run gphc tags --ack-move " + tag.name,
+	}
+	if entry.Commit == tag.commit {
+		finding.Severity = "low"
+		finding.Description = fmt.Sprintf("Tag %s was recreated on the same commit %s since %s", tag.name, types.ShortHash(tag.commit), since)
+	}
+	return finding
+}
+
+// tagHistoryPath returns the history store location inside the git directory
+func tagHistoryPath(repoPath string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-path", TagHistoryFile)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("locate tag history: %w", err)
+	}
+	path := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repoPath, path)
+	}
+	return path, nil
+}
+
+func loadTagHistory(path string) (*tagHistory, error) {
+	history := &tagHistory{Tags: make(map[string]tagHistoryEntry)}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read tag history: %w", err)
+	}
+	if err := json.Unmarshal(content, history); err != nil {
+		return nil, fmt.Errorf("parse tag history %s: %w", path, err)
+	}
+	if history.Tags == nil {
+		history.Tags = make(map[string]tagHistoryEntry)
+	}
+	return history, nil
+}
+
+func saveTagHistory(path string, history *tagHistory) error {
+	content, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("write tag history: %w", err)
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("write tag history: %w", err)
+	}
+	return nil
+}
+
+// tagIntegrityDetails renders the findings as result detail lines
+func tagIntegrityDetails(report *TagIntegrityReport) []string {
+	details := []string{fmt.Sprintf("Signed tags: %d of %d", report.Signed, report.Tags)}
+	for _, finding := range report.Findings {
+		details = append(details, fmt.Sprintf("[%s] %s", strings.ToUpper(finding.Severity), finding.Description))
+	}
+	return details
+}
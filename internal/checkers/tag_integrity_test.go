@@ -0,0 +1,227 @@
+package checkers
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeTagIntegrity(t *testing.T) {
+	repo := createGitRepository(t)
+	commit := func(message string) string {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, "work.txt"), []byte(message+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, repo, "add", ".")
+		runGit(t, repo, "commit", "-qm", message)
+		return gitOutput(t, repo, "rev-parse", "HEAD")
+	}
+	first := commit("feat: first")
+	second := commit("feat: second")
+	runGit(t, repo, "tag", "-a", "v1.0.0", "-m", "v1.0.0")
+	// A higher version on an older commit
+	runGit(t, repo, "tag", "-a", "v1.1.0", "-m", "v1.1.0", first)
+	// A lightweight tag on the commit of an annotated release
+	runGit(t, repo, "tag", "latest", second)
+	// A release tag only on a feature branch
+	runGit(t, repo, "checkout", "-qb", "feature")
+	commit("feat: experiment")
+	runGit(t, repo, "tag", "-a", "v1.2.0", "-m", "v1.2.0")
+	runGit(t, repo, "checkout", "-q", "master")
+	// Tags on maintenance branches are released
+	runGit(t, repo, "checkout", "-qb", "release/1.0", "v1.0.0")
+	commit("fix: backport")
+	runGit(t, repo, "tag", "-a", "v1.0.1", "-m", "v1.0.1")
+	runGit(t, repo, "checkout", "-q", "master")
+
+	options := DefaultTagIntegrityOptions()
+	options.ReleaseBranch = "master"
+	options.RecordTags = true
+	options.Now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	report, err := AnalyzeTagIntegrity(repo, options)
+	if err != nil {
+		t.Fatalf("AnalyzeTagIntegrity failed: %v", err)
+	}
+	findings := map[string]TagFinding{}
+	for _, finding := range report.Findings {
+		findings[finding.Type+" "+finding.Tag] = finding
+	}
+	if finding, ok := findings[TagFindingSemverRegression+" v1.1.0"]; !ok || finding.Related != "v1.0.1" {
+		t.Errorf("expected v1.1.0 to regress below v1.0.1: %+v", report.Findings)
+	}
+	if finding, ok := findings[TagFindingShadowed+" latest"]; !ok || finding.Related != "v1.0.0" {
+		t.Errorf("expected latest to shadow v1.0.0: %+v", report.Findings)
+	}
+	if _, ok := findings[TagFindingUnreachable+" v1.2.0"]; !ok {
+		t.Errorf("expected v1.2.0 to be unreachable from master: %+v", report.Findings)
+	}
+	if _, ok := findings[TagFindingUnreachable+" v1.0.1"]; ok {
+		t.Errorf("tags on maintenance branches are released: %+v", report.Findings)
+	}
+	if len(report.Findings) != 3 || report.Observed != nil {
+		t.Errorf("unexpected first run %+v", report)
+	}
+
+	// Moving a tag is reported on the next run
+	runGit(t, repo, "tag", "-f", "-a", "v1.0.0", "-m", "v1.0.0", first)
+	options.Now = options.Now.Add(24 * time.Hour)
+	report, err = AnalyzeTagIntegrity(repo, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moved := false
+	for _, finding := range report.Findings {
+		if finding.Type == TagFindingMoved && finding.Tag == "v1.0.0" {
+			moved = finding.Related == second && finding.Severity == "high"
+		}
+	}
+	if !moved || report.Observed == nil {
+		t.Errorf("expected v1.0.0 to have moved from %s: %+v", second, report.Findings)
+	}
+}
+
+func TestTagReachabilityOnAnyRemote(t *testing.T) {
+	repo := createGitRepository(t)
+	commit := func(message string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, "work.txt"), []byte(message+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, repo, "add", ".")
+		runGit(t, repo, "commit", "-qm", message)
+	}
+	commit("feat: first")
+	runGit(t, repo, "tag", "-a", "v1.0.0", "-m", "v1.0.0")
+	// A backport only found on the remote-tracking release branch of a second remote
+	runGit(t, repo, "remote", "add", "upstream", "https://example.com/acme/widget.git")
+	runGit(t, repo, "checkout", "-qb", "backport")
+	commit("fix: backport")
+	runGit(t, repo, "tag", "-a", "v1.0.1", "-m", "v1.0.1")
+	runGit(t, repo, "update-ref", "refs/remotes/upstream/release/1.0", "HEAD")
+	runGit(t, repo, "checkout", "-q", "master")
+	runGit(t, repo, "branch", "-qD", "backport")
+
+	options := DefaultTagIntegrityOptions()
+	options.ReleaseBranch = "master"
+	report, err := AnalyzeTagIntegrity(repo, options)
+	if err != nil {
+		t.Fatalf("AnalyzeTagIntegrity failed: %v", err)
+	}
+	for _, finding := range report.Findings {
+		if finding.Type == TagFindingUnreachable {
+			t.Errorf("v1.0.1 is on upstream/release/1.0: %+v", finding)
+		}
+	}
+}
+
+func TestTagMovesPersistUntilAcknowledged(t *testing.T) {
+	repo := createGitRepository(t)
+	first := gitOutput(t, repo, "rev-parse", "HEAD")
+	runGit(t, repo, "commit", "-q", "--allow-empty", "-m", "feat: second")
+	second := gitOutput(t, repo, "rev-parse", "HEAD")
+	runGit(t, repo, "tag", "-a", "v1.0.0", "-m", "v1.0.0")
+	original := gitOutput(t, repo, "rev-parse", "v1.0.0")
+
+	movedFrom := func(options TagIntegrityOptions) string {
+		t.Helper()
+		report, err := AnalyzeTagIntegrity(repo, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, finding := range report.Findings {
+			if finding.Type == TagFindingMoved {
+				return finding.Related
+			}
+		}
+		return ""
+	}
+	check := DefaultTagIntegrityOptions()
+	check.ReleaseBranch = "master"
+	record := check
+	record.RecordTags = true
+
+	// Health checks only read the store
+	movedFrom(check)
+	if path, _ := tagHistoryPath(repo); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			t.Fatalf("a read-only check wrote %s", path)
+		}
+	}
+
+	movedFrom(record)
+	runGit(t, repo, "tag", "-f", "-a", "v1.0.0", "-m", "v1.0.0", first)
+	for run := 0; run < 2; run++ {
+		if related := movedFrom(record); related != second {
+			t.Fatalf("run %d: expected v1.0.0 to be reported as moved from %s, got %q", run, second, related)
+		}
+	}
+	if related := movedFrom(check); related != second {
+		t.Errorf("expected the health check to report the recorded move, got %q", related)
+	}
+
+	// Restoring the tag clears the move
+	runGit(t, repo, "update-ref", "refs/tags/v1.0.0", original)
+	if related := movedFrom(record); related != "" {
+		t.Errorf("expected a restored tag not to be reported, got %q", related)
+	}
+
+	// Acknowledging a move makes the new commit the recorded one
+	runGit(t, repo, "tag", "-f", "-a", "v1.0.0", "-m", "v1.0.0", first)
+	acknowledge := record
+	acknowledge.AcknowledgedMoves = []string{"v1.0.0"}
+	if related := movedFrom(acknowledge); related != "" {
+		t.Errorf("expected an acknowledged move not to be reported, got %q", related)
+	}
+	if related := movedFrom(record); related != "" {
+		t.Errorf("expected the acknowledged commit to be recorded, got %q", related)
+	}
+}
+
+func TestTagSignatures(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	repo := createGitRepository(t)
+	trusted := generateSSHKey(t, "trusted")
+	untrusted := generateSSHKey(t, "untrusted")
+	runGit(t, repo, "config", "gpg.format", "ssh")
+	runGit(t, repo, "-c", "user.signingkey="+trusted+".pub", "tag", "-s", "v1.0.0", "-m", "v1.0.0")
+	runGit(t, repo, "-c", "user.signingkey="+untrusted+".pub", "tag", "-s", "v1.0.1", "-m", "v1.0.1")
+	runGit(t, repo, "tag", "-a", "v1.0.2", "-m", "v1.0.2")
+
+	publicKey, err := os.ReadFile(trusted + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := strings.Fields(string(publicKey))
+	signers := filepath.Join(t.TempDir(), "allowed_signers")
+	if err := os.WriteFile(signers, []byte("test@example.com "+fields[0]+" "+fields[1]+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	options := DefaultTagIntegrityOptions()
+	options.RequireSigned = true
+	options.TrackMoves = false
+	options.Signatures.AllowedSignersFile = signers
+	report, err := AnalyzeTagIntegrity(repo, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Signed != 2 || len(report.Signatures) != 2 {
+		t.Fatalf("unexpected signatures %+v", report.Signatures)
+	}
+	if good := report.Signatures[0]; good.Status != SignatureGood || good.Signer != "test@example.com" || good.Format != "ssh" {
+		t.Errorf("unexpected trusted signature %+v", good)
+	}
+	types := map[string]string{}
+	for _, finding := range report.Findings {
+		types[finding.Tag] = finding.Type
+	}
+	if types["v1.0.1"] != TagFindingUnknownKey || types["v1.0.2"] != TagFindingUnsigned || types["v1.0.0"] != "" {
+		t.Errorf("unexpected findings %+v", report.Findings)
+	}
+}
@@ -18,6 +18,7 @@ const (
 	FormatYAML     ExportFormat = "yaml"
 	FormatMarkdown ExportFormat = "markdown"
 	FormatHTML     ExportFormat = "html"
+	FormatCSV      ExportFormat = "csv"
 )
 
 // Exporter handles exporting health reports in different formats
@@ -77,6 +78,18 @@ func (e *Exporter) exportMarkdown(report *types.HealthReport) (string, error) {
 	output.WriteString(fmt.Sprintf("- **Failed:** %d\n", report.Summary.FailedChecks))
 	output.WriteString(fmt.Sprintf("- **Warnings:** %d\n\n", report.Summary.WarningChecks))
 
+	// Category scores
+	if len(report.Categories) > 0 {
+		output.WriteString("## Category Scores\n\n")
+		output.WriteString("| Category | Score | Grade | Passed |\n")
+		output.WriteString("|----------|-------|-------|--------|\n")
+		for _, category := range report.Categories {
+			output.WriteString(fmt.Sprintf("| %s | %d/100 | %s | %d/%d |\n",
+				category.Name, category.Score, category.Grade, category.Summary.PassedChecks, category.Summary.TotalChecks))
+		}
+		output.WriteString("\n")
+	}
+
 	// Results by category
 	categories := make(map[string][]types.CheckResult)
 	for _, result := range report.Results {
@@ -85,7 +98,11 @@ func (e *Exporter) exportMarkdown(report *types.HealthReport) (string, error) {
 	}
 
 	for category, results := range categories {
-		output.WriteString(fmt.Sprintf("## %s\n\n", category))
+		output.WriteString(fmt.Sprintf("## %s", category))
+		if score := report.Category(results[0].Category); score != nil {
+			output.WriteString(fmt.Sprintf(" (%d/100, %s)", score.Score, score.Grade))
+		}
+		output.WriteString("\n\n")
 
 		for _, result := range results {
 			status := "✅"
@@ -144,6 +161,9 @@ func (e *Exporter) exportHTML(report *types.HealthReport) (string, error) {
         .summary { display: flex; justify-content: space-around; margin: 20px 0; }
         .summary-item { text-align: center; padding: 15px; background-color: #ecf0f1; border-radius: 5px; }
         .category { margin: 20px 0; }
+        .categories { display: flex; flex-wrap: wrap; gap: 10px; margin: 20px 0; }
+        .category-score { flex: 1; min-width: 150px; text-align: center; padding: 15px; background-color: #f8f9fa; border-radius: 5px; }
+        .category-score .value { font-size: 1.5em; font-weight: bold; color: #2c3e50; }
         .result { margin: 15px 0; padding: 15px; border-left: 4px solid #3498db; background-color: #f8f9fa; }
         .result.fail { border-left-color: #e74c3c; }
         .result.warning { border-left-color: #f39c12; }
@@ -177,6 +197,18 @@ func (e *Exporter) exportHTML(report *types.HealthReport) (string, error) {
             </div>
         </div>
         
+        {{if .Categories}}
+        <div class="categories">
+            {{range .Categories}}
+            <div class="category-score">
+                <div class="value">{{.Score}}/100 ({{.Grade}})</div>
+                <p>{{.Name}}</p>
+                <small>{{.Summary.PassedChecks}}/{{.Summary.TotalChecks}} passed</small>
+            </div>
+            {{end}}
+        </div>
+        {{end}}
+
         {{range .Results}}
         <div class="result {{.Status}}">
             <h3>{{.ID}}: {{.Message}}</h3>
@@ -208,8 +240,136 @@ func (e *Exporter) exportHTML(report *types.HealthReport) (string, error) {
 	return output.String(), nil
 }
 
+// ExportComparison exports a comparison of two reports as JSON, YAML or Markdown
+func (e *Exporter) ExportComparison(comparison *types.ReportComparison, format ExportFormat) (string, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(comparison, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case FormatYAML:
+		data, err := yaml.Marshal(comparison)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case FormatMarkdown:
+		return e.exportComparisonMarkdown(comparison), nil
+	default:
+		return "", fmt.Errorf("unsupported comparison format: %s", format)
+	}
+}
+
+// exportComparisonMarkdown renders a comparison for pull request comments
+func (e *Exporter) exportComparisonMarkdown(comparison *types.ReportComparison) string {
+	var output strings.Builder
+
+	output.WriteString("# Repository Health Comparison\n\n")
+	output.WriteString(fmt.Sprintf("`%s` → `%s`\n\n", comparison.Base, comparison.Head))
+	output.WriteString(strings.TrimSpace(fmt.Sprintf("**Overall Health Score:** %d → %d (%s) %s",
+		comparison.BaseScore, comparison.HeadScore, markdownDelta(comparison.ScoreDelta), deltaIcon(comparison.ScoreDelta))))
+	output.WriteString("\n\n")
+	output.WriteString(fmt.Sprintf("**Grade:** %s\n\n", comparison.GradeTransition()))
+
+	if len(comparison.Categories) > 0 {
+		output.WriteString("## Category Scores\n\n")
+		output.WriteString("| Category | Base | Head | Change |\n")
+		output.WriteString("|----------|------|------|--------|\n")
+		for _, category := range comparison.Categories {
+			change := "new"
+			switch {
+			case category.BaseGrade != "" && category.HeadGrade != "":
+				change = strings.TrimSpace(markdownDelta(category.Delta) + " " + deltaIcon(category.Delta))
+			case category.HeadGrade == "":
+				change = "removed"
+			}
+			output.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", category.Name,
+				markdownScore(category.BaseScore, category.BaseGrade), markdownScore(category.HeadScore, category.HeadGrade), change))
+		}
+		output.WriteString("\n")
+	}
+
+	output.WriteString(fmt.Sprintf("## Status Changes (%d)\n\n", len(comparison.StatusChanges)))
+	if len(comparison.StatusChanges) > 0 {
+		output.WriteString("| | Check | Name | Base | Head |\n")
+		output.WriteString("|-|-------|------|------|------|\n")
+		for _, change := range comparison.StatusChanges {
+			icon := "✅"
+			if change.Regressed {
+				icon = "❌"
+			}
+			output.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", icon, change.ID, change.Name,
+				markdownStatus(change.BaseStatus), markdownStatus(change.HeadStatus)))
+		}
+		output.WriteString("\n")
+	}
+
+	output.WriteString(fmt.Sprintf("## New Findings (%d)\n\n", len(comparison.NewFindings)))
+	for _, finding := range comparison.NewFindings {
+		output.WriteString(fmt.Sprintf("- **%s** %s\n", finding.CheckID, strings.TrimSpace(finding.Detail)))
+	}
+	if len(comparison.NewFindings) > 0 {
+		output.WriteString("\n")
+	}
+
+	output.WriteString(fmt.Sprintf("## Resolved Findings (%d)\n\n", len(comparison.ResolvedFindings)))
+	for _, finding := range comparison.ResolvedFindings {
+		output.WriteString(fmt.Sprintf("- **%s** %s\n", finding.CheckID, strings.TrimSpace(finding.Detail)))
+	}
+
+	return output.String()
+}
+
+// markdownDelta formats a score change with its sign
+func markdownDelta(delta int) string {
+	if delta == 0 {
+		return "±0"
+	}
+	return fmt.Sprintf("%+d", delta)
+}
+
+// deltaIcon marks improvements and regressions
+func deltaIcon(delta int) string {
+	switch {
+	case delta > 0:
+		return "📈"
+	case delta < 0:
+		return "📉"
+	default:
+		return ""
+	}
+}
+
+// markdownScore formats a category score and grade, or a dash when the report has none
+func markdownScore(score int, grade string) string {
+	if grade == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%d (%s)", score, grade)
+}
+
+// markdownStatus formats a check status, or a dash when the report lacks the check
+func markdownStatus(status string) string {
+	if status == "" {
+		return "-"
+	}
+	return status
+}
+
 // GenerateBadgeURL generates a badge URL for the health score
 func (e *Exporter) GenerateBadgeURL(score int) string {
+	return e.generateBadgeURL("Health Score", score)
+}
+
+// GenerateCategoryBadgeURL generates a badge URL for the sub-score of a category
+func (e *Exporter) GenerateCategoryBadgeURL(category types.CategoryScore) string {
+	return e.generateBadgeURL(categoryBadgeLabel(category.Category), category.Score)
+}
+
+// generateBadgeURL builds a shields.io badge colored by score
+func (e *Exporter) generateBadgeURL(label string, score int) string {
 	var color string
 	switch {
 	case score >= 90:
@@ -226,7 +386,8 @@ func (e *Exporter) GenerateBadgeURL(score int) string {
 		color = "red"
 	}
 
-	return fmt.Sprintf("https://img.shields.io/badge/Health_Score-%d%%2F100-%s?style=for-the-badge&logo=github", score, color)
+	label = strings.ReplaceAll(strings.ReplaceAll(label, "-", "--"), " ", "_")
+	return fmt.Sprintf("https://img.shields.io/badge/%s-%d%%2F100-%s?style=for-the-badge&logo=github", label, score, color)
 }
 
 // GenerateMarkdownBadge generates a markdown badge for the health score
@@ -234,3 +395,26 @@ func (e *Exporter) GenerateMarkdownBadge(score int) string {
 	badgeURL := e.GenerateBadgeURL(score)
 	return fmt.Sprintf("![Health Score](%s)", badgeURL)
 }
+
+// GenerateCategoryMarkdownBadge generates a markdown badge for the sub-score of a category
+func (e *Exporter) GenerateCategoryMarkdownBadge(category types.CategoryScore) string {
+	return fmt.Sprintf("![%s](%s)", categoryBadgeLabel(category.Category), e.GenerateCategoryBadgeURL(category))
+}
+
+// categoryBadgeLabel is the short badge label of a category
+func categoryBadgeLabel(category types.Category) string {
+	switch category {
+	case types.CategoryDocs:
+		return "Docs"
+	case types.CategoryCommits:
+		return "Commits"
+	case types.CategoryHygiene:
+		return "Hygiene"
+	case types.CategoryStructure:
+		return "Structure"
+	case types.CategorySecurity:
+		return "Security"
+	default:
+		return "Health Score"
+	}
+}
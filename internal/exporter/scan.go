@@ -0,0 +1,300 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+	"gopkg.in/yaml.v2"
+)
+
+// ExportScan exports a multi-repository scan as JSON, YAML, Markdown, HTML or CSV
+func (e *Exporter) ExportScan(report *types.ScanReport, format ExportFormat) (string, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case FormatYAML:
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case FormatMarkdown:
+		return e.exportScanMarkdown(report), nil
+	case FormatHTML:
+		return e.exportScanHTML(report)
+	case FormatCSV:
+		return e.exportScanCSV(report)
+	default:
+		return "", fmt.Errorf("unsupported scan format: %s", format)
+	}
+}
+
+// exportScanMarkdown exports the scan as Markdown
+func (e *Exporter) exportScanMarkdown(report *types.ScanReport) string {
+	var output strings.Builder
+
+	output.WriteString("# Multi-Repository Health Scan\n\n")
+	if report.Root != "" {
+		output.WriteString(fmt.Sprintf("**Root:** `%s`\n\n", report.Root))
+	}
+
+	summary := report.Summary
+	output.WriteString("## Summary\n\n")
+	output.WriteString(fmt.Sprintf("- **Total Repositories:** %d\n", summary.TotalRepositories))
+	output.WriteString(fmt.Sprintf("- **Average Health:** %.1f/100\n", summary.AverageScore))
+	output.WriteString(fmt.Sprintf("- **Median Health:** %.1f/100\n", summary.MedianScore))
+	if summary.Highest != nil {
+		output.WriteString(fmt.Sprintf("- **Highest Score:** %s (%d/100)\n", summary.Highest.Name, summary.Highest.Score))
+		output.WriteString(fmt.Sprintf("- **Lowest Score:** %s (%d/100)\n", summary.Lowest.Name, summary.Lowest.Score))
+	}
+	if len(report.Errors) > 0 {
+		output.WriteString(fmt.Sprintf("- **Errors:** %d\n", len(report.Errors)))
+	}
+	output.WriteString("\n")
+
+	output.WriteString("## Score Distribution\n\n")
+	output.WriteString("| Score | Repositories | |\n")
+	output.WriteString("|-------|--------------|-|\n")
+	for i := len(report.Histogram) - 1; i >= 0; i-- {
+		bucket := report.Histogram[i]
+		output.WriteString(fmt.Sprintf("| %s | %d | %s |\n", bucket.Label(), bucket.Count, strings.Repeat("█", bucket.Count)))
+	}
+	output.WriteString("\n")
+
+	if len(report.Categories) > 0 {
+		output.WriteString("## Category Averages\n\n")
+		output.WriteString("| Category | Average | Min | Max | Worst Offenders |\n")
+		output.WriteString("|----------|---------|-----|-----|-----------------|\n")
+		for _, category := range report.Categories {
+			output.WriteString(fmt.Sprintf("| %s | %.1f | %d | %d | %s |\n",
+				category.Name, category.AverageScore, category.MinScore, category.MaxScore, formatOffenders(category.WorstOffenders)))
+		}
+		output.WriteString("\n")
+	}
+
+	if len(report.CheckFailures) > 0 {
+		output.WriteString("## Check Failures\n\n")
+		output.WriteString("| Check | Name | Failed | Warnings | Passed |\n")
+		output.WriteString("|-------|------|--------|----------|--------|\n")
+		for _, check := range report.CheckFailures {
+			output.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %d |\n", check.ID, check.Name, check.Failed, check.Warnings, check.Passed))
+		}
+		output.WriteString("\n")
+	}
+
+	output.WriteString("## Repositories\n\n")
+	output.WriteString("| Repository | Score | Grade | Passed | Warnings | Failed |\n")
+	output.WriteString("|------------|-------|-------|--------|----------|--------|\n")
+	for _, repository := range report.Repositories {
+		summary := repository.Report.Summary
+		output.WriteString(fmt.Sprintf("| %s | %d/100 | %s | %d | %d | %d |\n",
+			repository.Name, repository.Score, repository.Grade, summary.PassedChecks, summary.WarningChecks, summary.FailedChecks))
+	}
+
+	if len(report.Errors) > 0 {
+		output.WriteString("\n## Errors\n\n")
+		for _, scanError := range report.Errors {
+			output.WriteString(fmt.Sprintf("- `%s`: %s\n", scanError.Path, scanError.Error))
+		}
+	}
+
+	return output.String()
+}
+
+// formatOffenders lists repositories with their scores
+func formatOffenders(offenders []types.RepositoryScore) string {
+	parts := make([]string, 0, len(offenders))
+	for _, offender := range offenders {
+		parts = append(parts, fmt.Sprintf("%s (%d)", offender.Name, offender.Score))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// exportScanCSV exports the scan as CSV tables separated by blank lines: repositories with
+// their category scores, check failures, category averages and the score distribution
+func (e *Exporter) exportScanCSV(report *types.ScanReport) (string, error) {
+	var output strings.Builder
+	writer := csv.NewWriter(&output)
+
+	header := []string{"repository", "path", "score", "grade", "passed", "warnings", "failed"}
+	for _, category := range types.Categories() {
+		header = append(header, category.Key())
+	}
+	rows := [][]string{header}
+	for _, repository := range report.Repositories {
+		summary := repository.Report.Summary
+		row := []string{repository.Name, repository.Path, strconv.Itoa(repository.Score), repository.Grade,
+			strconv.Itoa(summary.PassedChecks), strconv.Itoa(summary.WarningChecks), strconv.Itoa(summary.FailedChecks)}
+		for _, category := range types.Categories() {
+			score := ""
+			if categoryScore := repository.Report.Category(category); categoryScore != nil {
+				score = strconv.Itoa(categoryScore.Score)
+			}
+			row = append(row, score)
+		}
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, nil, []string{"check", "name", "category", "failed", "warnings", "passed", "failed_repositories"})
+	for _, check := range report.CheckFailures {
+		rows = append(rows, []string{check.ID, check.Name, check.Category.Key(), strconv.Itoa(check.Failed),
+			strconv.Itoa(check.Warnings), strconv.Itoa(check.Passed), strings.Join(check.FailedRepositories, ";")})
+	}
+
+	rows = append(rows, nil, []string{"category", "repositories", "average", "min", "max", "worst_offenders"})
+	for _, category := range report.Categories {
+		rows = append(rows, []string{category.Key, strconv.Itoa(category.Repositories), strconv.FormatFloat(category.AverageScore, 'f', 1, 64),
+			strconv.Itoa(category.MinScore), strconv.Itoa(category.MaxScore), formatOffenders(category.WorstOffenders)})
+	}
+
+	rows = append(rows, nil, []string{"score_range", "repositories"})
+	for _, bucket := range report.Histogram {
+		rows = append(rows, []string{bucket.Label(), strconv.Itoa(bucket.Count)})
+	}
+
+	for _, row := range rows {
+		if row == nil {
+			writer.Flush()
+			output.WriteString("\n")
+			continue
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return output.String(), writer.Error()
+}
+
+// exportScanHTML exports the scan as a standalone HTML page
+func (e *Exporter) exportScanHTML(report *types.ScanReport) (string, error) {
+	tmpl := `
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Multi-Repository Health Scan</title>
+    <style>
+        body { font-family: Arial, sans-serif; margin: 40px; background-color: #f5f5f5; }
+        .container { background-color: white; padding: 30px; border-radius: 8px; box-shadow: 0 2px 10px rgba(0,0,0,0.1); }
+        .header { text-align: center; margin-bottom: 30px; }
+        .score { font-size: 2em; font-weight: bold; color: #2c3e50; }
+        .summary { display: flex; justify-content: space-around; margin: 20px 0; }
+        .summary-item { text-align: center; padding: 15px; background-color: #ecf0f1; border-radius: 5px; }
+        table { width: 100%; border-collapse: collapse; margin: 15px 0; }
+        th, td { text-align: left; padding: 8px; border-bottom: 1px solid #ecf0f1; }
+        th { background-color: #f8f9fa; }
+        .bar { display: inline-block; height: 14px; background-color: #3498db; border-radius: 3px; }
+        .fail { color: #e74c3c; }
+        .warning { color: #f39c12; }
+        .pass { color: #27ae60; }
+        details { margin: 10px 0; padding: 10px; background-color: #f8f9fa; border-radius: 5px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Multi-Repository Health Scan</h1>
+            {{if .Root}}<p>{{.Root}}</p>{{end}}
+            <div class="score">{{printf "%.1f" .Summary.AverageScore}}/100 average</div>
+        </div>
+
+        <div class="summary">
+            <div class="summary-item"><h3>{{.Summary.TotalRepositories}}</h3><p>Repositories</p></div>
+            <div class="summary-item"><h3>{{printf "%.1f" .Summary.MedianScore}}</h3><p>Median</p></div>
+            {{with .Summary.Highest}}<div class="summary-item"><h3>{{.Score}}</h3><p>Highest: {{.Name}}</p></div>{{end}}
+            {{with .Summary.Lowest}}<div class="summary-item"><h3>{{.Score}}</h3><p>Lowest: {{.Name}}</p></div>{{end}}
+        </div>
+
+        <h2>Score Distribution</h2>
+        <table>
+            {{range .Histogram}}
+            <tr><td>{{.Label}}</td><td>{{.Count}}</td><td><span class="bar" style="width: {{barWidth .Count}}%"></span></td></tr>
+            {{end}}
+        </table>
+
+        {{if .Categories}}
+        <h2>Category Averages</h2>
+        <table>
+            <tr><th>Category</th><th>Average</th><th>Min</th><th>Max</th><th>Worst Offenders</th></tr>
+            {{range .Categories}}
+            <tr><td>{{.Name}}</td><td>{{printf "%.1f" .AverageScore}}</td><td>{{.MinScore}}</td><td>{{.MaxScore}}</td><td>{{offenders .WorstOffenders}}</td></tr>
+            {{end}}
+        </table>
+        {{end}}
+
+        {{if .CheckFailures}}
+        <h2>Check Failures</h2>
+        <table>
+            <tr><th>Check</th><th>Name</th><th>Failed</th><th>Warnings</th><th>Passed</th></tr>
+            {{range .CheckFailures}}
+            <tr><td>{{.ID}}</td><td>{{.Name}}</td><td class="fail">{{.Failed}}</td><td class="warning">{{.Warnings}}</td><td class="pass">{{.Passed}}</td></tr>
+            {{end}}
+        </table>
+        {{end}}
+
+        <h2>Repositories</h2>
+        {{range .Repositories}}
+        <details>
+            <summary><strong>{{.Name}}</strong>: {{.Score}}/100 ({{.Grade}}) <small>{{.Path}}</small></summary>
+            {{with .Report}}
+            <p>{{range .Categories}}{{.Name}}: {{.Score}} ({{.Grade}}) &nbsp; {{end}}</p>
+            <table>
+                {{range .Results}}
+                <tr><td class="{{statusClass .Status}}">{{.Status}}</td><td>{{.ID}}</td><td>{{.Message}}</td></tr>
+                {{end}}
+            </table>
+            {{end}}
+        </details>
+        {{end}}
+
+        {{if .Errors}}
+        <h2>Errors</h2>
+        <ul>
+            {{range .Errors}}<li>{{.Path}}: {{.Error}}</li>{{end}}
+        </ul>
+        {{end}}
+    </div>
+</body>
+</html>`
+
+	largest := 0
+	for _, bucket := range report.Histogram {
+		if bucket.Count > largest {
+			largest = bucket.Count
+		}
+	}
+	funcs := template.FuncMap{
+		"barWidth": func(count int) int {
+			if largest == 0 {
+				return 0
+			}
+			return count * 100 / largest
+		},
+		"offenders": formatOffenders,
+		"statusClass": func(status types.Status) string {
+			return strings.ToLower(status.String())
+		},
+	}
+
+	t, err := template.New("scan").Funcs(funcs).Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var output strings.Builder
+	if err := t.Execute(&output, report); err != nil {
+		return "", err
+	}
+	return output.String(), nil
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,11 +22,34 @@ type RepositoryAnalyzer struct {
 	path                     string
 	maxCommits               int
 	staleBranchThresholdDays int
+	selection                CommitSelection
+}
+
+// CommitSelection chooses the slice of history the commit checkers analyze.
+// The zero value selects the most recent commits reachable from HEAD.
+type CommitSelection struct {
+	// Range is a revision range such as v1.2.0..HEAD
+	Range string
+	// Since and Until bound the commit date; any date git understands is accepted
+	Since string
+	Until string
+	// AllRefs walks every branch, tag and remote-tracking ref instead of HEAD
+	AllRefs bool
+	// NoMerges leaves out merge commits
+	NoMerges bool
+	// MaxCommits caps the number of commits. When zero, the analyzer's limit applies to
+	// the default HEAD walk while ranges, time windows and all refs are not capped.
+	MaxCommits int
+}
+
+// IsDefault reports whether the selection is the default walk from HEAD
+func (s CommitSelection) IsDefault() bool {
+	return s.Range == "" && s.Since == "" && s.Until == "" && !s.AllRefs
 }
 
 // NewRepositoryAnalyzer creates a new repository analyzer
 func NewRepositoryAnalyzer(path string) (*RepositoryAnalyzer, error) {
-	repo, err := git.PlainOpen(path)
+	repo, err := openRepository(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open repository: %w", err)
 	}
@@ -34,13 +59,19 @@ func NewRepositoryAnalyzer(path string) (*RepositoryAnalyzer, error) {
 
 // NewRepositoryAnalyzerWithOptions creates an analyzer with configurable limits.
 func NewRepositoryAnalyzerWithOptions(path string, maxCommits, staleBranchThresholdDays int) (*RepositoryAnalyzer, error) {
-	repo, err := git.PlainOpen(path)
+	repo, err := openRepository(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open repository: %w", err)
 	}
 	return newRepositoryAnalyzer(repo, path, maxCommits, staleBranchThresholdDays), nil
 }
 
+// openRepository opens a repository or one of its linked worktrees, which share the
+// objects and refs of the main repository
+func openRepository(path string) (*git.Repository, error) {
+	return git.PlainOpenWithOptions(path, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
+}
+
 func newRepositoryAnalyzer(repo *git.Repository, path string, maxCommits, staleBranchThresholdDays int) *RepositoryAnalyzer {
 	if maxCommits <= 0 {
 		maxCommits = 50
@@ -56,6 +87,11 @@ func newRepositoryAnalyzer(repo *git.Repository, path string, maxCommits, staleB
 	}
 }
 
+// SetCommitSelection chooses which commits Analyze collects
+func (ra *RepositoryAnalyzer) SetCommitSelection(selection CommitSelection) {
+	ra.selection = selection
+}
+
 // Analyze performs a comprehensive analysis of the repository
 func (ra *RepositoryAnalyzer) Analyze() (*types.RepositoryData, error) {
 	data := &types.RepositoryData{
@@ -92,63 +128,152 @@ func (ra *RepositoryAnalyzer) Analyze() (*types.RepositoryData, error) {
 	return data, nil
 }
 
-// analyzeCommits analyzes the last 50 commits
+// commitRecordFormat separates commits with RS and their fields with US, followed by the
+// NUL-terminated numstat records of git log -z
+const commitRecordFormat = "--format=%x1e%H%x1f%P%x1f%an%x1f%ae%x1f%aI%x1f%cn%x1f%ce%x1f%cI%x1f%B%x1f"
+
+// analyzeCommits collects the commits chosen by the commit selection
 func (ra *RepositoryAnalyzer) analyzeCommits() ([]types.CommitInfo, error) {
-	ref, err := ra.repo.Head()
-	if err != nil {
+	selection := ra.selection
+	if _, err := ra.repo.Head(); err != nil && !selection.AllRefs {
 		// Repository might be empty (no commits)
 		return []types.CommitInfo{}, nil
 	}
 
-	cIter, err := ra.repo.Log(&git.LogOptions{
-		From: ref.Hash(),
-	})
+	args := []string{"log", "-z", commitRecordFormat, "--numstat", "--diff-merges=first-parent"}
+	maxCommits := selection.MaxCommits
+	if maxCommits <= 0 && selection.IsDefault() {
+		maxCommits = ra.maxCommits
+	}
+	if maxCommits > 0 {
+		args = append(args, fmt.Sprintf("--max-count=%d", maxCommits))
+	}
+	if selection.NoMerges {
+		args = append(args, "--no-merges")
+	}
+	if selection.Since != "" {
+		args = append(args, "--since="+selection.Since)
+	}
+	if selection.Until != "" {
+		args = append(args, "--until="+selection.Until)
+	}
+	switch {
+	case selection.AllRefs:
+		args = append(args, "--all")
+	case selection.Range != "":
+		if strings.HasPrefix(selection.Range, "-") {
+			return nil, fmt.Errorf("invalid revision range %q", selection.Range)
+		}
+		args = append(args, selection.Range)
+	default:
+		args = append(args, "HEAD")
+	}
+	args = append(args, "--")
+
+	commits, err := ra.logCommits(args, nil)
 	if err != nil {
 		return nil, err
 	}
+	if commits == nil {
+		commits = []types.CommitInfo{}
+	}
+	return commits, nil
+}
 
-	var commits []types.CommitInfo
-	count := 0
-	err = cIter.ForEach(func(c *object.Commit) error {
-		if count >= ra.maxCommits {
-			return nil
-		}
+// AnalyzeCommitsByHash builds commit information for specific commits, such as the
+// commits of a push, in the order given
+func (ra *RepositoryAnalyzer) AnalyzeCommitsByHash(hashes []string) ([]types.CommitInfo, error) {
+	if len(hashes) == 0 {
+		return []types.CommitInfo{}, nil
+	}
+	args := []string{"log", "-z", commitRecordFormat, "--numstat", "--diff-merges=first-parent", "--no-walk=unsorted", "--stdin"}
+	commits, err := ra.logCommits(args, strings.NewReader(strings.Join(hashes, "\n")+"\n"))
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) != len(hashes) {
+		return nil, fmt.Errorf("expected %d commits, found %d", len(hashes), len(commits))
+	}
+	return commits, nil
+}
 
-		// Calculate diff stats
-		var linesAdded, linesDeleted int
-		if len(c.ParentHashes) > 0 {
-			parent := c.ParentHashes[0]
-			pCommit, err := ra.repo.CommitObject(parent)
-			if err == nil {
-				stats, err := getCommitStats(c, pCommit)
-				if err == nil {
-					linesAdded = stats.Additions
-					linesDeleted = stats.Deletions
-				}
-			}
+// logCommits runs git log with commitRecordFormat and parses its records
+func (ra *RepositoryAnalyzer) logCommits(args []string, stdin io.Reader) ([]types.CommitInfo, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = ra.path
+	cmd.Stdin = stdin
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("git log: %s", message)
 		}
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+	return parseCommitRecords(string(output)), nil
+}
 
-		message := c.Message
-		subject := strings.Split(message, "\n")[0]
-		body := strings.Join(strings.Split(message, "\n")[1:], "\n")
-
-		commits = append(commits, types.CommitInfo{
-			Hash:         c.Hash.String()[:8],
-			Message:      message,
-			Subject:      subject,
-			Body:         body,
-			Author:       c.Author.Name,
-			AuthorEmail:  c.Author.Email,
-			Date:         c.Author.When,
-			LinesAdded:   linesAdded,
-			LinesDeleted: linesDeleted,
-		})
+// parseCommitRecords parses the output of git log with commitRecordFormat and --numstat
+func parseCommitRecords(output string) []types.CommitInfo {
+	var commits []types.CommitInfo
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.Split(record, "\x1f")
+		if len(fields) < 10 {
+			continue
+		}
 
-		count++
-		return nil
-	})
+		message := fields[8]
+		lines := strings.Split(message, "\n")
+		commit := types.CommitInfo{
+			Hash:           fields[0],
+			Parents:        strings.Fields(fields[1]),
+			Message:        message,
+			Subject:        lines[0],
+			Body:           strings.Join(lines[1:], "\n"),
+			Author:         fields[2],
+			AuthorEmail:    fields[3],
+			Committer:      fields[5],
+			CommitterEmail: fields[6],
+		}
+		commit.Date, _ = time.Parse(time.RFC3339, fields[4])
+		commit.CommitDate, _ = time.Parse(time.RFC3339, fields[7])
+		commit.IsMerge = len(commit.Parents) > 1
+
+		for _, change := range parseNumstat(fields[9]) {
+			commit.LinesAdded += change.LinesAdded
+			commit.LinesDeleted += change.LinesDeleted
+			commit.Files = append(commit.Files, change)
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
 
-	return commits, err
+// parseNumstat parses git log -z --numstat records: <added>\t<deleted>\t<path>\0, or for a rename
+// <added>\t<deleted>\t\0<old path>\0<new path>\0. Paths are unquoted and never abbreviated
+// as "dir/{old => new}". Binary files report "-" for both counts.
+func parseNumstat(output string) []types.FileChange {
+	var changes []types.FileChange
+	tokens := strings.Split(output, "\x00")
+	for i := 0; i < len(tokens); i++ {
+		stat := strings.SplitN(strings.TrimLeft(tokens[i], "\n"), "\t", 3)
+		if len(stat) != 3 {
+			continue
+		}
+		added, _ := strconv.Atoi(stat[0])
+		deleted, _ := strconv.Atoi(stat[1])
+		change := types.FileChange{Path: stat[2], LinesAdded: added, LinesDeleted: deleted, Binary: stat[0] == "-"}
+		if change.Path == "" {
+			if i+2 >= len(tokens) {
+				break
+			}
+			change.OldPath, change.Path = tokens[i+1], tokens[i+2]
+			i += 2
+		}
+		changes = append(changes, change)
+	}
+	return changes
 }
 
 // analyzeBranches analyzes local branches
@@ -304,43 +429,3 @@ func (ra *RepositoryAnalyzer) countBranchCommits(branchHash plumbing.Hash) int {
 
 	return count
 }
-
-// CommitStats represents commit statistics
-type CommitStats struct {
-	Additions int
-	Deletions int
-}
-
-// getCommitStats calculates diff statistics between two commits
-func getCommitStats(commit, parent *object.Commit) (*CommitStats, error) {
-	tree, err := commit.Tree()
-	if err != nil {
-		return nil, err
-	}
-
-	parentTree, err := parent.Tree()
-	if err != nil {
-		return nil, err
-	}
-
-	changes, err := object.DiffTree(parentTree, tree)
-	if err != nil {
-		return nil, err
-	}
-
-	stats := &CommitStats{}
-	for _, change := range changes {
-		patch, err := change.Patch()
-		if err != nil {
-			continue
-		}
-
-		fileStats := patch.Stats()
-		for _, fileStat := range fileStats {
-			stats.Additions += fileStat.Addition
-			stats.Deletions += fileStat.Deletion
-		}
-	}
-
-	return stats, nil
-}
@@ -4,7 +4,12 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
 )
 
 func TestAnalyzerIncludesLatestCommitStatsAndHonorsLimit(t *testing.T) {
@@ -42,6 +47,209 @@ func TestAnalyzerIncludesLatestCommitStatsAndHonorsLimit(t *testing.T) {
 	}
 }
 
+func TestAnalyzeCommitsByHashKeepsOrder(t *testing.T) {
+	repo := t.TempDir()
+	runGitCommand(t, repo, "init", "-q")
+	runGitCommand(t, repo, "config", "user.email", "test@example.com")
+	runGitCommand(t, repo, "config", "user.name", "Test")
+
+	var hashes []string
+	for _, subject := range []string{"chore: initial commit", "feat: add second line"} {
+		if err := os.WriteFile(filepath.Join(repo, "data.txt"), []byte(subject+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGitCommand(t, repo, "add", ".")
+		runGitCommand(t, repo, "commit", "-qm", subject)
+		output, err := exec.Command("git", "-C", repo, "rev-parse", "HEAD").Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashes = append(hashes, strings.TrimSpace(string(output)))
+	}
+
+	analyzer, err := NewRepositoryAnalyzer(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commits, err := analyzer.AnalyzeCommitsByHash([]string{hashes[1], hashes[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 || commits[0].Subject != "feat: add second line" || commits[1].Subject != "chore: initial commit" {
+		t.Fatalf("commits = %+v", commits)
+	}
+	if commits[0].LinesAdded != 1 || commits[0].LinesDeleted != 1 {
+		t.Fatalf("stats = %+v", commits[0])
+	}
+	if _, err := analyzer.AnalyzeCommitsByHash([]string{"0123456789012345678901234567890123456789"}); err == nil {
+		t.Fatal("expected an error for an unknown commit")
+	}
+}
+
+func TestAnalyzerCommitSelection(t *testing.T) {
+	repo := t.TempDir()
+	runGitCommand(t, repo, "init", "-q", "-b", "main")
+	runGitCommand(t, repo, "config", "user.email", "committer@example.com")
+	runGitCommand(t, repo, "config", "user.name", "Committer")
+
+	commit := func(file, subject, date string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, file), []byte(subject+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGitCommand(t, repo, "add", ".")
+		cmd := exec.Command("git", "commit", "-qm", subject, "--author", "Author <author@example.com>")
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("commit failed: %v\n%s", err, output)
+		}
+	}
+	commit("a.txt", "feat: first", "2024-01-01T10:00:00Z")
+	runGitCommand(t, repo, "tag", "v1.0.0")
+	commit("b.txt", "feat: second", "2024-02-01T10:00:00Z")
+	runGitCommand(t, repo, "checkout", "-q", "-b", "topic", "v1.0.0")
+	commit("c.txt", "feat: on topic", "2024-02-15T10:00:00Z")
+	runGitCommand(t, repo, "checkout", "-q", "main")
+	runGitCommand(t, repo, "merge", "-q", "--no-ff", "-m", "Merge branch 'topic'", "topic")
+	runGitCommand(t, repo, "branch", "side", "v1.0.0")
+	runGitCommand(t, repo, "checkout", "-q", "side")
+	commit("d.txt", "feat: only on side", "2024-03-01T10:00:00Z")
+	runGitCommand(t, repo, "checkout", "-q", "main")
+
+	analyze := func(selection CommitSelection) []string {
+		t.Helper()
+		analyzer, err := NewRepositoryAnalyzerWithOptions(repo, 2, 30)
+		if err != nil {
+			t.Fatal(err)
+		}
+		analyzer.SetCommitSelection(selection)
+		commits, err := analyzer.analyzeCommits()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var subjects []string
+		for _, commit := range commits {
+			subjects = append(subjects, commit.Subject)
+		}
+		return subjects
+	}
+
+	if got := analyze(CommitSelection{}); len(got) != 2 {
+		t.Fatalf("default walk = %v, want the 2 most recent commits", got)
+	}
+	if got := analyze(CommitSelection{Range: "v1.0.0..HEAD"}); len(got) != 3 {
+		t.Fatalf("range = %v, want merge, topic and second commit", got)
+	}
+	if got := analyze(CommitSelection{Range: "v1.0.0..HEAD", NoMerges: true}); len(got) != 2 {
+		t.Fatalf("range without merges = %v", got)
+	}
+	if got := analyze(CommitSelection{Since: "2024-01-15", Until: "2024-02-10"}); len(got) != 1 || got[0] != "feat: second" {
+		t.Fatalf("time window = %v", got)
+	}
+	if got := analyze(CommitSelection{AllRefs: true}); len(got) != 5 {
+		t.Fatalf("all refs = %v, want 5 commits", got)
+	}
+	if got := analyze(CommitSelection{AllRefs: true, MaxCommits: 1}); len(got) != 1 {
+		t.Fatalf("all refs with a cap = %v", got)
+	}
+
+	analyzer, err := NewRepositoryAnalyzer(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commits, err := analyzer.analyzeCommits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	merge := commits[0]
+	if !merge.IsMerge || len(merge.Parents) != 2 || len(merge.Hash) != 40 {
+		t.Fatalf("merge commit = %+v", merge)
+	}
+	if merge.Author != "Committer" {
+		t.Fatalf("merge author = %q", merge.Author)
+	}
+	topic := commits[1]
+	if topic.IsMerge || topic.Author != "Author" || topic.AuthorEmail != "author@example.com" || topic.Committer != "Committer" || topic.CommitterEmail != "committer@example.com" {
+		t.Fatalf("topic commit identities = %+v", topic)
+	}
+	if !topic.Date.Equal(time.Date(2024, 2, 15, 10, 0, 0, 0, time.UTC)) || !topic.CommitDate.Equal(topic.Date) {
+		t.Fatalf("topic commit dates = %v, %v", topic.Date, topic.CommitDate)
+	}
+	if merge.LinesAdded != 1 {
+		t.Fatalf("merge stats against first parent = %+v", merge)
+	}
+
+	analyzer.SetCommitSelection(CommitSelection{Range: "--all"})
+	if _, err := analyzer.analyzeCommits(); err == nil {
+		t.Fatal("expected an error for an option passed as a range")
+	}
+}
+
+func TestAnalyzerRecordsFileChanges(t *testing.T) {
+	repo := t.TempDir()
+	runGitCommand(t, repo, "init", "-q")
+	runGitCommand(t, repo, "config", "user.email", "test@example.com")
+	runGitCommand(t, repo, "config", "user.name", "Test")
+
+	if err := os.MkdirAll(filepath.Join(repo, "pkg"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "package pkg\n\nfunc A() {}\n\nfunc B() {}\n"
+	if err := os.WriteFile(filepath.Join(repo, "pkg", "aé.go"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "logo.bin"), []byte{0, 1, 2, 0}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitCommand(t, repo, "add", ".")
+	runGitCommand(t, repo, "commit", "-qm", "chore: initial commit")
+	runGitCommand(t, repo, "mv", "pkg/aé.go", "pkg/b.go")
+	runGitCommand(t, repo, "commit", "-qm", "refactor: rename aé.go")
+
+	analyzer, err := NewRepositoryAnalyzer(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := analyzer.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rename, initial := data.Commits[0], data.Commits[1]
+	if len(rename.Files) != 1 || rename.Files[0].Path != "pkg/b.go" || rename.Files[0].OldPath != "pkg/aé.go" {
+		t.Fatalf("rename files = %+v", rename.Files)
+	}
+	if len(initial.Files) != 2 {
+		t.Fatalf("initial files = %+v", initial.Files)
+	}
+	for _, file := range initial.Files {
+		switch file.Path {
+		case "logo.bin":
+			if !file.Binary {
+				t.Errorf("logo.bin not marked binary: %+v", file)
+			}
+		case "pkg/aé.go":
+			if file.LinesAdded != 5 || file.OldPath != "" {
+				t.Errorf("pkg/aé.go change = %+v", file)
+			}
+		default:
+			t.Errorf("unexpected file %+v", file)
+		}
+	}
+
+	// Without -z git writes the rename as pkg/{"a\303\251.go" => b.go} and a
+	// path with spaces in quotes; -z keeps both as they are
+	changes := parseNumstat("\x00\n3\t1\tmy notes.txt\x00-\t-\tlogo.bin\x000\t0\t\x00pkg/aé.go\x00pkg/b.go\x00")
+	want := []types.FileChange{
+		{Path: "my notes.txt", LinesAdded: 3, LinesDeleted: 1},
+		{Path: "logo.bin", Binary: true},
+		{Path: "pkg/b.go", OldPath: "pkg/aé.go"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("parseNumstat = %+v, want %+v", changes, want)
+	}
+}
+
 func runGitCommand(t *testing.T, dir string, args ...string) {
 	t.Helper()
 	cmd := exec.Command("git", args...)
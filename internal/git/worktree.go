@@ -0,0 +1,61 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Worktree is a temporary detached checkout of a revision, used to analyze another ref
+// without touching the working tree
+type Worktree struct {
+	// Path is the root of the checkout
+	Path     string
+	repoPath string
+	tempDir  string
+}
+
+// AddWorktree checks out rev in a new linked worktree under a temporary directory.
+// Call Remove when done with it.
+func AddWorktree(repoPath, rev string) (*Worktree, error) {
+	if _, err := runGit(repoPath, "rev-parse", "--verify", "--quiet", rev+"^{commit}"); err != nil {
+		return nil, fmt.Errorf("unknown revision %q", rev)
+	}
+	tempDir, err := os.MkdirTemp("", "gphc-worktree-")
+	if err != nil {
+		return nil, fmt.Errorf("create worktree directory: %w", err)
+	}
+	worktree := &Worktree{Path: filepath.Join(tempDir, "tree"), repoPath: repoPath, tempDir: tempDir}
+	if _, err := runGit(repoPath, "worktree", "add", "--quiet", "--detach", worktree.Path, rev); err != nil {
+		os.RemoveAll(tempDir)
+		return nil, err
+	}
+	return worktree, nil
+}
+
+// Remove deletes the worktree and its temporary directory
+func (w *Worktree) Remove() error {
+	_, err := runGit(w.repoPath, "worktree", "remove", "--force", w.Path)
+	if removeErr := os.RemoveAll(w.tempDir); err == nil && removeErr != nil {
+		err = fmt.Errorf("remove worktree directory: %w", removeErr)
+	}
+	return err
+}
+
+// runGit runs a git command in dir and returns its output
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], message)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(output), nil
+}
@@ -0,0 +1,59 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAddWorktree(t *testing.T) {
+	repo := t.TempDir()
+	runGitCommand(t, repo, "init", "-q")
+	runGitCommand(t, repo, "config", "user.email", "test@example.com")
+	runGitCommand(t, repo, "config", "user.name", "Test")
+
+	file := filepath.Join(repo, "data.txt")
+	if err := os.WriteFile(file, []byte("one\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitCommand(t, repo, "add", ".")
+	runGitCommand(t, repo, "commit", "-qm", "chore: initial commit")
+	runGitCommand(t, repo, "tag", "base")
+	if err := os.WriteFile(file, []byte("one\ntwo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitCommand(t, repo, "commit", "-qam", "feat: add second line")
+
+	if _, err := AddWorktree(repo, "missing"); err == nil {
+		t.Error("expected an error for an unknown revision")
+	}
+
+	worktree, err := AddWorktree(repo, "base")
+	if err != nil {
+		t.Fatalf("AddWorktree failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(worktree.Path, "data.txt"))
+	if err != nil || string(content) != "one\n" {
+		t.Errorf("expected the base revision to be checked out, got %q (%v)", content, err)
+	}
+
+	// The analyzer sees the history of the checked out revision
+	analyzer, err := NewRepositoryAnalyzer(worktree.Path)
+	if err != nil {
+		t.Fatalf("open worktree: %v", err)
+	}
+	data, err := analyzer.Analyze()
+	if err != nil {
+		t.Fatalf("analyze worktree: %v", err)
+	}
+	if len(data.Commits) != 1 {
+		t.Errorf("expected one commit in the worktree, got %d", len(data.Commits))
+	}
+
+	if err := worktree.Remove(); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := os.Stat(worktree.Path); !os.IsNotExist(err) {
+		t.Error("expected the worktree directory to be removed")
+	}
+}
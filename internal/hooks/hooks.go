@@ -0,0 +1,201 @@
+// Package hooks installs and manages the Git hooks that run gphc checks.
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Hook stages managed by gphc
+const (
+	StagePreCommit = "pre-commit"
+	StageCommitMsg = "commit-msg"
+	StagePrePush   = "pre-push"
+)
+
+// Stages lists every hook stage gphc can install, in the order Git runs them
+var Stages = []string{StagePreCommit, StageCommitMsg, StagePrePush}
+
+// managedMarker identifies hook scripts written by gphc
+const managedMarker = "# gphc-managed-hook"
+
+// chainedSuffix is appended to a user's hook when a managed hook takes its place
+const chainedSuffix = ".pre-gphc"
+
+// HookStatus describes the state of one hook stage
+type HookStatus struct {
+	Stage string `json:"stage"`
+	Path  string `json:"path"`
+	// Managed is true when the hook is a gphc script
+	Managed bool `json:"managed"`
+	// Foreign is true when another hook occupies the stage
+	Foreign bool `json:"foreign"`
+	// Chained is the path of the user's hook run before gphc, if any
+	Chained string `json:"chained,omitempty"`
+}
+
+// Manager installs hook scripts into a repository's hooks directory
+type Manager struct {
+	hooksDir string
+	command  string
+}
+
+// NewManager resolves the hooks directory of a repository, honoring core.hooksPath.
+// command is the gphc executable the hook scripts invoke.
+func NewManager(repoPath, command string) (*Manager, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("resolve hooks directory: %w", err)
+	}
+	hooksDir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(repoPath, hooksDir)
+	}
+	if command == "" {
+		command = "gphc"
+	}
+	return &Manager{hooksDir: filepath.Clean(hooksDir), command: command}, nil
+}
+
+// HooksDir returns the directory hooks are installed into
+func (m *Manager) HooksDir() string {
+	return m.hooksDir
+}
+
+// Install writes managed hook scripts for the given stages. An existing user hook
+// is renamed with the .pre-gphc suffix and run before the gphc checks.
+func (m *Manager) Install(stages []string) error {
+	if err := validateStages(stages); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.hooksDir, 0755); err != nil {
+		return err
+	}
+
+	for _, stage := range stages {
+		path := m.hookPath(stage)
+		if _, err := os.Stat(path); err == nil && !isManaged(path) {
+			chained := path + chainedSuffix
+			if _, err := os.Stat(chained); err == nil {
+				return fmt.Errorf("%s: cannot chain existing hook, %s already exists", stage, chained)
+			}
+			if err := os.Rename(path, chained); err != nil {
+				return fmt.Errorf("%s: %w", stage, err)
+			}
+		}
+		if err := os.WriteFile(path, []byte(Script(stage, m.command)), 0755); err != nil {
+			return fmt.Errorf("%s: %w", stage, err)
+		}
+	}
+	return nil
+}
+
+// Uninstall removes managed hook scripts and restores chained user hooks.
+// Hooks that gphc did not write are left untouched.
+func (m *Manager) Uninstall(stages []string) error {
+	if err := validateStages(stages); err != nil {
+		return err
+	}
+
+	for _, stage := range stages {
+		path := m.hookPath(stage)
+		if !isManaged(path) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("%s: %w", stage, err)
+		}
+		chained := path + chainedSuffix
+		if _, err := os.Stat(chained); err == nil {
+			if err := os.Rename(chained, path); err != nil {
+				return fmt.Errorf("%s: restore %s: %w", stage, chained, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Status reports the state of every hook stage
+func (m *Manager) Status() []HookStatus {
+	statuses := make([]HookStatus, 0, len(Stages))
+	for _, stage := range Stages {
+		path := m.hookPath(stage)
+		status := HookStatus{Stage: stage, Path: path}
+		if _, err := os.Stat(path); err == nil {
+			status.Managed = isManaged(path)
+			status.Foreign = !status.Managed
+		}
+		if status.Managed {
+			if _, err := os.Stat(path + chainedSuffix); err == nil {
+				status.Chained = path + chainedSuffix
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func (m *Manager) hookPath(stage string) string {
+	return filepath.Join(m.hooksDir, stage)
+}
+
+// Script returns the managed hook script for a stage
+func Script(stage, command string) string {
+	var script strings.Builder
+	script.WriteString("#!/bin/sh\n")
+	script.WriteString(managedMarker + "\n")
+	script.WriteString("# Installed by 'gphc hooks install'; remove with 'gphc hooks uninstall'.\n")
+	script.WriteString("# Choose the checks this hook runs in gphc.yml under hooks." + strings.ReplaceAll(stage, "-", "_") + ".\n\n")
+	fmt.Fprintf(&script, "gphc=%s\n", shellQuote(command))
+	script.WriteString("command -v \"$gphc\" >/dev/null 2>&1 || gphc=gphc\n")
+	fmt.Fprintf(&script, "chained=\"$(dirname \"$0\")/%s%s\"\n\n", stage, chainedSuffix)
+
+	switch stage {
+	case StagePrePush:
+		// Git writes the ref updates to stdin once; both hooks need them
+		script.WriteString("updates=$(cat)\n")
+		script.WriteString("if [ -x \"$chained\" ]; then\n")
+		script.WriteString("\tprintf '%s\\n' \"$updates\" | \"$chained\" \"$@\" || exit $?\n")
+		script.WriteString("fi\n")
+		fmt.Fprintf(&script, "printf '%%s\\n' \"$updates\" | \"$gphc\" %s \"$@\"\n", stage)
+	default:
+		script.WriteString("if [ -x \"$chained\" ]; then\n")
+		script.WriteString("\t\"$chained\" \"$@\" || exit $?\n")
+		script.WriteString("fi\n")
+		fmt.Fprintf(&script, "exec \"$gphc\" %s \"$@\"\n", stage)
+	}
+	return script.String()
+}
+
+func isManaged(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(content), managedMarker)
+}
+
+func validateStages(stages []string) error {
+	for _, stage := range stages {
+		valid := false
+		for _, known := range Stages {
+			if stage == known {
+				valid = true
+			}
+		}
+		if !valid {
+			return fmt.Errorf("unknown hook stage %q (use %s)", stage, strings.Join(Stages, ", "))
+		}
+	}
+	return nil
+}
+
+// shellQuote quotes a value for a POSIX shell
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
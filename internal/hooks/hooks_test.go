@@ -0,0 +1,159 @@
+package hooks
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallChainsExistingHookAndUninstallRestoresIt(t *testing.T) {
+	repo := createGitRepository(t)
+	hookPath := filepath.Join(repo, ".git", "hooks", StagePreCommit)
+	userHook := "#!/bin/sh\necho user hook\n"
+	if err := os.WriteFile(hookPath, []byte(userHook), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	manager, err := NewManager(repo, "/usr/local/bin/gphc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Install(Stages); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := manager.Status()
+	if !statuses[0].Managed || statuses[0].Chained != hookPath+chainedSuffix {
+		t.Fatalf("pre-commit status = %+v", statuses[0])
+	}
+	if !statuses[2].Managed || statuses[2].Chained != "" {
+		t.Fatalf("pre-push status = %+v", statuses[2])
+	}
+	content, _ := os.ReadFile(hookPath)
+	if !strings.Contains(string(content), "'/usr/local/bin/gphc'") {
+		t.Fatalf("hook does not invoke the configured command:\n%s", content)
+	}
+
+	// Reinstalling must not chain the managed hook onto itself
+	if err := manager.Install(Stages); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.Uninstall(Stages); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := os.ReadFile(hookPath)
+	if err != nil || string(restored) != userHook {
+		t.Fatalf("user hook not restored: %q, %v", restored, err)
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".git", "hooks", StagePrePush)); !os.IsNotExist(err) {
+		t.Fatalf("pre-push hook still present: %v", err)
+	}
+}
+
+func TestNewManagerHonorsCoreHooksPath(t *testing.T) {
+	repo := createGitRepository(t)
+	runGit(t, repo, "config", "core.hooksPath", ".githooks")
+
+	manager, err := NewManager(repo, "gphc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(repo, ".githooks"); manager.HooksDir() != want {
+		t.Fatalf("HooksDir() = %s, want %s", manager.HooksDir(), want)
+	}
+	if err := manager.Install([]string{StageCommitMsg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".githooks", StageCommitMsg)); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Install([]string{"post-merge"}); err == nil {
+		t.Fatal("expected an error for an unknown stage")
+	}
+}
+
+func TestParsePushUpdatesAndOutgoingCommits(t *testing.T) {
+	repo := createGitRepository(t)
+	base := gitOutput(t, repo, "rev-parse", "HEAD")
+	if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "feat: add a")
+	head := gitOutput(t, repo, "rev-parse", "HEAD")
+
+	zero := strings.Repeat("0", 40)
+	input := "refs/heads/main " + head + " refs/heads/main " + base + "\n" +
+		"(delete) " + zero + " refs/heads/old " + base + "\n"
+	updates, err := ParsePushUpdates(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 2 || updates[0].IsNew() || !updates[1].IsDelete() {
+		t.Fatalf("updates = %+v", updates)
+	}
+
+	commits, err := OutgoingCommits(repo, "origin", updates[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 1 || commits[0] != head {
+		t.Fatalf("outgoing = %v, want [%s]", commits, head)
+	}
+
+	// Without remote-tracking refs every commit of a new branch is outgoing
+	commits, err = OutgoingCommits(repo, "origin", PushUpdate{LocalRef: "refs/heads/main", LocalOID: head, RemoteRef: "refs/heads/new", RemoteOID: zero})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 || commits[0] != base {
+		t.Fatalf("outgoing for new branch = %v", commits)
+	}
+
+	// Pushing to a URL compares against every remote-tracking ref, not none
+	runGit(t, repo, "update-ref", "refs/remotes/origin/main", base)
+	commits, err = OutgoingCommits(repo, "https://example.com/repo.git", PushUpdate{LocalRef: "refs/heads/main", LocalOID: head, RemoteRef: "refs/heads/new", RemoteOID: zero})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 1 || commits[0] != head {
+		t.Fatalf("outgoing for a push to a URL = %v, want [%s]", commits, head)
+	}
+
+	if _, err := ParsePushUpdates(strings.NewReader("refs/heads/main abc\n")); err == nil {
+		t.Fatal("expected an error for a malformed update")
+	}
+}
+
+func createGitRepository(t *testing.T) string {
+	t.Helper()
+	repo := t.TempDir()
+	runGit(t, repo, "init", "-q")
+	runGit(t, repo, "config", "user.email", "test@example.com")
+	runGit(t, repo, "config", "user.name", "Test")
+	if err := os.WriteFile(filepath.Join(repo, "README.md"), []byte("# Test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-qm", "chore: initial commit")
+	return repo
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	gitOutput(t, dir, args...)
+}
+
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,102 @@
+package hooks
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// PushUpdate is one ref update Git passes to the pre-push hook on stdin
+type PushUpdate struct {
+	LocalRef  string `json:"local_ref"`
+	LocalOID  string `json:"local_oid"`
+	RemoteRef string `json:"remote_ref"`
+	RemoteOID string `json:"remote_oid"`
+}
+
+// IsDelete reports whether the update deletes the remote ref
+func (u PushUpdate) IsDelete() bool {
+	return isZeroOID(u.LocalOID)
+}
+
+// IsNew reports whether the update creates the remote ref
+func (u PushUpdate) IsNew() bool {
+	return isZeroOID(u.RemoteOID)
+}
+
+// ParsePushUpdates reads "<local ref> <local oid> <remote ref> <remote oid>" lines
+func ParsePushUpdates(reader io.Reader) ([]PushUpdate, error) {
+	var updates []PushUpdate
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("malformed ref update %q", line)
+		}
+		updates = append(updates, PushUpdate{LocalRef: fields[0], LocalOID: fields[1], RemoteRef: fields[2], RemoteOID: fields[3]})
+	}
+	return updates, scanner.Err()
+}
+
+// OutgoingCommits lists the commits an update sends that the remote does not have yet,
+// oldest first. New remote refs are compared against every ref already known for the remote,
+// or against every remote-tracking ref when the push goes straight to a URL.
+func OutgoingCommits(repoPath, remote string, update PushUpdate) ([]string, error) {
+	if update.IsDelete() {
+		return nil, nil
+	}
+
+	args := []string{"rev-list", "--reverse", update.LocalOID}
+	switch {
+	case !update.IsNew() && objectExists(repoPath, update.RemoteOID):
+		args = append(args, "^"+update.RemoteOID)
+	case remote != "" && isRemoteName(repoPath, remote):
+		args = append(args, "--not", "--remotes="+remote)
+	default:
+		args = append(args, "--not", "--remotes")
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("list commits for %s: %w", update.LocalRef, err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// isRemoteName reports whether remote is a configured remote; Git passes the URL
+// instead when pushing to one directly
+func isRemoteName(repoPath, remote string) bool {
+	cmd := exec.Command("git", "remote")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	for _, name := range strings.Fields(string(output)) {
+		if name == remote {
+			return true
+		}
+	}
+	return false
+}
+
+// objectExists reports whether the remote's tip is known locally; after a
+// force push it may not be, in which case the remote-tracking refs are used instead
+func objectExists(repoPath, oid string) bool {
+	cmd := exec.Command("git", "cat-file", "-e", oid+"^{commit}")
+	cmd.Dir = repoPath
+	return cmd.Run() == nil
+}
+
+// isZeroOID reports whether oid is the all-zero name Git uses for a missing ref
+func isZeroOID(oid string) bool {
+	return strings.Trim(oid, "0") == ""
+}
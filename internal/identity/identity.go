@@ -0,0 +1,334 @@
+// Package identity resolves the names and emails found in commits to the people and bots behind them
+package identity
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// Options controls how commit identities are merged and classified
+type Options struct {
+	// UseMailmap applies the repository .mailmap before merging
+	UseMailmap bool
+	// MergeByName merges identities whose names match after normalization
+	MergeByName bool
+	// BotPatterns are extra case-insensitive regular expressions matched against "name <email>"
+	BotPatterns []string
+	// ExcludeBots leaves bots out of Contributors; they are still listed by Bots
+	ExcludeBots bool
+}
+
+// DefaultOptions applies .mailmap, merges aliases by email and name and excludes bots
+func DefaultOptions() Options {
+	return Options{UseMailmap: true, MergeByName: true, ExcludeBots: true}
+}
+
+// Person is a name and email as recorded in commits
+type Person struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Commits int    `json:"commits"`
+}
+
+// Identity is one person or bot together with every alias they committed under
+type Identity struct {
+	Name    string   `json:"name"`
+	Email   string   `json:"email"`
+	Commits int      `json:"commits"`
+	Bot     bool     `json:"bot,omitempty"`
+	Aliases []Person `json:"aliases,omitempty"`
+	// Heuristic is set when aliases were merged by shared email or name rather than all mapped
+	// to one person by .mailmap
+	Heuristic bool `json:"heuristic,omitempty"`
+}
+
+// Emails returns every email the identity committed with, lowercased
+func (id *Identity) Emails() []string {
+	emails := []string{strings.ToLower(id.Email)}
+	for _, alias := range id.Aliases {
+		if email := strings.ToLower(alias.Email); !contains(emails, email) {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// Names returns every name the identity committed with
+func (id *Identity) Names() []string {
+	names := []string{id.Name}
+	for _, alias := range id.Aliases {
+		if !contains(names, alias.Name) {
+			names = append(names, alias.Name)
+		}
+	}
+	return names
+}
+
+// knownBots matches automation accounts by name or email
+var knownBots = regexp.MustCompile(`(?i)\[bot\]|^(dependabot|renovate|github-actions|greenkeeper|snyk-bot|mergify|pre-commit-ci|semantic-release-bot|allcontributors|imgbot|codecov|deepsource-autofix|whitesource|mend-bolt|depfu|pyup-bot|gitlab-bot|copilot-swe-agent)\b|\bbot@|@bot\.|^bot\b|-bot\b`)
+
+// genericNames are shared by unrelated people and never used to merge identities
+var genericNames = map[string]bool{
+	"root": true, "admin": true, "administrator": true, "user": true, "unknown": true,
+	"ubuntu": true, "github": true, "gitlab": true, "dev": true, "developer": true, "test": true,
+}
+
+// sharedEmails are used by many accounts and never used to merge identities
+var sharedEmails = map[string]bool{
+	"": true, "noreply@github.com": true, "none@none": true, "nobody@nowhere": true,
+}
+
+var noreplyRe = regexp.MustCompile(`^(?:\d+\+)?([^@]+)@users\.noreply\.github(?:usercontent)?\.com$`)
+
+// Resolver merges commit identities into people
+type Resolver struct {
+	mailmap *Mailmap
+	options Options
+	bots    []*regexp.Regexp
+}
+
+// NewResolver creates a resolver for a repository, loading its .mailmap when enabled
+func NewResolver(repoPath string, options Options) (*Resolver, error) {
+	resolver := &Resolver{options: options}
+	if options.UseMailmap && repoPath != "" {
+		mailmap, err := LoadMailmap(repoPath)
+		if err != nil {
+			return nil, err
+		}
+		resolver.mailmap = mailmap
+	}
+	for _, pattern := range options.BotPatterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, err
+		}
+		resolver.bots = append(resolver.bots, re)
+	}
+	return resolver, nil
+}
+
+// NewResolverWithMailmap creates a resolver with an already parsed mailmap
+func NewResolverWithMailmap(mailmap *Mailmap, options Options) (*Resolver, error) {
+	resolver, err := NewResolver("", options)
+	if err != nil {
+		return nil, err
+	}
+	resolver.mailmap = mailmap
+	return resolver, nil
+}
+
+// IsBot reports whether a commit identity belongs to automation
+func (r *Resolver) IsBot(name, email string) bool {
+	if knownBots.MatchString(name) || knownBots.MatchString(email) {
+		return true
+	}
+	for _, re := range r.bots {
+		if re.MatchString(name + " <" + email + ">") {
+			return true
+		}
+	}
+	return false
+}
+
+// PeopleFromCommits counts the commits of each author name and email
+func PeopleFromCommits(commits []types.CommitInfo) []Person {
+	index := make(map[string]int)
+	var people []Person
+	for _, commit := range commits {
+		key := commit.Author + "\x00" + strings.ToLower(commit.AuthorEmail)
+		if i, ok := index[key]; ok {
+			people[i].Commits++
+			continue
+		}
+		index[key] = len(people)
+		people = append(people, Person{Name: commit.Author, Email: commit.AuthorEmail, Commits: 1})
+	}
+	return people
+}
+
+// Index maps commit identities to the resolved people and bots
+type Index struct {
+	resolver   *Resolver
+	identities []*Identity
+	byPerson   map[string]*Identity
+}
+
+// Resolve merges people into identities: first through .mailmap, then by shared
+// email, normalized name or GitHub noreply login
+func (r *Resolver) Resolve(people []Person) *Index {
+	parent := make([]int, len(people))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	mapped := make([]Person, len(people))
+	bots := make([]bool, len(people))
+	owner := make(map[string]int)
+	for i, person := range people {
+		name, email := r.mailmap.Lookup(person.Name, person.Email)
+		mapped[i] = Person{Name: name, Email: email, Commits: person.Commits}
+		bots[i] = r.IsBot(person.Name, person.Email) || r.IsBot(name, email)
+
+		for _, key := range r.mergeKeys(mapped[i], bots[i]) {
+			if j, ok := owner[key]; ok {
+				if a, b := find(i), find(j); a != b && bots[a] == bots[b] {
+					parent[a] = b
+				}
+			} else {
+				owner[key] = i
+			}
+		}
+	}
+
+	index := &Index{resolver: r, byPerson: make(map[string]*Identity)}
+	groups := make(map[int]*Identity)
+	best := make(map[int]int)
+	aliases := make(map[string]int)
+	canonical := make(map[int]string)
+	for i, person := range people {
+		root := find(i)
+		id := groups[root]
+		if id == nil {
+			id = &Identity{Bot: bots[root]}
+			groups[root] = id
+			index.identities = append(index.identities, id)
+			best[root] = -1
+		}
+		id.Commits += person.Commits
+		key := personKey(person.Name, person.Email)
+		if alias, ok := aliases[key]; ok {
+			id.Aliases[alias].Commits += person.Commits
+		} else {
+			aliases[key] = len(id.Aliases)
+			id.Aliases = append(id.Aliases, person)
+		}
+		// The canonical name and email are those of the alias with the most commits
+		if best[root] < 0 || person.Commits > people[best[root]].Commits {
+			best[root] = i
+			id.Name, id.Email = mapped[i].Name, mapped[i].Email
+		}
+		index.byPerson[key] = id
+
+		// Aliases .mailmap maps to one person need no further entries
+		mappedKey := personKey(mapped[i].Name, mapped[i].Email)
+		if first, ok := canonical[root]; !ok {
+			canonical[root] = mappedKey
+		} else if first != mappedKey {
+			id.Heuristic = true
+		}
+	}
+
+	sort.SliceStable(index.identities, func(i, j int) bool {
+		return index.identities[i].Commits > index.identities[j].Commits
+	})
+	return index
+}
+
+// mergeKeys lists the keys under which a person joins others; bots only merge by email
+func (r *Resolver) mergeKeys(person Person, bot bool) []string {
+	var keys []string
+	email := strings.ToLower(person.Email)
+	if !sharedEmails[email] {
+		keys = append(keys, "email:"+email)
+	}
+	if bot || !r.options.MergeByName {
+		return keys
+	}
+	if name := normalizeName(person.Name); name != "" {
+		keys = append(keys, "name:"+name)
+	}
+	if match := noreplyRe.FindStringSubmatch(email); match != nil {
+		if login := normalizeName(match[1]); login != "" {
+			keys = append(keys, "name:"+login)
+		}
+	}
+	return keys
+}
+
+// normalizeName reduces a name to lowercase letters and digits; short and generic names yield ""
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	normalized := b.String()
+	if len(normalized) < 4 || genericNames[normalized] {
+		return ""
+	}
+	return normalized
+}
+
+// Identities returns the resolved identities, most commits first
+func (x *Index) Identities() []*Identity {
+	return x.identities
+}
+
+// People returns the identities that are not bots
+func (x *Index) People() []*Identity {
+	var people []*Identity
+	for _, id := range x.identities {
+		if !id.Bot {
+			people = append(people, id)
+		}
+	}
+	return people
+}
+
+// Contributors returns the identities counted as contributors: everyone, or only people when bots are excluded
+func (x *Index) Contributors() []*Identity {
+	if x.resolver.options.ExcludeBots {
+		return x.People()
+	}
+	return x.identities
+}
+
+// Bots returns the identities classified as bots
+func (x *Index) Bots() []*Identity {
+	var bots []*Identity
+	for _, id := range x.identities {
+		if id.Bot {
+			bots = append(bots, id)
+		}
+	}
+	return bots
+}
+
+// Lookup returns the identity of a commit author; authors not seen by Resolve get an identity of their own
+func (x *Index) Lookup(name, email string) *Identity {
+	if id, ok := x.byPerson[personKey(name, email)]; ok {
+		return id
+	}
+	mappedName, mappedEmail := x.resolver.mailmap.Lookup(name, email)
+	return &Identity{
+		Name:    mappedName,
+		Email:   mappedEmail,
+		Bot:     x.resolver.IsBot(name, email),
+		Aliases: []Person{{Name: name, Email: email}},
+	}
+}
+
+func personKey(name, email string) string {
+	return name + "\x00" + strings.ToLower(email)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMailmapLookup(t *testing.T) {
+	mailmap, err := ParseMailmap(strings.NewReader(strings.Join([]string{
+		"# comment",
+		"Jane Doe <jane@old.example.com>",
+		"<jane@example.com> <JANE@Laptop.local>",
+		"Jane Doe <jane@example.com> <jdoe@corp.example.com>",
+		"Joe Smith <joe@example.com> joe <shared@example.com>",
+		"broken line",
+	}, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct{ name, email, wantName, wantEmail string }{
+		{"jane", "jane@old.example.com", "Jane Doe", "jane@old.example.com"},
+		{"jane", "jane@laptop.local", "jane", "jane@example.com"},
+		{"J. Doe", "jdoe@corp.example.com", "Jane Doe", "jane@example.com"},
+		{"Joe", "shared@example.com", "Joe Smith", "joe@example.com"},
+		{"Someone Else", "shared@example.com", "Someone Else", "shared@example.com"},
+		{"Unmapped", "unmapped@example.com", "Unmapped", "unmapped@example.com"},
+	} {
+		name, email := mailmap.Lookup(tt.name, tt.email)
+		if name != tt.wantName || email != tt.wantEmail {
+			t.Errorf("Lookup(%s, %s) = %s, %s; want %s, %s", tt.name, tt.email, name, email, tt.wantName, tt.wantEmail)
+		}
+	}
+	if mailmap.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", mailmap.Len())
+	}
+}
+
+func TestResolveMergesAliasesAndClassifiesBots(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repo, ".mailmap"), []byte("Jane Doe <jane@example.com> <jane@laptop.local>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resolver, err := NewResolver(repo, Options{UseMailmap: true, MergeByName: true, ExcludeBots: true, BotPatterns: []string{`^ci runner `}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index := resolver.Resolve([]Person{
+		{Name: "Jane Doe", Email: "jane@example.com", Commits: 10},
+		{Name: "jane", Email: "jane@laptop.local", Commits: 2},
+		{Name: "Jane  DOE", Email: "jane.doe@gmail.com", Commits: 1},
+		{Name: "octocat", Email: "583231+octocat@users.noreply.github.com", Commits: 3},
+		{Name: "The Octocat", Email: "octocat@users.noreply.github.com", Commits: 1},
+		{Name: "Octocat", Email: "octo@example.com", Commits: 4},
+		{Name: "root", Email: "root@build-1", Commits: 1},
+		{Name: "root", Email: "root@build-2", Commits: 1},
+		{Name: "dependabot[bot]", Email: "49699333+dependabot[bot]@users.noreply.github.com", Commits: 7},
+		{Name: "renovate-bot", Email: "bot@renovateapp.com", Commits: 2},
+		{Name: "CI Runner", Email: "ci@example.com", Commits: 5},
+		{Name: "Jane Doe", Email: "jane@example.com", Commits: 1},
+	})
+
+	people := index.People()
+	if len(people) != 4 {
+		t.Fatalf("people = %+v", people)
+	}
+	jane := index.Lookup("jane", "jane@laptop.local")
+	if jane.Name != "Jane Doe" || jane.Email != "jane@example.com" || jane.Commits != 14 || len(jane.Aliases) != 3 {
+		t.Fatalf("jane = %+v", jane)
+	}
+	if !jane.Heuristic {
+		t.Fatalf("jane.doe@gmail.com is merged by name: %+v", jane)
+	}
+	octocat := index.Lookup("The Octocat", "octocat@users.noreply.github.com")
+	if octocat.Commits != 8 || octocat.Email != "octo@example.com" {
+		t.Fatalf("octocat = %+v", octocat)
+	}
+	mapped := resolver.Resolve([]Person{
+		{Name: "Jane Doe", Email: "jane@example.com", Commits: 1},
+		{Name: "jane", Email: "jane@laptop.local", Commits: 1},
+	})
+	if ids := mapped.Identities(); len(ids) != 1 || ids[0].Heuristic {
+		t.Fatalf("aliases joined by .mailmap = %+v", ids)
+	}
+	if index.Lookup("root", "root@build-1") == index.Lookup("root", "root@build-2") {
+		t.Fatal("generic names must not be merged")
+	}
+
+	bots := index.Bots()
+	if len(bots) != 3 {
+		t.Fatalf("bots = %+v", bots)
+	}
+	if len(index.Contributors()) != 4 {
+		t.Fatalf("contributors include bots: %+v", index.Contributors())
+	}
+	if unseen := index.Lookup("github-actions[bot]", "41898282+github-actions[bot]@users.noreply.github.com"); !unseen.Bot {
+		t.Fatalf("unseen bot = %+v", unseen)
+	}
+
+	// Without name merging only shared emails join identities
+	resolver, _ = NewResolverWithMailmap(nil, Options{})
+	index = resolver.Resolve([]Person{
+		{Name: "Jane Doe", Email: "jane@example.com", Commits: 1},
+		{Name: "Jane Doe", Email: "jane@gmail.com", Commits: 1},
+		{Name: "J", Email: "JANE@example.com", Commits: 1},
+	})
+	if len(index.Identities()) != 2 || len(index.Contributors()) != 2 {
+		t.Fatalf("identities without name merging = %+v", index.Identities())
+	}
+}
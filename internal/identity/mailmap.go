@@ -0,0 +1,120 @@
+package identity
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Mailmap maps the names and emails recorded in commits to canonical ones, as git does with .mailmap
+type Mailmap struct {
+	// entries are keyed by lowercased commit email; an empty commit name matches any name
+	entries map[string][]mailmapEntry
+}
+
+type mailmapEntry struct {
+	properName  string
+	properEmail string
+	commitName  string
+}
+
+// LoadMailmap reads .mailmap from the repository root; a missing file yields an empty mailmap
+func LoadMailmap(repoPath string) (*Mailmap, error) {
+	file, err := os.Open(filepath.Join(repoPath, ".mailmap"))
+	if os.IsNotExist(err) {
+		return &Mailmap{entries: make(map[string][]mailmapEntry)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseMailmap(file)
+}
+
+// ParseMailmap parses the four .mailmap line forms:
+//
+//	Proper Name <commit@email>
+//	<proper@email> <commit@email>
+//	Proper Name <proper@email> <commit@email>
+//	Proper Name <proper@email> Commit Name <commit@email>
+func ParseMailmap(r io.Reader) (*Mailmap, error) {
+	mailmap := &Mailmap{entries: make(map[string][]mailmapEntry)}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		var names, emails []string
+		for {
+			open := strings.Index(line, "<")
+			if open < 0 {
+				break
+			}
+			end := strings.Index(line[open:], ">")
+			if end < 0 {
+				break
+			}
+			names = append(names, strings.TrimSpace(line[:open]))
+			emails = append(emails, strings.TrimSpace(line[open+1:open+end]))
+			line = line[open+end+1:]
+		}
+
+		var entry mailmapEntry
+		var commitEmail string
+		switch len(emails) {
+		case 1:
+			entry.properName = names[0]
+			commitEmail = emails[0]
+		case 2:
+			entry.properName, entry.properEmail = names[0], emails[0]
+			entry.commitName, commitEmail = names[1], emails[1]
+		default:
+			continue
+		}
+		if commitEmail == "" || entry.properName == "" && entry.properEmail == "" {
+			continue
+		}
+		key := strings.ToLower(commitEmail)
+		mailmap.entries[key] = append(mailmap.entries[key], entry)
+	}
+	return mailmap, scanner.Err()
+}
+
+// Lookup returns the canonical name and email for a commit identity. An entry naming
+// the commit name wins over one matching only the email; later lines win over earlier ones.
+func (m *Mailmap) Lookup(name, email string) (string, string) {
+	if m == nil {
+		return name, email
+	}
+	var match *mailmapEntry
+	for i, entry := range m.entries[strings.ToLower(email)] {
+		switch {
+		case entry.commitName != "" && strings.EqualFold(entry.commitName, name):
+			match = &m.entries[strings.ToLower(email)][i]
+		case entry.commitName == "" && (match == nil || match.commitName == ""):
+			match = &m.entries[strings.ToLower(email)][i]
+		}
+	}
+	if match == nil {
+		return name, email
+	}
+	if match.properName != "" {
+		name = match.properName
+	}
+	if match.properEmail != "" {
+		email = match.properEmail
+	}
+	return name, email
+}
+
+// Len returns the number of mapped commit emails
+func (m *Mailmap) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.entries)
+}
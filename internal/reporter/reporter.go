@@ -102,7 +102,7 @@ func (r *Reporter) Report(report *types.HealthReport) string {
 	// Category results
 	categoryResults := r.groupResultsByCategory(report.Results)
 	for category, results := range categoryResults {
-		output.WriteString(r.renderCategory(category, results))
+		output.WriteString(r.renderCategory(category, results, report.Category(category)))
 		output.WriteString("\n")
 	}
 
@@ -114,6 +114,149 @@ func (r *Reporter) Report(report *types.HealthReport) string {
 	return output.String()
 }
 
+// Explain renders how the overall score was computed: each check's weight, share and points,
+// the penalties that capped the score, and the grade bands
+func (r *Reporter) Explain(report *types.HealthReport) string {
+	var output strings.Builder
+
+	output.WriteString(r.style.Title.Render("Score Breakdown"))
+	output.WriteString("\n")
+	output.WriteString(r.style.Separator.Render(strings.Repeat("-", 50)))
+	output.WriteString("\n")
+	breakdown := report.Breakdown
+	if breakdown == nil {
+		output.WriteString("No checks were scored\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("%-28s %9s %18s %7s %7s\n", "Check", "Score", "Weight", "Share", "Points"))
+	for _, check := range breakdown.Checks {
+		score := fmt.Sprintf("%d", check.Normalized)
+		if check.Score != check.Normalized {
+			score = fmt.Sprintf("%d→%d", check.Score, check.Normalized)
+		}
+		weight := fmt.Sprintf("%d×%d = %d", check.CheckWeight, check.CategoryWeight, check.Weight)
+		output.WriteString(fmt.Sprintf("%-28s %9s %18s %6.1f%% %7.2f\n", check.ID, score, weight, check.Share*100, check.Points))
+	}
+	output.WriteString("\n")
+	output.WriteString(fmt.Sprintf("Weighted score: %d (sum of points; weight = check weight × category weight)\n", breakdown.WeightedScore))
+	for _, penalty := range breakdown.Penalties {
+		output.WriteString(r.style.Fail.Render(fmt.Sprintf("Penalty %q on %s (%s): score capped at %d", penalty.Rule, penalty.Check, penalty.Reason, penalty.MaxScore)))
+		output.WriteString("\n")
+	}
+	output.WriteString(r.style.Score.Render(fmt.Sprintf("Overall: %d (%s)", report.OverallScore, report.Grade)))
+	output.WriteString("\n")
+
+	var bands []string
+	for _, band := range breakdown.Grades {
+		bands = append(bands, fmt.Sprintf("%s ≥%d", band.Grade, band.Min))
+	}
+	output.WriteString(r.style.Detail.Render("Grades: " + strings.Join(bands, ", ")))
+	output.WriteString("\n")
+
+	return output.String()
+}
+
+// Compare renders the change in health between two reports: the overall and category score
+// deltas, the grade transition, checks that changed status and new or resolved findings
+func (r *Reporter) Compare(comparison *types.ReportComparison) string {
+	var output strings.Builder
+
+	output.WriteString(r.style.Header.Render("Repository Health Comparison"))
+	output.WriteString("\n")
+	output.WriteString(r.style.Detail.Render(fmt.Sprintf("%s → %s", comparison.Base, comparison.Head)))
+	output.WriteString("\n\n")
+
+	scoreText := fmt.Sprintf("Overall Health Score: %d → %d (%s)", comparison.BaseScore, comparison.HeadScore, formatDelta(comparison.ScoreDelta))
+	output.WriteString(r.deltaStyle(comparison.ScoreDelta).Render(scoreText))
+	output.WriteString("\n")
+	output.WriteString(r.style.Grade.Render("Grade: " + comparison.GradeTransition()))
+	output.WriteString("\n")
+	output.WriteString(r.style.Separator.Render(strings.Repeat("-", 50)))
+	output.WriteString("\n")
+
+	if len(comparison.Categories) > 0 {
+		output.WriteString(r.style.Category.Render("Categories"))
+		output.WriteString("\n")
+		for _, category := range comparison.Categories {
+			line := fmt.Sprintf("  %-36s %9s %11s", category.Name,
+				fmt.Sprintf("%s → %s", scoreOrDash(category.BaseScore, category.BaseGrade), scoreOrDash(category.HeadScore, category.HeadGrade)),
+				fmt.Sprintf("%s → %s", orDash(category.BaseGrade), orDash(category.HeadGrade)))
+			if category.BaseGrade != "" && category.HeadGrade != "" {
+				line += " " + formatDelta(category.Delta)
+			}
+			output.WriteString(r.deltaStyle(category.Delta).Render(line))
+			output.WriteString("\n")
+		}
+		output.WriteString("\n")
+	}
+
+	output.WriteString(r.style.Category.Render(fmt.Sprintf("Status Changes (%d)", len(comparison.StatusChanges))))
+	output.WriteString("\n")
+	for _, change := range comparison.StatusChanges {
+		style := r.style.Pass
+		if change.Regressed {
+			style = r.style.Fail
+		}
+		output.WriteString(style.Render(fmt.Sprintf("  %s %s: %s → %s", change.ID, change.Name, orDash(change.BaseStatus), orDash(change.HeadStatus))))
+		output.WriteString("\n")
+	}
+	output.WriteString("\n")
+
+	output.WriteString(r.style.Category.Render(fmt.Sprintf("New Findings (%d)", len(comparison.NewFindings))))
+	output.WriteString("\n")
+	for _, finding := range comparison.NewFindings {
+		output.WriteString(r.style.Fail.Render(fmt.Sprintf("  + %s: %s", finding.CheckID, strings.TrimSpace(finding.Detail))))
+		output.WriteString("\n")
+	}
+	output.WriteString("\n")
+
+	output.WriteString(r.style.Category.Render(fmt.Sprintf("Resolved Findings (%d)", len(comparison.ResolvedFindings))))
+	output.WriteString("\n")
+	for _, finding := range comparison.ResolvedFindings {
+		output.WriteString(r.style.Pass.Render(fmt.Sprintf("  - %s: %s", finding.CheckID, strings.TrimSpace(finding.Detail))))
+		output.WriteString("\n")
+	}
+
+	return output.String()
+}
+
+// deltaStyle colors improvements green, regressions red and unchanged scores grey
+func (r *Reporter) deltaStyle(delta int) lipgloss.Style {
+	switch {
+	case delta > 0:
+		return r.style.Pass
+	case delta < 0:
+		return r.style.Fail
+	default:
+		return r.style.Detail
+	}
+}
+
+// formatDelta formats a score change with its sign
+func formatDelta(delta int) string {
+	if delta == 0 {
+		return "±0"
+	}
+	return fmt.Sprintf("%+d", delta)
+}
+
+// scoreOrDash formats a score, or a dash when the report has no grade for it
+func scoreOrDash(score int, grade string) string {
+	if grade == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%d", score)
+}
+
+// orDash returns the value, or a dash when it is empty
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
 // groupResultsByCategory groups results by category
 func (r *Reporter) groupResultsByCategory(results []types.CheckResult) map[types.Category][]types.CheckResult {
 	categories := make(map[types.Category][]types.CheckResult)
@@ -125,8 +268,8 @@ func (r *Reporter) groupResultsByCategory(results []types.CheckResult) map[types
 	return categories
 }
 
-// renderCategory renders a category section
-func (r *Reporter) renderCategory(category types.Category, results []types.CheckResult) string {
+// renderCategory renders a category section with its sub-score when the report has one
+func (r *Reporter) renderCategory(category types.Category, results []types.CheckResult, score *types.CategoryScore) string {
 	var output strings.Builder
 
 	// Category header
@@ -142,6 +285,9 @@ func (r *Reporter) renderCategory(category types.Category, results []types.Check
 		category.String(),
 		passed,
 		len(results))
+	if score != nil {
+		categoryHeader += fmt.Sprintf(" - %d/100 (%s)", score.Score, score.Grade)
+	}
 
 	output.WriteString(r.style.Category.Render(categoryHeader))
 	output.WriteString("\n")
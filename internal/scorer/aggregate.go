@@ -0,0 +1,179 @@
+package scorer
+
+import (
+	"math"
+	"sort"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// DefaultWorstOffenders is the number of lowest scoring repositories listed per category
+const DefaultWorstOffenders = 5
+
+// AggregateScan combines the reports of many repositories: it summarizes the overall scores,
+// counts each check's failures, averages every category with its worst offenders and buckets
+// the scores into a histogram. Repositories without a report are left out.
+func AggregateScan(repositories []types.RepositoryScan, worstOffenders int) *types.ScanReport {
+	if worstOffenders <= 0 {
+		worstOffenders = DefaultWorstOffenders
+	}
+	scanned := make([]types.RepositoryScan, 0, len(repositories))
+	for _, repository := range repositories {
+		if repository.Report != nil {
+			scanned = append(scanned, repository)
+		}
+	}
+	sort.Slice(scanned, func(i, j int) bool { return scanned[i].Path < scanned[j].Path })
+
+	return &types.ScanReport{
+		Repositories:  scanned,
+		Summary:       scanSummary(scanned),
+		CheckFailures: checkFailureCounts(scanned),
+		Categories:    categoryAggregates(scanned, worstOffenders),
+		Histogram:     scoreHistogram(scanned),
+	}
+}
+
+// scanSummary averages the overall scores and finds the highest and lowest
+func scanSummary(repositories []types.RepositoryScan) types.ScanSummary {
+	summary := types.ScanSummary{TotalRepositories: len(repositories)}
+	if len(repositories) == 0 {
+		return summary
+	}
+	scores := make([]int, 0, len(repositories))
+	highest, lowest := repositories[0], repositories[0]
+	for _, repository := range repositories {
+		scores = append(scores, repository.Score)
+		if repository.Score > highest.Score {
+			highest = repository
+		}
+		if repository.Score < lowest.Score {
+			lowest = repository
+		}
+	}
+	summary.AverageScore = average(scores)
+	summary.MedianScore = median(scores)
+	summary.Highest = &types.RepositoryScore{Name: highest.Name, Path: highest.Path, Score: highest.Score, Grade: highest.Grade}
+	summary.Lowest = &types.RepositoryScore{Name: lowest.Name, Path: lowest.Path, Score: lowest.Score, Grade: lowest.Grade}
+	return summary
+}
+
+// checkFailureCounts counts each check's statuses, ordered by failures, then warnings, then ID
+func checkFailureCounts(repositories []types.RepositoryScan) []types.CheckFailureCount {
+	counts := make(map[string]*types.CheckFailureCount)
+	for _, repository := range repositories {
+		for _, result := range repository.Report.Results {
+			count, ok := counts[result.ID]
+			if !ok {
+				count = &types.CheckFailureCount{ID: result.ID, Name: result.Name, Category: result.Category}
+				counts[result.ID] = count
+			}
+			switch result.Status {
+			case types.StatusFail:
+				count.Failed++
+				count.FailedRepositories = append(count.FailedRepositories, repository.Name)
+			case types.StatusWarning:
+				count.Warnings++
+			default:
+				count.Passed++
+			}
+		}
+	}
+
+	failures := make([]types.CheckFailureCount, 0, len(counts))
+	for _, count := range counts {
+		failures = append(failures, *count)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].Failed != failures[j].Failed {
+			return failures[i].Failed > failures[j].Failed
+		}
+		if failures[i].Warnings != failures[j].Warnings {
+			return failures[i].Warnings > failures[j].Warnings
+		}
+		return failures[i].ID < failures[j].ID
+	})
+	return failures
+}
+
+// categoryAggregates averages each category's sub-scores and lists its lowest scoring repositories
+func categoryAggregates(repositories []types.RepositoryScan, worstOffenders int) []types.CategoryAggregate {
+	aggregates := []types.CategoryAggregate{}
+	for _, category := range types.Categories() {
+		var scores []int
+		var offenders []types.RepositoryScore
+		for _, repository := range repositories {
+			categoryScore := repository.Report.Category(category)
+			if categoryScore == nil {
+				continue
+			}
+			scores = append(scores, categoryScore.Score)
+			offenders = append(offenders, types.RepositoryScore{
+				Name:  repository.Name,
+				Path:  repository.Path,
+				Score: categoryScore.Score,
+				Grade: categoryScore.Grade,
+			})
+		}
+		if len(scores) == 0 {
+			continue
+		}
+
+		sort.SliceStable(offenders, func(i, j int) bool { return offenders[i].Score < offenders[j].Score })
+		aggregate := types.CategoryAggregate{
+			Category:     category,
+			Key:          category.Key(),
+			Name:         category.String(),
+			Repositories: len(scores),
+			AverageScore: average(scores),
+			MinScore:     offenders[0].Score,
+			MaxScore:     offenders[len(offenders)-1].Score,
+		}
+		if len(offenders) > worstOffenders {
+			offenders = offenders[:worstOffenders]
+		}
+		aggregate.WorstOffenders = offenders
+		aggregates = append(aggregates, aggregate)
+	}
+	return aggregates
+}
+
+// scoreHistogram buckets the overall scores into 0-9, 10-19, ... 90-100
+func scoreHistogram(repositories []types.RepositoryScan) []types.ScoreBucket {
+	buckets := make([]types.ScoreBucket, 10)
+	for i := range buckets {
+		buckets[i] = types.ScoreBucket{Min: i * 10, Max: i*10 + 9}
+	}
+	buckets[9].Max = 100
+	for _, repository := range repositories {
+		index := repository.Score / 10
+		if index > 9 {
+			index = 9
+		}
+		if index < 0 {
+			index = 0
+		}
+		buckets[index].Count++
+	}
+	return buckets
+}
+
+// average returns the mean of the scores rounded to one decimal
+func average(scores []int) float64 {
+	total := 0
+	for _, score := range scores {
+		total += score
+	}
+	return math.Round(float64(total)/float64(len(scores))*10) / 10
+}
+
+// median returns the middle score, or the mean of the two middle scores
+func median(scores []int) float64 {
+	sorted := append([]int(nil), scores...)
+	sort.Ints(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return float64(sorted[middle-1]+sorted[middle]) / 2
+	}
+	return float64(sorted[middle])
+}
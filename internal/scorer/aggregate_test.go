@@ -0,0 +1,69 @@
+package scorer
+
+import (
+	"testing"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestAggregateScan(t *testing.T) {
+	repository := func(name string, score int, docs, security int, statuses ...types.Status) types.RepositoryScan {
+		report := &types.HealthReport{
+			OverallScore: score,
+			Grade:        calculateGrade(score),
+			Categories: []types.CategoryScore{
+				{Category: types.CategoryDocs, Key: "docs", Score: docs},
+				{Category: types.CategorySecurity, Key: "security", Score: security},
+			},
+		}
+		for i, status := range statuses {
+			report.Results = append(report.Results, types.CheckResult{ID: []string{"DOC-101", "SEC-001"}[i], Status: status})
+		}
+		return types.RepositoryScan{Name: name, Path: "/src/" + name, Score: score, Grade: report.Grade, Report: report}
+	}
+
+	report := AggregateScan([]types.RepositoryScan{
+		repository("web", 95, 90, 100, types.StatusPass, types.StatusPass),
+		repository("api", 72, 60, 40, types.StatusFail, types.StatusFail),
+		repository("cli", 41, 30, 80, types.StatusFail, types.StatusWarning),
+		{Name: "broken", Path: "/src/broken"},
+	}, 2)
+
+	if len(report.Repositories) != 3 || report.Repositories[0].Name != "api" {
+		t.Fatalf("expected three repositories ordered by path, got %+v", report.Repositories)
+	}
+	summary := report.Summary
+	if summary.TotalRepositories != 3 || summary.AverageScore != 69.3 || summary.MedianScore != 72 ||
+		summary.Highest.Name != "web" || summary.Lowest.Name != "cli" {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	if len(report.CheckFailures) != 2 {
+		t.Fatalf("unexpected check failures %+v", report.CheckFailures)
+	}
+	if docs := report.CheckFailures[0]; docs.ID != "DOC-101" || docs.Failed != 2 || docs.Passed != 1 || len(docs.FailedRepositories) != 2 {
+		t.Errorf("expected DOC-101 to fail most often: %+v", docs)
+	}
+	if security := report.CheckFailures[1]; security.Failed != 1 || security.Warnings != 1 {
+		t.Errorf("unexpected SEC-001 counts %+v", security)
+	}
+
+	if len(report.Categories) != 2 {
+		t.Fatalf("unexpected categories %+v", report.Categories)
+	}
+	security := report.Categories[1]
+	if security.Key != "security" || security.AverageScore != 73.3 || security.MinScore != 40 || security.MaxScore != 100 {
+		t.Errorf("unexpected security aggregate %+v", security)
+	}
+	if len(security.WorstOffenders) != 2 || security.WorstOffenders[0].Name != "api" || security.WorstOffenders[1].Name != "cli" {
+		t.Errorf("unexpected worst offenders %+v", security.WorstOffenders)
+	}
+
+	counts := map[string]int{}
+	for _, bucket := range report.Histogram {
+		counts[bucket.Label()] = bucket.Count
+	}
+	if len(report.Histogram) != 10 || counts["90-100"] != 1 || counts["70-79"] != 1 || counts["40-49"] != 1 {
+		t.Errorf("unexpected histogram %+v", report.Histogram)
+	}
+}
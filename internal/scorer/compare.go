@@ -0,0 +1,175 @@
+package scorer
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+// findingNumberRe matches the counts and measurements in a finding, which change without the finding changing
+var findingNumberRe = regexp.MustCompile(`[0-9]+(\.[0-9]+)?`)
+
+// counterDetailRe matches summary counters such as "Executable Files: 0" or "Total Size: 1.5 MB"
+var counterDetailRe = regexp.MustCompile(`^[\pL\pN ()/-]+:\s*[0-9][0-9.,/]*\s*(%|[A-Za-z]{1,3})?$`)
+
+// Compare computes the change in health from base to head: the overall and per-category
+// score deltas, the grade transition, the checks whose status changed and the findings that
+// appeared or were resolved. Findings are the details of failing and warning checks that name a
+// problem, leaving out headings, counters and table decoration, matched
+// by check ID and text with numbers ignored, so "3 commits exceed 500 lines" becoming
+// "4 commits exceed 500 lines" is neither new nor resolved.
+func Compare(base, head *types.HealthReport) *types.ReportComparison {
+	comparison := &types.ReportComparison{
+		BaseScore:        base.OverallScore,
+		HeadScore:        head.OverallScore,
+		ScoreDelta:       head.OverallScore - base.OverallScore,
+		BaseGrade:        base.Grade,
+		HeadGrade:        head.Grade,
+		Categories:       []types.CategoryDelta{},
+		StatusChanges:    []types.CheckStatusChange{},
+		NewFindings:      []types.Finding{},
+		ResolvedFindings: []types.Finding{},
+	}
+
+	for _, category := range types.Categories() {
+		baseScore, headScore := base.Category(category), head.Category(category)
+		if baseScore == nil && headScore == nil {
+			continue
+		}
+		delta := types.CategoryDelta{Category: category, Key: category.Key(), Name: category.String()}
+		if baseScore != nil {
+			delta.BaseScore, delta.BaseGrade = baseScore.Score, baseScore.Grade
+		}
+		if headScore != nil {
+			delta.HeadScore, delta.HeadGrade = headScore.Score, headScore.Grade
+		}
+		if baseScore != nil && headScore != nil {
+			delta.Delta = headScore.Score - baseScore.Score
+		}
+		comparison.Categories = append(comparison.Categories, delta)
+	}
+
+	baseResults := resultsByID(base.Results)
+	headResults := resultsByID(head.Results)
+	for _, result := range head.Results {
+		previous, ok := baseResults[result.ID]
+		if !ok {
+			comparison.StatusChanges = append(comparison.StatusChanges, statusChange(nil, &result))
+			comparison.NewFindings = append(comparison.NewFindings, newFindings(nil, &result)...)
+			continue
+		}
+		if previous.Status != result.Status {
+			comparison.StatusChanges = append(comparison.StatusChanges, statusChange(previous, &result))
+		}
+		comparison.NewFindings = append(comparison.NewFindings, newFindings(previous, &result)...)
+		comparison.ResolvedFindings = append(comparison.ResolvedFindings, newFindings(&result, previous)...)
+	}
+	for _, result := range base.Results {
+		if _, ok := headResults[result.ID]; !ok {
+			comparison.StatusChanges = append(comparison.StatusChanges, statusChange(&result, nil))
+			comparison.ResolvedFindings = append(comparison.ResolvedFindings, newFindings(nil, &result)...)
+		}
+	}
+
+	return comparison
+}
+
+// resultsByID indexes results by check ID
+func resultsByID(results []types.CheckResult) map[string]*types.CheckResult {
+	index := make(map[string]*types.CheckResult, len(results))
+	for i := range results {
+		index[results[i].ID] = &results[i]
+	}
+	return index
+}
+
+// statusChange describes a check moving from base to head; either side may be missing
+func statusChange(base, head *types.CheckResult) types.CheckStatusChange {
+	change := types.CheckStatusChange{}
+	baseRank, headRank := 0, 0
+	if base != nil {
+		change.ID, change.Name, change.Category = base.ID, base.Name, base.Category
+		change.BaseStatus, change.BaseScore = base.Status.String(), base.Score
+		baseRank = statusRank(base.Status)
+	}
+	if head != nil {
+		change.ID, change.Name, change.Category = head.ID, head.Name, head.Category
+		change.HeadStatus, change.HeadScore = head.Status.String(), head.Score
+		headRank = statusRank(head.Status)
+	}
+	change.Regressed = headRank > baseRank
+	return change
+}
+
+// statusRank orders statuses from best to worst
+func statusRank(status types.Status) int {
+	switch status {
+	case types.StatusWarning:
+		return 1
+	case types.StatusFail:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// newFindings returns the findings of after that before does not report
+func newFindings(before, after *types.CheckResult) []types.Finding {
+	if after.Status == types.StatusPass {
+		return nil
+	}
+	known := make(map[string]bool)
+	if before != nil && before.Status != types.StatusPass {
+		for _, detail := range before.Details {
+			known[findingKey(detail)] = true
+		}
+	}
+	var findings []types.Finding
+	for _, detail := range after.Details {
+		if !isFindingDetail(detail) {
+			continue
+		}
+		key := findingKey(detail)
+		if known[key] {
+			continue
+		}
+		known[key] = true
+		findings = append(findings, types.Finding{CheckID: after.ID, Check: after.Name, Category: after.Category, Detail: detail})
+	}
+	return findings
+}
+
+// isFindingDetail reports whether a detail line names a problem rather than laying out the
+// details: blank lines, "Label:" headings, "Label: N" counters, "... and N more" notes and
+// table borders and rows are not findings
+func isFindingDetail(detail string) bool {
+	line := strings.TrimSpace(detail)
+	switch {
+	case line == "",
+		strings.HasSuffix(line, ":"),
+		strings.HasPrefix(line, "..."),
+		strings.HasPrefix(line, "|"),
+		counterDetailRe.MatchString(stripLeadingSymbols(line)):
+		return false
+	}
+	first := []rune(line)[0]
+	if first >= '\u2500' && first <= '\u257f' {
+		// Box drawing characters frame tables
+		return false
+	}
+	return strings.IndexFunc(line, unicode.IsLetter) >= 0
+}
+
+// stripLeadingSymbols drops the emoji and bullets some checks put before their details
+func stripLeadingSymbols(line string) string {
+	return strings.TrimLeftFunc(line, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// findingKey identifies a finding by its text with numbers masked
+func findingKey(detail string) string {
+	return findingNumberRe.ReplaceAllString(detail, "#")
+}
@@ -0,0 +1,110 @@
+package scorer
+
+import (
+	"testing"
+
+	"github.com/vahidaghazadeh/gphc/pkg/types"
+)
+
+func TestCompare(t *testing.T) {
+	base := &types.HealthReport{
+		OverallScore: 72,
+		Grade:        "B-",
+		Results: []types.CheckResult{
+			{ID: "DOC-101", Name: "README", Status: types.StatusFail, Score: 20, Category: types.CategoryDocs, Details: []string{"README.md is missing"}},
+			{ID: "SEC-001", Name: "Secrets", Status: types.StatusWarning, Score: 70, Category: types.CategorySecurity, Details: []string{"token in a.env", "key in b.pem", "2 secrets found"}},
+			{ID: "OLD-001", Name: "Removed", Status: types.StatusWarning, Score: 50, Category: types.CategoryHygiene, Details: []string{"old finding"}},
+		},
+		Categories: []types.CategoryScore{
+			{Category: types.CategoryDocs, Key: "docs", Score: 20, Grade: "F"},
+			{Category: types.CategorySecurity, Key: "security", Score: 70, Grade: "B-"},
+			{Category: types.CategoryHygiene, Key: "hygiene", Score: 50, Grade: "D"},
+		},
+	}
+	head := &types.HealthReport{
+		OverallScore: 78,
+		Grade:        "B",
+		Results: []types.CheckResult{
+			{ID: "DOC-101", Name: "README", Status: types.StatusPass, Score: 100, Category: types.CategoryDocs, Details: []string{"README.md found"}},
+			{ID: "SEC-001", Name: "Secrets", Status: types.StatusFail, Score: 40, Category: types.CategorySecurity, Details: []string{"key in b.pem", "password in c.yml", "3 secrets found"}},
+			{ID: "NEW-001", Name: "Added", Status: types.StatusPass, Score: 100, Category: types.CategoryStructure},
+		},
+		Categories: []types.CategoryScore{
+			{Category: types.CategoryDocs, Key: "docs", Score: 100, Grade: "A+"},
+			{Category: types.CategoryStructure, Key: "structure", Score: 100, Grade: "A+"},
+			{Category: types.CategorySecurity, Key: "security", Score: 40, Grade: "F"},
+		},
+	}
+
+	comparison := Compare(base, head)
+	if comparison.ScoreDelta != 6 || comparison.GradeTransition() != "B- → B" {
+		t.Errorf("unexpected overall change %+v", comparison)
+	}
+
+	categories := map[string]types.CategoryDelta{}
+	for _, category := range comparison.Categories {
+		categories[category.Key] = category
+	}
+	if len(categories) != 4 || categories["docs"].Delta != 80 || categories["security"].Delta != -30 {
+		t.Errorf("unexpected category deltas %+v", comparison.Categories)
+	}
+	if hygiene := categories["hygiene"]; hygiene.HeadGrade != "" || hygiene.Delta != 0 {
+		t.Errorf("a category missing from the head should have no head grade: %+v", hygiene)
+	}
+
+	changes := map[string]types.CheckStatusChange{}
+	for _, change := range comparison.StatusChanges {
+		changes[change.ID] = change
+	}
+	if len(changes) != 4 {
+		t.Fatalf("unexpected status changes %+v", comparison.StatusChanges)
+	}
+	if change := changes["DOC-101"]; change.BaseStatus != "FAIL" || change.HeadStatus != "PASS" || change.Regressed {
+		t.Errorf("unexpected DOC-101 change %+v", change)
+	}
+	if change := changes["SEC-001"]; !change.Regressed {
+		t.Errorf("expected SEC-001 to regress: %+v", change)
+	}
+	if change := changes["NEW-001"]; change.BaseStatus != "" || change.Regressed {
+		t.Errorf("unexpected NEW-001 change %+v", change)
+	}
+	if change := changes["OLD-001"]; change.HeadStatus != "" || change.Regressed {
+		t.Errorf("unexpected OLD-001 change %+v", change)
+	}
+
+	if len(comparison.NewFindings) != 1 || comparison.NewFindings[0].Detail != "password in c.yml" {
+		t.Errorf("unexpected new findings %+v", comparison.NewFindings)
+	}
+	resolved := map[string]bool{}
+	for _, finding := range comparison.ResolvedFindings {
+		resolved[finding.Detail] = true
+	}
+	if len(resolved) != 3 || !resolved["README.md is missing"] || !resolved["token in a.env"] || !resolved["old finding"] {
+		t.Errorf("unexpected resolved findings %+v", comparison.ResolvedFindings)
+	}
+}
+
+func TestCompareIgnoresDetailLayout(t *testing.T) {
+	base := &types.HealthReport{Results: []types.CheckResult{
+		{ID: "BINARY-AUDIT", Name: "Binary Files", Status: types.StatusPass, Score: 100, Category: types.CategoryHygiene},
+	}}
+	head := &types.HealthReport{Results: []types.CheckResult{
+		{ID: "BINARY-AUDIT", Name: "Binary Files", Status: types.StatusWarning, Score: 70, Category: types.CategoryHygiene, Details: []string{
+			"Executable Files: 0",
+			"Total Size: 1.5 MB",
+			"",
+			"📋 File Summary Table:",
+			"┌─────────────────┬──────────┐",
+			"│ Large Files     │ 1        │",
+			"└─────────────────┴──────────┘",
+			"📦 Large Files:",
+			"  • assets/video.mp4 [high] 12.0 MB",
+			"  ... and 3 more files (use --format json for complete list)",
+		}},
+	}}
+
+	comparison := Compare(base, head)
+	if len(comparison.NewFindings) != 1 || comparison.NewFindings[0].Detail != "  • assets/video.mp4 [high] 12.0 MB" {
+		t.Errorf("only the listed file should be a new finding: %+v", comparison.NewFindings)
+	}
+}
@@ -1,6 +1,10 @@
 package scorer
 
 import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/vahidaghazadeh/gphc/pkg/types"
@@ -9,35 +13,156 @@ import (
 // Scorer calculates the overall health score from check results
 type Scorer struct {
 	results []types.CheckResult
-	weights map[types.Category]int
+	// checkWeights holds the checker weight of each result; zero when the result was added without one
+	checkWeights []int
+	weights      map[types.Category]int
+	options      Options
+}
+
+// Options configures how check results combine into the overall score and grade
+type Options struct {
+	// CategoryWeights override the default weight of each category
+	CategoryWeights map[types.Category]int
+	// CheckWeights override checker weights by result ID, compared case-insensitively; zero leaves a check out
+	CheckWeights map[string]int
+	// Grades are the grade bands; empty uses DefaultGradeBands
+	Grades []types.GradeBand
+	// Penalties cap the overall score when a check matches them
+	Penalties []Penalty
+}
+
+// Penalty caps the overall score when a check matches, however well the other checks scored
+type Penalty struct {
+	// Name describes the rule in the breakdown; empty derives one from the conditions
+	Name string
+	// Check is the result ID the rule applies to, compared case-insensitively; empty matches every check
+	Check string
+	// Status matches the check's status: pass, warning or fail; empty matches any status
+	Status string
+	// Severity matches when the check reports at least one finding of this severity
+	Severity string
+	// MaxScore caps the overall score; zero leaves the cap to MaxGrade
+	MaxScore int
+	// MaxGrade caps the grade by keeping the score below the next better band
+	MaxGrade string
+}
+
+// DefaultGradeBands returns the grade ladder from A+ at 95 down to F
+func DefaultGradeBands() []types.GradeBand {
+	return []types.GradeBand{
+		{Grade: "A+", Min: 95},
+		{Grade: "A", Min: 90},
+		{Grade: "A-", Min: 85},
+		{Grade: "B+", Min: 80},
+		{Grade: "B", Min: 75},
+		{Grade: "B-", Min: 70},
+		{Grade: "C+", Min: 65},
+		{Grade: "C", Min: 60},
+		{Grade: "C-", Min: 55},
+		{Grade: "D", Min: 50},
+		{Grade: "F", Min: 0},
+	}
+}
+
+// DefaultPenalties returns the penalties applied when gphc.yml sets none: a critical secret caps the grade at D
+func DefaultPenalties() []Penalty {
+	return DefaultPenaltiesFor(DefaultGradeBands())
+}
+
+// DefaultPenaltiesFor returns the default penalties for custom grade bands: a critical secret
+// caps the grade at the second-lowest band, which is D on the default ladder. With fewer
+// than two bands there is no grade to cap at and no default penalty.
+func DefaultPenaltiesFor(grades []types.GradeBand) []Penalty {
+	if len(grades) < 2 {
+		return nil
+	}
+	sorted := append([]types.GradeBand(nil), grades...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Min < sorted[j].Min })
+	return []Penalty{
+		{Name: "critical secret", Check: "secret-scanning", Severity: "critical", MaxGrade: sorted[1].Grade},
+	}
+}
+
+// DefaultOptions returns the default grade bands and penalties
+func DefaultOptions() Options {
+	return Options{Grades: DefaultGradeBands(), Penalties: DefaultPenalties()}
+}
+
+// Validate reports grade bands and penalties that cannot be applied
+func (o Options) Validate() error {
+	grades := make(map[string]bool)
+	for _, band := range o.Grades {
+		if band.Grade == "" {
+			return fmt.Errorf("grade band with min %d has no grade", band.Min)
+		}
+		if grades[band.Grade] {
+			return fmt.Errorf("grade %s is defined twice", band.Grade)
+		}
+		if band.Min < 0 || band.Min > 100 {
+			return fmt.Errorf("grade %s: min %d is outside 0-100", band.Grade, band.Min)
+		}
+		grades[band.Grade] = true
+	}
+	for _, penalty := range o.Penalties {
+		if penalty.MaxGrade != "" && len(o.Grades) > 0 && !grades[penalty.MaxGrade] {
+			return fmt.Errorf("penalty %s: unknown grade %s", penalty.describe(), penalty.MaxGrade)
+		}
+		switch strings.ToLower(penalty.Status) {
+		case "", "pass", "warning", "fail":
+		default:
+			return fmt.Errorf("penalty %s: unknown status %s", penalty.describe(), penalty.Status)
+		}
+		if penalty.MaxScore == 0 && penalty.MaxGrade == "" {
+			return fmt.Errorf("penalty %s: set max_score or max_grade", penalty.describe())
+		}
+	}
+	return nil
 }
 
 // NewScorer creates a new scorer
 func NewScorer() *Scorer {
-	return &Scorer{
-		results: make([]types.CheckResult, 0),
-	}
+	return NewScorerWithOptions(DefaultOptions())
 }
 
 // NewScorerWithWeights creates a scorer with category weight overrides.
 func NewScorerWithWeights(weights map[types.Category]int) *Scorer {
+	options := DefaultOptions()
+	options.CategoryWeights = weights
+	return NewScorerWithOptions(options)
+}
+
+// NewScorerWithOptions creates a scorer with custom weights, grade bands and penalties
+func NewScorerWithOptions(options Options) *Scorer {
+	if len(options.Grades) == 0 {
+		options.Grades = DefaultGradeBands()
+	}
+	options.Grades = append([]types.GradeBand(nil), options.Grades...)
+	sort.SliceStable(options.Grades, func(i, j int) bool { return options.Grades[i].Min > options.Grades[j].Min })
 	return &Scorer{
 		results: make([]types.CheckResult, 0),
-		weights: weights,
+		weights: options.CategoryWeights,
+		options: options,
 	}
 }
 
 // AddResult adds a check result to the scorer
 func (s *Scorer) AddResult(result types.CheckResult) {
+	s.AddWeightedResult(result, 0)
+}
+
+// AddWeightedResult adds a check result with the weight of the checker that produced it
+func (s *Scorer) AddWeightedResult(result types.CheckResult, weight int) {
 	s.results = append(s.results, result)
+	s.checkWeights = append(s.checkWeights, weight)
 }
 
-// CalculateHealthReport calculates the overall health report
+// CalculateHealthReport calculates the overall health report. Each score is clamped to 0-100 and
+// weighted by its check weight times its category weight; penalties then cap the weighted average.
 func (s *Scorer) CalculateHealthReport() *types.HealthReport {
 	if len(s.results) == 0 {
 		return &types.HealthReport{
 			OverallScore: 0,
-			Grade:        "F",
+			Grade:        s.grade(0),
 			Results:      []types.CheckResult{},
 			Summary: types.ReportSummary{
 				TotalChecks:   0,
@@ -45,26 +170,30 @@ func (s *Scorer) CalculateHealthReport() *types.HealthReport {
 				FailedChecks:  0,
 				WarningChecks: 0,
 			},
-			Timestamp: time.Now(),
+			Categories: []types.CategoryScore{},
+			Timestamp:  time.Now(),
 		}
 	}
 
-	// Calculate weighted score
-	totalWeightedScore := 0
-	totalWeight := 0
-
 	summary := types.ReportSummary{
 		TotalChecks: len(s.results),
 	}
+	breakdown := &types.ScoreBreakdown{Grades: s.options.Grades}
 
-	for _, result := range s.results {
-		// Weight the score based on the checker's importance
-		weight := getWeightForCategory(result.Category)
-		if configuredWeight := s.weights[result.Category]; configuredWeight > 0 {
-			weight = configuredWeight
+	totalWeight := 0
+	for i, result := range s.results {
+		contribution := types.CheckContribution{
+			ID:             result.ID,
+			Name:           result.Name,
+			Category:       result.Category,
+			Score:          result.Score,
+			Normalized:     min(max(result.Score, 0), 100),
+			CheckWeight:    s.checkWeight(result.ID, s.checkWeights[i]),
+			CategoryWeight: s.categoryWeight(result.Category),
 		}
-		totalWeight += weight
-		totalWeightedScore += result.Score * weight
+		contribution.Weight = contribution.CheckWeight * contribution.CategoryWeight
+		totalWeight += contribution.Weight
+		breakdown.Checks = append(breakdown.Checks, contribution)
 
 		// Count statuses
 		switch result.Status {
@@ -78,21 +207,201 @@ func (s *Scorer) CalculateHealthReport() *types.HealthReport {
 	}
 
 	// Calculate overall score (0-100)
-	var overallScore int
-	if totalWeight > 0 {
-		overallScore = totalWeightedScore / totalWeight
+	weightedScore := 0.0
+	for i := range breakdown.Checks {
+		check := &breakdown.Checks[i]
+		if totalWeight > 0 {
+			check.Share = float64(check.Weight) / float64(totalWeight)
+		}
+		check.Points = float64(check.Normalized) * check.Share
+		weightedScore += check.Points
 	}
+	breakdown.WeightedScore = int(math.Round(weightedScore))
 
-	// Calculate grade
-	grade := calculateGrade(overallScore)
+	overallScore := breakdown.WeightedScore
+	caps := make(map[types.Category]int)
+	for _, result := range s.results {
+		for _, penalty := range s.options.Penalties {
+			applied, ok := s.applyPenalty(penalty, result)
+			if !ok {
+				continue
+			}
+			breakdown.Penalties = append(breakdown.Penalties, applied)
+			overallScore = min(overallScore, applied.MaxScore)
+			if limit, capped := caps[result.Category]; !capped || applied.MaxScore < limit {
+				caps[result.Category] = applied.MaxScore
+			}
+		}
+	}
 
 	return &types.HealthReport{
 		OverallScore: overallScore,
-		Grade:        grade,
+		Grade:        s.grade(overallScore),
 		Results:      s.results,
 		Summary:      summary,
 		Timestamp:    time.Now(),
+		Categories:   s.categoryScores(breakdown.Checks, caps),
+		Breakdown:    breakdown,
+	}
+}
+
+// categoryScores averages the checks of each category by check weight, and caps the categories
+// whose checks matched a penalty. Checks weighted zero are left out of the scores and summaries,
+// so a category whose checks all weigh zero is not listed.
+func (s *Scorer) categoryScores(checks []types.CheckContribution, caps map[types.Category]int) []types.CategoryScore {
+	scores := make([]types.CategoryScore, 0)
+	for _, category := range types.Categories() {
+		var points, weights int
+		var summary types.ReportSummary
+		for i, check := range checks {
+			if check.Category != category || check.CheckWeight == 0 {
+				continue
+			}
+			points += check.Normalized * check.CheckWeight
+			weights += check.CheckWeight
+			summary.TotalChecks++
+			switch s.results[i].Status {
+			case types.StatusPass:
+				summary.PassedChecks++
+			case types.StatusFail:
+				summary.FailedChecks++
+			case types.StatusWarning:
+				summary.WarningChecks++
+			}
+		}
+		if weights == 0 {
+			continue
+		}
+		score := int(math.Round(float64(points) / float64(weights)))
+		if limit, capped := caps[category]; capped {
+			score = min(score, limit)
+		}
+		scores = append(scores, types.CategoryScore{
+			Category: category,
+			Key:      category.Key(),
+			Name:     category.String(),
+			Score:    score,
+			Grade:    s.grade(score),
+			Summary:  summary,
+		})
+	}
+	return scores
+}
+
+// checkWeight returns the configured weight of a check, else the checker's weight, else 1
+func (s *Scorer) checkWeight(id string, weight int) int {
+	for configured, override := range s.options.CheckWeights {
+		if strings.EqualFold(configured, id) {
+			return max(override, 0)
+		}
+	}
+	if weight > 0 {
+		return weight
+	}
+	return 1
+}
+
+// categoryWeight returns the configured weight of a category, else its default
+func (s *Scorer) categoryWeight(category types.Category) int {
+	if configuredWeight := s.weights[category]; configuredWeight > 0 {
+		return configuredWeight
+	}
+	return getWeightForCategory(category)
+}
+
+// applyPenalty matches a penalty against a result and returns the cap it imposes
+func (s *Scorer) applyPenalty(penalty Penalty, result types.CheckResult) (types.AppliedPenalty, bool) {
+	if penalty.Check != "" && !strings.EqualFold(penalty.Check, result.ID) {
+		return types.AppliedPenalty{}, false
+	}
+	if penalty.Status != "" && !strings.EqualFold(penalty.Status, statusName(result.Status)) {
+		return types.AppliedPenalty{}, false
+	}
+	var reasons []string
+	if penalty.Status != "" {
+		reasons = append(reasons, "status "+statusName(result.Status))
+	}
+	if penalty.Severity != "" {
+		count := result.Severities[strings.ToLower(penalty.Severity)]
+		if count == 0 {
+			return types.AppliedPenalty{}, false
+		}
+		reasons = append(reasons, fmt.Sprintf("%d %s findings", count, strings.ToLower(penalty.Severity)))
+	}
+
+	maxScore := 100
+	if penalty.MaxScore > 0 {
+		maxScore = penalty.MaxScore
+	}
+	if penalty.MaxGrade != "" {
+		for i, band := range s.options.Grades {
+			if band.Grade == penalty.MaxGrade && i > 0 {
+				maxScore = min(maxScore, s.options.Grades[i-1].Min-1)
+			}
+		}
+	}
+	if maxScore >= 100 {
+		return types.AppliedPenalty{}, false
 	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, "matched")
+	}
+	return types.AppliedPenalty{
+		Rule:     penalty.describe(),
+		Check:    result.ID,
+		Reason:   strings.Join(reasons, ", "),
+		MaxScore: maxScore,
+	}, true
+}
+
+// describe names a penalty after its conditions and cap when it has no name
+func (p Penalty) describe() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	var parts []string
+	if p.Check != "" {
+		parts = append(parts, p.Check)
+	}
+	if p.Status != "" {
+		parts = append(parts, strings.ToLower(p.Status))
+	}
+	if p.Severity != "" {
+		parts = append(parts, strings.ToLower(p.Severity)+" finding")
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "any check")
+	}
+	switch {
+	case p.MaxGrade != "":
+		parts = append(parts, "caps grade at "+p.MaxGrade)
+	case p.MaxScore > 0:
+		parts = append(parts, fmt.Sprintf("caps score at %d", p.MaxScore))
+	}
+	return strings.Join(parts, " ")
+}
+
+// statusName is the lowercase status used in penalty rules
+func statusName(status types.Status) string {
+	return strings.ToLower(status.String())
+}
+
+// grade returns the grade of the best band the score reaches, or the lowest band's grade
+func (s *Scorer) grade(score int) string {
+	return gradeForScore(s.options.Grades, score)
+}
+
+// gradeForScore finds the grade of a score in bands sorted from best to worst
+func gradeForScore(bands []types.GradeBand, score int) string {
+	for _, band := range bands {
+		if score >= band.Min {
+			return band.Grade
+		}
+	}
+	if len(bands) == 0 {
+		return "F"
+	}
+	return bands[len(bands)-1].Grade
 }
 
 // getWeightForCategory returns the weight for a category
@@ -113,32 +422,9 @@ func getWeightForCategory(category types.Category) int {
 	}
 }
 
-// calculateGrade converts score to letter grade
+// calculateGrade converts score to letter grade on the default scale
 func calculateGrade(score int) string {
-	switch {
-	case score >= 95:
-		return "A+"
-	case score >= 90:
-		return "A"
-	case score >= 85:
-		return "A-"
-	case score >= 80:
-		return "B+"
-	case score >= 75:
-		return "B"
-	case score >= 70:
-		return "B-"
-	case score >= 65:
-		return "C+"
-	case score >= 60:
-		return "C"
-	case score >= 55:
-		return "C-"
-	case score >= 50:
-		return "D"
-	default:
-		return "F"
-	}
+	return gradeForScore(DefaultGradeBands(), score)
 }
 
 // GetCategoryResults returns results grouped by category
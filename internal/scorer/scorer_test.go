@@ -301,3 +301,136 @@ func TestGetWeightForCategory(t *testing.T) {
 		}
 	}
 }
+
+func TestCalculateHealthReportWeightsAndClamping(t *testing.T) {
+	scorer := NewScorerWithOptions(Options{CheckWeights: map[string]int{"stash-501": 1}})
+	scorer.AddWeightedResult(types.CheckResult{ID: "SEC-1", Score: 100, Category: types.CategorySecurity}, 4)
+	scorer.AddWeightedResult(types.CheckResult{ID: "DOC-101", Score: 40, Category: types.CategoryDocs}, 0)
+	// Negative scores count as zero; the configured weight replaces the checker's
+	scorer.AddWeightedResult(types.CheckResult{ID: "STASH-501", Score: -50, Category: types.CategoryHygiene}, 5)
+
+	report := scorer.CalculateHealthReport()
+	// (100*4*5 + 40*1*3 + 0*1*2) / (20 + 3 + 2) = 84.8
+	if report.OverallScore != 85 || report.Grade != "A-" {
+		t.Errorf("expected 85 (A-), got %d (%s)", report.OverallScore, report.Grade)
+	}
+	stash := report.Breakdown.Checks[2]
+	if stash.Score != -50 || stash.Normalized != 0 || stash.CheckWeight != 1 || stash.Weight != 2 {
+		t.Errorf("unexpected stash contribution %+v", stash)
+	}
+	if report.Breakdown.WeightedScore != 85 || len(report.Breakdown.Penalties) != 0 {
+		t.Errorf("unexpected breakdown %+v", report.Breakdown)
+	}
+}
+
+func TestCalculateHealthReportPenalties(t *testing.T) {
+	scorer := NewScorer()
+	scorer.AddWeightedResult(types.CheckResult{ID: "DOC-101", Score: 100, Category: types.CategoryDocs}, 50)
+	scorer.AddWeightedResult(types.CheckResult{
+		ID:         "secret-scanning",
+		Status:     types.StatusFail,
+		Score:      0,
+		Category:   types.CategorySecurity,
+		Severities: map[string]int{"critical": 1, "high": 2},
+	}, 1)
+
+	report := scorer.CalculateHealthReport()
+	if report.Breakdown.WeightedScore < 90 {
+		t.Fatalf("expected a high weighted score, got %d", report.Breakdown.WeightedScore)
+	}
+	// A critical secret caps the grade at D, just below C-
+	if report.OverallScore != 54 || report.Grade != "D" || len(report.Breakdown.Penalties) != 1 {
+		t.Errorf("expected the critical secret to cap the grade at D, got %d (%s) %+v", report.OverallScore, report.Grade, report.Breakdown.Penalties)
+	}
+
+	options := Options{
+		Grades: []types.GradeBand{{Grade: "F", Min: 0}, {Grade: "Pass", Min: 70}},
+		Penalties: []Penalty{
+			{Check: "SEC-1", Status: "fail", MaxScore: 60},
+			{Check: "SEC-1", Severity: "critical", MaxGrade: "F"},
+		},
+	}
+	if err := options.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	scorer = NewScorerWithOptions(options)
+	scorer.AddWeightedResult(types.CheckResult{ID: "sec-1", Status: types.StatusFail, Score: 90}, 1)
+	report = scorer.CalculateHealthReport()
+	if report.OverallScore != 60 || report.Grade != "F" || len(report.Breakdown.Penalties) != 1 {
+		t.Errorf("expected the failed check to cap the score at 60, got %d (%s) %+v", report.OverallScore, report.Grade, report.Breakdown.Penalties)
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	invalid := []Options{
+		{Grades: []types.GradeBand{{Grade: "A", Min: 90}, {Grade: "A", Min: 80}}},
+		{Grades: []types.GradeBand{{Grade: "A", Min: 120}}},
+		{Grades: DefaultGradeBands(), Penalties: []Penalty{{Check: "X", MaxGrade: "Z"}}},
+		{Penalties: []Penalty{{Check: "X", Status: "broken", MaxScore: 50}}},
+		{Penalties: []Penalty{{Check: "X"}}},
+	}
+	for _, options := range invalid {
+		if err := options.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", options)
+		}
+	}
+	if err := DefaultOptions().Validate(); err != nil {
+		t.Errorf("default options are invalid: %v", err)
+	}
+}
+
+func TestDefaultPenaltiesFollowGradeBands(t *testing.T) {
+	if got := DefaultPenalties(); len(got) != 1 || got[0].MaxGrade != "D" {
+		t.Errorf("DefaultPenalties() = %+v, want a cap at D", got)
+	}
+	grades := []types.GradeBand{{Grade: "Pass", Min: 70}, {Grade: "Fail", Min: 0}, {Grade: "Good", Min: 90}}
+	options := Options{Grades: grades, Penalties: DefaultPenaltiesFor(grades)}
+	if len(options.Penalties) != 1 || options.Penalties[0].MaxGrade != "Pass" {
+		t.Errorf("DefaultPenaltiesFor() = %+v, want a cap at Pass", options.Penalties)
+	}
+	if err := options.Validate(); err != nil {
+		t.Errorf("default penalties for custom grades are invalid: %v", err)
+	}
+	if got := DefaultPenaltiesFor(grades[1:2]); got != nil {
+		t.Errorf("DefaultPenaltiesFor(one band) = %+v, want none", got)
+	}
+}
+
+func TestCalculateHealthReportCategories(t *testing.T) {
+	options := DefaultOptions()
+	options.CheckWeights = map[string]int{"DOC-103": 0, "STASH-501": 0}
+	scorer := NewScorerWithOptions(options)
+	scorer.AddWeightedResult(types.CheckResult{ID: "DOC-101", Status: types.StatusPass, Score: 100, Category: types.CategoryDocs}, 3)
+	scorer.AddWeightedResult(types.CheckResult{ID: "DOC-102", Status: types.StatusWarning, Score: 60, Category: types.CategoryDocs}, 1)
+	scorer.AddWeightedResult(types.CheckResult{ID: "TAGS-901", Status: types.StatusPass, Score: 90, Category: types.CategoryCommits}, 6)
+	scorer.AddWeightedResult(types.CheckResult{
+		ID:         "secret-scanning",
+		Status:     types.StatusFail,
+		Score:      80,
+		Category:   types.CategorySecurity,
+		Severities: map[string]int{"critical": 1},
+	}, 25)
+	// Checks weighted out of the score do not count in their category either
+	scorer.AddWeightedResult(types.CheckResult{ID: "DOC-103", Status: types.StatusFail, Score: 0, Category: types.CategoryDocs}, 1)
+	scorer.AddWeightedResult(types.CheckResult{ID: "STASH-501", Status: types.StatusFail, Score: 0, Category: types.CategoryHygiene}, 1)
+
+	report := scorer.CalculateHealthReport()
+	if len(report.Categories) != 3 {
+		t.Fatalf("expected 3 categories, got %+v", report.Categories)
+	}
+	docs := report.Category(types.CategoryDocs)
+	// (100*3 + 60*1) / 4
+	if docs == nil || docs.Score != 90 || docs.Grade != "A" || docs.Key != "docs" || docs.Summary.TotalChecks != 2 || docs.Summary.WarningChecks != 1 {
+		t.Errorf("unexpected docs score %+v", docs)
+	}
+	// The critical secret caps the security category as well as the overall grade
+	if security := report.Category(types.CategorySecurity); security == nil || security.Score != 54 || security.Grade != "D" {
+		t.Errorf("unexpected security score %+v", security)
+	}
+	if commits := report.Category(types.CategoryCommits); commits == nil || commits.Score != 90 {
+		t.Errorf("unexpected commits score %+v", commits)
+	}
+	if report.Category(types.CategoryHygiene) != nil {
+		t.Error("categories without checks should be left out")
+	}
+}
@@ -23,8 +23,251 @@ type Config struct {
 	// Commit size settings
 	MaxCommitSizeLines int `mapstructure:"max_commit_size_lines"`
 
+	// Commit convention shared by the health check and commit hooks
+	CommitConvention CommitConvention `mapstructure:"commit_convention"`
+
+	// Commit message rules enforced by the commit-msg hook
+	CommitMessage CommitMessage `mapstructure:"commit_message"`
+
 	// Scoring weights
 	Weights Weights `mapstructure:"weights"`
+
+	// Repository size thresholds
+	RepositorySize RepositorySize `mapstructure:"repository_size"`
+
+	// Checks run by each installed Git hook
+	Hooks Hooks `mapstructure:"hooks"`
+
+	// History shape expectations
+	History History `mapstructure:"history"`
+
+	// Hotspot ranking and temporal coupling settings
+	Hotspots Hotspots `mapstructure:"hotspots"`
+
+	// CODEOWNERS validation settings
+	CodeOwners CodeOwners `mapstructure:"codeowners"`
+
+	// Author identity resolution
+	Identity Identity `mapstructure:"identity"`
+
+	// Blame-based bus factor settings
+	BusFactor BusFactor `mapstructure:"bus_factor"`
+
+	// Contributor timeline settings
+	Timeline Timeline `mapstructure:"timeline"`
+
+	// Release workflow settings
+	Release Release `mapstructure:"release"`
+
+	// Changelog generation settings
+	Changelog Changelog `mapstructure:"changelog"`
+
+	// Tag integrity settings
+	Tags Tags `mapstructure:"tags"`
+
+	// Go module release settings
+	GoModules GoModules `mapstructure:"gomod"`
+
+	// Overall score and grade settings
+	Scoring Scoring `mapstructure:"scoring"`
+}
+
+// CommitConvention selects the commit message profile and its options
+type CommitConvention struct {
+	Profile      string   `mapstructure:"profile"`
+	Types        []string `mapstructure:"types"`
+	Scopes       []string `mapstructure:"scopes"`
+	RequireScope bool     `mapstructure:"require_scope"`
+	Projects     []string `mapstructure:"projects"`
+	Pattern      string   `mapstructure:"pattern"`
+}
+
+// CommitMessage holds the body and trailer rules for commit messages
+type CommitMessage struct {
+	MaxBodyLineLength     int      `mapstructure:"max_body_line_length"`
+	RequiredTrailers      []string `mapstructure:"required_trailers"`
+	RequireIssueReference bool     `mapstructure:"require_issue_reference"`
+	IssuePattern          string   `mapstructure:"issue_pattern"`
+}
+
+// RepositorySize holds the thresholds above which a clone is considered too heavy
+type RepositorySize struct {
+	MaxPackSizeMB   float64 `mapstructure:"max_pack_size_mb"`
+	MaxLooseObjects int     `mapstructure:"max_loose_objects"`
+	MaxBlobSizeMB   float64 `mapstructure:"max_blob_size_mb"`
+	// ScanHistory makes the health check walk the whole history for large blobs, as gphc size always does
+	ScanHistory bool `mapstructure:"scan_history"`
+}
+
+// Hooks lists the checks each Git hook stage runs
+type Hooks struct {
+	PreCommit []string `mapstructure:"pre_commit"`
+	CommitMsg []string `mapstructure:"commit_msg"`
+	PrePush   []string `mapstructure:"pre_push"`
+}
+
+// History selects the merge strategy the main branch must follow
+type History struct {
+	// Strategy is linear, merge-only or squash-only; empty only reports findings
+	Strategy   string `mapstructure:"strategy"`
+	MainBranch string `mapstructure:"main_branch"`
+}
+
+// Hotspots tunes the churn hotspot and temporal coupling analysis
+type Hotspots struct {
+	Top          int `mapstructure:"top"`
+	HalfLifeDays int `mapstructure:"half_life_days"`
+	// MinSharedCommits and MinCoupling decide when two files count as changing together
+	MinSharedCommits int     `mapstructure:"min_shared_commits"`
+	MinCoupling      float64 `mapstructure:"min_coupling"`
+	// Since is the history window gphc hotspots analyzes when no commit selection is given
+	Since string `mapstructure:"since"`
+}
+
+// CodeOwners tunes the CODEOWNERS validation
+type CodeOwners struct {
+	// Syntax is auto, github or gitlab
+	Syntax string `mapstructure:"syntax"`
+	// StaleDays is how long an owner may go without committing to the paths they own
+	StaleDays       int `mapstructure:"stale_days"`
+	TopContributors int `mapstructure:"top_contributors"`
+}
+
+// Identity controls how commit authors are merged into people and which are bots
+type Identity struct {
+	// Mailmap applies the repository .mailmap
+	Mailmap bool `mapstructure:"mailmap"`
+	// MergeByName merges authors whose normalized names or GitHub logins match
+	MergeByName bool `mapstructure:"merge_by_name"`
+	// ExcludeBots leaves bots out of contributor counts and bus factor
+	ExcludeBots bool `mapstructure:"exclude_bots"`
+	// Bots are extra regular expressions matched against "name <email>"
+	Bots []string `mapstructure:"bots"`
+}
+
+// BusFactor tunes the per-directory bus factor computed from blame
+type BusFactor struct {
+	// HalfLifeDays is the age at which a blamed line counts half as much knowledge
+	HalfLifeDays int `mapstructure:"half_life_days"`
+	// InactiveDays is how long an author may go without committing before their knowledge is at risk
+	InactiveDays int `mapstructure:"inactive_days"`
+	// Coverage is the share of a directory's knowledge the bus factor authors hold together
+	Coverage float64 `mapstructure:"coverage"`
+	// Depth groups files by their first path segments; 0 groups by package directory
+	Depth   int      `mapstructure:"depth"`
+	Exclude []string `mapstructure:"exclude"`
+}
+
+// Timeline tunes the contributor activity timeline of gphc authors --timeline
+type Timeline struct {
+	// Weeks is the number of most recent weeks charted
+	Weeks int `mapstructure:"weeks"`
+	// ActiveWindowWeeks is how many weeks a contributor counts as active after a commit
+	ActiveWindowWeeks int `mapstructure:"active_window_weeks"`
+	// InactiveDays is how long a contributor may go without committing before they count as offboarded
+	InactiveDays int `mapstructure:"inactive_days"`
+}
+
+// Release configures gphc release
+type Release struct {
+	// TagPrefix is prepended to versions in tag names
+	TagPrefix string `mapstructure:"tag_prefix"`
+	// VersionFile is updated with each release; empty detects VERSION, version.txt or package.json
+	VersionFile string `mapstructure:"version_file"`
+	// Changelog is the file release notes are merged into; empty skips it
+	Changelog string `mapstructure:"changelog"`
+	// Sign creates signed tags
+	Sign bool `mapstructure:"sign"`
+	// Components are the independently versioned parts of a monorepo, each with its own tags
+	Components []Component `mapstructure:"components"`
+	// GoModules adds a component for every Go module, tagged sub/dir/vX.Y.Z by Go convention
+	GoModules bool `mapstructure:"go_modules"`
+}
+
+// Component is an independently versioned part of a monorepo
+type Component struct {
+	// Name identifies the component, as in gphc release --component api
+	Name string `mapstructure:"name"`
+	// TagPrefix is prepended to the component's versions; empty means "<name>/v"
+	TagPrefix string `mapstructure:"tag_prefix"`
+	// Paths are the pathspecs whose commits belong to the component
+	Paths []string `mapstructure:"paths"`
+	// Exclude removes pathspecs from the component
+	Exclude []string `mapstructure:"exclude"`
+	// Changelog is the component's changelog; empty means CHANGELOG.md in its first path
+	Changelog string `mapstructure:"changelog"`
+	// VersionFile is updated with each release of the component
+	VersionFile string `mapstructure:"version_file"`
+}
+
+// Changelog configures gphc changelog
+type Changelog struct {
+	// Output is the changelog file generated changes are merged into
+	Output string `mapstructure:"output"`
+	// Format is keep-a-changelog or template
+	Format string `mapstructure:"format"`
+	// Template is the path of a text/template file used by the template format
+	Template string `mapstructure:"template"`
+	// Types maps commit types to section titles, on top of the defaults; an empty title hides a type
+	Types map[string]string `mapstructure:"types"`
+	// Unreleased adds a section for commits after the latest tag
+	Unreleased bool `mapstructure:"unreleased"`
+	// Authors credits the author of each entry
+	Authors bool `mapstructure:"authors"`
+}
+
+// Tags configures the tag integrity checks of the tag checker
+type Tags struct {
+	// ReleaseBranch is the branch release tags must be reachable from; empty uses history.main_branch
+	ReleaseBranch string `mapstructure:"release_branch"`
+	// MaintenanceBranches are branch patterns whose tags count as released, such as release/*
+	MaintenanceBranches []string `mapstructure:"maintenance_branches"`
+	// RequireSigned reports unsigned release tags
+	RequireSigned bool `mapstructure:"require_signed"`
+	// AllowedSignersFile verifies SSH tag signatures, and commit signatures in the pre-push hook; defaults to gpg.ssh.allowedSignersFile
+	AllowedSignersFile string `mapstructure:"allowed_signers_file"`
+	// GPGHome is a keyring directory used to verify GPG tag and pre-push commit signatures
+	GPGHome string `mapstructure:"gpg_home"`
+	// TrackMoves reports tags that moved from the commit the history store recorded; gphc tags records them
+	TrackMoves bool `mapstructure:"track_moves"`
+}
+
+// GoModules configures the Go module release checker
+type GoModules struct {
+	// MaxTags is the number of most recent tags of each module whose go.mod is checked
+	MaxTags int `mapstructure:"max_tags"`
+	// APIDiff also compares the exported API of the last two stable tags in the health check;
+	// gphc gomod always compares it unless --no-api is given
+	APIDiff bool `mapstructure:"api_diff"`
+}
+
+// Scoring configures how check results combine into the overall score and grade
+type Scoring struct {
+	// Checks overrides checker weights by result ID, such as secret-scanning: 30; 0 leaves a check out
+	Checks map[string]int `mapstructure:"checks"`
+	// Grades are the grade bands; empty uses A+ (95) down to F (0)
+	Grades []GradeBand `mapstructure:"grades"`
+	// Penalties cap the overall score when a check matches, however well the other checks scored
+	Penalties []ScorePenalty `mapstructure:"penalties"`
+}
+
+// GradeBand is the lowest score that earns a grade
+type GradeBand struct {
+	Grade string `mapstructure:"grade"`
+	Min   int    `mapstructure:"min"`
+}
+
+// ScorePenalty caps the overall score or grade when a check matches all of its conditions
+type ScorePenalty struct {
+	Name string `mapstructure:"name"`
+	// Check is the result ID the rule applies to; empty matches every check
+	Check string `mapstructure:"check"`
+	// Status is pass, warning or fail; empty matches any status
+	Status string `mapstructure:"status"`
+	// Severity matches when the check reports at least one finding of this severity
+	Severity string `mapstructure:"severity"`
+	MaxScore int    `mapstructure:"max_score"`
+	MaxGrade string `mapstructure:"max_grade"`
 }
 
 // Weights holds the scoring weights for different categories
@@ -43,6 +286,12 @@ func DefaultConfig() *Config {
 		StaleBranchThresholdDays: 60,
 		MaxCommitMessageLength:   72,
 		MaxCommitSizeLines:       500,
+		CommitConvention: CommitConvention{
+			Profile: "conventional",
+		},
+		CommitMessage: CommitMessage{
+			MaxBodyLineLength: 72,
+		},
 		Weights: Weights{
 			Documentation: 3,
 			Commits:       4,
@@ -50,6 +299,64 @@ func DefaultConfig() *Config {
 			Structure:     2,
 			Security:      5,
 		},
+		RepositorySize: RepositorySize{
+			MaxPackSizeMB:   500,
+			MaxLooseObjects: 1000,
+			MaxBlobSizeMB:   50,
+		},
+		Hooks: Hooks{
+			PreCommit: []string{"format", "large_files", "sensitive_files"},
+			CommitMsg: []string{"subject_length", "convention", "blank_line", "body_line_length", "required_trailer", "issue_reference"},
+			PrePush:   []string{"conventional", "length", "commit_size", "secrets", "binaries", "policy"},
+		},
+		Hotspots: Hotspots{
+			Top:              10,
+			HalfLifeDays:     90,
+			MinSharedCommits: 3,
+			MinCoupling:      0.7,
+			Since:            "12 months ago",
+		},
+		CodeOwners: CodeOwners{
+			Syntax:          "auto",
+			StaleDays:       90,
+			TopContributors: 3,
+		},
+		Identity: Identity{
+			Mailmap:     true,
+			MergeByName: true,
+			ExcludeBots: true,
+		},
+		BusFactor: BusFactor{
+			HalfLifeDays: 365,
+			InactiveDays: 90,
+			Coverage:     0.8,
+		},
+		Timeline: Timeline{
+			Weeks:             26,
+			ActiveWindowWeeks: 4,
+			InactiveDays:      90,
+		},
+		Release: Release{
+			TagPrefix: "v",
+			Changelog: "CHANGELOG.md",
+		},
+		Changelog: Changelog{
+			Output:     "CHANGELOG.md",
+			Format:     "keep-a-changelog",
+			Unreleased: true,
+		},
+		Tags: Tags{
+			MaintenanceBranches: []string{"release/*", "release-*", "support/*"},
+			TrackMoves:          true,
+		},
+		GoModules: GoModules{
+			MaxTags: 20,
+		},
+		Scoring: Scoring{
+			Penalties: []ScorePenalty{
+				{Name: "critical secret", Check: "secret-scanning", Severity: "critical", MaxGrade: "D"},
+			},
+		},
 	}
 }
 
@@ -77,11 +384,51 @@ func LoadConfig(configPath string) (*Config, error) {
 	v.SetDefault("stale_branch_threshold_days", 60)
 	v.SetDefault("max_commit_message_length", 72)
 	v.SetDefault("max_commit_size_lines", 500)
+	v.SetDefault("commit_convention.profile", "conventional")
+	v.SetDefault("commit_message.max_body_line_length", 72)
 	v.SetDefault("weights.documentation", 3)
 	v.SetDefault("weights.commits", 4)
 	v.SetDefault("weights.hygiene", 2)
 	v.SetDefault("weights.structure", 2)
 	v.SetDefault("weights.security", 5)
+	v.SetDefault("repository_size.max_pack_size_mb", 500)
+	v.SetDefault("repository_size.max_loose_objects", 1000)
+	v.SetDefault("repository_size.max_blob_size_mb", 50)
+	v.SetDefault("repository_size.scan_history", false)
+	v.SetDefault("hooks.pre_commit", []string{"format", "large_files", "sensitive_files"})
+	v.SetDefault("hooks.commit_msg", []string{"subject_length", "convention", "blank_line", "body_line_length", "required_trailer", "issue_reference"})
+	v.SetDefault("hooks.pre_push", []string{"conventional", "length", "commit_size", "secrets", "binaries", "policy"})
+	v.SetDefault("hotspots.top", 10)
+	v.SetDefault("hotspots.half_life_days", 90)
+	v.SetDefault("hotspots.min_shared_commits", 3)
+	v.SetDefault("hotspots.min_coupling", 0.7)
+	v.SetDefault("hotspots.since", "12 months ago")
+	v.SetDefault("codeowners.syntax", "auto")
+	v.SetDefault("codeowners.stale_days", 90)
+	v.SetDefault("codeowners.top_contributors", 3)
+	v.SetDefault("identity.mailmap", true)
+	v.SetDefault("identity.merge_by_name", true)
+	v.SetDefault("identity.exclude_bots", true)
+	v.SetDefault("bus_factor.half_life_days", 365)
+	v.SetDefault("bus_factor.inactive_days", 90)
+	v.SetDefault("bus_factor.coverage", 0.8)
+	v.SetDefault("bus_factor.depth", 0)
+	v.SetDefault("timeline.weeks", 26)
+	v.SetDefault("timeline.active_window_weeks", 4)
+	v.SetDefault("timeline.inactive_days", 90)
+	v.SetDefault("release.tag_prefix", "v")
+	v.SetDefault("release.changelog", "CHANGELOG.md")
+	v.SetDefault("release.sign", false)
+	v.SetDefault("release.go_modules", false)
+	v.SetDefault("changelog.output", "CHANGELOG.md")
+	v.SetDefault("changelog.format", "keep-a-changelog")
+	v.SetDefault("changelog.unreleased", true)
+	v.SetDefault("changelog.authors", false)
+	v.SetDefault("tags.maintenance_branches", []string{"release/*", "release-*", "support/*"})
+	v.SetDefault("tags.require_signed", false)
+	v.SetDefault("tags.track_moves", true)
+	v.SetDefault("gomod.max_tags", 20)
+	v.SetDefault("gomod.api_diff", false)
 
 	// Read config file
 	if err := v.ReadInConfig(); err != nil {
@@ -24,3 +24,81 @@ func TestLoadConfigUsesFileAndEnvironment(t *testing.T) {
 		t.Fatalf("environment override = %d, want 120", cfg.MaxCommitSizeLines)
 	}
 }
+
+func TestDefaultPrePushGatesSkipSignatures(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "gphc.yml")
+	if err := os.WriteFile(configPath, []byte("max_commits_to_analyze: 12\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, gates := range [][]string{cfg.Hooks.PrePush, DefaultConfig().Hooks.PrePush} {
+		found := false
+		for _, gate := range gates {
+			found = found || gate == "signatures"
+		}
+		// Repositories that do not sign commits could not push at all; a policy
+		// document with signing.required_percentage turns the gate on instead
+		if found {
+			t.Errorf("default pre-push gates %v should not require signatures", gates)
+		}
+	}
+}
+
+func TestLoadConfigCommitConvention(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "gphc.yml")
+	content := "commit_convention:\n  profile: conventional\n  types: [feat, fix]\n  scopes: [api]\n  require_scope: true\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	convention := cfg.CommitConvention
+	if convention.Profile != "conventional" || len(convention.Types) != 2 || len(convention.Scopes) != 1 || !convention.RequireScope {
+		t.Fatalf("CommitConvention = %+v", convention)
+	}
+
+	if profile := DefaultConfig().CommitConvention.Profile; profile != "conventional" {
+		t.Fatalf("default profile = %q, want conventional", profile)
+	}
+}
+
+func TestLoadConfigScoring(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "gphc.yml")
+	content := `scoring:
+  checks:
+    STASH-501: 1
+  grades:
+    - {grade: Pass, min: 70}
+    - {grade: Fail, min: 0}
+  penalties:
+    - check: TAGS-901
+      status: fail
+      max_score: 60
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scoring := cfg.Scoring
+	// Map keys are lowercased by the loader
+	if scoring.Checks["stash-501"] != 1 || len(scoring.Grades) != 2 || scoring.Grades[0] != (GradeBand{Grade: "Pass", Min: 70}) {
+		t.Fatalf("Scoring = %+v", scoring)
+	}
+	if len(scoring.Penalties) != 1 || scoring.Penalties[0].Check != "TAGS-901" || scoring.Penalties[0].MaxScore != 60 {
+		t.Fatalf("Penalties = %+v", scoring.Penalties)
+	}
+
+	if penalties := DefaultConfig().Scoring.Penalties; len(penalties) != 1 || penalties[0].MaxGrade != "D" {
+		t.Fatalf("default penalties = %+v", penalties)
+	}
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -14,6 +16,8 @@ type CheckResult struct {
 	Details   []string  `json:"details,omitempty"`
 	Category  Category  `json:"category"`
 	Timestamp time.Time `json:"timestamp"`
+	// Severities counts the check's findings by severity, for checks that grade their findings
+	Severities map[string]int `json:"severities,omitempty"`
 }
 
 // Status represents the status of a check
@@ -66,6 +70,39 @@ func (c Category) String() string {
 	}
 }
 
+// Key returns the short lowercase name of the category used in configuration, flags and APIs
+func (c Category) Key() string {
+	switch c {
+	case CategoryDocs:
+		return "docs"
+	case CategoryCommits:
+		return "commits"
+	case CategoryHygiene:
+		return "hygiene"
+	case CategoryStructure:
+		return "structure"
+	case CategorySecurity:
+		return "security"
+	default:
+		return "unknown"
+	}
+}
+
+// Categories lists every category in report order
+func Categories() []Category {
+	return []Category{CategoryDocs, CategoryCommits, CategoryHygiene, CategoryStructure, CategorySecurity}
+}
+
+// ParseCategory finds a category by its key, such as security
+func ParseCategory(key string) (Category, error) {
+	for _, category := range Categories() {
+		if strings.EqualFold(category.Key(), key) {
+			return category, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown category %q (use docs, commits, hygiene, structure or security)", key)
+}
+
 // HealthReport represents the overall health report
 type HealthReport struct {
 	OverallScore int           `json:"overall_score"`
@@ -73,6 +110,219 @@ type HealthReport struct {
 	Results      []CheckResult `json:"results"`
 	Summary      ReportSummary `json:"summary"`
 	Timestamp    time.Time     `json:"timestamp"`
+	// Categories holds the sub-score and grade of each category with at least one check
+	Categories []CategoryScore `json:"categories"`
+	// Breakdown explains how OverallScore and Grade were computed
+	Breakdown *ScoreBreakdown `json:"breakdown,omitempty"`
+}
+
+// CategoryScore is the weighted score and grade of one category's checks
+type CategoryScore struct {
+	Category Category      `json:"category"`
+	Key      string        `json:"key"`
+	Name     string        `json:"name"`
+	Score    int           `json:"score"`
+	Grade    string        `json:"grade"`
+	Summary  ReportSummary `json:"summary"`
+}
+
+// Category returns the sub-score of a category, or nil when the report has none for it
+func (r *HealthReport) Category(category Category) *CategoryScore {
+	for i := range r.Categories {
+		if r.Categories[i].Category == category {
+			return &r.Categories[i]
+		}
+	}
+	return nil
+}
+
+// ScoreBreakdown explains how the overall score and grade were computed
+type ScoreBreakdown struct {
+	Checks []CheckContribution `json:"checks"`
+	// WeightedScore is the weighted average before penalties
+	WeightedScore int              `json:"weighted_score"`
+	Penalties     []AppliedPenalty `json:"penalties,omitempty"`
+	Grades        []GradeBand      `json:"grades"`
+}
+
+// CheckContribution is how much one check contributed to the overall score
+type CheckContribution struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Category Category `json:"category"`
+	// Score is the score the check reported; Normalized is clamped to 0-100
+	Score      int `json:"score"`
+	Normalized int `json:"normalized"`
+	// Weight is CheckWeight times CategoryWeight; Share is its fraction of the total weight
+	CheckWeight    int     `json:"check_weight"`
+	CategoryWeight int     `json:"category_weight"`
+	Weight         int     `json:"weight"`
+	Share          float64 `json:"share"`
+	// Points is Normalized times Share, the check's part of the weighted score
+	Points float64 `json:"points"`
+}
+
+// AppliedPenalty is a penalty rule that capped the overall score
+type AppliedPenalty struct {
+	Rule   string `json:"rule"`
+	Check  string `json:"check"`
+	Reason string `json:"reason"`
+	// MaxScore is the cap applied to the score
+	MaxScore int `json:"max_score"`
+}
+
+// GradeBand is the lowest score that earns a grade
+type GradeBand struct {
+	Grade string `json:"grade"`
+	Min   int    `json:"min"`
+}
+
+// ReportComparison is the change in health from a base report to a head report
+type ReportComparison struct {
+	// Base and Head name the compared reports, e.g. a report file or a ref
+	Base       string `json:"base"`
+	Head       string `json:"head"`
+	BaseScore  int    `json:"base_score"`
+	HeadScore  int    `json:"head_score"`
+	ScoreDelta int    `json:"score_delta"`
+	BaseGrade  string `json:"base_grade"`
+	HeadGrade  string `json:"head_grade"`
+	// Categories holds every category scored in either report
+	Categories    []CategoryDelta     `json:"categories"`
+	StatusChanges []CheckStatusChange `json:"status_changes"`
+	// NewFindings are details of failing or warning checks that only the head reports;
+	// ResolvedFindings only the base
+	NewFindings      []Finding `json:"new_findings"`
+	ResolvedFindings []Finding `json:"resolved_findings"`
+}
+
+// GradeTransition describes the grade change, e.g. "B → A-", or the grade alone when it did not change
+func (c *ReportComparison) GradeTransition() string {
+	if c.BaseGrade == c.HeadGrade {
+		return c.HeadGrade
+	}
+	return c.BaseGrade + " → " + c.HeadGrade
+}
+
+// CategoryDelta is the change of one category's sub-score. A category missing from one
+// report has an empty grade on that side and a zero Delta.
+type CategoryDelta struct {
+	Category  Category `json:"category"`
+	Key       string   `json:"key"`
+	Name      string   `json:"name"`
+	BaseScore int      `json:"base_score"`
+	HeadScore int      `json:"head_score"`
+	Delta     int      `json:"delta"`
+	BaseGrade string   `json:"base_grade"`
+	HeadGrade string   `json:"head_grade"`
+}
+
+// CheckStatusChange is a check whose status differs between the reports. A check missing
+// from one report has an empty status on that side.
+type CheckStatusChange struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Category   Category `json:"category"`
+	BaseStatus string   `json:"base_status"`
+	HeadStatus string   `json:"head_status"`
+	BaseScore  int      `json:"base_score"`
+	HeadScore  int      `json:"head_score"`
+	// Regressed is set when the head status is worse, counting a missing check as passing
+	Regressed bool `json:"regressed"`
+}
+
+// Finding is one detail reported by a failing or warning check
+type Finding struct {
+	CheckID  string   `json:"check_id"`
+	Check    string   `json:"check"`
+	Category Category `json:"category"`
+	Detail   string   `json:"detail"`
+}
+
+// ScanReport is the health of many repositories with fleet-wide aggregates
+type ScanReport struct {
+	Root      string    `json:"root"`
+	Timestamp time.Time `json:"timestamp"`
+	// Repositories holds the full report of every scanned repository, ordered by path
+	Repositories []RepositoryScan `json:"repositories"`
+	// Errors lists the repositories that could not be checked
+	Errors  []ScanError `json:"errors,omitempty"`
+	Summary ScanSummary `json:"summary"`
+	// CheckFailures counts each check's outcomes across repositories, most failed first
+	CheckFailures []CheckFailureCount `json:"check_failures"`
+	Categories    []CategoryAggregate `json:"categories"`
+	// Histogram buckets the overall scores in steps of ten
+	Histogram []ScoreBucket `json:"histogram"`
+}
+
+// RepositoryScan is one scanned repository
+type RepositoryScan struct {
+	Name   string        `json:"name"`
+	Path   string        `json:"path"`
+	Score  int           `json:"score"`
+	Grade  string        `json:"grade"`
+	Report *HealthReport `json:"report"`
+}
+
+// ScanError is a repository whose health check failed
+type ScanError struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// ScanSummary summarizes the overall scores of a scan
+type ScanSummary struct {
+	TotalRepositories int              `json:"total_repositories"`
+	AverageScore      float64          `json:"average_score"`
+	MedianScore       float64          `json:"median_score"`
+	Highest           *RepositoryScore `json:"highest,omitempty"`
+	Lowest            *RepositoryScore `json:"lowest,omitempty"`
+}
+
+// RepositoryScore is a repository's overall or category score
+type RepositoryScore struct {
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	Score int    `json:"score"`
+	Grade string `json:"grade"`
+}
+
+// CheckFailureCount is how one check fared across the scanned repositories
+type CheckFailureCount struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Category Category `json:"category"`
+	Failed   int      `json:"failed"`
+	Warnings int      `json:"warnings"`
+	Passed   int      `json:"passed"`
+	// FailedRepositories names the repositories where the check failed
+	FailedRepositories []string `json:"failed_repositories,omitempty"`
+}
+
+// CategoryAggregate is a category's sub-scores across the scanned repositories
+type CategoryAggregate struct {
+	Category Category `json:"category"`
+	Key      string   `json:"key"`
+	Name     string   `json:"name"`
+	// Repositories counts the repositories with a score in the category
+	Repositories int     `json:"repositories"`
+	AverageScore float64 `json:"average_score"`
+	MinScore     int     `json:"min_score"`
+	MaxScore     int     `json:"max_score"`
+	// WorstOffenders are the lowest scoring repositories, lowest first
+	WorstOffenders []RepositoryScore `json:"worst_offenders"`
+}
+
+// ScoreBucket counts the repositories whose overall score is within [Min, Max]
+type ScoreBucket struct {
+	Min   int `json:"min"`
+	Max   int `json:"max"`
+	Count int `json:"count"`
+}
+
+// Label formats the bucket range, e.g. "70-79"
+func (b ScoreBucket) Label() string {
+	return fmt.Sprintf("%d-%d", b.Min, b.Max)
 }
 
 // ReportSummary provides a summary of the health check
@@ -99,15 +349,42 @@ type RepositoryData struct {
 
 // CommitInfo contains information about a commit
 type CommitInfo struct {
-	Hash         string
-	Message      string
-	Subject      string
-	Body         string
-	Author       string
-	AuthorEmail  string
-	Date         time.Time
+	Hash    string
+	Parents []string
+	Message string
+	Subject string
+	Body    string
+	// Author wrote the change; Date is the author date
+	Author      string
+	AuthorEmail string
+	Date        time.Time
+	// Committer applied the change, e.g. when rebasing, cherry-picking or merging a pull request
+	Committer      string
+	CommitterEmail string
+	CommitDate     time.Time
+	IsMerge        bool
+	// Line stats are measured against the first parent
+	LinesAdded   int
+	LinesDeleted int
+	Files        []FileChange
+}
+
+// FileChange is one file touched by a commit, measured against the first parent
+type FileChange struct {
+	Path string
+	// OldPath is set when the file was renamed or copied from another path
+	OldPath      string
 	LinesAdded   int
 	LinesDeleted int
+	Binary       bool
+}
+
+// ShortHash returns the abbreviated commit hash used in reports
+func ShortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
 }
 
 // BranchInfo contains information about a branch